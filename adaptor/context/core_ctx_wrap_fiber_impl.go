@@ -7,9 +7,15 @@
 package context
 
 import (
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/url"
 	"sync"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/lamxy/fiberhouse/globalmanager"
+	"google.golang.org/protobuf/proto"
 )
 
 // fiberContextPool FiberContext 对象池
@@ -63,3 +69,196 @@ func (f *FiberContext) GetHeader(key string) string {
 func (f *FiberContext) SetHeader(key string, value string) {
 	f.Ctx.Set(key, value)
 }
+
+// Vary 向 Vary 响应头追加字段
+func (f *FiberContext) Vary(fields ...string) {
+	if vary := appendVary(string(f.Ctx.Response().Header.Peek(fiber.HeaderVary)), fields); vary != "" {
+		f.Ctx.Set(fiber.HeaderVary, vary)
+	}
+}
+
+// Scope 获取请求作用域管理器，优先读取 Locals，其次读取 UserContext
+func (f *FiberContext) Scope() *globalmanager.GlobalManager {
+	if scope := globalmanager.ScopeFromValue(f.Ctx.Locals(globalmanager.ScopeLocalsKey)); scope != nil {
+		return scope
+	}
+	return globalmanager.ScopeFromContext(f.Ctx.UserContext())
+}
+
+// ClientCertificate 获取经 mTLS 校验通过的客户端证书，读取 fasthttp 连接的 TLS 状态
+func (f *FiberContext) ClientCertificate() *x509.Certificate {
+	return verifiedClientCertificate(f.Ctx.Context().TLSConnectionState())
+}
+
+// Next 执行后续处理器
+func (f *FiberContext) Next() error {
+	return f.Ctx.Next()
+}
+
+// Method 获取请求方法
+func (f *FiberContext) Method() string {
+	return f.Ctx.Method()
+}
+
+// Path 获取请求路径
+func (f *FiberContext) Path() string {
+	return f.Ctx.Path()
+}
+
+// Param 获取路由参数
+func (f *FiberContext) Param(key string) string {
+	return f.Ctx.Params(key)
+}
+
+// Params 获取全部路由参数
+func (f *FiberContext) Params() map[string]string {
+	return f.Ctx.AllParams()
+}
+
+// Query 获取查询参数
+func (f *FiberContext) Query(key string, defaultValue ...string) string {
+	return f.Ctx.Query(key, defaultValue...)
+}
+
+// Queries 获取全部查询参数，保留同名参数的多个值
+func (f *FiberContext) Queries() url.Values {
+	values := url.Values{}
+	f.Ctx.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		values.Add(string(key), string(value))
+	})
+	return values
+}
+
+// FormValue 获取请求体表单字段，不读取查询参数（fasthttp FormValue 会先查查询参数）
+func (f *FiberContext) FormValue(key string) string {
+	if value := f.Ctx.Request().PostArgs().Peek(key); len(value) > 0 {
+		return string(value)
+	}
+	if form, err := f.Ctx.MultipartForm(); err == nil && len(form.Value[key]) > 0 {
+		return form.Value[key][0]
+	}
+	return ""
+}
+
+// Body 获取原始请求体，不按 Content-Encoding 解压，与 Gin、Hertz 一致
+func (f *FiberContext) Body() ([]byte, error) {
+	return f.Ctx.BodyRaw(), nil
+}
+
+// BindBody 使用 Fiber BodyParser 解码请求体
+func (f *FiberContext) BindBody(out interface{}) error {
+	return f.Ctx.BodyParser(out)
+}
+
+// Cookie 获取请求 Cookie
+func (f *FiberContext) Cookie(name string) string {
+	return f.Ctx.Cookies(name)
+}
+
+// ClientIP 获取客户端 IP，由 fiber.Config.ProxyHeader 决定是否读取代理头
+func (f *FiberContext) ClientIP() string {
+	return f.Ctx.IP()
+}
+
+// Locals 读写 fiber.Ctx Locals
+func (f *FiberContext) Locals(key string, value ...interface{}) interface{} {
+	return f.Ctx.Locals(key, value...)
+}
+
+// Status 设置响应状态码
+func (f *FiberContext) Status(statusCode int) ICoreContext {
+	f.Ctx.Status(statusCode)
+	return f
+}
+
+// SetCookie 设置响应 Cookie
+func (f *FiberContext) SetCookie(cookie *http.Cookie) {
+	fc := &fiber.Cookie{
+		Name:     cookie.Name,
+		Value:    cookie.Value,
+		Path:     cookie.Path,
+		Domain:   cookie.Domain,
+		MaxAge:   cookie.MaxAge,
+		Expires:  cookie.Expires,
+		Secure:   cookie.Secure,
+		HTTPOnly: cookie.HttpOnly,
+	}
+	switch cookie.SameSite {
+	case http.SameSiteLaxMode:
+		fc.SameSite = fiber.CookieSameSiteLaxMode
+	case http.SameSiteStrictMode:
+		fc.SameSite = fiber.CookieSameSiteStrictMode
+	case http.SameSiteNoneMode:
+		fc.SameSite = fiber.CookieSameSiteNoneMode
+	default:
+		fc.SameSite = fiber.CookieSameSiteDisabled
+	}
+	f.Ctx.Cookie(fc)
+}
+
+// JSONP 以 JSONP 格式响应数据
+func (f *FiberContext) JSONP(statusCode int, data interface{}, callback string) error {
+	defer f.Release()
+	callback, err := resolveJSONPCallback(callback, f.Ctx.Query("callback"))
+	if err != nil {
+		return err
+	}
+	if callback == "" {
+		return f.Ctx.Status(statusCode).JSON(data)
+	}
+	return f.Ctx.Status(statusCode).JSONP(data, callback)
+}
+
+// XML 以 XML 格式响应数据
+func (f *FiberContext) XML(statusCode int, data interface{}) error {
+	defer f.Release()
+	return f.Ctx.Status(statusCode).XML(data)
+}
+
+// SendProto 以 Protobuf 格式响应消息
+func (f *FiberContext) SendProto(statusCode int, msg proto.Message) error {
+	defer f.Release()
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	f.Ctx.Set(fiber.HeaderContentType, MIMEProtobuf)
+	return f.Ctx.Status(statusCode).Send(body)
+}
+
+// SendStream 以分块方式发送 reader 的内容，fasthttp 在处理器返回后读取 reader
+func (f *FiberContext) SendStream(statusCode int, contentType string, reader io.Reader) error {
+	defer f.Release()
+	if contentType != "" {
+		f.Ctx.Set(fiber.HeaderContentType, contentType)
+	}
+	return f.Ctx.Status(statusCode).SendStream(reader)
+}
+
+// Redirect 重定向到 location
+func (f *FiberContext) Redirect(statusCode int, location string) error {
+	defer f.Release()
+	return f.Ctx.Redirect(location, statusCode)
+}
+
+// File 发送文件内容
+func (f *FiberContext) File(path string) error {
+	defer f.Release()
+	return f.Ctx.SendFile(path)
+}
+
+// Attachment 以附件形式发送文件
+func (f *FiberContext) Attachment(path string, filename string) error {
+	defer f.Release()
+	return f.Ctx.Download(path, attachmentName(path, filename))
+}
+
+// FiberRoute 返回请求匹配的路由模板，供指标与链路追踪作为低基数路由标签；
+// 仅经过 Use 中间件而未匹配处理器路由时 Fiber 报告根路由，此时返回空串
+func FiberRoute(c *fiber.Ctx) string {
+	route := c.Route()
+	if route == nil || (route.Path == "/" && c.Path() != "/") {
+		return ""
+	}
+	return route.Path
+}
@@ -1,15 +1,23 @@
 package context
 
 import (
+	"bytes"
+	"compress/gzip"
 	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestFiberContext_RequestAndResponseContract(t *testing.T) {
@@ -19,6 +27,8 @@ func TestFiberContext_RequestAndResponseContract(t *testing.T) {
 		assert.Same(t, c, wrapped.GetCtx())
 		assert.Equal(t, "request-value", wrapped.GetHeader("X-Request"))
 		wrapped.SetHeader("X-Response", "response-value")
+		wrapped.SetHeader("Vary", "Origin")
+		wrapped.Vary("Accept", "origin")
 		return wrapped.JSON(fiber.StatusCreated, fiber.Map{"core": "fiber"})
 	})
 	app.Get("/raw", func(c *fiber.Ctx) error {
@@ -33,6 +43,7 @@ func TestFiberContext_RequestAndResponseContract(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, fiber.StatusCreated, jsonResponse.StatusCode)
 	assert.Equal(t, "response-value", jsonResponse.Header.Get("X-Response"))
+	assert.Equal(t, "Origin, Accept", jsonResponse.Header.Get("Vary"))
 	assert.JSONEq(t, `{"core":"fiber"}`, readFiberResponseBody(t, jsonResponse))
 
 	rawResponse, err := app.Test(httptest.NewRequest("GET", "/raw", nil))
@@ -61,6 +72,182 @@ func TestFiberContext_JSONPropagatesEncoderError(t *testing.T) {
 	assert.ErrorIs(t, got, sentinel)
 }
 
+func TestFiberContext_RequestAccessors(t *testing.T) {
+	app := fiber.New(fiber.Config{ProxyHeader: fiber.HeaderXForwardedFor, EnableIPValidation: true})
+	app.Post("/users/:id", func(c *fiber.Ctx) error {
+		wrapped := WithFiberContext(c)
+		assert.Equal(t, fiber.MethodPost, wrapped.Method())
+		assert.Equal(t, "/users/42", wrapped.Path())
+		assert.Equal(t, "42", wrapped.Param("id"))
+		assert.Equal(t, map[string]string{"id": "42"}, wrapped.Params())
+		assert.Equal(t, "2", wrapped.Query("page"))
+		assert.Equal(t, "10", wrapped.Query("size", "10"))
+		assert.Equal(t, url.Values{"page": {"2"}, "tag": {"a", "b"}}, wrapped.Queries())
+		assert.Equal(t, "alice", wrapped.FormValue("name"))
+		assert.Empty(t, wrapped.FormValue("page"), "FormValue must not fall back to the query string")
+		assert.Equal(t, "s1", wrapped.Cookie("session"))
+		assert.Empty(t, wrapped.Cookie("absent"))
+		assert.Equal(t, "203.0.113.7", wrapped.ClientIP())
+		assert.Equal(t, "tenant-a", wrapped.Locals("tenant", "tenant-a"))
+		assert.Equal(t, "tenant-a", wrapped.Locals("tenant"))
+		return wrapped.Send(fiber.StatusNoContent, nil)
+	})
+
+	request := httptest.NewRequest("POST", "/users/42?page=2&tag=a&tag=b", strings.NewReader("name=alice"))
+	request.Header.Set("Content-Type", fiber.MIMEApplicationForm)
+	request.Header.Set("Cookie", "session=s1")
+	request.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	response, err := app.Test(request)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, response.StatusCode)
+}
+
+func TestFiberRoute_ReportsMatchedTemplateOnly(t *testing.T) {
+	routes := make(map[string]string)
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		err := c.Next()
+		routes[strings.Clone(c.Path())] = FiberRoute(c)
+		return err
+	})
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+	app.Get("/users/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+
+	for _, path := range []string{"/", "/users/42", "/missing"} {
+		response, err := app.Test(httptest.NewRequest("GET", path, nil))
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+	}
+	assert.Equal(t, map[string]string{"/": "/", "/users/42": "/users/:id", "/missing": ""}, routes)
+}
+
+func TestFiberContext_BodyAndBindBody(t *testing.T) {
+	type payload struct {
+		Name string `json:"name"`
+	}
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		wrapped := WithFiberContext(c)
+		body, err := wrapped.Body()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"bob"}`, string(body))
+		var out payload
+		require.NoError(t, wrapped.BindBody(&out))
+		assert.Equal(t, "bob", out.Name)
+		return wrapped.Send(fiber.StatusOK, nil)
+	})
+
+	request := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"bob"}`))
+	request.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+	response, err := app.Test(request)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, response.StatusCode)
+}
+
+func TestFiberContext_BodyKeepsContentEncoding(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, err := writer.Write([]byte(`{"name":"bob"}`))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		body, err := WithFiberContext(c).Body()
+		require.NoError(t, err)
+		assert.Equal(t, compressed.Bytes(), body, "Body returns the raw request body like Gin")
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	request := httptest.NewRequest("POST", "/", bytes.NewReader(compressed.Bytes()))
+	request.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+	request.Header.Set("Content-Encoding", "gzip")
+	response, err := app.Test(request)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, response.StatusCode)
+}
+
+func TestFiberContext_ResponseWriters(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "report.txt")
+	require.NoError(t, os.WriteFile(file, []byte("report-body"), 0o600))
+	type item struct {
+		Name string `xml:"name"`
+	}
+	app := fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+		return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+	}})
+	app.Get("/status", func(c *fiber.Ctx) error {
+		wrapped := WithFiberContext(c)
+		wrapped.SetCookie(&http.Cookie{Name: "token", Value: "t1", Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode})
+		return wrapped.Status(fiber.StatusCreated).Send(fiber.StatusAccepted, []byte("ok"))
+	})
+	app.Get("/jsonp", func(c *fiber.Ctx) error {
+		return WithFiberContext(c).JSONP(fiber.StatusOK, fiber.Map{"ok": true}, "")
+	})
+	app.Get("/xml", func(c *fiber.Ctx) error {
+		return WithFiberContext(c).XML(fiber.StatusOK, item{Name: "fiber"})
+	})
+	app.Get("/proto", func(c *fiber.Ctx) error {
+		return WithFiberContext(c).SendProto(fiber.StatusOK, wrapperspb.String("fiber"))
+	})
+	app.Get("/stream", func(c *fiber.Ctx) error {
+		return WithFiberContext(c).SendStream(fiber.StatusOK, "text/plain", strings.NewReader("streamed-fiber"))
+	})
+	app.Get("/redirect", func(c *fiber.Ctx) error {
+		return WithFiberContext(c).Redirect(fiber.StatusFound, "/target")
+	})
+	app.Get("/file", func(c *fiber.Ctx) error {
+		return WithFiberContext(c).File(file)
+	})
+	app.Get("/attachment", func(c *fiber.Ctx) error {
+		return WithFiberContext(c).Attachment(file, "")
+	})
+
+	get := func(target string) *http.Response {
+		response, err := app.Test(httptest.NewRequest("GET", target, nil))
+		require.NoError(t, err)
+		return response
+	}
+
+	response := get("/status")
+	assert.Equal(t, fiber.StatusAccepted, response.StatusCode, "Send status overrides Status")
+	assert.Equal(t, "token=t1; path=/; HttpOnly; SameSite=Lax", response.Header.Get("Set-Cookie"))
+
+	response = get("/jsonp?callback=app.cb")
+	assert.Equal(t, `app.cb({"ok":true});`, readFiberResponseBody(t, response))
+	response = get("/jsonp")
+	assert.JSONEq(t, `{"ok":true}`, readFiberResponseBody(t, response))
+	response = get("/jsonp?callback=" + url.QueryEscape("alert(1)//"))
+	assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+	assert.Equal(t, ErrInvalidJSONPCallback.Error(), readFiberResponseBody(t, response))
+
+	response = get("/xml")
+	assert.Contains(t, response.Header.Get("Content-Type"), "application/xml")
+	assert.Equal(t, "<item><name>fiber</name></item>", readFiberResponseBody(t, response))
+
+	response = get("/proto")
+	assert.Equal(t, MIMEProtobuf, response.Header.Get("Content-Type"))
+	var message wrapperspb.StringValue
+	require.NoError(t, proto.Unmarshal([]byte(readFiberResponseBody(t, response)), &message))
+	assert.Equal(t, "fiber", message.GetValue())
+
+	response = get("/stream")
+	assert.Equal(t, "text/plain", response.Header.Get("Content-Type"))
+	assert.Equal(t, "streamed-fiber", readFiberResponseBody(t, response))
+
+	response = get("/redirect")
+	assert.Equal(t, fiber.StatusFound, response.StatusCode)
+	assert.Equal(t, "/target", response.Header.Get("Location"))
+
+	response = get("/file")
+	assert.Equal(t, "report-body", readFiberResponseBody(t, response))
+
+	response = get("/attachment")
+	assert.Equal(t, `attachment; filename="report.txt"`, response.Header.Get("Content-Disposition"))
+	assert.Equal(t, "report-body", readFiberResponseBody(t, response))
+}
+
 func readFiberResponseBody(t *testing.T, response *http.Response) string {
 	t.Helper()
 	defer response.Body.Close()
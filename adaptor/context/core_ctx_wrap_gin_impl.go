@@ -7,9 +7,17 @@
 package context
 
 import (
+	"bytes"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/url"
 	"sync"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+	"github.com/lamxy/fiberhouse/globalmanager"
+	"google.golang.org/protobuf/proto"
 )
 
 // ginContextPool GinContext 对象池
@@ -66,3 +74,204 @@ func (g *GinContext) GetHeader(key string) string {
 func (g *GinContext) SetHeader(key string, value string) {
 	g.Ctx.Writer.Header()[key] = []string{value}
 }
+
+// Vary 向 Vary 响应头追加字段
+func (g *GinContext) Vary(fields ...string) {
+	if vary := appendVary(g.Ctx.Writer.Header().Get("Vary"), fields); vary != "" {
+		g.Ctx.Writer.Header().Set("Vary", vary)
+	}
+}
+
+// Scope 获取请求作用域管理器，优先读取 Keys，其次读取 Request.Context()
+func (g *GinContext) Scope() *globalmanager.GlobalManager {
+	if value, ok := g.Ctx.Get(globalmanager.ScopeLocalsKey); ok {
+		if scope := globalmanager.ScopeFromValue(value); scope != nil {
+			return scope
+		}
+	}
+	if g.Ctx.Request == nil {
+		return nil
+	}
+	return globalmanager.ScopeFromContext(g.Ctx.Request.Context())
+}
+
+// ClientCertificate 获取经 mTLS 校验通过的客户端证书，读取 http.Request.TLS
+func (g *GinContext) ClientCertificate() *x509.Certificate {
+	return verifiedClientCertificate(g.Ctx.Request.TLS)
+}
+
+// Next 执行后续处理器，返回其间经 Context.Error 记录的最后一个错误
+func (g *GinContext) Next() error {
+	recorded := len(g.Ctx.Errors)
+	g.Ctx.Next()
+	if len(g.Ctx.Errors) > recorded {
+		return g.Ctx.Errors.Last().Err
+	}
+	return nil
+}
+
+// Method 获取请求方法
+func (g *GinContext) Method() string {
+	return g.Ctx.Request.Method
+}
+
+// Path 获取请求路径
+func (g *GinContext) Path() string {
+	return g.Ctx.Request.URL.Path
+}
+
+// Param 获取路由参数
+func (g *GinContext) Param(key string) string {
+	return g.Ctx.Param(key)
+}
+
+// Params 获取全部路由参数
+func (g *GinContext) Params() map[string]string {
+	params := make(map[string]string, len(g.Ctx.Params))
+	for _, param := range g.Ctx.Params {
+		params[param.Key] = param.Value
+	}
+	return params
+}
+
+// Query 获取查询参数
+func (g *GinContext) Query(key string, defaultValue ...string) string {
+	return queryDefault(g.Ctx.Query(key), defaultValue)
+}
+
+// Queries 获取全部查询参数
+func (g *GinContext) Queries() url.Values {
+	return g.Ctx.Request.URL.Query()
+}
+
+// FormValue 获取表单字段，只读取请求体中的表单
+func (g *GinContext) FormValue(key string) string {
+	return g.Ctx.PostForm(key)
+}
+
+// Body 获取原始请求体，读取后以内存副本替换 Request.Body 以便重复读取与后续绑定
+func (g *GinContext) Body() ([]byte, error) {
+	if g.Ctx.Request.Body == nil || g.Ctx.Request.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(g.Ctx.Request.Body)
+	_ = g.Ctx.Request.Body.Close()
+	g.Ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// BindBody 使用 Gin ShouldBind 按方法与 Content-Type 解码请求体
+func (g *GinContext) BindBody(out interface{}) error {
+	return g.Ctx.ShouldBind(out)
+}
+
+// Cookie 获取请求 Cookie
+func (g *GinContext) Cookie(name string) string {
+	value, _ := g.Ctx.Cookie(name)
+	return value
+}
+
+// ClientIP 获取客户端 IP，由 gin.Engine 的 RemoteIPHeaders 与 ForwardedByClientIP 决定是否读取代理头
+func (g *GinContext) ClientIP() string {
+	return g.Ctx.ClientIP()
+}
+
+// Locals 读写 gin.Context Keys
+func (g *GinContext) Locals(key string, value ...interface{}) interface{} {
+	if len(value) > 0 {
+		g.Ctx.Set(key, value[0])
+		return value[0]
+	}
+	v, _ := g.Ctx.Get(key)
+	return v
+}
+
+// Status 设置响应状态码
+func (g *GinContext) Status(statusCode int) ICoreContext {
+	g.Ctx.Status(statusCode)
+	return g
+}
+
+// SetCookie 设置响应 Cookie
+func (g *GinContext) SetCookie(cookie *http.Cookie) {
+	http.SetCookie(g.Ctx.Writer, cookie)
+}
+
+// JSONP 以 JSONP 格式响应数据
+func (g *GinContext) JSONP(statusCode int, data interface{}, callback string) error {
+	defer g.Release()
+	callback, err := resolveJSONPCallback(callback, g.Ctx.Query("callback"))
+	if err != nil {
+		return err
+	}
+	if callback == "" {
+		g.Ctx.JSON(statusCode, data)
+		return nil
+	}
+	g.Ctx.Render(statusCode, render.JsonpJSON{Callback: callback, Data: data})
+	return nil
+}
+
+// XML 以 XML 格式响应数据
+func (g *GinContext) XML(statusCode int, data interface{}) error {
+	defer g.Release()
+	g.Ctx.XML(statusCode, data)
+	return nil
+}
+
+// SendProto 以 Protobuf 格式响应消息
+func (g *GinContext) SendProto(statusCode int, msg proto.Message) error {
+	defer g.Release()
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	g.Ctx.Data(statusCode, MIMEProtobuf, body)
+	return nil
+}
+
+// SendStream 以分块方式发送 reader 的内容，每次写入后刷新
+func (g *GinContext) SendStream(statusCode int, contentType string, reader io.Reader) error {
+	defer g.Release()
+	if contentType != "" {
+		g.Ctx.Header("Content-Type", contentType)
+	}
+	g.Ctx.Status(statusCode)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			if _, writeErr := g.Ctx.Writer.Write(buf[:n]); writeErr != nil {
+				return writeErr
+			}
+			g.Ctx.Writer.Flush()
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Redirect 重定向到 location
+func (g *GinContext) Redirect(statusCode int, location string) error {
+	defer g.Release()
+	g.Ctx.Redirect(statusCode, location)
+	return nil
+}
+
+// File 发送文件内容
+func (g *GinContext) File(path string) error {
+	defer g.Release()
+	g.Ctx.File(path)
+	return fileSent(g.Ctx.Writer.Status(), path)
+}
+
+// Attachment 以附件形式发送文件
+func (g *GinContext) Attachment(path string, filename string) error {
+	defer g.Release()
+	g.Ctx.FileAttachment(path, attachmentName(path, filename))
+	return fileSent(g.Ctx.Writer.Status(), path)
+}
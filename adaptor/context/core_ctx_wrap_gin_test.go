@@ -2,12 +2,19 @@ package context
 
 import (
 	"errors"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 type failingGinResponseWriter struct {
@@ -32,9 +39,12 @@ func TestGinContext_RequestAndResponseContract(t *testing.T) {
 	assert.Same(t, jsonCtx, jsonWrapped.GetCtx())
 	assert.Equal(t, "request-value", jsonWrapped.GetHeader("X-Request"))
 	jsonWrapped.SetHeader("X-Response", "response-value")
+	jsonWrapped.SetHeader("Vary", "Origin")
+	jsonWrapped.Vary("Accept", "origin")
 	require.NoError(t, jsonWrapped.JSON(201, gin.H{"core": "gin"}))
 	assert.Equal(t, 201, jsonRecorder.Code)
 	assert.Equal(t, "response-value", jsonRecorder.Header().Get("X-Response"))
+	assert.Equal(t, "Origin, Accept", jsonRecorder.Header().Get("Vary"))
 	assert.JSONEq(t, `{"core":"gin"}`, jsonRecorder.Body.String())
 
 	rawRecorder := httptest.NewRecorder()
@@ -61,3 +71,155 @@ func TestGinContext_SendPropagatesWriterError(t *testing.T) {
 	err := WithGinContext(ginCtx).Send(503, []byte("unwritten"))
 	assert.ErrorIs(t, err, sentinel)
 }
+
+func TestGinContext_RequestAccessors(t *testing.T) {
+	oldMode := gin.Mode()
+	t.Cleanup(func() { gin.SetMode(oldMode) })
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.RemoteIPHeaders = []string{"X-Forwarded-For"}
+	engine.POST("/users/:id", func(c *gin.Context) {
+		wrapped := WithGinContext(c)
+		assert.Equal(t, http.MethodPost, wrapped.Method())
+		assert.Equal(t, "/users/42", wrapped.Path())
+		assert.Equal(t, "42", wrapped.Param("id"))
+		assert.Equal(t, map[string]string{"id": "42"}, wrapped.Params())
+		assert.Equal(t, "2", wrapped.Query("page"))
+		assert.Equal(t, "10", wrapped.Query("size", "10"))
+		assert.Equal(t, url.Values{"page": {"2"}, "tag": {"a", "b"}}, wrapped.Queries())
+		assert.Equal(t, "alice", wrapped.FormValue("name"))
+		assert.Empty(t, wrapped.FormValue("page"), "FormValue must not fall back to the query string")
+		assert.Equal(t, "s1", wrapped.Cookie("session"))
+		assert.Empty(t, wrapped.Cookie("absent"))
+		assert.Equal(t, "203.0.113.7", wrapped.ClientIP())
+		assert.Equal(t, "tenant-a", wrapped.Locals("tenant", "tenant-a"))
+		assert.Equal(t, "tenant-a", wrapped.Locals("tenant"))
+		_ = wrapped.Send(http.StatusNoContent, nil)
+	})
+
+	request := httptest.NewRequest("POST", "/users/42?page=2&tag=a&tag=b", strings.NewReader("name=alice"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Cookie", "session=s1")
+	request.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+func TestGinContext_BodyIsRereadableBeforeBindBody(t *testing.T) {
+	oldMode := gin.Mode()
+	t.Cleanup(func() { gin.SetMode(oldMode) })
+	gin.SetMode(gin.TestMode)
+	type payload struct {
+		Name string `json:"name"`
+	}
+	ginCtx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ginCtx.Request = httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"bob"}`))
+	ginCtx.Request.Header.Set("Content-Type", "application/json")
+	wrapped := WithGinContext(ginCtx)
+
+	for range 2 {
+		body, err := wrapped.Body()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"bob"}`, string(body))
+	}
+	var out payload
+	require.NoError(t, wrapped.BindBody(&out))
+	assert.Equal(t, "bob", out.Name)
+}
+
+func TestGinContext_ResponseWriters(t *testing.T) {
+	oldMode := gin.Mode()
+	t.Cleanup(func() { gin.SetMode(oldMode) })
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	file := filepath.Join(dir, "report.txt")
+	require.NoError(t, os.WriteFile(file, []byte("report-body"), 0o600))
+	type item struct {
+		Name string `xml:"name"`
+	}
+	engine := gin.New()
+	engine.GET("/status", func(c *gin.Context) {
+		wrapped := WithGinContext(c)
+		wrapped.SetCookie(&http.Cookie{Name: "token", Value: "t1", Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode})
+		_ = wrapped.Status(http.StatusCreated).Send(http.StatusAccepted, []byte("ok"))
+	})
+	engine.GET("/jsonp", func(c *gin.Context) {
+		if err := WithGinContext(c).JSONP(http.StatusOK, gin.H{"ok": true}, ""); err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+		}
+	})
+	engine.GET("/xml", func(c *gin.Context) {
+		_ = WithGinContext(c).XML(http.StatusOK, item{Name: "gin"})
+	})
+	engine.GET("/proto", func(c *gin.Context) {
+		_ = WithGinContext(c).SendProto(http.StatusOK, wrapperspb.String("gin"))
+	})
+	engine.GET("/stream", func(c *gin.Context) {
+		_ = WithGinContext(c).SendStream(http.StatusOK, "text/plain", strings.NewReader("streamed-gin"))
+	})
+	engine.GET("/redirect", func(c *gin.Context) {
+		_ = WithGinContext(c).Redirect(http.StatusFound, "/target")
+	})
+	engine.GET("/file", func(c *gin.Context) {
+		_ = WithGinContext(c).File(file)
+	})
+	engine.GET("/attachment", func(c *gin.Context) {
+		_ = WithGinContext(c).Attachment(file, "")
+	})
+	missing := filepath.Join(dir, "missing.txt")
+	var fileErrs []error
+	engine.GET("/missing", func(c *gin.Context) {
+		fileErrs = append(fileErrs, WithGinContext(c).File(missing))
+	})
+	engine.GET("/missing-attachment", func(c *gin.Context) {
+		fileErrs = append(fileErrs, WithGinContext(c).Attachment(missing, ""))
+	})
+
+	get := func(target string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, httptest.NewRequest("GET", target, nil))
+		return recorder
+	}
+
+	recorder := get("/status")
+	assert.Equal(t, http.StatusAccepted, recorder.Code, "Send status overrides Status")
+	assert.Equal(t, "token=t1; Path=/; HttpOnly; SameSite=Lax", recorder.Header().Get("Set-Cookie"))
+
+	assert.Equal(t, `app.cb({"ok":true});`, get("/jsonp?callback=app.cb").Body.String())
+	assert.JSONEq(t, `{"ok":true}`, get("/jsonp").Body.String())
+	recorder = get("/jsonp?callback=" + url.QueryEscape("alert(1)//"))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, ErrInvalidJSONPCallback.Error(), recorder.Body.String())
+
+	recorder = get("/xml")
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "application/xml")
+	assert.Equal(t, "<item><name>gin</name></item>", recorder.Body.String())
+
+	recorder = get("/proto")
+	assert.Equal(t, MIMEProtobuf, recorder.Header().Get("Content-Type"))
+	var message wrapperspb.StringValue
+	require.NoError(t, proto.Unmarshal(recorder.Body.Bytes(), &message))
+	assert.Equal(t, "gin", message.GetValue())
+
+	recorder = get("/stream")
+	assert.Equal(t, "text/plain", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "streamed-gin", recorder.Body.String())
+
+	recorder = get("/redirect")
+	assert.Equal(t, http.StatusFound, recorder.Code)
+	assert.Equal(t, "/target", recorder.Header().Get("Location"))
+
+	assert.Equal(t, "report-body", get("/file").Body.String())
+
+	recorder = get("/attachment")
+	assert.Equal(t, `attachment; filename="report.txt"`, recorder.Header().Get("Content-Disposition"))
+	assert.Equal(t, "report-body", recorder.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, get("/missing").Code)
+	assert.Equal(t, http.StatusNotFound, get("/missing-attachment").Code)
+	require.Len(t, fileErrs, 2)
+	for _, err := range fileErrs {
+		assert.ErrorIs(t, err, ErrFileNotFound)
+	}
+}
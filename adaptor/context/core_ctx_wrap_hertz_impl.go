@@ -0,0 +1,309 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package context
+
+import (
+	ctxpkg "context"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/json"
+	"github.com/cloudwego/hertz/pkg/network"
+	"github.com/cloudwego/hertz/pkg/protocol"
+	"github.com/lamxy/fiberhouse/globalmanager"
+	"google.golang.org/protobuf/proto"
+)
+
+// hertzContextPool HertzContext 对象池
+var hertzContextPool = sync.Pool{
+	New: func() interface{} {
+		return &HertzContext{}
+	},
+}
+
+// HertzContext Hertz 框架适配器
+type HertzContext struct {
+	Ctx *app.RequestContext
+	// StdCtx Hertz 处理器收到的 context.Context，Next 以它调用后续处理器；为空时使用 context.Background
+	StdCtx ctxpkg.Context
+}
+
+// WithHertzContext 从对象池获取 Hertz 上下文适配器
+func WithHertzContext(c *app.RequestContext) ICoreContext {
+	ctx := hertzContextPool.Get().(*HertzContext)
+	ctx.Ctx = c
+	return ctx
+}
+
+// WithHertzHandlerContext 从对象池获取 Hertz 上下文适配器，并保留处理器的 context.Context 供 Next 使用
+func WithHertzHandlerContext(c ctxpkg.Context, reqCtx *app.RequestContext) ICoreContext {
+	ctx := hertzContextPool.Get().(*HertzContext)
+	ctx.Ctx = reqCtx
+	ctx.StdCtx = c
+	return ctx
+}
+
+// Release 释放 HertzContext 回对象池
+func (h *HertzContext) Release() {
+	h.Ctx = nil
+	h.StdCtx = nil
+	hertzContextPool.Put(h)
+}
+
+// GetCtx 获取原生上下文
+func (h *HertzContext) GetCtx() any {
+	return h.Ctx
+}
+
+// JSON 以 JSON 格式响应数据，hertz 的 RequestContext.JSON 无返回值，统一返回 nil
+func (h *HertzContext) JSON(statusCode int, data interface{}) error {
+	defer h.Release()
+	h.Ctx.JSON(statusCode, data)
+	return nil
+}
+
+// Send 发送原始字节数据，沿用调用方已设置的 Content-Type
+func (h *HertzContext) Send(statusCode int, body []byte) error {
+	defer h.Release()
+	h.Ctx.SetStatusCode(statusCode)
+	h.Ctx.Response.SetBody(body)
+	return nil
+}
+
+// GetHeader 获取请求头，hertz 返回 []byte，缺失时转换为空字符串
+func (h *HertzContext) GetHeader(key string) string {
+	return string(h.Ctx.GetHeader(key))
+}
+
+// SetHeader 设置响应头
+func (h *HertzContext) SetHeader(key string, value string) {
+	h.Ctx.Response.Header.Set(key, value)
+}
+
+// Vary 向 Vary 响应头追加字段
+func (h *HertzContext) Vary(fields ...string) {
+	if vary := appendVary(string(h.Ctx.Response.Header.Peek("Vary")), fields); vary != "" {
+		h.Ctx.Response.Header.Set("Vary", vary)
+	}
+}
+
+// Scope 获取请求作用域管理器，读取 RequestContext Keys
+func (h *HertzContext) Scope() *globalmanager.GlobalManager {
+	value, _ := h.Ctx.Get(globalmanager.ScopeLocalsKey)
+	return globalmanager.ScopeFromValue(value)
+}
+
+// ClientCertificate 获取经 mTLS 校验通过的客户端证书，连接实现 network.ConnTLSer 时读取其 TLS 状态
+func (h *HertzContext) ClientCertificate() *x509.Certificate {
+	conn, ok := h.Ctx.GetConn().(network.ConnTLSer)
+	if !ok {
+		return nil
+	}
+	state := conn.ConnectionState()
+	return verifiedClientCertificate(&state)
+}
+
+// Next 执行后续处理器，返回其间经 RequestContext.Error 记录的最后一个错误
+func (h *HertzContext) Next() error {
+	c := h.StdCtx
+	if c == nil {
+		c = ctxpkg.Background()
+	}
+	recorded := len(h.Ctx.Errors)
+	h.Ctx.Next(c)
+	if len(h.Ctx.Errors) > recorded {
+		return h.Ctx.Errors.Last().Err
+	}
+	return nil
+}
+
+// Method 获取请求方法
+func (h *HertzContext) Method() string {
+	return string(h.Ctx.Method())
+}
+
+// Path 获取请求路径
+func (h *HertzContext) Path() string {
+	return string(h.Ctx.Path())
+}
+
+// Param 获取路由参数
+func (h *HertzContext) Param(key string) string {
+	return h.Ctx.Param(key)
+}
+
+// Params 获取全部路由参数
+func (h *HertzContext) Params() map[string]string {
+	params := make(map[string]string, len(h.Ctx.Params))
+	for _, param := range h.Ctx.Params {
+		params[param.Key] = param.Value
+	}
+	return params
+}
+
+// Query 获取查询参数
+func (h *HertzContext) Query(key string, defaultValue ...string) string {
+	return queryDefault(h.Ctx.Query(key), defaultValue)
+}
+
+// Queries 获取全部查询参数
+func (h *HertzContext) Queries() url.Values {
+	values := url.Values{}
+	h.Ctx.QueryArgs().VisitAll(func(key, value []byte) {
+		values.Add(string(key), string(value))
+	})
+	return values
+}
+
+// FormValue 获取请求体表单字段，不读取查询参数
+func (h *HertzContext) FormValue(key string) string {
+	if value := h.Ctx.Request.PostArgs().Peek(key); len(value) > 0 {
+		return string(value)
+	}
+	if form, err := h.Ctx.MultipartForm(); err == nil && len(form.Value[key]) > 0 {
+		return form.Value[key][0]
+	}
+	return ""
+}
+
+// Body 获取原始请求体
+func (h *HertzContext) Body() ([]byte, error) {
+	return h.Ctx.Body()
+}
+
+// BindBody 使用 Hertz Bind 解码请求
+func (h *HertzContext) BindBody(out interface{}) error {
+	return h.Ctx.Bind(out)
+}
+
+// Cookie 获取请求 Cookie
+func (h *HertzContext) Cookie(name string) string {
+	return string(h.Ctx.Cookie(name))
+}
+
+// ClientIP 获取客户端 IP，由 Hertz 的 ClientIP 解析函数决定是否读取代理头
+func (h *HertzContext) ClientIP() string {
+	return h.Ctx.ClientIP()
+}
+
+// Locals 读写 RequestContext Keys
+func (h *HertzContext) Locals(key string, value ...interface{}) interface{} {
+	if len(value) > 0 {
+		h.Ctx.Set(key, value[0])
+		return value[0]
+	}
+	v, _ := h.Ctx.Get(key)
+	return v
+}
+
+// Status 设置响应状态码
+func (h *HertzContext) Status(statusCode int) ICoreContext {
+	h.Ctx.SetStatusCode(statusCode)
+	return h
+}
+
+// SetCookie 设置响应 Cookie
+func (h *HertzContext) SetCookie(cookie *http.Cookie) {
+	hc := protocol.AcquireCookie()
+	defer protocol.ReleaseCookie(hc)
+	hc.SetKey(cookie.Name)
+	hc.SetValue(cookie.Value)
+	hc.SetPath(cookie.Path)
+	hc.SetDomain(cookie.Domain)
+	hc.SetMaxAge(cookie.MaxAge)
+	if !cookie.Expires.IsZero() {
+		hc.SetExpire(cookie.Expires)
+	}
+	hc.SetSecure(cookie.Secure)
+	hc.SetHTTPOnly(cookie.HttpOnly)
+	switch cookie.SameSite {
+	case http.SameSiteLaxMode:
+		hc.SetSameSite(protocol.CookieSameSiteLaxMode)
+	case http.SameSiteStrictMode:
+		hc.SetSameSite(protocol.CookieSameSiteStrictMode)
+	case http.SameSiteNoneMode:
+		hc.SetSameSite(protocol.CookieSameSiteNoneMode)
+	}
+	h.Ctx.Response.Header.SetCookie(hc)
+}
+
+// JSONP 以 JSONP 格式响应数据，Hertz 无原生 JSONP，按 callback(json); 写出
+func (h *HertzContext) JSONP(statusCode int, data interface{}, callback string) error {
+	defer h.Release()
+	callback, err := resolveJSONPCallback(callback, h.Ctx.Query("callback"))
+	if err != nil {
+		return err
+	}
+	if callback == "" {
+		h.Ctx.JSON(statusCode, data)
+		return nil
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	h.Ctx.SetStatusCode(statusCode)
+	h.Ctx.SetContentType("application/javascript; charset=utf-8")
+	h.Ctx.Response.SetBody([]byte(callback + "(" + string(body) + ");"))
+	return nil
+}
+
+// XML 以 XML 格式响应数据
+func (h *HertzContext) XML(statusCode int, data interface{}) error {
+	defer h.Release()
+	h.Ctx.XML(statusCode, data)
+	return nil
+}
+
+// SendProto 以 Protobuf 格式响应消息
+func (h *HertzContext) SendProto(statusCode int, msg proto.Message) error {
+	defer h.Release()
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	h.Ctx.SetStatusCode(statusCode)
+	h.Ctx.SetContentType(MIMEProtobuf)
+	h.Ctx.Response.SetBody(body)
+	return nil
+}
+
+// SendStream 以分块方式发送 reader 的内容
+func (h *HertzContext) SendStream(statusCode int, contentType string, reader io.Reader) error {
+	defer h.Release()
+	if contentType != "" {
+		h.Ctx.SetContentType(contentType)
+	}
+	h.Ctx.SetStatusCode(statusCode)
+	h.Ctx.SetBodyStream(reader, -1)
+	return nil
+}
+
+// Redirect 重定向到 location
+func (h *HertzContext) Redirect(statusCode int, location string) error {
+	defer h.Release()
+	h.Ctx.Redirect(statusCode, []byte(location))
+	return nil
+}
+
+// File 发送文件内容
+func (h *HertzContext) File(path string) error {
+	defer h.Release()
+	h.Ctx.File(path)
+	return fileSent(h.Ctx.Response.StatusCode(), path)
+}
+
+// Attachment 以附件形式发送文件
+func (h *HertzContext) Attachment(path string, filename string) error {
+	defer h.Release()
+	h.Ctx.FileAttachment(path, attachmentName(path, filename))
+	return fileSent(h.Ctx.Response.StatusCode(), path)
+}
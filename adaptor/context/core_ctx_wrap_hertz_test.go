@@ -0,0 +1,152 @@
+package context
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/route/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestHertzContext_RequestAndResponseContract(t *testing.T) {
+	reqCtx := app.NewContext(0)
+	reqCtx.Request.Header.Set("X-Request", "request-value")
+
+	wrapped := WithHertzContext(reqCtx)
+	assert.Same(t, reqCtx, wrapped.GetCtx())
+	assert.Equal(t, "request-value", wrapped.GetHeader("X-Request"))
+
+	wrapped.SetHeader("X-Response", "response-value")
+	wrapped.SetHeader("Vary", "Origin")
+	wrapped.Vary("Accept", "origin")
+	require.NoError(t, wrapped.JSON(consts.StatusCreated, map[string]string{"core": "hertz"}))
+
+	assert.Equal(t, consts.StatusCreated, reqCtx.Response.StatusCode())
+	assert.Equal(t, "response-value", reqCtx.Response.Header.Get("X-Response"))
+	assert.Equal(t, "Origin, Accept", reqCtx.Response.Header.Get("Vary"))
+	assert.JSONEq(t, `{"core":"hertz"}`, string(reqCtx.Response.Body()))
+}
+
+func TestHertzContext_SendWritesRawBytes(t *testing.T) {
+	reqCtx := app.NewContext(0)
+	wrapped := WithHertzContext(reqCtx)
+
+	wrapped.SetHeader("Content-Type", "application/octet-stream")
+	require.NoError(t, wrapped.Send(consts.StatusAccepted, []byte("raw-hertz")))
+
+	assert.Equal(t, consts.StatusAccepted, reqCtx.Response.StatusCode())
+	assert.Equal(t, "application/octet-stream", reqCtx.Response.Header.Get("Content-Type"))
+	assert.Equal(t, "raw-hertz", string(reqCtx.Response.Body()))
+}
+
+// TestHertzContext_MissingHeaderReturnsEmpty 验证缺失请求头返回空字符串，
+// hertz 的 GetHeader 返回 []byte(nil)，转换后须为空字符串。
+func TestHertzContext_MissingHeaderReturnsEmpty(t *testing.T) {
+	reqCtx := app.NewContext(0)
+	assert.Equal(t, "", WithHertzContext(reqCtx).GetHeader("X-Absent"))
+}
+
+// TestHertzContext_ReleaseResetsPooledObject 验证回收后不残留上一个请求的上下文引用，
+// 避免对象池复用时发生跨请求数据泄漏。
+func TestHertzContext_ReleaseResetsPooledObject(t *testing.T) {
+	reqCtx := app.NewContext(0)
+	wrapped := WithHertzContext(reqCtx)
+
+	releasable, ok := wrapped.(interface{ Release() })
+	require.True(t, ok, "HertzContext 必须实现 Release() 以接入框架的对象池回收")
+	releasable.Release()
+
+	assert.Nil(t, wrapped.(*HertzContext).Ctx)
+}
+
+func TestHertzContext_RequestAccessors(t *testing.T) {
+	reqCtx := app.NewContext(0)
+	reqCtx.Request.SetMethod(consts.MethodPost)
+	reqCtx.Request.SetRequestURI("/users/42?page=2&tag=a&tag=b")
+	reqCtx.Request.Header.SetContentTypeBytes([]byte(consts.MIMEApplicationHTMLForm))
+	reqCtx.Request.SetBodyString("name=alice")
+	reqCtx.Request.Header.SetCookie("session", "s1")
+	reqCtx.Params = param.Params{{Key: "id", Value: "42"}}
+
+	wrapped := WithHertzContext(reqCtx)
+	assert.Equal(t, consts.MethodPost, wrapped.Method())
+	assert.Equal(t, "/users/42", wrapped.Path())
+	assert.Equal(t, "42", wrapped.Param("id"))
+	assert.Equal(t, map[string]string{"id": "42"}, wrapped.Params())
+	assert.Equal(t, "2", wrapped.Query("page"))
+	assert.Equal(t, "10", wrapped.Query("size", "10"))
+	assert.Equal(t, url.Values{"page": {"2"}, "tag": {"a", "b"}}, wrapped.Queries())
+	assert.Equal(t, "alice", wrapped.FormValue("name"))
+	assert.Empty(t, wrapped.FormValue("page"), "FormValue must not fall back to the query string")
+	assert.Equal(t, "s1", wrapped.Cookie("session"))
+	assert.Empty(t, wrapped.Cookie("absent"))
+	assert.Equal(t, "tenant-a", wrapped.Locals("tenant", "tenant-a"))
+	assert.Equal(t, "tenant-a", wrapped.Locals("tenant"))
+
+	body, err := wrapped.Body()
+	require.NoError(t, err)
+	assert.Equal(t, "name=alice", string(body))
+}
+
+func TestHertzContext_ResponseWriters(t *testing.T) {
+	type item struct {
+		Name string `xml:"name"`
+	}
+
+	reqCtx := app.NewContext(0)
+	wrapped := WithHertzContext(reqCtx)
+	wrapped.SetCookie(&http.Cookie{Name: "token", Value: "t1", Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode})
+	require.NoError(t, wrapped.Status(consts.StatusCreated).Send(consts.StatusAccepted, []byte("ok")))
+	assert.Equal(t, consts.StatusAccepted, reqCtx.Response.StatusCode(), "Send status overrides Status")
+	assert.Equal(t, "token=t1; path=/; HttpOnly; SameSite=Lax", string(reqCtx.Response.Header.Peek("Set-Cookie")))
+
+	reqCtx = app.NewContext(0)
+	reqCtx.Request.SetRequestURI("/jsonp?callback=app.cb")
+	require.NoError(t, WithHertzContext(reqCtx).JSONP(consts.StatusOK, map[string]bool{"ok": true}, ""))
+	assert.Equal(t, "application/javascript; charset=utf-8", string(reqCtx.Response.Header.ContentType()))
+	assert.Equal(t, `app.cb({"ok":true});`, string(reqCtx.Response.Body()))
+
+	reqCtx = app.NewContext(0)
+	reqCtx.Request.SetRequestURI("/jsonp?callback=" + url.QueryEscape("alert(1)//"))
+	assert.ErrorIs(t, WithHertzContext(reqCtx).JSONP(consts.StatusOK, map[string]bool{"ok": true}, ""), ErrInvalidJSONPCallback)
+
+	reqCtx = app.NewContext(0)
+	require.NoError(t, WithHertzContext(reqCtx).XML(consts.StatusOK, item{Name: "hertz"}))
+	assert.Equal(t, "<item><name>hertz</name></item>", string(reqCtx.Response.Body()))
+
+	reqCtx = app.NewContext(0)
+	require.NoError(t, WithHertzContext(reqCtx).SendProto(consts.StatusOK, wrapperspb.String("hertz")))
+	assert.Equal(t, MIMEProtobuf, string(reqCtx.Response.Header.ContentType()))
+	var message wrapperspb.StringValue
+	require.NoError(t, proto.Unmarshal(reqCtx.Response.Body(), &message))
+	assert.Equal(t, "hertz", message.GetValue())
+
+	reqCtx = app.NewContext(0)
+	require.NoError(t, WithHertzContext(reqCtx).SendStream(consts.StatusOK, "text/plain", strings.NewReader("streamed-hertz")))
+	assert.Equal(t, "text/plain", string(reqCtx.Response.Header.ContentType()))
+	streamed, err := io.ReadAll(reqCtx.Response.BodyStream())
+	require.NoError(t, err)
+	assert.Equal(t, "streamed-hertz", string(streamed))
+
+	reqCtx = app.NewContext(0)
+	reqCtx.Request.SetRequestURI("/source")
+	require.NoError(t, WithHertzContext(reqCtx).Redirect(consts.StatusFound, "/target"))
+	assert.Equal(t, consts.StatusFound, reqCtx.Response.StatusCode())
+	assert.Equal(t, "/target", string(reqCtx.Response.Header.Peek("Location")))
+
+	missing := filepath.Join(t.TempDir(), "missing.txt")
+	reqCtx = app.NewContext(0)
+	assert.ErrorIs(t, WithHertzContext(reqCtx).File(missing), ErrFileNotFound)
+	assert.Equal(t, consts.StatusNotFound, reqCtx.Response.StatusCode())
+	reqCtx = app.NewContext(0)
+	assert.ErrorIs(t, WithHertzContext(reqCtx).Attachment(missing, ""), ErrFileNotFound)
+}
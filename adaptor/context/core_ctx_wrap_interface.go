@@ -6,17 +6,164 @@
 
 package context
 
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lamxy/fiberhouse/globalmanager"
+	"google.golang.org/protobuf/proto"
+)
+
+// MIMEProtobuf Protobuf 响应的 Content-Type
+const MIMEProtobuf = "application/x-protobuf"
+
+// ErrInvalidJSONPCallback JSONP 回调函数名不是合法的 JavaScript 标识符
+var ErrInvalidJSONPCallback = errors.New("invalid JSONP callback")
+
+// ErrFileNotFound File、Attachment 发送的文件不存在，响应已写入 404
+var ErrFileNotFound = errors.New("file not found")
+
+// jsonpCallbackPattern 允许的回调函数名：标识符或以点分隔的成员访问，如 jQuery123.cb
+var jsonpCallbackPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*$`)
+
 // ICoreContext 统一核心的上下文包装器接口
+//
+// 发送响应的方法（JSON、Send、JSONP、XML、SendProto、SendStream、Redirect、File、Attachment）返回时
+// 把包装器归还对象池，调用后不可再使用该包装器；其余方法可多次调用。
 type ICoreContext interface {
 	// GetCtx 获取底层的原生上下文对象
 	GetCtx() interface{}
+
+	// Method 获取请求方法
+	Method() string
+	// Path 获取请求路径，不含查询串
+	Path() string
+	// Param 获取路由参数，不存在时返回空字符串
+	Param(key string) string
+	// Params 获取全部路由参数
+	Params() map[string]string
+	// Query 获取查询参数，不存在或为空时返回 defaultValue 的第一个值
+	Query(key string, defaultValue ...string) string
+	// Queries 获取全部查询参数
+	Queries() url.Values
+	// FormValue 获取请求体中的表单字段（urlencoded 或 multipart），不读取查询参数
+	FormValue(key string) string
+	// Body 获取原始请求体，可重复读取
+	Body() ([]byte, error)
+	// BindBody 按 Content-Type 把请求体（JSON、XML、表单）解码到 out，使用引擎原生绑定
+	BindBody(out interface{}) error
 	// GetHeader 获取请求头信息
 	GetHeader(key string) string
+	// Cookie 获取请求 Cookie 的值，不存在时返回空字符串
+	Cookie(name string) string
+	// ClientIP 获取客户端 IP，按核心配置的 proxyHeader 从代理头读取，未配置时为连接对端地址
+	ClientIP() string
+	// Locals 读写请求级的键值，传入 value 时写入并返回该值，否则返回已存的值
+	Locals(key string, value ...interface{}) interface{}
+	// Scope 获取请求作用域管理器（globalmanager 子作用域），请求处理返回后自动释放；未挂载请求作用域中间件时返回 nil
+	Scope() *globalmanager.GlobalManager
+	// ClientCertificate 获取经 mTLS 校验通过的客户端证书（验证链的叶子证书）；非 TLS 连接或客户端证书未经校验时返回 nil
+	ClientCertificate() *x509.Certificate
+	// Next 在中间件中执行后续处理器链，返回链中处理器产生的错误
+	Next() error
+
+	// Status 设置响应状态码，返回自身以便链式调用
+	Status(statusCode int) ICoreContext
 	// SetHeader 设置响应头信息
 	SetHeader(key string, value string)
+	// Vary 向 Vary 响应头追加字段，已存在的字段（不区分大小写）不重复追加
+	Vary(fields ...string)
+	// SetCookie 设置响应 Cookie
+	SetCookie(cookie *http.Cookie)
 	// JSON 以 JSON 格式响应数据
 	JSON(statusCode int, data interface{}) error
+	// JSONP 以 JSONP 格式响应数据；callback 为空时读取查询参数 callback，仍为空时按 JSON 响应，非法函数名返回错误
+	JSONP(statusCode int, data interface{}, callback string) error
+	// XML 以 XML 格式响应数据
+	XML(statusCode int, data interface{}) error
+	// SendProto 以 application/x-protobuf 响应 Protobuf 消息
+	SendProto(statusCode int, msg proto.Message) error
 	// Send 发送原始字节数据
 	Send(statusCode int, body []byte) error
-	// TODO 支持更多的方法： JSONP、XML、SendProto...
+	// SendStream 以分块方式发送 reader 的内容，contentType 为空时不设置 Content-Type
+	SendStream(statusCode int, contentType string, reader io.Reader) error
+	// Redirect 重定向到 location，statusCode 通常为 301、302、303、307 或 308
+	Redirect(statusCode int, location string) error
+	// File 发送文件内容，Content-Type 按扩展名推断；文件不存在时写入 404 并返回错误
+	File(path string) error
+	// Attachment 以附件形式发送文件，filename 为空时取文件名；文件不存在时写入 404 并返回错误
+	Attachment(path string, filename string) error
+}
+
+// verifiedClientCertificate 返回连接状态中已校验的客户端叶子证书
+func verifiedClientCertificate(state *tls.ConnectionState) *x509.Certificate {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return state.VerifiedChains[0][0]
+}
+
+// resolveJSONPCallback 返回 JSONP 回调函数名：优先使用 callback，其次 query；均为空时返回空字符串
+func resolveJSONPCallback(callback, query string) (string, error) {
+	if callback == "" {
+		callback = query
+	}
+	if callback != "" && !jsonpCallbackPattern.MatchString(callback) {
+		return "", ErrInvalidJSONPCallback
+	}
+	return callback, nil
+}
+
+// queryDefault 值为空时返回 defaultValue 的第一个值
+func queryDefault(value string, defaultValue []string) string {
+	if value == "" && len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return value
+}
+
+// attachmentName 返回附件文件名，filename 为空时取 path 的文件名
+func attachmentName(path, filename string) string {
+	if filename != "" {
+		return filename
+	}
+	return filepath.Base(path)
+}
+
+// fileSent 文件响应状态为 404 时返回 ErrFileNotFound，与 Fiber SendFile 的返回一致
+func fileSent(statusCode int, path string) error {
+	if statusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrFileNotFound, path)
+	}
+	return nil
+}
+
+// appendVary 把 fields 追加到已有的 Vary 值，跳过已存在的字段；已有值为 * 时保持不变
+func appendVary(existing string, fields []string) string {
+	if strings.TrimSpace(existing) == "*" {
+		return existing
+	}
+	present := map[string]bool{}
+	values := make([]string, 0, len(fields)+1)
+	for _, field := range strings.Split(existing, ",") {
+		if field = strings.TrimSpace(field); field != "" && !present[strings.ToLower(field)] {
+			present[strings.ToLower(field)] = true
+			values = append(values, field)
+		}
+	}
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" && !present[strings.ToLower(field)] {
+			present[strings.ToLower(field)] = true
+			values = append(values, field)
+		}
+	}
+	return strings.Join(values, ", ")
 }
@@ -1,11 +1,13 @@
 package errorhandler
 
 import (
+	ctxpkg "context"
 	"errors"
 	"io"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/gin-gonic/gin"
 	"github.com/gofiber/fiber/v2"
 	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
@@ -65,3 +67,20 @@ func TestFiberErrorHandler_PropagatesCallbackError(t *testing.T) {
 	require.NoError(t, err)
 	assert.ErrorIs(t, got, callbackErr)
 }
+
+func TestHertzErrorHandler_MatchesFiberHandledResponse(t *testing.T) {
+	sentinel := errors.New("route failed")
+	callback := func(ctx adaptorctx.ICoreContext, got error) error {
+		assert.ErrorIs(t, got, sentinel)
+		return ctx.JSON(fiber.StatusTeapot, map[string]bool{"handled": true})
+	}
+	reqCtx := app.NewContext(0)
+	reqCtx.SetHandlers(app.HandlersChain{
+		HertzErrorHandler(callback),
+		func(_ ctxpkg.Context, c *app.RequestContext) { _ = c.Error(sentinel) },
+	})
+
+	reqCtx.Next(ctxpkg.Background())
+	assert.Equal(t, fiber.StatusTeapot, reqCtx.Response.StatusCode())
+	assert.JSONEq(t, `{"handled":true}`, string(reqCtx.Response.Body()))
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package errorhandler
+
+import (
+	ctxpkg "context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+)
+
+// HertzErrorHandler 创建一个 Hertz 框架的错误处理适配器，语义与 GinErrorHandler 一致：
+// 处理器通过 c.Error(err) 追加的错误链在请求结束后交由统一错误处理函数输出
+func HertzErrorHandler(fn func(adaptorctx.ICoreContext, error) error) app.HandlerFunc {
+	return func(c ctxpkg.Context, reqCtx *app.RequestContext) {
+		reqCtx.Next(c)
+		if last := reqCtx.Errors.Last(); last != nil {
+			err := fn(adaptorctx.WithHertzContext(reqCtx), last.Err)
+			if err != nil {
+				panic(err)
+			}
+			return
+		}
+		if err, ok := reqCtx.Get("error"); ok { // 自定义错误处理
+			if errObj, isErr := err.(error); isErr {
+				err := fn(adaptorctx.WithHertzContext(reqCtx), errObj)
+				if err != nil {
+					panic(err)
+				}
+				return
+			}
+		}
+	}
+}
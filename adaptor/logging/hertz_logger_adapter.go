@@ -1,4 +1,10 @@
-package adaptor
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package logging
 
 import (
 	ctxpkg "context"
@@ -21,13 +27,13 @@ const (
 	hertzSystemLogPrefix = "HERTZ: "
 )
 
-// TraceIDContextKey 请求追踪 ID 在标准 context 中的键。
+// HertzTraceIDContextKey 请求追踪 ID 在标准 context 中的键。
 //
 // 使用具名类型而非裸字符串，避免与其他包的 context 键冲突。
-type traceIDContextKey struct{}
+type hertzTraceIDContextKey struct{}
 
-// TraceIDContextKey 供中间件写入、日志适配器读取请求追踪 ID
-var TraceIDContextKey = traceIDContextKey{}
+// HertzTraceIDContextKey 供中间件写入、日志适配器读取请求追踪 ID
+var HertzTraceIDContextKey = hertzTraceIDContextKey{}
 
 // HertzLoggerAdapter 将 hertz 引擎的日志与调试输出映射到框架统一日志器。
 //
@@ -52,11 +58,11 @@ func NewHertzLoggerAdapter(
 	}
 }
 
-// levelOf 将 hertz 日志级别映射到框架（zerolog）级别。
+// hertzLevelOf 将 hertz 日志级别映射到框架（zerolog）级别。
 //
 // hertz 的 Trace 与 Notice 在框架中没有对应级别，分别归并到 Debug 与 Info，
 // 保持语义相近且不丢失信息。
-func levelOf(lv hlog.Level) zerolog.Level {
+func hertzLevelOf(lv hlog.Level) zerolog.Level {
 	switch lv {
 	case hlog.LevelTrace, hlog.LevelDebug:
 		return zerolog.DebugLevel
@@ -76,7 +82,7 @@ func levelOf(lv hlog.Level) zerolog.Level {
 // event 按级别取得框架日志事件，并附加组件标识
 func (a *HertzLoggerAdapter) event(lv hlog.Level) *zerolog.Event {
 	var event *zerolog.Event
-	switch levelOf(lv) {
+	switch hertzLevelOf(lv) {
 	case zerolog.DebugLevel:
 		event = a.logger.DebugWith(a.origin)
 	case zerolog.WarnLevel:
@@ -91,8 +97,8 @@ func (a *HertzLoggerAdapter) event(lv hlog.Level) *zerolog.Event {
 	return event.Str("Component", hertzLogComponent)
 }
 
-// normalize 剥离 hertz 系统前缀与行尾换行
-func normalize(message string) string {
+// normalizeHertzMessage 剥离 hertz 系统前缀与行尾换行
+func normalizeHertzMessage(message string) string {
 	message = strings.TrimRight(message, "\r\n")
 	message = strings.TrimPrefix(message, hertzSystemLogPrefix)
 	return strings.TrimRight(message, "\r\n")
@@ -100,7 +106,7 @@ func normalize(message string) string {
 
 // emit 输出一条日志；空消息直接丢弃
 func (a *HertzLoggerAdapter) emit(lv hlog.Level, message string) {
-	if message = normalize(message); message == "" {
+	if message = normalizeHertzMessage(message); message == "" {
 		return
 	}
 	a.event(lv).Msg(message)
@@ -112,23 +118,23 @@ func (a *HertzLoggerAdapter) emitCtx(
 	lv hlog.Level,
 	message string,
 ) {
-	if message = normalize(message); message == "" {
+	if message = normalizeHertzMessage(message); message == "" {
 		return
 	}
 
 	event := a.event(lv)
-	if traceID := traceIDFrom(ctx); traceID != "" {
+	if traceID := hertzTraceIDFrom(ctx); traceID != "" {
 		event = event.Str("traceId", traceID)
 	}
 	event.Msg(message)
 }
 
-// traceIDFrom 从标准 context 提取请求追踪 ID；缺失时返回空字符串
-func traceIDFrom(ctx ctxpkg.Context) string {
+// hertzTraceIDFrom 从标准 context 提取请求追踪 ID；缺失时返回空字符串
+func hertzTraceIDFrom(ctx ctxpkg.Context) string {
 	if ctx == nil {
 		return ""
 	}
-	if v, ok := ctx.Value(TraceIDContextKey).(string); ok {
+	if v, ok := ctx.Value(HertzTraceIDContextKey).(string); ok {
 		return v
 	}
 	return ""
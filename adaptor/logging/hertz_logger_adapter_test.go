@@ -1,4 +1,4 @@
-package adaptor
+package logging
 
 import (
 	"bytes"
@@ -117,7 +117,7 @@ func TestHertzLoggerAdapter_SkipsEmptyMessage(t *testing.T) {
 // 提取 traceId，使引擎日志可与业务请求链路关联。
 func TestHertzLoggerAdapter_CtxVariantsAttachTraceID(t *testing.T) {
 	adapter, output := newTestHertzLogAdapter(t)
-	ctx := ctxpkg.WithValue(ctxpkg.Background(), TraceIDContextKey, "trace-xyz")
+	ctx := ctxpkg.WithValue(ctxpkg.Background(), HertzTraceIDContextKey, "trace-xyz")
 
 	adapter.CtxInfof(ctx, "handling request")
 
@@ -1,4 +1,10 @@
-package adaptor
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package logging
 
 import (
 	ctxpkg "context"
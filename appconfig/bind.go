@@ -0,0 +1,388 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package appconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	bindTagName    = "koanf"   // 配置 key 名标签，缺省时使用字段名
+	defaultTagName = "default" // 默认值标签
+)
+
+// SchemaIssue 配置绑定或校验的单个问题
+type SchemaIssue struct {
+	// Key 出问题的完整配置 key
+	Key string
+	// Message 问题描述
+	Message string
+}
+
+// SchemaError 配置绑定或校验失败，汇总全部问题
+type SchemaError struct {
+	Issues []SchemaIssue
+}
+
+// Error 逐行列出全部问题
+func (e *SchemaError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid config:")
+	for _, issue := range e.Issues {
+		b.WriteString("\n  - ")
+		b.WriteString(issue.Key)
+		b.WriteString(": ")
+		b.WriteString(issue.Message)
+	}
+	return b.String()
+}
+
+// StructValidator 配置结构体校验器，按 validate 标签校验已绑定的结构体，返回问题的 key 相对于结构体根
+type StructValidator func(schema interface{}) []SchemaIssue
+
+var (
+	structValidatorMu sync.RWMutex
+	structValidator   StructValidator = defaultStructValidator()
+
+	schemaMu sync.RWMutex
+	schemas  = make(map[string]reflect.Type)
+)
+
+// SetStructValidator 设置 Bind 使用的结构体校验器，nil 恢复为内置校验器；bootstrap 启动时设置为 component/validate 提供的带翻译校验器
+func SetStructValidator(v StructValidator) {
+	if v == nil {
+		v = defaultStructValidator()
+	}
+	structValidatorMu.Lock()
+	structValidator = v
+	structValidatorMu.Unlock()
+}
+
+// ConfigFieldName 返回配置结构体字段对应的配置 key 名：koanf 标签名，缺省时为字段名
+func ConfigFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get(bindTagName), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// Bind 将 path 下的配置绑定到结构体 T 并校验
+//
+// 字段按 koanf 标签（缺省为字段名，大小写不敏感）匹配配置 key；配置缺失的字段取 default 标签的值，显式配置的零值保留；
+// 字符串形式的环境变量值按字段类型转换，time.Duration 字段接受 "5s" 形式的字符串。绑定后按 validate 标签校验。
+// 字符串值中的密钥引用在绑定前解析，见 ResolveSecret。
+// 类型不符、校验失败和结构体中不存在的 key 汇总为 *SchemaError 返回。path 为空时绑定整个配置树。
+func Bind[T any](cfg IAppConfig, path string) (T, error) {
+	var out T
+	target := reflect.ValueOf(&out).Elem()
+	if target.Kind() != reflect.Struct {
+		return out, fmt.Errorf("appconfig.Bind: target type %s is not a struct", target.Type())
+	}
+	if issues := bindValue(cfg, path, target); len(issues) > 0 {
+		return out, &SchemaError{Issues: issues}
+	}
+	return out, nil
+}
+
+// MustBind 同 Bind，失败时 panic
+func MustBind[T any](cfg IAppConfig, path string) T {
+	out, err := Bind[T](cfg, path)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// RegisterSchema 注册配置段结构，供启动期 CheckSchemas 校验；schema 为结构体零值，同一 path 重复注册时后者覆盖
+//
+// 注册需在 bootstrap.NewConfigOnce 之前完成，组件通常在包初始化时注册其默认配置路径。
+func RegisterSchema(path string, schema interface{}) {
+	t := reflect.TypeOf(schema)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("appconfig.RegisterSchema: schema for '%s' is not a struct", path))
+	}
+	schemaMu.Lock()
+	schemas[path] = t
+	schemaMu.Unlock()
+}
+
+// RegisteredSchemas 返回已注册的配置段路径，已排序
+func RegisteredSchemas() []string {
+	schemaMu.RLock()
+	defer schemaMu.RUnlock()
+	paths := make([]string, 0, len(schemas))
+	for path := range schemas {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// CheckSchemas 按注册的结构校验配置中存在的配置段，汇总全部类型错误、校验失败与未知 key；配置中缺失的配置段跳过
+//
+// 签名与 ConfigValidator 一致，可通过 AddValidator 让热更新同样拒绝不合规的配置。
+func CheckSchemas(cfg IAppConfig) error {
+	ko, err := GetCoreWithConfig[*koanf.Koanf](cfg)
+	if err != nil {
+		return err
+	}
+	var issues []SchemaIssue
+	for _, path := range RegisteredSchemas() {
+		if !ko.Exists(path) {
+			continue
+		}
+		schemaMu.RLock()
+		t := schemas[path]
+		schemaMu.RUnlock()
+		issues = append(issues, bindValue(cfg, path, reflect.New(t).Elem())...)
+	}
+	if len(issues) > 0 {
+		return &SchemaError{Issues: issues}
+	}
+	return nil
+}
+
+// bindValue 绑定并校验 path 下的配置到 target，返回全部问题
+func bindValue(cfg IAppConfig, path string, target reflect.Value) []SchemaIssue {
+	ko, err := GetCoreWithConfig[*koanf.Koanf](cfg)
+	if err != nil {
+		return []SchemaIssue{{Key: path, Message: err.Error()}}
+	}
+	delim := ko.Delim()
+	var issues []SchemaIssue
+
+	if defaults := defaultValues(target.Type()); len(defaults) > 0 {
+		if err := decodeInto(defaults, target); err != nil {
+			issues = append(issues, decodeIssues(path, delim, fmt.Errorf("default tag: %w", err))...)
+		}
+	}
+
+	var raw interface{}
+	if path == "" {
+		raw = ko.Raw()
+	} else {
+		raw = ko.Get(path)
+	}
+	if raw != nil {
+		if err := decodeInto(raw, target); err != nil {
+			issues = append(issues, decodeIssues(path, delim, err)...)
+		}
+		if sub, ok := raw.(map[string]interface{}); ok {
+			for _, key := range unknownKeys(target.Type(), sub, delim) {
+				issues = append(issues, SchemaIssue{Key: joinKey(path, key, delim), Message: "unknown key"})
+			}
+		}
+	}
+	if len(issues) > 0 {
+		return sortIssues(issues)
+	}
+
+	structValidatorMu.RLock()
+	validate := structValidator
+	structValidatorMu.RUnlock()
+	for _, issue := range validate(target.Addr().Interface()) {
+		issues = append(issues, SchemaIssue{Key: joinKey(path, issue.Key, delim), Message: issue.Message})
+	}
+	return sortIssues(issues)
+}
+
+func decodeInto(input interface{}, target reflect.Value) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			secretHookFunc(),
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.TextUnmarshallerHookFunc()),
+		WeaklyTypedInput: true,
+		TagName:          bindTagName,
+		Result:           target.Addr().Interface(),
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(input)
+}
+
+// secretHookFunc 绑定前解析字符串值中的密钥引用
+func secretHookFunc() mapstructure.DecodeHookFuncKind {
+	return func(from reflect.Kind, to reflect.Kind, data interface{}) (interface{}, error) {
+		if from != reflect.String {
+			return data, nil
+		}
+		return ResolveSecret(data.(string))
+	}
+}
+
+// decodeIssues 将 mapstructure 的聚合错误拆分为逐个 key 的问题
+func decodeIssues(path, delim string, err error) []SchemaIssue {
+	var issues []SchemaIssue
+	var walk func(err error)
+	walk = func(err error) {
+		decodeErr, isDecodeErr := err.(*mapstructure.DecodeError)
+		switch {
+		case isDecodeErr:
+			issues = append(issues, SchemaIssue{Key: joinKey(path, decodeErr.Name(), delim), Message: decodeErr.Unwrap().Error()})
+		case isJoined(err):
+			for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+				walk(e)
+			}
+		case errors.Unwrap(err) != nil:
+			walk(errors.Unwrap(err))
+		default:
+			issues = append(issues, SchemaIssue{Key: path, Message: err.Error()})
+		}
+	}
+	walk(err)
+	return issues
+}
+
+func isJoined(err error) bool {
+	_, ok := err.(interface{ Unwrap() []error })
+	return ok
+}
+
+// defaultValues 按 default 标签生成嵌套的默认值 map
+func defaultValues(t reflect.Type) map[string]interface{} {
+	values := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if def, ok := field.Tag.Lookup(defaultTagName); ok {
+			values[ConfigFieldName(field)] = def
+			continue
+		}
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			continue
+		}
+		if ft.Kind() == reflect.Struct {
+			if nested := defaultValues(ft); len(nested) > 0 {
+				if squashed(field) {
+					for k, v := range nested {
+						values[k] = v
+					}
+				} else {
+					values[ConfigFieldName(field)] = nested
+				}
+			}
+		}
+	}
+	return values
+}
+
+// unknownKeys 返回配置中在结构体里找不到对应字段的 key（相对路径），map、interface 与非结构体字段下的 key 不再展开
+func unknownKeys(t reflect.Type, raw map[string]interface{}, delim string) []string {
+	var unknown []string
+	for key, value := range raw {
+		field, ok := lookupField(t, key)
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		nested, isMap := value.(map[string]interface{})
+		if !isMap || ft.Kind() != reflect.Struct {
+			continue
+		}
+		for _, sub := range unknownKeys(ft, nested, delim) {
+			unknown = append(unknown, key+delim+sub)
+		}
+	}
+	return unknown
+}
+
+// lookupField 按配置 key 查找结构体字段（大小写不敏感，展开 squash 的嵌入结构体）
+func lookupField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get(bindTagName) == "-" {
+			continue
+		}
+		if squashed(field) && field.Type.Kind() == reflect.Struct {
+			if found, ok := lookupField(field.Type, key); ok {
+				return found, true
+			}
+			continue
+		}
+		if strings.EqualFold(ConfigFieldName(field), key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func squashed(field reflect.StructField) bool {
+	_, opts, _ := strings.Cut(field.Tag.Get(bindTagName), ",")
+	return field.Anonymous && strings.Contains(opts, "squash")
+}
+
+func joinKey(path, key, delim string) string {
+	switch {
+	case path == "":
+		return key
+	case key == "":
+		return path
+	default:
+		return path + delim + key
+	}
+}
+
+func sortIssues(issues []SchemaIssue) []SchemaIssue {
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Key < issues[j].Key })
+	return issues
+}
+
+// defaultStructValidator 内置结构体校验器，字段名取配置 key 名，消息为未翻译的校验标签
+func defaultStructValidator() StructValidator {
+	va := validator.New(validator.WithRequiredStructEnabled())
+	va.RegisterTagNameFunc(ConfigFieldName)
+	return func(schema interface{}) []SchemaIssue {
+		err := va.Struct(schema)
+		var errs validator.ValidationErrors
+		if !errors.As(err, &errs) {
+			if err != nil {
+				return []SchemaIssue{{Message: err.Error()}}
+			}
+			return nil
+		}
+		issues := make([]SchemaIssue, 0, len(errs))
+		for _, fe := range errs {
+			msg := fmt.Sprintf("failed on the '%s' tag", fe.Tag())
+			if fe.Param() != "" {
+				msg = fmt.Sprintf("failed on the '%s=%s' tag", fe.Tag(), fe.Param())
+			}
+			issues = append(issues, SchemaIssue{Key: ValidationKey(fe.Namespace()), Message: msg})
+		}
+		return issues
+	}
+}
+
+// ValidationKey 将校验错误的命名空间（根结构体名.字段路径）转换为相对配置 key
+func ValidationKey(namespace string) string {
+	_, key, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+	return key
+}
@@ -0,0 +1,104 @@
+package appconfig
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bindPoolConfig struct {
+	Size    int           `koanf:"size" default:"8" validate:"gt=0"`
+	Timeout time.Duration `koanf:"timeout" default:"3s"`
+}
+
+type bindTestConfig struct {
+	Host    string         `koanf:"host" validate:"required"`
+	Port    int            `koanf:"port" default:"6379" validate:"gt=0,lt=65536"`
+	Debug   bool           `koanf:"debug"`
+	Retries int            `koanf:"retries" default:"3"`
+	Pool    bindPoolConfig `koanf:"pool"`
+	Labels  map[string]string
+}
+
+func TestBind_DefaultsAndWeakTyping(t *testing.T) {
+	cfg := NewAppConfig().LoadDefault(map[string]interface{}{
+		"svc.host":         "10.0.0.1",
+		"svc.port":         "6380", // 环境变量形式的字符串
+		"svc.debug":        "true",
+		"svc.retries":      0, // 显式零值不被默认值覆盖
+		"svc.pool.timeout": "500ms",
+		"svc.labels.zone":  "a",
+	}).Initialize()
+
+	got, err := Bind[bindTestConfig](cfg, "svc")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", got.Host)
+	assert.Equal(t, 6380, got.Port)
+	assert.True(t, got.Debug)
+	assert.Zero(t, got.Retries)
+	assert.Equal(t, 8, got.Pool.Size)
+	assert.Equal(t, 500*time.Millisecond, got.Pool.Timeout)
+	assert.Equal(t, map[string]string{"zone": "a"}, got.Labels)
+}
+
+func TestBind_ReportsAllIssues(t *testing.T) {
+	cfg := NewAppConfig().LoadDefault(map[string]interface{}{
+		"svc.port":      "not-a-port",
+		"svc.hots":      "typo",
+		"svc.pool.size": "many",
+		"svc.pool.idle": 10,
+	}).Initialize()
+
+	_, err := Bind[bindTestConfig](cfg, "svc")
+	var schemaErr *SchemaError
+	require.True(t, errors.As(err, &schemaErr))
+	keys := make([]string, 0, len(schemaErr.Issues))
+	for _, issue := range schemaErr.Issues {
+		keys = append(keys, issue.Key)
+	}
+	assert.Equal(t, []string{"svc.hots", "svc.pool.idle", "svc.pool.size", "svc.port"}, keys)
+	assert.Contains(t, err.Error(), "svc.hots: unknown key")
+
+	// 类型正确时按 validate 标签校验
+	cfg = NewAppConfig().LoadDefault(map[string]interface{}{"svc.port": 70000, "svc.pool.size": 0}).Initialize()
+	_, err = Bind[bindTestConfig](cfg, "svc")
+	require.True(t, errors.As(err, &schemaErr))
+	assert.Equal(t, []SchemaIssue{
+		{Key: "svc.host", Message: "failed on the 'required' tag"},
+		{Key: "svc.pool.size", Message: "failed on the 'gt=0' tag"},
+		{Key: "svc.port", Message: "failed on the 'lt=65536' tag"},
+	}, schemaErr.Issues)
+
+	assert.Panics(t, func() { MustBind[bindTestConfig](cfg, "svc") })
+	_, err = Bind[int](cfg, "svc")
+	assert.Error(t, err)
+}
+
+func TestCheckSchemas_ValidatesRegisteredSections(t *testing.T) {
+	const path = "bindtest.svc"
+	RegisterSchema(path, &bindTestConfig{})
+	t.Cleanup(func() {
+		schemaMu.Lock()
+		delete(schemas, path)
+		schemaMu.Unlock()
+	})
+	assert.Contains(t, RegisteredSchemas(), path)
+	assert.Panics(t, func() { RegisterSchema("bindtest.bad", 1) })
+
+	// 配置中缺失的配置段跳过
+	require.NoError(t, CheckSchemas(NewAppConfig().Initialize()))
+
+	rc, fp := newReloadableConfig(t, "bindtest:\n  svc:\n    host: a\n")
+	require.NoError(t, CheckSchemas(rc))
+	rc.AddValidator(CheckSchemas)
+
+	rewriteYAML(t, fp, "bindtest:\n  svc:\n    host: a\n    port: x\n    extra: 1\n")
+	err := rc.Reload()
+	require.ErrorIs(t, err, ErrConfigInvalid)
+	assert.Contains(t, err.Error(), "bindtest.svc.extra: unknown key")
+	assert.Contains(t, err.Error(), "bindtest.svc.port:")
+	assert.Equal(t, 6379, MustBind[bindTestConfig](rc, path).Port, "rejected config is not committed")
+}
@@ -13,8 +13,10 @@ import (
 	"github.com/lamxy/fiberhouse/constant"
 	"github.com/lamxy/fiberhouse/globalmanager"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/knadh/koanf/parsers/yaml"
@@ -112,9 +114,10 @@ type IAppConfig interface {
 	配置加载方法
 	*/
 
-	LoadDefault(m map[string]interface{}) IAppConfig   // 加载默认Map配置，后加载覆盖先加载的配置；可用于测试时快速设定指定的配置项
-	LoadYaml(filename ...string) IAppConfig            // 加载yaml配置文件，后加载覆盖先加载的配置
-	LoadFunc(f func(IAppConfig) IAppConfig) IAppConfig // 通过回调自定义加载配置，后加载覆盖先加载的配置
+	LoadDefault(m map[string]interface{}) IAppConfig              // 加载默认Map配置，后加载覆盖先加载的配置；可用于测试时快速设定指定的配置项
+	LoadYaml(filename ...string) IAppConfig                       // 加载yaml配置文件，后加载覆盖先加载的配置
+	LoadFunc(f func(IAppConfig) IAppConfig) IAppConfig            // 通过回调自定义加载配置，后加载覆盖先加载的配置
+	LoadSource(src Source, priority ...SourcePriority) IAppConfig // 加载配置来源，按优先级覆盖，缺省时同上
 
 	/**
 	配置路径管理
@@ -195,13 +198,27 @@ func GetCoreWithConfig[T any](config IAppConfig) (T, error) {
 
 // AppConfig 应用配置对象
 // 注意：应用配置非并发安全，建议只读，仅在应用启动阶段按需可写，运行时阶段禁止任何直接写，以免引起并发数据竞争问题。有限使用安全读写方法。
+// 配置树通过 Reload 整体原子替换，运行时读取始终看到某一完整版本的配置。
 type AppConfig struct {
-	ko        *koanf.Koanf // 私有属性
+	ko        atomic.Pointer[koanf.Koanf] // 私有属性，热更新时原子替换
+	delim     string
 	confPath  string
 	container *globalmanager.GlobalManager
 	lock      sync.RWMutex // 安全读写锁
 
-	// 基本配置项
+	// 热更新：记录的加载步骤、校验器与订阅者
+	loaders     []loadStep
+	loading     bool              // LoadFunc 回调执行中，嵌套加载不重复记录
+	origins     map[string]string // 扁平 key -> 最终生效的加载步骤名称
+	watchFiles  map[string]struct{}
+	reloadMu    sync.Mutex
+	validators  []ConfigValidator
+	subMu       sync.RWMutex
+	subscribers map[uint64]subscription
+	subSeq      uint64
+
+	// 基本配置项，热更新时整体替换
+	baseMu          sync.RWMutex
 	applicationBase ConfApplicationBase
 	appLogBase      ConfAppLogBase
 	recoverBase     ConfRecoverBase
@@ -227,10 +244,8 @@ func NewAppConfig(delim ...string) *AppConfig {
 	} else {
 		d = defaultDelimiter
 	}
-	ko := koanf.New(d)
-
-	return &AppConfig{
-		ko:        ko,
+	ac := &AppConfig{
+		delim:     d,
 		confPath:  p,
 		container: globalmanager.NewGlobalManagerOnce(),
 		lock:      sync.RWMutex{},
@@ -251,31 +266,46 @@ func NewAppConfig(delim ...string) *AppConfig {
 		middleware: map[string]bool{
 			"coreHttp": false,
 		},
+		origins:     make(map[string]string),
+		watchFiles:  make(map[string]struct{}),
+		subscribers: make(map[uint64]subscription),
 	}
+	ac.ko.Store(koanf.New(d))
+	return ac
 }
 
 // GetApplication 获取应用基础配置副本
 func (ac *AppConfig) GetApplication() ConfApplicationBase {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
 	return ac.applicationBase // 副本
 }
 
 // GetRecover 获取应用异常恢复配置副本
 func (ac *AppConfig) GetRecover() ConfRecoverBase {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
 	return ac.recoverBase
 }
 
 // GetAppLog 获取应用日志器配置副本
 func (ac *AppConfig) GetAppLog() ConfAppLogBase {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
 	return ac.appLogBase
 }
 
 // GetTrace 获取应用链路配置副本
 func (ac *AppConfig) GetTrace() ConfTraceBase {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
 	return ac.traceBase
 }
 
 // GetAppId 应用ID
 func (ac *AppConfig) GetAppId() string {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
 	return ac.applicationBase.AppID
 }
 
@@ -283,7 +313,9 @@ func (ac *AppConfig) GetAppId() string {
 func (ac *AppConfig) SetAppId(id string) {
 	_ = ac.SafeSet("", id, func(confPath string, value interface{}, c IAppConfig) error {
 		if v, ok := value.(string); ok {
+			ac.baseMu.Lock()
 			ac.applicationBase.AppID = v
+			ac.baseMu.Unlock()
 			return nil
 		}
 		return fmt.Errorf("SetAppId: type assertion failure for string")
@@ -292,6 +324,8 @@ func (ac *AppConfig) SetAppId(id string) {
 
 // GetAppName 应用名称
 func (ac *AppConfig) GetAppName() string {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
 	return ac.applicationBase.AppName
 }
 
@@ -299,7 +333,9 @@ func (ac *AppConfig) GetAppName() string {
 func (ac *AppConfig) SetAppName(name string) {
 	_ = ac.SafeSet("", name, func(confPath string, value interface{}, c IAppConfig) error {
 		if v, ok := value.(string); ok {
+			ac.baseMu.Lock()
 			ac.applicationBase.AppName = v
+			ac.baseMu.Unlock()
 			return nil
 		}
 		return fmt.Errorf("SetAppName: type assertion failure for string")
@@ -308,6 +344,8 @@ func (ac *AppConfig) SetAppName(name string) {
 
 // GetVersion 应用版本
 func (ac *AppConfig) GetVersion() string {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
 	return ac.applicationBase.Version
 }
 
@@ -315,7 +353,9 @@ func (ac *AppConfig) GetVersion() string {
 func (ac *AppConfig) SetVersion(version string) {
 	_ = ac.SafeSet("", version, func(confPath string, value interface{}, c IAppConfig) error {
 		if v, ok := value.(string); ok {
+			ac.baseMu.Lock()
 			ac.applicationBase.Version = v
+			ac.baseMu.Unlock()
 			return nil
 		}
 		return fmt.Errorf("SetVersion: type assertion failure for string")
@@ -324,6 +364,8 @@ func (ac *AppConfig) SetVersion(version string) {
 
 // Initialize 初始化配置属性参数
 func (ac *AppConfig) Initialize() IAppConfig {
+	ac.baseMu.Lock()
+	defer ac.baseMu.Unlock()
 	// 初始化基础配置结构体
 	ac.applicationBase = ConfApplicationBase{
 		AppID:      ac.String("application.appId", ""),
@@ -434,6 +476,8 @@ func (ac *AppConfig) LogOriginTest() LogOrigin {
 
 // RegisterLogOrigin 应用启动阶段，注册自定义日志源标识，非线程安全，且不会覆盖已有key。运行阶段需要设置，使用安全读写方法。
 func (ac *AppConfig) RegisterLogOrigin(key string, customLogOrigin LogOrigin) error {
+	ac.baseMu.Lock()
+	defer ac.baseMu.Unlock()
 	if _, ok := ac.logOriginEnum[key]; !ok {
 		ac.logOriginEnum[key] = customLogOrigin
 	} else {
@@ -444,6 +488,8 @@ func (ac *AppConfig) RegisterLogOrigin(key string, customLogOrigin LogOrigin) er
 
 // GetLogOrigin 按key获取日志源标识
 func (ac *AppConfig) GetLogOrigin(key string) LogOrigin {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
 	if v, ok := ac.logOriginEnum[key]; ok {
 		return v
 	}
@@ -457,6 +503,8 @@ func (ac *AppConfig) LogOriginCustom(key string) LogOrigin {
 
 // GetLogOriginMap 获取日志器来源map
 func (ac *AppConfig) GetLogOriginMap() map[string]LogOrigin {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
 	result := make(map[string]LogOrigin, len(ac.logOriginEnum))
 	for key, origin := range ac.logOriginEnum {
 		result[key] = origin
@@ -465,6 +513,8 @@ func (ac *AppConfig) GetLogOriginMap() map[string]LogOrigin {
 }
 
 func (ac *AppConfig) GetMiddlewareSwitch(key string) bool {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
 	if v, ok := ac.middleware[key]; ok {
 		return v
 	}
@@ -489,9 +539,15 @@ func (ac *AppConfig) SafeSet(pathname string, value interface{}, setter func(pat
 
 // LoadDefault 从map加载默认配置
 func (ac *AppConfig) LoadDefault(m map[string]interface{}) IAppConfig {
-	if err := ac.GetKOANF().Load(confmap.Provider(m, "."), nil); err != nil {
-		panic("LoadMap: " + err.Error())
+	load := func(target *AppConfig) {
+		if err := target.applyLayer(layerNameMap, func(ko *koanf.Koanf) error {
+			return ko.Load(confmap.Provider(m, "."), nil)
+		}); err != nil {
+			panic("LoadMap: " + err.Error())
+		}
 	}
+	load(ac)
+	ac.recordLoader(layerNameMap, load)
 	return ac
 }
 
@@ -507,15 +563,81 @@ func (ac *AppConfig) LoadYaml(filename ...string) IAppConfig {
 		fName = defaultFile
 	}
 	fName = filepath.ToSlash(baseDir + strings.TrimLeft(fName, "/"))
-	if err := ac.GetKOANF().Load(file.Provider(fName), yaml.Parser()); err != nil {
-		panic(fmt.Sprintf("LoadYaml: %s, filename: %s", err.Error(), fName))
+	name := sourceNameFile + fName
+	load := func(target *AppConfig) {
+		if err := target.applyLayer(name, func(ko *koanf.Koanf) error {
+			return ko.Load(file.Provider(fName), yaml.Parser())
+		}); err != nil {
+			panic(fmt.Sprintf("LoadYaml: %s, filename: %s", err.Error(), fName))
+		}
 	}
+	load(ac)
+	ac.recordLoader(name, load)
+	ac.watchFiles[filepath.Clean(fName)] = struct{}{}
 	return ac
 }
 
 // LoadFunc 自定义回调装载配置
+//
+// 回调在 Reload 时以新的暂存配置重放，回调内应通过参数 config 加载，而不是捕获外部的配置对象。
 func (ac *AppConfig) LoadFunc(f func(config IAppConfig) IAppConfig) IAppConfig {
-	return f(ac)
+	ret := ac.runFunc(f)
+	ac.recordLoader(layerNameFunc, func(target *AppConfig) { target.runFunc(f) })
+	return ret
+}
+
+// runFunc 执行加载回调，回调直接写入且未经嵌套加载步骤记录来源的 key 归属于回调步骤
+func (ac *AppConfig) runFunc(f func(config IAppConfig) IAppConfig) IAppConfig {
+	nested := ac.loading
+	ac.loading = true
+	defer func() { ac.loading = nested }()
+
+	before := ac.GetKOANF().All()
+	origins := make(map[string]string, len(ac.origins))
+	for k, v := range ac.origins {
+		origins[k] = v
+	}
+	ret := f(ac)
+	for k, v := range ac.GetKOANF().All() {
+		if ov, ok := before[k]; ok && reflect.DeepEqual(ov, v) {
+			continue
+		}
+		if ac.origins[k] == origins[k] {
+			ac.origins[k] = layerNameFunc
+		}
+	}
+	return ret
+}
+
+// applyLayer 将一个加载步骤装载到独立的配置树后合并到当前配置树，并记录各 key 的来源
+func (ac *AppConfig) applyLayer(name string, fill func(ko *koanf.Koanf) error) error {
+	layer := koanf.New(ac.delim)
+	if err := fill(layer); err != nil {
+		return err
+	}
+	if err := ac.GetKOANF().Merge(layer); err != nil {
+		return err
+	}
+	for _, k := range layer.Keys() {
+		ac.origins[k] = name
+	}
+	return nil
+}
+
+// recordLoader 以 PriorityDefault 记录已加载的步骤以供 Reload 重放，排在同优先级已有步骤之后；
+// 已记录更高优先级的步骤时在其后重新应用，使当前配置树与 Reload 的重放结果一致。
+// LoadFunc 回调内的嵌套加载已由外层步骤覆盖，不重复记录
+func (ac *AppConfig) recordLoader(name string, load func(target *AppConfig)) {
+	if ac.loading {
+		return
+	}
+	idx := ac.insertLoader(loadStep{name: name, priority: PriorityDefault, load: load})
+	ac.reloadMu.Lock()
+	higher := append([]loadStep(nil), ac.loaders[idx+1:]...)
+	ac.reloadMu.Unlock()
+	for _, step := range higher {
+		step.load(ac)
+	}
 }
 
 // SetConfPath 自定义配置文件目录
@@ -537,21 +659,29 @@ func (ac *AppConfig) GetCore() interface{} {
 
 // GetKOANF 获取底层配置对象
 func (ac *AppConfig) GetKOANF() *koanf.Koanf {
-	return ac.ko
+	return ac.ko.Load()
 }
 
-// String 获取带默认值的字符串配置
+// String 获取带默认值的字符串配置，密钥引用在读取时解析，无法解析时按空值处理，见 ResolveSecret
 func (ac *AppConfig) String(keyPath string, defVal ...string) string {
-	v := ac.ko.String(keyPath)
+	v, err := ResolveSecret(ac.GetKOANF().String(keyPath))
+	if err != nil {
+		v = ""
+	}
 	if v == "" && len(defVal) > 0 {
 		return defVal[0]
 	}
 	return v
 }
 
-// Strings 获取带默认值的字符串切片配置
+// Strings 获取带默认值的字符串切片配置，逐项解析密钥引用
 func (ac *AppConfig) Strings(keyPath string, defVal ...[]string) []string {
 	ret := ac.GetKOANF().Strings(keyPath)
+	for i, v := range ret {
+		if IsSecretRef(v) {
+			ret[i], _ = ResolveSecret(v)
+		}
+	}
 	if len(ret) == 0 && len(defVal) > 0 {
 		return defVal[0]
 	}
@@ -560,7 +690,7 @@ func (ac *AppConfig) Strings(keyPath string, defVal ...[]string) []string {
 
 // Int64 获取带默认值的int64配置
 func (ac *AppConfig) Int64(keyPath string, defVal ...int64) int64 {
-	v := ac.ko.Int64(keyPath)
+	v := ac.GetKOANF().Int64(keyPath)
 	if v == 0 && len(defVal) > 0 {
 		return defVal[0]
 	}
@@ -569,7 +699,7 @@ func (ac *AppConfig) Int64(keyPath string, defVal ...int64) int64 {
 
 // Int 获取带默认值的int配置
 func (ac *AppConfig) Int(keyPath string, defVal ...int) int {
-	v := ac.ko.Int(keyPath)
+	v := ac.GetKOANF().Int(keyPath)
 	if v == 0 && len(defVal) > 0 {
 		return defVal[0]
 	}
@@ -578,7 +708,7 @@ func (ac *AppConfig) Int(keyPath string, defVal ...int) int {
 
 // Float64 获取带默认值的float64配置
 func (ac *AppConfig) Float64(keyPath string, defVal ...float64) float64 {
-	v := ac.ko.Float64(keyPath)
+	v := ac.GetKOANF().Float64(keyPath)
 	if v == 0 && len(defVal) > 0 {
 		return defVal[0]
 	}
@@ -587,12 +717,12 @@ func (ac *AppConfig) Float64(keyPath string, defVal ...float64) float64 {
 
 // Bool 获取bool配置
 func (ac *AppConfig) Bool(keyPath string) bool {
-	return ac.ko.Bool(keyPath)
+	return ac.GetKOANF().Bool(keyPath)
 }
 
 // Duration 获取带默认值的时间间隔配置
 func (ac *AppConfig) Duration(key string, defaultValue ...time.Duration) time.Duration {
-	v := ac.ko.Duration(key)
+	v := ac.GetKOANF().Duration(key)
 	if v == 0 && len(defaultValue) > 0 {
 		return defaultValue[0]
 	}
@@ -601,7 +731,7 @@ func (ac *AppConfig) Duration(key string, defaultValue ...time.Duration) time.Du
 
 // GetBytes 获取带默认值的字节切片配置
 func (ac *AppConfig) GetBytes(key string, defaultValue ...[]byte) []byte {
-	v := ac.ko.Bytes(key)
+	v := ac.GetKOANF().Bytes(key)
 	if len(v) == 0 && len(defaultValue) > 0 {
 		return defaultValue[0]
 	}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package appconfig
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// MaskedValue 敏感配置值的打印替代
+const MaskedValue = "******"
+
+var (
+	secretKeyMu sync.RWMutex
+	// secretKeyPatterns key 最后一段（小写）包含其中任一片段时视为敏感配置
+	secretKeyPatterns = []string{
+		"password", "passwd", "secret", "token", "dsn", "credential",
+		"apikey", "api_key", "accesskey", "access_key", "privatekey", "private_key",
+	}
+)
+
+// RegisterSecretKeys 追加敏感配置 key 片段（大小写不敏感），匹配 key 的最后一段
+func RegisterSecretKeys(patterns ...string) {
+	secretKeyMu.Lock()
+	defer secretKeyMu.Unlock()
+	for _, p := range patterns {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			secretKeyPatterns = append(secretKeyPatterns, p)
+		}
+	}
+}
+
+// IsSecretKey key 的最后一段是否包含敏感片段
+func IsSecretKey(key string) bool {
+	last := strings.ToLower(key[strings.LastIndexAny(key, ".")+1:])
+	secretKeyMu.RLock()
+	defer secretKeyMu.RUnlock()
+	for _, p := range secretKeyPatterns {
+		if strings.Contains(last, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskValue 返回可打印的配置值：敏感 key 的值、已解析的密钥值（见 RedactSecrets）与 URL 中的密码替换为 MaskedValue
+//
+// 未解析的 ${env:...}、${file:...} 引用与 enc: 密文不含明文，原样打印。
+func MaskValue(key string, value interface{}) interface{} {
+	if IsSecretKey(key) {
+		return MaskedValue
+	}
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	if s = RedactSecrets(s); !strings.Contains(s, "://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	if _, has := u.User.Password(); !has {
+		return s
+	}
+	u.User = url.UserPassword(u.User.Username(), MaskedValue)
+	masked, _ := url.PathUnescape(u.String())
+	return masked
+}
+
+// Dump 打印配置来源的优先级与合并后的全部配置项，每项标注生效来源，敏感值已脱敏
+//
+// 输出格式：
+//
+//	# config precedence (later overrides earlier)
+//	#   1. [   0] file:./config/application_dev.yml
+//	#   2. [1000] env:APP_CONF_
+//	application.appName = "orders"  # env:APP_CONF_
+func Dump(cfg IAppConfig, w io.Writer) error {
+	ko, err := GetCoreWithConfig[*koanf.Koanf](cfg)
+	if err != nil {
+		return err
+	}
+	layered, _ := cfg.(ILayeredConfig)
+
+	bw := bufio.NewWriter(w)
+	if layered != nil {
+		fmt.Fprintln(bw, "# config precedence (later overrides earlier)")
+		for i, layer := range layered.Layers() {
+			fmt.Fprintf(bw, "#  %2d. [%4d] %s\n", i+1, layer.Priority, layer.Name)
+		}
+	}
+	all := ko.All()
+	keys := make([]string, 0, len(all))
+	for k := range all {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		value, err := json.Marshal(MaskValue(k, all[k]))
+		if err != nil {
+			value = []byte(fmt.Sprintf("%q", fmt.Sprint(all[k])))
+		}
+		fmt.Fprintf(bw, "%s = %s", k, value)
+		if layered != nil {
+			if origin := layered.Origin(k); origin != "" {
+				fmt.Fprintf(bw, "  # %s", origin)
+			}
+		}
+		fmt.Fprintln(bw)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package appconfig
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/knadh/koanf/v2"
+	"github.com/lamxy/fiberhouse/globalmanager"
+)
+
+var (
+	// ErrConfigReload 重放配置加载步骤失败，当前配置保持不变
+	ErrConfigReload = errors.New("config reload failed")
+	// ErrConfigInvalid 新配置未通过校验，当前配置保持不变
+	ErrConfigInvalid = errors.New("config reload rejected by validator")
+)
+
+// DefaultWatchDebounce 配置文件变更事件的默认合并窗口
+const DefaultWatchDebounce = 200 * time.Millisecond
+
+// ConfigValidator 配置校验器，next 为待提交的新配置，返回错误时拒绝本次热更新
+type ConfigValidator func(next IAppConfig) error
+
+// ConfigSubscriber 配置变更订阅者，返回的错误聚合到 Reload 的返回值，不回滚已提交的配置
+type ConfigSubscriber func(change ConfigChange) error
+
+// ConfigChange 一次热更新中与订阅前缀匹配的变更
+type ConfigChange struct {
+	// Prefix 订阅前缀
+	Prefix string
+	// Keys 前缀下发生变化的扁平 key（新增、删除或值变化），已排序
+	Keys []string
+	// Old 变更前的配置快照
+	Old IAppConfig
+	// New 变更后的配置
+	New IAppConfig
+}
+
+// WatchOptions 配置目录监听选项
+type WatchOptions struct {
+	// Debounce 事件合并窗口，<=0 时使用 DefaultWatchDebounce
+	Debounce time.Duration
+	// OnReload 每次由文件变更触发的 Reload 完成后回调，err 为 Reload 的返回值
+	OnReload func(err error)
+}
+
+// IReloadableConfig 支持热更新的应用配置，AppConfig 实现该接口
+type IReloadableConfig interface {
+	IAppConfig
+	Reload() error                                                                      // 重放加载步骤，校验通过后原子替换配置树并通知订阅者
+	AddValidator(validator ConfigValidator)                                             // 添加热更新校验器
+	Subscribe(prefix string, subscriber ConfigSubscriber) (unsubscribe func())          // 订阅 key 前缀的变更
+	Watch(opts ...WatchOptions) (stop func() error, err error)                          // 监听配置目录，文件变更时自动 Reload
+	RebuildOnChange(prefix string, names ...globalmanager.KeyName) (unsubscribe func()) // 前缀变更时重建已初始化的全局对象
+	AddSource(src Source, priority SourcePriority) (remove func())                      // 按优先级记录配置来源，下次 Reload 时生效
+}
+
+type subscription struct {
+	id         uint64
+	prefix     string
+	subscriber ConfigSubscriber
+}
+
+// AddValidator 添加热更新校验器，校验器在新配置提交前按添加顺序执行
+func (ac *AppConfig) AddValidator(validator ConfigValidator) {
+	if validator == nil {
+		return
+	}
+	ac.reloadMu.Lock()
+	defer ac.reloadMu.Unlock()
+	ac.validators = append(ac.validators, validator)
+}
+
+// Subscribe 订阅 key 前缀的变更，前缀为空时订阅全部 key
+//
+// 前缀按分隔符边界匹配：cache.redis 匹配 cache.redis 与 cache.redis.host，不匹配 cache.redisCluster。
+// 订阅者在新配置提交后按订阅顺序同步调用。
+func (ac *AppConfig) Subscribe(prefix string, subscriber ConfigSubscriber) (unsubscribe func()) {
+	if subscriber == nil {
+		return func() {}
+	}
+	ac.subMu.Lock()
+	ac.subSeq++
+	id := ac.subSeq
+	ac.subscribers[id] = subscription{id: id, prefix: prefix, subscriber: subscriber}
+	ac.subMu.Unlock()
+	return func() {
+		ac.subMu.Lock()
+		delete(ac.subscribers, id)
+		ac.subMu.Unlock()
+	}
+}
+
+// RebuildOnChange 前缀下配置变更时，依次 Rebuild 已初始化的全局对象；未注册或尚未初始化的对象跳过，首次 Get 时自然读取新配置
+func (ac *AppConfig) RebuildOnChange(prefix string, names ...globalmanager.KeyName) (unsubscribe func()) {
+	return ac.Subscribe(prefix, func(change ConfigChange) error {
+		var errs []error
+		for _, name := range names {
+			if !ac.container.IsInitialized(name) {
+				continue
+			}
+			if err := ac.container.Rebuild(name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// Reload 按优先级重放记录的加载步骤（LoadDefault、LoadYaml、LoadFunc、LoadSource、AddSource）生成新配置树，校验通过后原子替换并通知订阅者
+//
+// 加载或校验失败时当前配置保持不变，分别返回 ErrConfigReload、ErrConfigInvalid。
+// 应用ID、名称和版本不参与热更新。并发调用串行执行，校验器与订阅者内不得再调用 Reload。
+func (ac *AppConfig) Reload() error {
+	ac.reloadMu.Lock()
+	defer ac.reloadMu.Unlock()
+
+	next, err := ac.replay()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrConfigReload, err)
+	}
+	// 密钥引用在校验与之后的读取中重新解析，轮换后的密钥文件随 Reload 生效
+	resetSecretCache()
+	var errs []error
+	for _, validator := range ac.validators {
+		if err := validator(next); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrConfigInvalid, errors.Join(errs...))
+	}
+
+	old := ac.snapshot(ac.GetKOANF())
+	ac.commit(next)
+
+	changed := changedKeys(old.GetKOANF().All(), next.GetKOANF().All())
+	if len(changed) == 0 {
+		return nil
+	}
+	return ac.notify(old, changed)
+}
+
+// replay 在新的暂存配置上重放加载步骤，加载步骤的 panic 转换为错误
+func (ac *AppConfig) replay() (next *AppConfig, err error) {
+	next = ac.stage(koanf.New(ac.delim))
+	defer func() {
+		if r := recover(); r != nil {
+			next, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+	for _, step := range ac.loaders {
+		step.load(next)
+	}
+	next.Initialize()
+	return next, nil
+}
+
+// stage 创建共享容器与日志源的暂存配置
+func (ac *AppConfig) stage(ko *koanf.Koanf) *AppConfig {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
+	staged := &AppConfig{
+		delim:           ac.delim,
+		confPath:        ac.confPath,
+		container:       ac.container,
+		applicationBase: ac.applicationBase,
+		logOriginEnum:   make(map[string]LogOrigin, len(ac.logOriginEnum)),
+		middleware:      make(map[string]bool, len(ac.middleware)),
+		origins:         make(map[string]string),
+		watchFiles:      make(map[string]struct{}),
+		subscribers:     make(map[uint64]subscription),
+	}
+	for k, v := range ac.logOriginEnum {
+		staged.logOriginEnum[k] = v
+	}
+	for k, v := range ac.middleware {
+		staged.middleware[k] = v
+	}
+	staged.ko.Store(ko)
+	return staged
+}
+
+// snapshot 以指定配置树创建只读快照
+func (ac *AppConfig) snapshot(ko *koanf.Koanf) *AppConfig {
+	return ac.stage(ko).Initialize().(*AppConfig)
+}
+
+// commit 原子替换配置树，并替换除应用ID、名称和版本外的基础配置项
+func (ac *AppConfig) commit(next *AppConfig) {
+	ac.baseMu.Lock()
+	defer ac.baseMu.Unlock()
+	ac.ko.Store(next.GetKOANF())
+	ac.origins = next.origins
+	ac.appLogBase = next.appLogBase
+	ac.recoverBase = next.recoverBase
+	ac.traceBase = next.traceBase
+	ac.logOriginEnum = next.logOriginEnum
+	ac.middleware = next.middleware
+}
+
+// notify 按订阅顺序通知前缀匹配的订阅者
+func (ac *AppConfig) notify(old *AppConfig, changed []string) error {
+	ac.subMu.RLock()
+	subs := make([]subscription, 0, len(ac.subscribers))
+	for _, sub := range ac.subscribers {
+		subs = append(subs, sub)
+	}
+	ac.subMu.RUnlock()
+	sort.Slice(subs, func(i, j int) bool { return subs[i].id < subs[j].id })
+
+	var errs []error
+	for _, sub := range subs {
+		keys := matchPrefix(changed, sub.prefix, ac.delim)
+		if len(keys) == 0 {
+			continue
+		}
+		if err := sub.subscriber(ConfigChange{Prefix: sub.prefix, Keys: keys, Old: old, New: ac}); err != nil {
+			errs = append(errs, fmt.Errorf("config subscriber '%s': %w", sub.prefix, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// changedKeys 比较扁平配置，返回新增、删除或值变化的 key
+func changedKeys(old, next map[string]interface{}) []string {
+	var keys []string
+	for k, v := range next {
+		if ov, ok := old[k]; !ok || !reflect.DeepEqual(ov, v) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range old {
+		if _, ok := next[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func matchPrefix(keys []string, prefix, delim string) []string {
+	if prefix == "" {
+		return keys
+	}
+	var matched []string
+	for _, k := range keys {
+		if k == prefix || strings.HasPrefix(k, prefix+delim) {
+			matched = append(matched, k)
+		}
+	}
+	return matched
+}
+
+// Watch 监听配置目录，LoadYaml 加载过的文件发生变更时（合并 Debounce 窗口内的事件）自动 Reload
+//
+// 监听目录而非文件，编辑器以重命名替换文件时仍能收到事件。返回的 stop 停止监听并等待后台协程退出，可重复调用。
+func (ac *AppConfig) Watch(opts ...WatchOptions) (stop func() error, err error) {
+	var opt WatchOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	dir := filepath.Clean(ac.GetConfPath())
+	files := make(map[string]struct{}, len(ac.watchFiles))
+	for f := range ac.watchFiles {
+		files[absPath(f)] = struct{}{}
+	}
+	stop, err = WatchDirs([]string{dir}, opt.Debounce, func(event fsnotify.Event) bool {
+		return watchedEvent(event, files)
+	}, func() {
+		err := ac.Reload()
+		if opt.OnReload != nil {
+			opt.OnReload(err)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	return stop, nil
+}
+
+// watchedEvent 是否为已加载配置文件的内容变更；未记录任何文件时，目录下的 yaml 文件均视为配置文件
+func watchedEvent(event fsnotify.Event, files map[string]struct{}) bool {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) && !event.Has(fsnotify.Remove) {
+		return false
+	}
+	name := absPath(event.Name)
+	if len(files) == 0 {
+		ext := strings.ToLower(filepath.Ext(name))
+		return ext == ".yml" || ext == ".yaml"
+	}
+	_, ok := files[name]
+	return ok
+}
+
+func absPath(name string) string {
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
+	}
+	return filepath.Clean(name)
+}
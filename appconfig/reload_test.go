@@ -0,0 +1,216 @@
+package appconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reloadBaseYAML = `
+application:
+  appId: "RELOAD-ID"
+  appLog:
+    level: info
+  recover:
+    debugMode: false
+  middleware:
+    coreHttp: false
+cache:
+  redis:
+    host: 10.0.0.1
+  redisCluster:
+    size: 3
+`
+
+func newReloadableConfig(t *testing.T, content string) (IReloadableConfig, string) {
+	t.Helper()
+	fp := writeTempYAML(t, content)
+	ac := NewAppConfig().
+		SetConfPath(filepath.Dir(fp)).
+		LoadYaml(filepath.Base(fp)).
+		Initialize()
+	rc, ok := ac.(IReloadableConfig)
+	require.True(t, ok, "AppConfig must implement IReloadableConfig")
+	return rc, fp
+}
+
+func rewriteYAML(t *testing.T, fp, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(fp, []byte(content), 0o600))
+}
+
+func TestAppConfig_ReloadSwapsTreeAndNotifiesPrefixSubscribers(t *testing.T) {
+	rc, fp := newReloadableConfig(t, reloadBaseYAML)
+	rc.SetAppId("OVERRIDDEN")
+
+	var redisChanges, clusterChanges, logChanges []ConfigChange
+	rc.Subscribe("cache.redis", func(change ConfigChange) error {
+		redisChanges = append(redisChanges, change)
+		return nil
+	})
+	rc.Subscribe("cache.redisCluster", func(change ConfigChange) error {
+		clusterChanges = append(clusterChanges, change)
+		return nil
+	})
+	unsubscribe := rc.Subscribe("application.appLog", func(change ConfigChange) error {
+		logChanges = append(logChanges, change)
+		return nil
+	})
+	unsubscribe()
+
+	before := rc.GetCore().(*koanf.Koanf)
+	rewriteYAML(t, fp, `
+application:
+  appId: "RELOAD-ID"
+  appLog:
+    level: debug
+  recover:
+    debugMode: true
+  middleware:
+    coreHttp: true
+cache:
+  redis:
+    host: 10.0.0.2
+    password: secret
+  redisCluster:
+    size: 3
+`)
+	require.NoError(t, rc.Reload())
+
+	assert.NotSame(t, before, rc.GetCore().(*koanf.Koanf), "reload must swap the koanf tree")
+	assert.Equal(t, "10.0.0.2", rc.String("cache.redis.host"))
+	assert.True(t, rc.GetRecover().DebugMode)
+	assert.Equal(t, "debug", rc.GetAppLog().Level)
+	assert.True(t, rc.GetMiddlewareSwitch("coreHttp"))
+	assert.Equal(t, "OVERRIDDEN", rc.GetAppId(), "application identity is not hot reloaded")
+
+	require.Len(t, redisChanges, 1)
+	assert.Equal(t, []string{"cache.redis.host", "cache.redis.password"}, redisChanges[0].Keys)
+	assert.Equal(t, "10.0.0.1", redisChanges[0].Old.String("cache.redis.host"))
+	assert.Equal(t, "10.0.0.2", redisChanges[0].New.String("cache.redis.host"))
+	assert.Empty(t, clusterChanges, "prefix must match on delimiter boundaries")
+	assert.Empty(t, logChanges, "unsubscribed subscriber was notified")
+
+	// 内容未变化时不通知
+	require.NoError(t, rc.Reload())
+	assert.Len(t, redisChanges, 1)
+}
+
+func TestAppConfig_ReloadRejectsInvalidTreeAndKeepsCurrent(t *testing.T) {
+	rc, fp := newReloadableConfig(t, reloadBaseYAML)
+	var notified atomic.Int32
+	rc.Subscribe("", func(ConfigChange) error {
+		notified.Add(1)
+		return nil
+	})
+	rc.AddValidator(func(next IAppConfig) error {
+		if next.String("cache.redis.host") == "" {
+			return errors.New("cache.redis.host is required")
+		}
+		return nil
+	})
+
+	rewriteYAML(t, fp, "cache:\n  redis:\n    port: 6379\n")
+	err := rc.Reload()
+	require.ErrorIs(t, err, ErrConfigInvalid)
+	assert.Contains(t, err.Error(), "cache.redis.host is required")
+	assert.Equal(t, "10.0.0.1", rc.String("cache.redis.host"))
+	assert.Zero(t, rc.Int("cache.redis.port"))
+
+	rewriteYAML(t, fp, "cache: [broken")
+	require.ErrorIs(t, rc.Reload(), ErrConfigReload)
+	assert.Equal(t, "10.0.0.1", rc.String("cache.redis.host"))
+	assert.Zero(t, notified.Load())
+
+	rewriteYAML(t, fp, "cache:\n  redis:\n    host: 10.0.0.3\n")
+	subscriberErr := errors.New("apply failed")
+	rc.Subscribe("cache", func(ConfigChange) error { return subscriberErr })
+	err = rc.Reload()
+	require.ErrorIs(t, err, subscriberErr, "subscriber errors are reported after commit")
+	assert.Equal(t, "10.0.0.3", rc.String("cache.redis.host"))
+}
+
+func TestAppConfig_ReloadReplaysLoadStepsInOrder(t *testing.T) {
+	rc, fp := newReloadableConfig(t, reloadBaseYAML)
+	var source atomic.Value
+	source.Store("from-func-1")
+	rc.LoadFunc(func(c IAppConfig) IAppConfig {
+		return c.LoadDefault(map[string]interface{}{"custom.source": source.Load()})
+	})
+	rc.LoadDefault(map[string]interface{}{"cache.redis.host": "override"})
+	require.Equal(t, "override", rc.String("cache.redis.host"))
+
+	source.Store("from-func-2")
+	rewriteYAML(t, fp, "cache:\n  redis:\n    host: 10.0.0.9\n")
+	require.NoError(t, rc.Reload())
+	assert.Equal(t, "from-func-2", rc.String("custom.source"))
+	assert.Equal(t, "override", rc.String("cache.redis.host"), "later load steps still override earlier ones")
+}
+
+func TestAppConfig_WatchReloadsOnFileChange(t *testing.T) {
+	rc, fp := newReloadableConfig(t, reloadBaseYAML)
+	reloaded := make(chan error, 4)
+	stop, err := rc.Watch(WatchOptions{
+		Debounce: 20 * time.Millisecond,
+		OnReload: func(err error) { reloaded <- err },
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, stop()) }()
+
+	// 未加载的文件不触发热更新
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(fp), "other.yml"), []byte("a: 1\n"), 0o600))
+	rewriteYAML(t, fp, "application:\n  appLog:\n    level: warn\n")
+
+	select {
+	case err := <-reloaded:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("config watch did not reload after file change")
+	}
+	assert.Equal(t, "warn", rc.GetAppLog().Level)
+
+	require.NoError(t, stop())
+}
+
+type reloadRebuilder struct {
+	generation int
+}
+
+func (r *reloadRebuilder) Rebuild(...interface{}) (interface{}, error) {
+	return &reloadRebuilder{generation: r.generation + 1}, nil
+}
+
+func (r *reloadRebuilder) GetConfPath() string { return "cache.redis" }
+
+func TestAppConfig_RebuildOnChangeRebuildsInitializedGlobals(t *testing.T) {
+	rc, fp := newReloadableConfig(t, reloadBaseYAML)
+	gm := rc.GetContainer()
+	const (
+		initialized = "reload-test-initialized"
+		lazy        = "reload-test-lazy"
+	)
+	t.Cleanup(func() {
+		gm.Unregister(initialized)
+		gm.Unregister(lazy)
+	})
+	gm.Register(initialized, func() (interface{}, error) { return &reloadRebuilder{}, nil })
+	gm.Register(lazy, func() (interface{}, error) { return &reloadRebuilder{}, nil })
+	_, err := gm.Get(initialized)
+	require.NoError(t, err)
+	rc.RebuildOnChange("cache.redis", initialized, lazy, "reload-test-missing")
+
+	rewriteYAML(t, fp, "cache:\n  redis:\n    host: 10.0.0.5\n")
+	require.NoError(t, rc.Reload())
+
+	current, err := gm.Get(initialized)
+	require.NoError(t, err)
+	assert.Equal(t, 1, current.(*reloadRebuilder).generation)
+	assert.False(t, gm.IsInitialized(lazy), "uninitialized globals are not created by reload")
+}
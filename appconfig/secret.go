@@ -0,0 +1,272 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package appconfig
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// SecretEncPrefix 加密配置值前缀，其后为 base64(nonce + AES-GCM 密文)
+const SecretEncPrefix = "enc:"
+
+// minRedactLen 参与脱敏替换的密钥值最小长度，过短的值替换会误伤日志中的普通文本
+const minRedactLen = 4
+
+var (
+	// ErrSecretKeyMissing 存在 enc: 加密值但未设置解密密钥
+	ErrSecretKeyMissing = errors.New("secret key is not set")
+
+	// secretRefPattern 配置值中的 ${env:NAME}、${file:/path} 引用
+	secretRefPattern = regexp.MustCompile(`\$\{(env|file):([^}]+)\}`)
+
+	secretAEADMu sync.RWMutex
+	secretAEAD   cipher.AEAD
+
+	// secretCache 引用原文到解析结果的缓存，配置热更新提交时清空
+	secretCache sync.Map
+
+	redactMu       sync.RWMutex
+	redactValues   = make(map[string]struct{})
+	redactReplacer *strings.Replacer
+)
+
+// SetSecretKey 设置 enc: 加密值的 AES 解密密钥，长度须为 16、24 或 32 字节；nil 清除密钥
+func SetSecretKey(key []byte) error {
+	var aead cipher.AEAD
+	if key != nil {
+		var err error
+		if aead, err = newSecretAEAD(key); err != nil {
+			return err
+		}
+	}
+	secretAEADMu.Lock()
+	secretAEAD = aead
+	secretAEADMu.Unlock()
+	resetSecretCache()
+	return nil
+}
+
+// HasSecretKey 是否已设置解密密钥
+func HasSecretKey() bool {
+	secretAEADMu.RLock()
+	defer secretAEADMu.RUnlock()
+	return secretAEAD != nil
+}
+
+// DecodeSecretKey 解码 base64 形式的解密密钥
+func DecodeSecretKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("decode secret key: %w", err)
+	}
+	return key, nil
+}
+
+// EncryptSecret 使用 key 加密明文，返回可直接写入配置的 enc: 值
+func EncryptSecret(key []byte, plaintext string) (string, error) {
+	aead, err := newSecretAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return SecretEncPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// IsSecretRef 配置值是否为 enc: 加密值或包含 ${env:...}、${file:...} 引用
+func IsSecretRef(value string) bool {
+	return strings.HasPrefix(value, SecretEncPrefix) || (strings.Contains(value, "${") && secretRefPattern.MatchString(value))
+}
+
+// ResolveSecret 解析配置值中的密钥引用，非引用值原样返回
+//
+// enc: 前缀的整个值按 SetSecretKey 设置的密钥解密；${env:NAME} 替换为环境变量值，未设置时返回错误；
+// ${file:/path} 替换为文件内容并去掉末尾换行。引用可嵌入其他文本，如 root:${env:DB_PASS}@tcp(127.0.0.1:3306)/db。
+// 解析出的值登记到 RedactSecrets 的脱敏列表。
+func ResolveSecret(value string) (string, error) {
+	if !IsSecretRef(value) {
+		return value, nil
+	}
+	if resolved, ok := secretCache.Load(value); ok {
+		return resolved.(string), nil
+	}
+	resolved, err := resolveSecret(value)
+	if err != nil {
+		return "", err
+	}
+	secretCache.Store(value, resolved)
+	return resolved, nil
+}
+
+func resolveSecret(value string) (string, error) {
+	if strings.HasPrefix(value, SecretEncPrefix) {
+		plain, err := decryptSecret(strings.TrimPrefix(value, SecretEncPrefix))
+		if err != nil {
+			return "", err
+		}
+		registerRedact(plain)
+		return plain, nil
+	}
+	var firstErr error
+	resolved := secretRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		m := secretRefPattern.FindStringSubmatch(ref)
+		var (
+			plain string
+			err   error
+		)
+		switch m[1] {
+		case "env":
+			var ok bool
+			if plain, ok = os.LookupEnv(m[2]); !ok {
+				err = fmt.Errorf("env %s is not set", m[2])
+			}
+		case "file":
+			var data []byte
+			if data, err = os.ReadFile(m[2]); err == nil {
+				plain = strings.TrimRight(string(data), "\r\n")
+			}
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return ""
+		}
+		registerRedact(plain)
+		return plain
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return resolved, nil
+}
+
+func decryptSecret(encoded string) (string, error) {
+	secretAEADMu.RLock()
+	aead := secretAEAD
+	secretAEADMu.RUnlock()
+	if aead == nil {
+		return "", ErrSecretKeyMissing
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("decode encrypted value: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("encrypted value is too short")
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", errors.New("decrypt encrypted value: authentication failed")
+	}
+	return string(plain), nil
+}
+
+func newSecretAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("secret key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// resetSecretCache 清空解析缓存，下次读取时重新解析引用（如轮换后的密钥文件）
+func resetSecretCache() {
+	secretCache.Range(func(key, _ interface{}) bool {
+		secretCache.Delete(key)
+		return true
+	})
+}
+
+// CheckSecrets 解析配置中的全部密钥引用，汇总无法解析的 key；签名与 ConfigValidator 一致
+func CheckSecrets(cfg IAppConfig) error {
+	ko, err := GetCoreWithConfig[*koanf.Koanf](cfg)
+	if err != nil {
+		return err
+	}
+	var issues []SchemaIssue
+	for key, value := range ko.All() {
+		var values []string
+		switch v := value.(type) {
+		case string:
+			values = []string{v}
+		case []string:
+			values = v
+		case []interface{}:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					values = append(values, s)
+				}
+			}
+		}
+		for _, s := range values {
+			if _, err := ResolveSecret(s); err != nil {
+				issues = append(issues, SchemaIssue{Key: key, Message: "unresolved secret: " + err.Error()})
+				break
+			}
+		}
+	}
+	if len(issues) > 0 {
+		sort.Slice(issues, func(i, j int) bool { return issues[i].Key < issues[j].Key })
+		return &SchemaError{Issues: issues}
+	}
+	return nil
+}
+
+// RedactSecrets 把 s 中出现的已解析密钥值替换为 MaskedValue，用于日志、panic 堆栈与调试响应
+//
+// 只替换长度不小于 4 的密钥值。
+func RedactSecrets(s string) string {
+	redactMu.RLock()
+	r := redactReplacer
+	redactMu.RUnlock()
+	if r == nil || s == "" {
+		return s
+	}
+	return r.Replace(s)
+}
+
+func registerRedact(plain string) {
+	if len(plain) < minRedactLen {
+		return
+	}
+	redactMu.RLock()
+	_, exists := redactValues[plain]
+	redactMu.RUnlock()
+	if exists {
+		return
+	}
+	redactMu.Lock()
+	defer redactMu.Unlock()
+	redactValues[plain] = struct{}{}
+	values := make([]string, 0, len(redactValues))
+	for v := range redactValues {
+		values = append(values, v)
+	}
+	// 长值优先，避免较短的密钥值先替换了较长值的一部分
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	pairs := make([]string, 0, len(values)*2)
+	for _, v := range values {
+		pairs = append(pairs, v, MaskedValue)
+	}
+	redactReplacer = strings.NewReplacer(pairs...)
+}
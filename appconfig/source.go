@@ -0,0 +1,305 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package appconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/providers/env"
+	"github.com/knadh/koanf/v2"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// SourcePriority 配置来源优先级，数值大的来源覆盖数值小的来源，相同优先级按加载顺序后者覆盖前者
+type SourcePriority int
+
+const (
+	PriorityDefault SourcePriority = 0    // 引导期默认层：APP_ENV_、application_<env>.yml 与 LoadDefault
+	PriorityFile    SourcePriority = 100  // 额外的 JSON/TOML/YAML 配置文件
+	PriorityConfD   SourcePriority = 200  // conf.d 目录片段
+	PriorityRemote  SourcePriority = 300  // HTTP、KV 等远程来源
+	PriorityEnv     SourcePriority = 1000 // APP_CONF_ 环境变量，始终覆盖其他来源
+)
+
+const (
+	layerNameMap   = "map"  // LoadDefault 加载步骤名称
+	layerNameFunc  = "func" // LoadFunc 回调直接写入的加载步骤名称
+	sourceNameFile = "file:"
+	sourceNameDir  = "dir:"
+	sourceNameEnv  = "env:"
+)
+
+// Source 配置来源
+type Source interface {
+	// Name 来源名称，用于优先级打印与 key 来源追踪，不得包含敏感信息
+	Name() string
+	// Load 读取来源的配置树，返回嵌套 map，或以分隔符表示层级的扁平 key
+	Load() (map[string]interface{}, error)
+}
+
+// ConfigLayer 已记录的加载步骤
+type ConfigLayer struct {
+	Name     string
+	Priority SourcePriority
+}
+
+// ILayeredConfig 可追踪配置来源的应用配置，AppConfig 实现该接口
+type ILayeredConfig interface {
+	Layers() []ConfigLayer    // 按生效顺序（后者覆盖前者）返回加载步骤
+	Origin(key string) string // 扁平 key 最终生效值的来源名称，未知时为空
+}
+
+type loadStep struct {
+	id       uint64 // 仅按优先级插入的来源步骤设置，用于移除
+	name     string
+	priority SourcePriority
+	load     func(target *AppConfig)
+}
+
+// LoadSource 加载配置来源并记录为加载步骤，失败时 panic
+//
+// 未指定优先级时与 LoadYaml 相同，以 PriorityDefault 记录；指定的优先级低于已记录步骤时，按优先级插入并 Reload 重建配置树。
+func (ac *AppConfig) LoadSource(src Source, priority ...SourcePriority) IAppConfig {
+	step := sourceStep(src, PriorityDefault)
+	if ac.loading || len(priority) == 0 {
+		step.load(ac)
+		ac.recordLoader(step.name, step.load)
+		return ac
+	}
+	step.priority = priority[0]
+	idx := ac.insertLoader(step)
+	if idx == len(ac.loaders)-1 {
+		step.load(ac)
+		return ac
+	}
+	if err := ac.Reload(); err != nil {
+		ac.removeLoader(step.id)
+		panic(fmt.Sprintf("LoadSource: %s: %s", src.Name(), err.Error()))
+	}
+	return ac
+}
+
+// AddSource 按优先级记录配置来源，不立即加载，下次 Reload 时生效；remove 移除该来源，同样在下次 Reload 时生效
+func (ac *AppConfig) AddSource(src Source, priority SourcePriority) (remove func()) {
+	step := sourceStep(src, priority)
+	ac.insertLoader(step)
+	return func() { ac.removeLoader(step.id) }
+}
+
+// Layers 按生效顺序返回已记录的加载步骤
+func (ac *AppConfig) Layers() []ConfigLayer {
+	ac.reloadMu.Lock()
+	defer ac.reloadMu.Unlock()
+	layers := make([]ConfigLayer, 0, len(ac.loaders))
+	for _, step := range ac.loaders {
+		layers = append(layers, ConfigLayer{Name: step.name, Priority: step.priority})
+	}
+	return layers
+}
+
+// Origin 返回扁平 key 最终生效值的来源名称
+func (ac *AppConfig) Origin(key string) string {
+	ac.baseMu.RLock()
+	defer ac.baseMu.RUnlock()
+	return ac.origins[key]
+}
+
+// removeLoader 移除指定的加载步骤
+func (ac *AppConfig) removeLoader(id uint64) {
+	ac.reloadMu.Lock()
+	defer ac.reloadMu.Unlock()
+	for i, step := range ac.loaders {
+		if step.id == id {
+			ac.loaders = append(ac.loaders[:i], ac.loaders[i+1:]...)
+			return
+		}
+	}
+}
+
+// insertLoader 按优先级稳定插入加载步骤，同优先级排在已有步骤之后，返回插入位置
+func (ac *AppConfig) insertLoader(step loadStep) int {
+	ac.reloadMu.Lock()
+	defer ac.reloadMu.Unlock()
+	idx := len(ac.loaders)
+	for idx > 0 && ac.loaders[idx-1].priority > step.priority {
+		idx--
+	}
+	ac.loaders = append(ac.loaders, loadStep{})
+	copy(ac.loaders[idx+1:], ac.loaders[idx:])
+	ac.loaders[idx] = step
+	return idx
+}
+
+var sourceStepSeq atomic.Uint64
+
+func sourceStep(src Source, priority SourcePriority) loadStep {
+	name := src.Name()
+	return loadStep{
+		id:       sourceStepSeq.Add(1),
+		name:     name,
+		priority: priority,
+		load: func(target *AppConfig) {
+			if err := target.applyLayer(name, func(ko *koanf.Koanf) error {
+				m, err := src.Load()
+				if err != nil {
+					return err
+				}
+				return ko.Load(confmap.Provider(m, target.delim), nil)
+			}); err != nil {
+				panic(fmt.Sprintf("LoadSource: %s: %s", name, err.Error()))
+			}
+		},
+	}
+}
+
+// FileSource JSON/TOML/YAML 配置文件来源
+type FileSource struct {
+	// Path 文件路径
+	Path string
+	// Format 文件格式 json、toml、yaml，为空时按扩展名识别
+	Format string
+}
+
+// NewFileSource 创建配置文件来源，格式按扩展名识别
+func NewFileSource(path string) *FileSource {
+	return &FileSource{Path: filepath.ToSlash(path)}
+}
+
+// Name 来源名称
+func (s *FileSource) Name() string {
+	return sourceNameFile + s.Path
+}
+
+// Load 读取并解析配置文件
+func (s *FileSource) Load() (map[string]interface{}, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	format := s.Format
+	if format == "" {
+		format = formatOf(s.Path)
+	}
+	return parseConfig(format, data)
+}
+
+// DirSource conf.d 片段目录来源，目录下的 json、toml、yaml 文件按文件名字典序合并，后者覆盖前者
+type DirSource struct {
+	// Dir 目录路径，目录不存在时视为空配置
+	Dir string
+}
+
+// NewDirSource 创建 conf.d 片段目录来源
+func NewDirSource(dir string) *DirSource {
+	return &DirSource{Dir: strings.TrimRight(filepath.ToSlash(dir), "/")}
+}
+
+// Name 来源名称
+func (s *DirSource) Name() string {
+	return sourceNameDir + s.Dir
+}
+
+// Load 读取并合并目录下的配置片段，子目录与不支持的文件忽略
+func (s *DirSource) Load() (map[string]interface{}, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if os.IsNotExist(err) {
+		return map[string]interface{}{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	merged := koanf.New(".")
+	for _, entry := range entries { // ReadDir 已按文件名排序
+		format := formatOf(entry.Name())
+		if entry.IsDir() || format == "" {
+			continue
+		}
+		fp := filepath.Join(s.Dir, entry.Name())
+		data, err := os.ReadFile(fp)
+		if err != nil {
+			return nil, err
+		}
+		m, err := parseConfig(format, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.ToSlash(fp), err)
+		}
+		if err := merged.Load(confmap.Provider(m, ""), nil); err != nil {
+			return nil, err
+		}
+	}
+	return merged.Raw(), nil
+}
+
+// EnvSource 环境变量来源
+//
+// 去掉前缀后把每个 _ 替换为 . 作为 key（大小写敏感），值含空格时按空格拆分为字符串切片。
+type EnvSource struct {
+	// Prefix 环境变量前缀，如 APP_CONF_
+	Prefix string
+}
+
+// NewEnvSource 创建环境变量来源
+func NewEnvSource(prefix string) *EnvSource {
+	return &EnvSource{Prefix: prefix}
+}
+
+// Name 来源名称
+func (s *EnvSource) Name() string {
+	return sourceNameEnv + s.Prefix
+}
+
+// Load 读取带前缀的环境变量
+func (s *EnvSource) Load() (map[string]interface{}, error) {
+	return env.ProviderWithValue(s.Prefix, ".", func(key string, value string) (string, interface{}) {
+		key = strings.Replace(strings.TrimPrefix(key, s.Prefix), "_", ".", -1)
+		if strings.Contains(value, " ") {
+			return key, strings.Split(value, " ")
+		}
+		return key, value
+	}).Read()
+}
+
+// formatOf 按扩展名识别配置格式，不支持时返回空
+func formatOf(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	case ".yml", ".yaml":
+		return "yaml"
+	default:
+		return ""
+	}
+}
+
+// parseConfig 按格式解析配置内容
+func parseConfig(format string, data []byte) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	switch strings.ToLower(format) {
+	case "json":
+		if err := json.Unmarshal(data, &out); err != nil {
+			return nil, err
+		}
+	case "toml":
+		if err := toml.Unmarshal(data, &out); err != nil {
+			return nil, err
+		}
+	case "yaml", "yml":
+		return yaml.Parser().Unmarshal(data)
+	default:
+		return nil, fmt.Errorf("unsupported config format '%s'", format)
+	}
+	return out, nil
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package appconfig
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRemoteTimeout 远程配置来源的默认读取超时
+const DefaultRemoteTimeout = 10 * time.Second
+
+const (
+	sourceNameHTTP = "http:"
+	sourceNameKV   = "kv:"
+)
+
+// HTTPSource HTTP 配置来源，GET 请求返回 json、toml 或 yaml 格式的配置树
+type HTTPSource struct {
+	// URL 配置地址
+	URL string
+	// Format 响应格式 json、toml、yaml，为空时依次按 Content-Type、URL 扩展名识别，均无法识别时按 json 解析
+	Format string
+	// Header 附加请求头，如认证信息
+	Header http.Header
+	// Client HTTP 客户端，为空时使用 http.DefaultClient
+	Client *http.Client
+	// Timeout 单次读取超时，<=0 时使用 DefaultRemoteTimeout
+	Timeout time.Duration
+}
+
+// NewHTTPSource 创建 HTTP 配置来源
+func NewHTTPSource(rawURL string) *HTTPSource {
+	return &HTTPSource{URL: rawURL, Header: make(http.Header)}
+}
+
+// Name 来源名称，去除 URL 中的用户信息与查询参数
+func (s *HTTPSource) Name() string {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return sourceNameHTTP + "<invalid url>"
+	}
+	u.User, u.RawQuery, u.Fragment = nil, "", ""
+	return sourceNameHTTP + u.String()
+}
+
+// Load 请求并解析配置，非 2xx 响应返回错误
+func (s *HTTPSource) Load() (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout(s.Timeout))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, values := range s.Header {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseConfig(s.format(resp.Header.Get("Content-Type")), data)
+}
+
+func (s *HTTPSource) format(contentType string) string {
+	if s.Format != "" {
+		return s.Format
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch {
+		case strings.HasSuffix(mediaType, "json"):
+			return "json"
+		case strings.HasSuffix(mediaType, "toml"):
+			return "toml"
+		case strings.HasSuffix(mediaType, "yaml"), strings.HasSuffix(mediaType, "yml"):
+			return "yaml"
+		}
+	}
+	if u, err := url.Parse(s.URL); err == nil {
+		if format := formatOf(path.Base(u.Path)); format != "" {
+			return format
+		}
+	}
+	return "json"
+}
+
+// KVStore Consul、etcd 风格的 KV 存储，由应用适配具体客户端
+type KVStore interface {
+	// List 返回 key 以 prefix 开头的全部键值，key 为完整的 / 分隔路径
+	List(ctx context.Context, prefix string) (map[string][]byte, error)
+}
+
+// KVSource KV 存储配置来源
+//
+// 去掉前缀后的 / 分隔路径映射为配置 key，如前缀 fiberhouse/ 下的 fiberhouse/cache/redis/host 映射为 cache.redis.host；值按字符串加载。
+type KVSource struct {
+	// Store KV 存储
+	Store KVStore
+	// Prefix key 前缀
+	Prefix string
+	// Timeout 单次读取超时，<=0 时使用 DefaultRemoteTimeout
+	Timeout time.Duration
+}
+
+// NewKVSource 创建 KV 存储配置来源
+func NewKVSource(store KVStore, prefix string) *KVSource {
+	return &KVSource{Store: store, Prefix: prefix}
+}
+
+// Name 来源名称
+func (s *KVSource) Name() string {
+	return sourceNameKV + s.Prefix
+}
+
+// Load 读取前缀下的全部键值
+func (s *KVSource) Load() (map[string]interface{}, error) {
+	if s.Store == nil {
+		return nil, fmt.Errorf("kv store is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout(s.Timeout))
+	defer cancel()
+	pairs, err := s.Store.List(ctx, s.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{}, len(pairs))
+	for k, v := range pairs {
+		key := strings.Trim(strings.TrimPrefix(k, s.Prefix), "/")
+		if key == "" {
+			continue
+		}
+		out[strings.ReplaceAll(key, "/", ".")] = string(v)
+	}
+	return out, nil
+}
+
+func remoteTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return DefaultRemoteTimeout
+	}
+	return timeout
+}
+
+// MemoryKVStore 内存 KV 存储，用于测试与本地开发，并发安全
+type MemoryKVStore struct {
+	mu   sync.RWMutex
+	data map[string][]byte
+}
+
+// NewMemoryKVStore 创建内存 KV 存储
+func NewMemoryKVStore() *MemoryKVStore {
+	return &MemoryKVStore{data: make(map[string][]byte)}
+}
+
+// Put 写入键值
+func (s *MemoryKVStore) Put(key, value string) {
+	s.mu.Lock()
+	s.data[key] = []byte(value)
+	s.mu.Unlock()
+}
+
+// Delete 删除键
+func (s *MemoryKVStore) Delete(key string) {
+	s.mu.Lock()
+	delete(s.data, key)
+	s.mu.Unlock()
+}
+
+// Keys 返回全部键，已排序
+func (s *MemoryKVStore) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// List 返回前缀下的全部键值副本
+func (s *MemoryKVStore) List(ctx context.Context, prefix string) (map[string][]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string][]byte)
+	for k, v := range s.data {
+		if strings.HasPrefix(k, prefix) {
+			out[k] = append([]byte(nil), v...)
+		}
+	}
+	return out, nil
+}
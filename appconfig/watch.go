@@ -0,0 +1,88 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package appconfig
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchDirs 监听目录，filter 接受的事件（filter 为 nil 时接受全部事件）合并 debounce 窗口后调用 onChange
+//
+// 监听目录而非文件，以便覆盖编辑器的重命名替换与 Kubernetes 挂载的符号链接切换；debounce<=0 时使用 DefaultWatchDebounce。
+// onChange 在后台协程中串行调用。返回的 stop 停止监听并等待后台协程退出，可重复调用。
+func WatchDirs(dirs []string, debounce time.Duration, filter func(event fsnotify.Event) bool, onChange func()) (stop func() error, err error) {
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create watcher: %w", err)
+	}
+	for _, dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return nil, fmt.Errorf("watch dir '%s': %w", dir, err)
+		}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		var (
+			timer   *time.Timer
+			trigger <-chan time.Time
+		)
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+		for {
+			select {
+			case <-done:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filter != nil && !filter(event) {
+					continue
+				}
+				if timer == nil {
+					timer = time.NewTimer(debounce)
+				} else {
+					timer.Reset(debounce)
+				}
+				trigger = timer.C
+			case <-trigger:
+				trigger = nil
+				onChange()
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() error {
+		var closeErr error
+		once.Do(func() {
+			close(done)
+			closeErr = watcher.Close()
+			wg.Wait()
+		})
+		return closeErr
+	}, nil
+}
@@ -212,6 +212,24 @@ type ApplicationRegister interface {
 	RegisterCoreHook(cs CoreStarter)
 }
 
+// GlobalDependencyConfigurer 可选接口，ApplicationRegister 实现该接口以声明全局对象初始化器之间的依赖
+//
+// 启动时框架据此校验依赖图（依赖缺失、循环依赖），按依赖并行初始化 ConfigRequiredGlobalKeys，
+// 并在重建与释放时沿依赖级联/逆序处理。
+type GlobalDependencyConfigurer interface {
+	// ConfigGlobalDependencies 配置并返回全局对象 key 到其依赖 key 列表的映射
+	ConfigGlobalDependencies() globalmanager.DependencyMap
+}
+
+// ConfigReloadConfigurer 可选接口，ApplicationRegister 实现该接口以声明配置热更新时需要重建的全局对象
+//
+// 配置前缀下任一 key 变更后，框架依次 Rebuild 其中已初始化的全局对象（对象需实现 globalmanager.Rebuilder），
+// 并沿依赖图级联重建依赖方。
+type ConfigReloadConfigurer interface {
+	// ConfigReloadRebuilds 配置并返回配置前缀到需要重建的全局对象 key 列表的映射
+	ConfigReloadRebuilds() map[string][]globalmanager.KeyName
+}
+
 // ModuleRegister 模块注册器
 //
 // 用于注册应用的模块/子系统，包括中间件、路由、swagger等
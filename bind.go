@@ -0,0 +1,253 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	hertzjson "github.com/cloudwego/hertz/pkg/common/json"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	ginJson "github.com/gin-gonic/gin/codec/json"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/lamxy/fiberhouse/component/validate"
+	"github.com/lamxy/fiberhouse/exception"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// 请求参数来源的结构体标签
+const (
+	BindTagPath   = "path"
+	BindTagQuery  = "query"
+	BindTagHeader = "header"
+	BindTagForm   = "form"
+)
+
+// bindMultipartMemory 解析 multipart 表单时保留在内存中的最大字节数
+const bindMultipartMemory = 32 << 20
+
+// ErrBindNoAppContext 全局应用上下文尚未创建时 Bind 返回的错误
+var ErrBindNoAppContext = errors.New("bind: application context is not initialized")
+
+// Bind 把请求参数解码到新建的 T 并执行结构体校验，使用全局应用上下文的验证包装器
+//
+// 请求体按 Content-Type 解码：JSON 使用核心配置的传输编解码器，表单按 form 标签，
+// msgpack 复用 json 标签，protobuf 要求 *T 实现 proto.Message；随后依次以 query、header、path
+// 标签绑定查询参数、请求头与路由参数，后绑定的来源覆盖先前的值。结构体未声明某个来源的标签时跳过该来源。
+//
+// 校验消息的语言由应用上下文的 i18n.Resolver 在 GetLangList() 中协商得出：配置的查询参数与请求头优先，其次为 Accept-Language。
+// 解码失败与校验失败均返回 *exception.ValidateException，由核心的错误处理器以 400 响应；
+// 校验失败时 Data 为蛇形字段名到本地化消息的映射，与 ValidateWrapper.Errors(errs, lang, true) 一致。
+//
+//	func (h *Handler) Create(c adaptorctx.ICoreContext) error {
+//		req, err := fiberhouse.Bind[requestvo.CreateExampleReqVo](c)
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func Bind[T any](c adaptorctx.ICoreContext) (*T, error) {
+	if applicationContext == nil {
+		return nil, ErrBindNoAppContext
+	}
+	return BindWith[T](applicationContext.GetValidateWrap(), c)
+}
+
+// BindWith 同 Bind，使用指定的验证包装器；vw 为 nil 时只解码不校验
+func BindWith[T any](vw validate.ValidateWrapper, c adaptorctx.ICoreContext) (*T, error) {
+	out := new(T)
+	if err := bindRequest(c, out); err != nil {
+		return nil, exception.VeGetInputError().RespData(err)
+	}
+	if vw == nil || reflect.TypeOf(out).Elem().Kind() != reflect.Struct {
+		return out, nil
+	}
+
+	lang := bindLang(c, vw.GetLangList())
+	if err := vw.GetValidate(lang).Struct(out); err != nil {
+		var errs validator.ValidationErrors
+		if errors.As(err, &errs) {
+			return nil, vw.Errors(errs, lang, true)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// bindLang 协商校验消息的语言，全局应用上下文未创建时只按 Accept-Language 协商
+func bindLang(c adaptorctx.ICoreContext, supported []validate.LangFlag) validate.LangFlag {
+	if applicationContext != nil && applicationContext.GetLangResolver() != nil {
+		return applicationContext.GetLangResolver().Resolve(c, supported)
+	}
+	return validate.NegotiateLang(c.GetHeader("Accept-Language"), supported)
+}
+
+// bindRequest 依次绑定请求体、查询参数、请求头与路由参数
+func bindRequest(c adaptorctx.ICoreContext, out interface{}) error {
+	if err := bindBody(c, out); err != nil {
+		return err
+	}
+
+	tags := bindTagsOf(reflect.TypeOf(out))
+	if tags.query {
+		if err := binding.MapFormWithTag(out, c.Queries(), BindTagQuery); err != nil {
+			return err
+		}
+	}
+	if len(tags.headers) > 0 {
+		headers := make(map[string][]string, len(tags.headers))
+		for _, name := range tags.headers {
+			if value := c.GetHeader(name); value != "" {
+				headers[name] = []string{value}
+			}
+		}
+		if err := binding.MapFormWithTag(out, headers, BindTagHeader); err != nil {
+			return err
+		}
+	}
+	if tags.path {
+		params := c.Params()
+		values := make(map[string][]string, len(params))
+		for key, value := range params {
+			values[key] = []string{value}
+		}
+		if err := binding.MapFormWithTag(out, values, BindTagPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindBody 按 Content-Type 解码请求体，空请求体不做处理
+func bindBody(c adaptorctx.ICoreContext, out interface{}) error {
+	body, err := c.Body()
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if err != nil {
+		return fmt.Errorf("bind: invalid Content-Type: %w", err)
+	}
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return bindJSONDecoder(c)(body, out)
+	case mediaType == "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return err
+		}
+		return binding.MapFormWithTag(out, form, BindTagForm)
+	case mediaType == "multipart/form-data":
+		form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(bindMultipartMemory)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = form.RemoveAll() }()
+		return binding.MapFormWithTag(out, form.Value, BindTagForm)
+	case mediaType == "application/msgpack" || mediaType == "application/x-msgpack":
+		dec := msgpack.NewDecoder(bytes.NewReader(body))
+		dec.SetCustomStructTag("json")
+		return dec.Decode(out)
+	case mediaType == adaptorctx.MIMEProtobuf || mediaType == "application/protobuf":
+		msg, ok := out.(proto.Message)
+		if !ok {
+			return fmt.Errorf("bind: %T does not implement proto.Message", out)
+		}
+		return proto.Unmarshal(body, msg)
+	default:
+		return fmt.Errorf("bind: unsupported Content-Type %q", mediaType)
+	}
+}
+
+// bindJSONDecoder 返回核心配置的 JSON 解码函数：Fiber 读取 fiber.Config.JSONDecoder，
+// Gin 使用 gin codec json.API，Hertz 使用 hertz common/json，其他核心回退到 encoding/json
+func bindJSONDecoder(c adaptorctx.ICoreContext) func(data []byte, v interface{}) error {
+	switch native := c.GetCtx().(type) {
+	case *fiber.Ctx:
+		if decoder := native.App().Config().JSONDecoder; decoder != nil {
+			return decoder
+		}
+	case *gin.Context:
+		return ginJson.API.Unmarshal
+	case *app.RequestContext:
+		return hertzjson.Unmarshal
+	}
+	return json.Unmarshal
+}
+
+// bindTags 结构体声明的参数来源标签
+type bindTags struct {
+	query   bool
+	path    bool
+	headers []string
+}
+
+// bindTagsCache 按类型缓存 bindTags
+var bindTagsCache sync.Map
+
+// bindTagsOf 扫描结构体（含嵌套与指针字段）声明的 query、path 与 header 标签
+func bindTagsOf(t reflect.Type) bindTags {
+	if cached, ok := bindTagsCache.Load(t); ok {
+		return cached.(bindTags)
+	}
+	var tags bindTags
+	collectBindTags(t, &tags, map[reflect.Type]bool{})
+	bindTagsCache.Store(t, tags)
+	return tags
+}
+
+func collectBindTags(t reflect.Type, tags *bindTags, seen map[reflect.Type]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return
+	}
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		if name := bindTagName(field, BindTagQuery); name != "" {
+			tags.query = true
+		}
+		if name := bindTagName(field, BindTagPath); name != "" {
+			tags.path = true
+		}
+		if name := bindTagName(field, BindTagHeader); name != "" {
+			tags.headers = append(tags.headers, name)
+		}
+		collectBindTags(field.Type, tags, seen)
+	}
+}
+
+// bindTagName 取标签中的参数名，忽略 default 等选项与 "-"
+func bindTagName(field reflect.StructField, tag string) string {
+	name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,189 @@
+package fiberhouse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/component/validate"
+	"github.com/lamxy/fiberhouse/constant"
+	"github.com/lamxy/fiberhouse/exception"
+	"github.com/lamxy/fiberhouse/globalmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// bindTestReq 覆盖 path、query、header 与请求体四种来源
+type bindTestReq struct {
+	ID    string `path:"id" json:"id" validate:"required"`
+	Name  string `json:"name" form:"name" validate:"required,min=2"`
+	Page  int    `query:"page,default=1" json:"page" validate:"min=1"`
+	Token string `header:"X-Token" json:"token"`
+}
+
+func installBindTestExceptions(t *testing.T) {
+	t.Helper()
+	manager := globalmanager.NewGlobalManagerOnce()
+	key := constant.RegisterKeyPrefix + "exceptions"
+	wasRegistered := manager.IsRegistered(key)
+	var previous interface{}
+	if wasRegistered {
+		var err error
+		previous, err = manager.Get(key)
+		require.NoError(t, err)
+	}
+	manager.Clear(key)
+	require.True(t, manager.Register(key, func() (interface{}, error) {
+		return exception.ExceptionMap{"InputParamError": {Code: 4000, Msg: "invalid input"}}, nil
+	}))
+	t.Cleanup(func() {
+		manager.Clear(key)
+		if wasRegistered {
+			require.True(t, manager.Register(key, func() (interface{}, error) { return previous, nil }))
+		}
+	})
+}
+
+// registerBindTestRoutes 校验失败时以 400 输出 ValidateException，便于跨核心比较
+func registerBindTestRoutes(r Router, vw validate.ValidateWrapper) {
+	respond := func(c adaptorctx.ICoreContext, out interface{}, err error) error {
+		var ve *exception.ValidateException
+		if errors.As(err, &ve) {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{"code": ve.Code, "data": ve.Data})
+		}
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, out)
+	}
+	r.Post("/items/:id", func(c adaptorctx.ICoreContext) error {
+		req, err := BindWith[bindTestReq](vw, c)
+		return respond(c, req, err)
+	})
+	r.Post("/proto", func(c adaptorctx.ICoreContext) error {
+		msg, err := BindWith[wrapperspb.StringValue](vw, c)
+		if err != nil {
+			return respond(c, nil, err)
+		}
+		return c.Send(http.StatusOK, []byte(msg.GetValue()))
+	})
+}
+
+func TestBind_DecodesSourcesAndLocalizesValidationOnEveryCore(t *testing.T) {
+	installBindTestExceptions(t)
+	preserveTask4GinMode(t)
+	gin.SetMode(gin.TestMode)
+
+	vw := validate.NewWrap(appconfig.NewAppConfig().LoadDefault(map[string]interface{}{
+		"application.validate.langFlags": []string{validate.LangEn, validate.LangZhCN},
+	}))
+	fiberDecodes := &atomic.Int32{}
+
+	cores := map[string]func(t *testing.T) http.Handler{
+		"fiber": func(t *testing.T) http.Handler {
+			fiberApp := fiber.New(fiber.Config{JSONDecoder: func(data []byte, v interface{}) error {
+				fiberDecodes.Add(1)
+				return json.Unmarshal(data, v)
+			}})
+			r, err := NewRouter(fiberApp)
+			require.NoError(t, err)
+			registerBindTestRoutes(r, vw)
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				resp, err := fiberApp.Test(req)
+				require.NoError(t, err)
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				w.WriteHeader(resp.StatusCode)
+				_, _ = w.Write(body)
+			})
+		},
+		"gin": func(t *testing.T) http.Handler {
+			engine := gin.New()
+			r, err := NewRouter(engine)
+			require.NoError(t, err)
+			registerBindTestRoutes(r, vw)
+			return engine
+		},
+	}
+
+	msgpackBody, err := msgpack.Marshal(map[string]interface{}{"name": "packed"})
+	require.NoError(t, err)
+	protoBody, err := proto.Marshal(wrapperspb.String("proto-value"))
+	require.NoError(t, err)
+
+	for name, build := range cores {
+		t.Run(name, func(t *testing.T) {
+			handler := build(t)
+			serve := func(target, contentType string, body []byte, header map[string]string) (int, string) {
+				req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+				req.Header.Set("Content-Type", contentType)
+				for k, v := range header {
+					req.Header.Set(k, v)
+				}
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, req)
+				return recorder.Code, recorder.Body.String()
+			}
+
+			status, body := serve("/items/42?page=3", "application/json", []byte(`{"name":"widget","page":9}`), map[string]string{"X-Token": "secret"})
+			assert.Equal(t, http.StatusOK, status)
+			assert.JSONEq(t, `{"id":"42","name":"widget","page":3,"token":"secret"}`, body, "query, header and path override the body")
+
+			status, body = serve("/items/7", "application/x-www-form-urlencoded", []byte("name=form-name"), nil)
+			assert.Equal(t, http.StatusOK, status)
+			assert.JSONEq(t, `{"id":"7","name":"form-name","page":1,"token":""}`, body, "query default applies when the parameter is absent")
+
+			status, body = serve("/items/8", "application/msgpack", msgpackBody, nil)
+			assert.Equal(t, http.StatusOK, status)
+			assert.JSONEq(t, `{"id":"8","name":"packed","page":1,"token":""}`, body)
+
+			status, body = serve("/proto", adaptorctx.MIMEProtobuf, protoBody, nil)
+			assert.Equal(t, http.StatusOK, status)
+			assert.Equal(t, "proto-value", body)
+
+			status, body = serve("/items/9", "application/json", []byte(`{"name":"x"}`), map[string]string{"Accept-Language": "zh-CN,zh;q=0.9,en;q=0.8"})
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.JSONEq(t, `{"code":4000,"data":{"name":"name长度必须至少为2个字符"}}`, body)
+
+			status, body = serve("/items/9", "application/json", []byte(`{"name":"x"}`), map[string]string{"Accept-Language": "fr-FR, en-US;q=0.5"})
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.JSONEq(t, `{"code":4000,"data":{"name":"name must be at least 2 characters in length"}}`, body)
+
+			status, body = serve("/items/9", "application/json", []byte(`{"name":`), nil)
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Contains(t, body, `"code":4000`)
+
+			status, body = serve("/items/9", "text/plain", []byte("name"), nil)
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Contains(t, body, "unsupported Content-Type")
+		})
+	}
+	assert.Positive(t, fiberDecodes.Load(), "Fiber decodes JSON bodies with the configured codec")
+}
+
+func TestBind_RequiresApplicationContext(t *testing.T) {
+	previous := applicationContext
+	applicationContext = nil
+	t.Cleanup(func() { applicationContext = previous })
+
+	fiberApp := fiber.New()
+	fiberApp.Get("/", func(c *fiber.Ctx) error {
+		_, err := Bind[bindTestReq](adaptorctx.WithFiberContext(c))
+		assert.ErrorIs(t, err, ErrBindNoAppContext)
+		return nil
+	})
+	_, err := fiberApp.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+}
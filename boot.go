@@ -62,10 +62,17 @@ type BootConfig struct {
 	TrafficCodec string
 	// 是否启用二进制协议支持，如Protobuf、MsgPack等
 	EnableBinaryProtocolSupport bool
+	// StrictContentNegotiation 是否启用严格内容协商，启用后 Accept 不接受任何可用的响应格式时返回 406 Not Acceptable，否则回退 JSON
+	StrictContentNegotiation bool
+	// ErrorEnvelope 错误响应信封类型标识，如"respinfo"（默认，{code,msg,data}）、"problem"（RFC 7807 application/problem+json）
+	// 见constant.ErrorEnvelopeWithRespInfo；路由分组可经 UseErrorEnvelope 覆盖
+	ErrorEnvelope string
 	// ConfigPath 全局应用配置文件的路径
 	ConfigPath string
 	// LogPath 全局应用日志文件的路径
 	LogPath string
+	// SecretKey base64 编码的配置解密密钥，用于解密 enc: 前缀的配置值；为空时读取环境变量 APP_SECRET_KEY
+	SecretKey string
 	// kvStorage 键值存储映射，用于存储额外自定义的属性
 	kvStorage map[string]any
 	// sealed 是否已封闭，封闭后不可再添加键值
@@ -144,6 +151,17 @@ func New(cfg *BootConfig) *FiberHouse {
 	}
 	fh.bootCfg = cfg
 
+	// 配置解密密钥须在加载配置前设置，启动期校验会解析全部密钥引用
+	if cfg.SecretKey != "" {
+		key, err := appconfig.DecodeSecretKey(cfg.SecretKey)
+		if err == nil {
+			err = appconfig.SetSecretKey(key)
+		}
+		if err != nil {
+			panic("BootConfig.SecretKey: " + err.Error())
+		}
+	}
+
 	// bootstrap 初始化启动配置(全局配置、全局日志器)，配置目录默认为当前工作目录"."下的`example_config/`
 	appCfg := bootstrap.NewConfigOnce(fh.bootCfg.ConfigPath)
 	// 日志目录默认为当前工作目录"."下的`example_main/logs`
@@ -261,6 +279,13 @@ func WithLogPath(logPath string) BootConfigOption {
 	}
 }
 
+// WithSecretKey 设置 base64 编码的配置解密密钥
+func WithSecretKey(secretKey string) BootConfigOption {
+	return func(boot *BootConfig) {
+		boot.SecretKey = secretKey
+	}
+}
+
 // WithCustomKV 设置自定义键值对
 func WithCustomKV(key string, value any) BootConfigOption {
 	return func(boot *BootConfig) {
@@ -294,22 +319,26 @@ func (fh *FiberHouse) WithPManagers(managers ...IProviderManager) *FiberHouse {
 
 // RunServer 运行应用服务器
 // 提供者状态/日志: pending、loaded、skipped、failed ???
-func (fh *FiberHouse) RunServer(manager ...IProviderManager) {
-	// 引导配置完成位置点，获取该位点的提供者管理器列表并加载提供者
-	ms := ProviderLocationDefault().LocationBootStrapConfig.GetManagers()
-	if len(ms) > 0 {
-		for _, m := range ms {
-			if m.IsUnique() { // 只允许唯一绑定单一提供者的管理器
-				_, _ = m.LoadProvider(func(manager IProviderManager) (any, error) {
-					return fh, nil // 向当前管理器加载提供者函数中注入当前执行位点的FiberHouse实例
-				})
-			}
-			break
-		}
+//
+// 返回核心应用运行错误与关闭错误（含全局对象逐项关闭失败）的聚合错误，正常退出时返回 nil
+func (fh *FiberHouse) RunServer(manager ...IProviderManager) error {
+	// 收集提供者并注册到同类型组的管理器中
+	defaultManager := fh.registerProviders(manager...)
+
+	// 引导配置完成位置点，获取该位点的提供者管理器列表并加载提供者（如配置来源管理器），配置来源加载失败时中止启动
+	if err := loadBootStrapConfigManagers(fh, ProviderLocationDefault().LocationBootStrapConfig.GetManagers()); err != nil {
+		_, cfg, logger := fh.resolveGlobalTools()
+		logger.ErrorWith(cfg.LogOriginFrame()).Err(err).Msg("LocationBootStrapConfig managers load failed")
+		return err
+	}
+
+	// 命令行参数含 --print-config 时打印配置来源优先级与脱敏后的合并配置，不启动服务
+	if PrintConfigRequested(os.Args[1:]) {
+		return fh.PrintConfig(os.Stdout)
 	}
 
-	// 收集提供者并注册到同类型组的管理器中，管理器加載提供者并執行提供者的初始化逻辑
-	fh.resolveManagerWithProviders(manager...)
+	// 管理器加載提供者并執行提供者的初始化逻辑
+	fh.loadManagerProviders(defaultManager)
 
 	// 框架启动器选项初始化位置点，获取创建框架启动器所需的选项参数列表
 	fso := fh.resolveFrameStarterOpts()
@@ -362,16 +391,10 @@ func (fh *FiberHouse) RunServer(manager ...IProviderManager) {
 	appStarter.RegisterGlobalsKeepalive(ProviderLocationDefault().LocationGlobalKeepaliveInit.GetManagers()...)
 
 	// 运行前执行位置点，完成核心应用服务器监听前的必要逻辑（如有）
-	runBeforeManagers := ProviderLocationDefault().LocationServerRunBefore.GetManagers()
-	if len(runBeforeManagers) > 0 {
-		for _, m := range runBeforeManagers {
-			if m.IsUnique() { // 只允许唯一绑定单一提供者的管理器
-				_, _ = m.LoadProvider(func(manager IProviderManager) (any, error) {
-					return appStarter, nil // 向当前管理器加载提供者函数中注入当前执行位点的应用启动器实例
-				})
-				break
-			}
-		}
+	// 插件启动管理器等非唯一模式的管理器均在此加载（位于全局对象保活注册之后）
+	if err := loadServerRunBeforeManagers(appStarter, ProviderLocationDefault().LocationServerRunBefore.GetManagers()); err != nil {
+		_, cfg, logger := fh.resolveGlobalTools()
+		logger.ErrorWith(cfg.LogOriginFrame()).Err(err).Msg("LocationServerRunBefore managers load failed")
 	}
 
 	// 监听系统信号，处理应用优雅关闭逻辑
@@ -398,6 +421,40 @@ func (fh *FiberHouse) RunServer(manager ...IProviderManager) {
 	}
 
 	fmt.Println("Application RunServer exited")
+	return errors.Join(runErr, shutdownErr)
+}
+
+// loadServerRunBeforeManagers 加载服务运行前执行位置点的管理器
+// 唯一模式的管理器只加载第一个；非唯一模式的管理器（如插件启动管理器）按绑定顺序全部加载；返回聚合错误
+func loadServerRunBeforeManagers(appStarter ApplicationStarter, managers []IProviderManager) error {
+	return loadLocationManagers(appStarter, managers)
+}
+
+// loadBootStrapConfigManagers 加载引导配置位置点的管理器，向加载函数注入 FiberHouse 实例
+// 唯一模式的管理器只加载第一个；非唯一模式的管理器（如配置来源管理器）按绑定顺序全部加载；返回聚合错误
+func loadBootStrapConfigManagers(fh *FiberHouse, managers []IProviderManager) error {
+	return loadLocationManagers(fh, managers)
+}
+
+// loadLocationManagers 按唯一模式只加载第一个、非唯一模式全部加载的规则加载位置点管理器，inject 注入到管理器的加载函数
+func loadLocationManagers(inject any, managers []IProviderManager) error {
+	var errs []error
+	uniqueLoaded := false
+	for _, m := range managers {
+		if m.IsUnique() {
+			if uniqueLoaded { // 只允许唯一绑定单一提供者的管理器
+				continue
+			}
+			uniqueLoaded = true
+		}
+		_, err := m.LoadProvider(func(manager IProviderManager) (any, error) {
+			return inject, nil // 向当前管理器加载提供者函数中注入当前执行位点的实例
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("manager '%s': %w", m.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // resolveGlobalTools 获取全局应用上下文、全局配置器和全局日志器
@@ -432,9 +489,13 @@ func (fh *FiberHouse) resolverAndReturnInitCoreManagers() []IProviderManager {
 	return initCoreManagers
 }
 
-// resolveRunAndShutdownManagers 获取运行时和关闭时的提供者管理器列表
+// resolveRunAndShutdownManagers 获取运行时和关闭时的提供者管理器列表，运行时列表含监听绑定后执行的 ServerRunAfter 管理器
 func (fh *FiberHouse) resolveRunAndShutdownManagers() ([]IProviderManager, []IProviderManager) {
-	runManagers := ProviderLocationDefault().LocationServerRun.GetManagers()
+	serverRunManagers := ProviderLocationDefault().LocationServerRun.GetManagers()
+	runAfterManagers := ProviderLocationDefault().LocationServerRunAfter.GetManagers()
+	runManagers := make([]IProviderManager, 0, len(serverRunManagers)+len(runAfterManagers))
+	runManagers = append(runManagers, serverRunManagers...)
+	runManagers = append(runManagers, runAfterManagers...)
 	shutdownBeforeManagers := ProviderLocationDefault().LocationServerShutdownBefore.GetManagers()
 	shutdownManagers := ProviderLocationDefault().LocationServerShutdown.GetManagers()
 	shutdownAfterManagers := ProviderLocationDefault().LocationServerShutdownAfter.GetManagers()
@@ -560,9 +621,8 @@ func (fh *FiberHouse) resolveAndReturnCoreStarter(cs []CoreStarterOption) CoreSt
 	return coreStarter
 }
 
-// resolveManagerWithProviders 收集提供者并注册到同类型组的管理器中，加載 providers 執行 initialize 初始化
-// 排除已绑定到特定位置点的管理器，这些管理器将在后续对应位置点被单独加载，不在此处解决
-func (fh *FiberHouse) resolveManagerWithProviders(manager ...IProviderManager) {
+// registerProviders 收集提供者并注册到同类型组的管理器中，未匹配的提供者注册到默认管理器，返回默认管理器
+func (fh *FiberHouse) registerProviders(manager ...IProviderManager) IProviderManager {
 	appContext, cfg, logger := fh.resolveGlobalTools()
 
 	var defaultManager IProviderManager
@@ -606,6 +666,12 @@ func (fh *FiberHouse) resolveManagerWithProviders(manager ...IProviderManager) {
 				Msgf("provider %s register to default manager failed", pdr.Type().GetTypeName())
 		}
 	}
+	return defaultManager
+}
+
+// loadManagerProviders 加載 providers 執行 initialize 初始化
+func (fh *FiberHouse) loadManagerProviders(defaultManager IProviderManager) {
+	_, cfg, logger := fh.resolveGlobalTools()
 
 	// 加载所有管理器中的提供者，排除已绑定到特定位置点的管理器，这些管理器将在对应位置点被单独加载
 	if len(fh.managers) > 0 {
@@ -20,6 +20,7 @@ func TestBootConfigOptions_SetEveryConfigurableField(t *testing.T) {
 		WithTrafficCodec("codec"),
 		WithConfigPath("config-path"),
 		WithLogPath("log-path"),
+		WithSecretKey("c2VjcmV0"),
 		WithCustomKV("custom", "value"),
 	}
 	for _, option := range options {
@@ -35,6 +36,7 @@ func TestBootConfigOptions_SetEveryConfigurableField(t *testing.T) {
 	assert.Equal(t, "codec", config.TrafficCodec)
 	assert.Equal(t, "config-path", config.ConfigPath)
 	assert.Equal(t, "log-path", config.LogPath)
+	assert.Equal(t, "c2VjcmV0", config.SecretKey)
 	value, err := config.GetValue("custom")
 	require.NoError(t, err)
 	assert.Equal(t, "value", value)
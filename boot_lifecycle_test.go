@@ -1,6 +1,7 @@
 package fiberhouse
 
 import (
+	"errors"
 	"os"
 	"syscall"
 	"testing"
@@ -166,3 +167,42 @@ func TestCoordinateServerRun_SignalInvokesShutdown(t *testing.T) {
 	assert.True(t, shutdownRequested)
 	assert.Equal(t, 1, starter.shutdownCalls)
 }
+
+type runBeforeStubManager struct {
+	IProviderManager
+	name     string
+	unique   bool
+	loadErr  error
+	calls    *[]string
+	injected any
+}
+
+func (m *runBeforeStubManager) Name() string   { return m.name }
+func (m *runBeforeStubManager) IsUnique() bool { return m.unique }
+
+func (m *runBeforeStubManager) LoadProvider(loadFunc ...ProviderLoadFunc) (any, error) {
+	*m.calls = append(*m.calls, m.name)
+	if len(loadFunc) > 0 {
+		m.injected, _ = loadFunc[0](m)
+	}
+	return nil, m.loadErr
+}
+
+func TestLoadServerRunBeforeManagers_LoadsFirstUniqueAndAllNonUnique(t *testing.T) {
+	var calls []string
+	loadErr := errors.New("plugin start failed")
+	firstUnique := &runBeforeStubManager{name: "unique-1", unique: true, calls: &calls}
+	secondUnique := &runBeforeStubManager{name: "unique-2", unique: true, calls: &calls}
+	plugins := &runBeforeStubManager{name: "plugins", calls: &calls, loadErr: loadErr}
+	other := &runBeforeStubManager{name: "other", calls: &calls}
+	starter := &coordinatedServerStarter{}
+
+	err := loadServerRunBeforeManagers(starter, []IProviderManager{plugins, firstUnique, secondUnique, other})
+
+	assert.ErrorIs(t, err, loadErr)
+	assert.Contains(t, err.Error(), "manager 'plugins'")
+	assert.Equal(t, []string{"plugins", "unique-1", "other"}, calls)
+	assert.Same(t, starter, firstUnique.injected)
+	assert.Same(t, starter, plugins.injected)
+	assert.Nil(t, secondUnique.injected)
+}
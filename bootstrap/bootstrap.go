@@ -16,10 +16,9 @@ import (
 	"sync"
 	"time"
 
-	"github.com/knadh/koanf/providers/env"
-	"github.com/knadh/koanf/v2"
 	"github.com/lamxy/fiberhouse/appconfig"
 	"github.com/lamxy/fiberhouse/component/logging/writer"
+	"github.com/lamxy/fiberhouse/component/validate"
 	"github.com/lamxy/fiberhouse/constant"
 	frameUtils "github.com/lamxy/fiberhouse/utils"
 	"github.com/rs/zerolog"
@@ -29,6 +28,7 @@ const (
 	defaultEnv      = "dev" // test、dev、prod
 	envPrefix       = "APP_ENV_"
 	envConfigPrefix = "APP_CONF_"
+	envSecretKey    = "APP_SECRET_KEY" // base64 编码的 enc: 配置值解密密钥
 )
 
 var (
@@ -80,8 +80,11 @@ func NewLoggerWrap(logger *zerolog.Logger, logCloser ...io.WriteCloser) LoggerWr
 	}
 }
 
-// GetLevel 返回当前日志级别
+// GetLevel 返回当前生效的日志级别：日志器自身级别与 zerolog 全局级别中较高者
 func (lw *LoggerWrap) GetLevel() zerolog.Level {
+	if level := zerolog.GlobalLevel(); level > lw.logger.GetLevel() {
+		return level
+	}
 	return lw.logger.GetLevel()
 }
 
@@ -198,23 +201,7 @@ func NewConfigOnce(path ...string) appconfig.IAppConfig {
 		// 加载基础环境变量： 前缀 APP_ENV_ ； e.g. APP_ENV_application_env=prod ==> application.env = prod、 APP_ENV_application_appType=web ==> application.appType=web
 		// 注意: APP_ENV_为大写，application_env跟yml文件配置的application.env对应，且必须大小写保持一致
 		// 加载环境类型和应用类别：用于支持不同环境和应用类别的配置文件选择
-		aCfg.LoadFunc(func(aConf appconfig.IAppConfig) appconfig.IAppConfig {
-			err := aConf.GetCore().(*koanf.Koanf).Load(env.ProviderWithValue(envPrefix, ".", func(s string, v string) (string, interface{}) {
-				// Strip out the MYVAR_ prefix and lowercase and get the key while also replacing
-				// the _ character with . in the key (koanf delimeter).
-				key := strings.Replace(strings.TrimPrefix(s, envPrefix), "_", ".", -1)
-				// If there is a space in the value, split the value into a slice by the space.
-				if strings.Contains(v, " ") {
-					return key, strings.Split(v, " ")
-				}
-				// Otherwise, return the plain string.
-				return key, v
-			}), nil)
-			if err != nil {
-				panic("LoadEnv: " + err.Error())
-			}
-			return aConf
-		})
+		aCfg.LoadSource(appconfig.NewEnvSource(envPrefix))
 		// 选择环境类型 prod dev test
 		envSelect := aCfg.String("application.env", defaultEnv) // 默认 dev
 		confFilename := "application_" + envSelect + ".yml"     // 比如： env=test ==> 配置文件名为 application_test.yml
@@ -230,26 +217,38 @@ func NewConfigOnce(path ...string) appconfig.IAppConfig {
 		// 加载配置参数环境变量： 前缀 APP_CONF_ ； e.g. APP_CONF_application_appName=xxx ==> application.appName = xxx
 		// 注意: APP_CONF_ 为大写，application_appName跟yml文件配置的application.appName对应，且必须大小写保持一致
 		// 加载环境配置：用于支持环境变量配置覆盖配置文件配置
-		aCfg.LoadFunc(func(aConf appconfig.IAppConfig) appconfig.IAppConfig {
-			err := aConf.GetCore().(*koanf.Koanf).Load(env.ProviderWithValue(envConfigPrefix, ".", func(s string, v string) (string, interface{}) {
-				// Strip out the MYVAR_ prefix and lowercase and get the key while also replacing
-				// the _ character with . in the key (koanf delimeter).
-				key := strings.Replace(strings.TrimPrefix(s, envConfigPrefix), "_", ".", -1)
-				// If there is a space in the value, split the value into a slice by the space.
-				if strings.Contains(v, " ") {
-					return key, strings.Split(v, " ")
-				}
-				// Otherwise, return the plain string.
-				return key, v
-			}), nil)
+		// 以最高优先级记录，LocationBootStrapConfig 位点追加的配置来源（JSON/TOML、conf.d、HTTP、KV）同样被其覆盖
+		aCfg.LoadSource(appconfig.NewEnvSource(envConfigPrefix), appconfig.PriorityEnv)
+
+		// 未通过 BootConfig.SecretKey 设置解密密钥时，从环境变量 APP_SECRET_KEY 读取
+		if raw, ok := os.LookupEnv(envSecretKey); ok && !appconfig.HasSecretKey() {
+			key, err := appconfig.DecodeSecretKey(raw)
+			if err == nil {
+				err = appconfig.SetSecretKey(key)
+			}
 			if err != nil {
-				panic("LoadEnvConf: " + err.Error())
+				panic(envSecretKey + ": " + err.Error())
 			}
-			return aConf
-		})
+		}
+
+		// 执行必要地初始化
+		aCfg.Initialize()
+
+		// 解析全部密钥引用，汇总无法解析的 key；热更新同样拒绝含无法解析引用的配置
+		if err := appconfig.CheckSecrets(aCfg); err != nil {
+			panic("CheckSecrets: " + err.Error())
+		}
+		aCfg.AddValidator(appconfig.CheckSecrets)
+
+		// 按组件注册的配置段结构校验配置，汇总全部类型错误、校验失败与未知 key；热更新同样拒绝不合规的配置
+		appconfig.SetStructValidator(validate.ConfigStructValidator())
+		if err := appconfig.CheckSchemas(aCfg); err != nil {
+			panic("CheckSchemas: " + err.Error())
+		}
+		aCfg.AddValidator(appconfig.CheckSchemas)
 
-		// 执行必要地初始化并返回最终的应用配置实例
-		AppConfigured = aCfg.Initialize()
+		// 返回最终的应用配置实例
+		AppConfigured = aCfg
 	})
 
 	return AppConfigured
@@ -290,12 +289,13 @@ func NewLoggerOnce(cfg appconfig.IAppConfig, path ...string) LoggerWrapper {
 			}
 			writers = append(writers, loggerWriter)
 		}
-		// 设置全局日志级别
-		level, err := zerolog.ParseLevel(strings.ToLower(cfg.String("application.appLog.level")))
+		// 设置全局日志级别，日志器自身不再限定级别，以便热更新全局级别后派生的子日志器同步生效
+		level, err := parseLogLevel(cfg.String("application.appLog.level"))
 		if err != nil {
 			level = zerolog.TraceLevel
 		}
 		zerolog.SetGlobalLevel(level)
+		watchLogLevel(cfg)
 		// 设置全局日志时间格式
 		zerolog.TimeFieldFormat = time.RFC3339Nano
 
@@ -307,12 +307,39 @@ func NewLoggerOnce(cfg appconfig.IAppConfig, path ...string) LoggerWrapper {
 		//zerolog.ErrorStackMarshaler = pkgerrors.MarshalStack
 
 		// 创建日志记录器，使用多写入器支持同时输出到多个目标
-		log := zerolog.New(io.MultiWriter(writers...)).With().Timestamp().Logger().Level(level)
+		log := zerolog.New(io.MultiWriter(writers...)).With().Timestamp().Logger()
 		Logger = NewLoggerWrap(&log, loggerWriter)
 	})
 	return Logger
 }
 
+// parseLogLevel 解析配置的日志级别
+func parseLogLevel(level string) (zerolog.Level, error) {
+	return zerolog.ParseLevel(strings.ToLower(level))
+}
+
+// watchLogLevel 配置支持热更新时，校验新的日志级别并在 application.appLog.level 变更后更新全局日志级别
+func watchLogLevel(cfg appconfig.IAppConfig) {
+	rc, ok := cfg.(appconfig.IReloadableConfig)
+	if !ok {
+		return
+	}
+	rc.AddValidator(func(next appconfig.IAppConfig) error {
+		if _, err := parseLogLevel(next.String("application.appLog.level")); err != nil {
+			return fmt.Errorf("application.appLog.level: %w", err)
+		}
+		return nil
+	})
+	rc.Subscribe("application.appLog.level", func(change appconfig.ConfigChange) error {
+		level, err := parseLogLevel(change.New.String("application.appLog.level"))
+		if err != nil {
+			return err
+		}
+		zerolog.SetGlobalLevel(level)
+		return nil
+	})
+}
+
 // NewWriterSync 同步写日志
 func NewWriterSync(cfg appconfig.IAppConfig, filename string) io.WriteCloser {
 	return writer.NewSyncLumberjackWriter(cfg, filename)
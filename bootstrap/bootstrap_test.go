@@ -1,12 +1,15 @@
 package bootstrap
 
 import (
+	"encoding/base64"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 
+	"github.com/lamxy/fiberhouse/appconfig"
 	"github.com/rs/zerolog"
 )
 
@@ -266,3 +269,134 @@ func TestLogger_NewLoggerOnceIsConcurrentSingleton(t *testing.T) {
 		}
 	}
 }
+
+func TestLogger_ReloadUpdatesLevelAndRejectsInvalidLevel(t *testing.T) {
+	state := isolateBootstrapGlobals(t)
+	dir := t.TempDir()
+	writeConfig(t, dir, "application_dev.yml", devYAML)
+	t.Setenv("APP_ENV_application_env", "dev")
+	t.Setenv("APP_CONF_application_appName", "env-name")
+	unsetenv(t, "APP_CONF_application_appLog_level")
+
+	cfg := NewConfigOnce(dir)
+	logger := NewLoggerOnce(cfg, dir)
+	reloadable, ok := cfg.(appconfig.IReloadableConfig)
+	if !ok {
+		t.Fatal("bootstrap config does not support reload")
+	}
+	if got := logger.GetLevel(); got != zerolog.DebugLevel {
+		t.Fatalf("initial level: got %s, want %s", got, zerolog.DebugLevel)
+	}
+
+	writeConfig(t, dir, "application_dev.yml", strings.Replace(devYAML, "level: debug", "level: error", 1))
+	if err := reloadable.Reload(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if got := logger.GetLevel(); got != zerolog.ErrorLevel {
+		t.Fatalf("reloaded level: got %s, want %s", got, zerolog.ErrorLevel)
+	}
+	if got := cfg.String("application.appName"); got != "env-name" {
+		t.Fatalf("env override after reload: got %q, want env-name", got)
+	}
+	derived := logger.With().Str("Origin", "Test").Logger()
+	derived.Debug().Msg("derived debug suppressed")
+	derived.Error().Msg("derived error emitted")
+
+	writeConfig(t, dir, "application_dev.yml", strings.Replace(devYAML, "level: debug", "level: not-a-level", 1))
+	if err := reloadable.Reload(); !errors.Is(err, appconfig.ErrConfigInvalid) {
+		t.Fatalf("invalid level reload error: got %v, want ErrConfigInvalid", err)
+	}
+	if got := cfg.String("application.appLog.level"); got != "error" {
+		t.Fatalf("config after rejected reload: got %q, want error", got)
+	}
+	state.closeLogger(t)
+
+	content := readFile(t, filepath.Join(dir, "test.log"))
+	if strings.Contains(content, "derived debug suppressed") {
+		t.Fatal("reloaded level was not applied to derived logger")
+	}
+	if !strings.Contains(content, "derived error emitted") {
+		t.Fatal("error message was not written")
+	}
+}
+
+type bootstrapSchemaConfig struct {
+	Host string `koanf:"host" validate:"required"`
+	Port int    `koanf:"port"`
+}
+
+func TestConfig_SchemaCheckFailsWithAllIssues(t *testing.T) {
+	isolateBootstrapGlobals(t)
+	unsetenv(t, "APP_ENV_application_env")
+	unsetenv(t, "APP_CONF_application_appName")
+	appconfig.RegisterSchema("bootstrapSchema.svc", bootstrapSchemaConfig{})
+	dir := t.TempDir()
+	writeConfig(t, dir, "application_dev.yml", devYAML+`
+bootstrapSchema:
+  svc:
+    port: not-a-port
+    hots: typo
+`)
+
+	defer func() {
+		r := recover()
+		msg, ok := r.(string)
+		if !ok || !strings.HasPrefix(msg, "CheckSchemas: invalid config:") {
+			t.Fatalf("NewConfigOnce did not fail the schema check: %v", r)
+		}
+		for _, want := range []string{"bootstrapSchema.svc.port:", "bootstrapSchema.svc.hots: unknown key"} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("schema error %q does not list %q", msg, want)
+			}
+		}
+	}()
+	NewConfigOnce(dir)
+}
+
+func TestConfig_SecretReferencesResolveWithEnvKey(t *testing.T) {
+	isolateBootstrapGlobals(t)
+	unsetenv(t, "APP_ENV_application_env")
+	unsetenv(t, "APP_CONF_application_appName")
+	t.Cleanup(func() { _ = appconfig.SetSecretKey(nil) })
+	key := []byte("0123456789abcdef0123456789abcdef")
+	enc, err := appconfig.EncryptSecret(key, "boot-secret-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("APP_SECRET_KEY", base64.StdEncoding.EncodeToString(key))
+	t.Setenv("BOOTSTRAP_TEST_SECRET", "boot-secret-2")
+	dir := t.TempDir()
+	writeConfig(t, dir, "application_dev.yml", devYAML+`
+bootstrapSecret:
+  encrypted: "`+enc+`"
+  fromEnv: "${env:BOOTSTRAP_TEST_SECRET}"
+`)
+
+	cfg := NewConfigOnce(dir)
+	if got := cfg.String("bootstrapSecret.encrypted"); got != "boot-secret-1" {
+		t.Errorf("encrypted value = %q, want %q", got, "boot-secret-1")
+	}
+	if got := cfg.String("bootstrapSecret.fromEnv"); got != "boot-secret-2" {
+		t.Errorf("env reference = %q, want %q", got, "boot-secret-2")
+	}
+}
+
+func TestConfig_UnresolvedSecretFailsBoot(t *testing.T) {
+	isolateBootstrapGlobals(t)
+	unsetenv(t, "APP_ENV_application_env")
+	unsetenv(t, "APP_CONF_application_appName")
+	unsetenv(t, "BOOTSTRAP_TEST_UNSET_SECRET")
+	dir := t.TempDir()
+	writeConfig(t, dir, "application_dev.yml", devYAML+`
+bootstrapSecret:
+  fromEnv: "${env:BOOTSTRAP_TEST_UNSET_SECRET}"
+`)
+
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.HasPrefix(msg, "CheckSecrets: invalid config:") || !strings.Contains(msg, "bootstrapSecret.fromEnv: unresolved secret") {
+			t.Fatalf("NewConfigOnce did not fail the secret check: %q", msg)
+		}
+	}()
+	NewConfigOnce(dir)
+}
@@ -95,6 +95,20 @@ const (
 	Level2
 )
 
+// String 返回缓存级别名称，用于日志与指标标签
+func (l Level) String() string {
+	switch l {
+	case Local:
+		return "local"
+	case Remote:
+		return "remote"
+	case Level2:
+		return "level2"
+	default:
+		return "unknown"
+	}
+}
+
 // 同步策略
 const (
 	WriteBoth Strategy = iota + 1
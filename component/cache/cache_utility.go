@@ -11,7 +11,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/lamxy/fiberhouse"
+	"github.com/lamxy/fiberhouse/component/metrics"
+	"github.com/lamxy/fiberhouse/component/tracing"
 	frameUtils "github.com/lamxy/fiberhouse/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // GetCached 通用缓存获取函数
@@ -53,25 +57,47 @@ func GetCached[R any](
 		jsonData string
 	)
 
+	// 链路追踪：span 记录时缓存实例与 loader 在 cache.get span 下执行，返回后恢复调用方上下文
+	level := cacheOption.GetCacheLevel().String()
+	parentCtx := cacheOption.GetContextCtx()
+	spanParent := parentCtx
+	if spanParent == nil {
+		spanParent = context.Background()
+	}
+	ctx, span := tracing.Tracer().Start(spanParent, "cache.get", trace.WithAttributes(attribute.String("cache.level", level)))
+	defer span.End()
+	if span.IsRecording() {
+		cacheOption.SetContextCtx(ctx)
+		defer cacheOption.SetContextCtx(parentCtx)
+	}
+	observe := func(result string) {
+		metrics.ObserveCache(level, result)
+		span.SetAttributes(attribute.String("cache.result", result))
+	}
+
 	jsonData, err = cacheInstance.Get(cacheOption.GetContextCtx(), cacheOption.GetCacheKey(), cacheOption)
 	if err != nil {
 		// 判断是否时特殊拦截错误
 		// 是否被布隆过滤器拦截，避免缓存穿透
 		var errRejectedByBloomFilter ErrRejectedByBloomFilter
 		if errors.As(err, &errRejectedByBloomFilter) {
+			observe(metrics.CacheBloomRejected)
 			return zero, errRejectedByBloomFilter
 		}
 		// 是否被熔断器拦截，避免缓存雪崩
 		var errCircuitBreakerOpen ErrCircuitBreakerOpen
 		if errors.As(err, &errCircuitBreakerOpen) {
+			observe(metrics.CacheBreakerOpen)
 			if len(fallback) > 0 {
 				return fallback[0]()
 			}
 			return zero, errCircuitBreakerOpen
 		}
 		// 其他错误，视为缓存未命中，调用loader获取数据
+		observe(metrics.CacheMiss)
 		data, err = loader(cacheOption.GetContextCtx())
 		if err != nil {
+			tracing.RecordError(span, err)
 			return zero, err
 		}
 
@@ -95,6 +121,8 @@ func GetCached[R any](
 		return data, nil
 	}
 
+	observe(metrics.CacheHit)
+
 	// 反序列化缓存数据
 	err = cacheOption.GetJsonWrapper().Unmarshal(frameUtils.UnsafeBytes(jsonData), &data)
 	if err != nil {
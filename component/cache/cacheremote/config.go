@@ -0,0 +1,67 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package cacheremote
+
+import (
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/constant"
+)
+
+func init() {
+	appconfig.RegisterSchema(constant.DefaultRedisDBConfName, Config{})
+}
+
+// Config redis 配置段结构，对应配置路径 cache.redis，超时类配置单位均为秒
+type Config struct {
+	Host            string           `koanf:"host" validate:"required"`
+	Port            string           `koanf:"port" default:"6379" validate:"required,numeric"`
+	Password        string           `koanf:"password"`
+	DB              int              `koanf:"db" validate:"gte=0"`
+	PoolSize        int              `koanf:"poolSize" validate:"gte=0"`
+	MinIdleConns    int              `koanf:"minIdleConns" validate:"gte=0"`
+	DialTimeout     int              `koanf:"dialTimeout" validate:"gte=0"`
+	ReadTimeout     int              `koanf:"readTimeout"` // -1 表示不超时
+	WriteTimeout    int              `koanf:"writeTimeout"`
+	PoolTimeout     int              `koanf:"poolTimeout" validate:"gte=0"`
+	ConnMaxIdleTime int              `koanf:"connMaxIdleTime"` // -1 表示不回收空闲连接
+	ConnMaxLifetime int              `koanf:"connMaxLifetime" validate:"gte=0"`
+	Protection      ProtectionConfig `koanf:"protection"`
+}
+
+// ProtectionConfig 缓存保护配置
+type ProtectionConfig struct {
+	Enable             bool                     `koanf:"enable"`
+	Type               ProtectionTypeConfig     `koanf:"type"`
+	ShardedBloomFilter ShardedBloomFilterConfig `koanf:"shardedBloomFilter"`
+	WrapCircuitBreaker WrapCircuitBreakerConfig `koanf:"wrapCircuitBreaker"`
+}
+
+// ProtectionTypeConfig 已选中的保护器类型，第三方保护器需以 constant.CacheProtectionKeyPrefix 为前缀注册到全局管理器
+type ProtectionTypeConfig struct {
+	BloomFilter struct {
+		Selected string `koanf:"selected" default:"shardedBloomFilter" validate:"required"`
+	} `koanf:"bloomFilter"`
+	CircuitBreaker struct {
+		Selected string `koanf:"selected" default:"wrapCircuitBreaker" validate:"required"`
+	} `koanf:"circuitBreaker"`
+}
+
+// ShardedBloomFilterConfig 分片布隆过滤器配置
+type ShardedBloomFilterConfig struct {
+	Shards      int     `koanf:"shards" default:"16" validate:"gt=0"`          // 分片数量，必须为2的幂次方
+	EstPerShard uint    `koanf:"estPerShard" default:"100000" validate:"gt=0"` // 每个分片的预估容量
+	FpRate      float64 `koanf:"fpRate" default:"0.01" validate:"gt=0,lt=1"`   // 误报率
+}
+
+// WrapCircuitBreakerConfig 包装熔断器配置，时间类配置单位为秒
+type WrapCircuitBreakerConfig struct {
+	Name         string `koanf:"name" default:"cacheCircuitBreaker"`
+	MaxRequests  uint32 `koanf:"maxRequests" default:"5"`
+	Interval     int    `koanf:"interval" default:"60" validate:"gte=0"`
+	Timeout      int    `koanf:"timeout" default:"30" validate:"gte=0"`
+	BucketPeriod int    `koanf:"bucketPeriod" default:"10" validate:"gte=0"`
+}
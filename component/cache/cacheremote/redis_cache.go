@@ -10,7 +10,9 @@ import (
 	"context"
 	"errors"
 	"github.com/lamxy/fiberhouse"
+	"github.com/lamxy/fiberhouse/appconfig"
 	"github.com/lamxy/fiberhouse/component/cache"
+	"github.com/lamxy/fiberhouse/component/tracing"
 	"github.com/lamxy/fiberhouse/constant"
 	frameUtils "github.com/lamxy/fiberhouse/utils"
 	"github.com/redis/go-redis/v9"
@@ -37,51 +39,46 @@ type RedisDb struct {
 }
 
 func NewRedisDb(appCtx fiberhouse.IContext, confPath ...string) (cache.Cache, error) {
-	ca := &RedisDb{
-		Client: NewClient(appCtx, confPath...),
-		Ctx:    appCtx,
-		sf:     &singleflight.Group{},
-		lock:   &sync.RWMutex{},
-		level:  cache.Remote,
+	basePath := redisConfPath(confPath...)
+	conf, err := appconfig.Bind[Config](appCtx.GetConfig(), basePath)
+	if err != nil {
+		return nil, err
 	}
-
-	var basePath string
-	if len(confPath) > 0 && confPath[0] != "" {
-		basePath = confPath[0]
-	} else {
-		basePath = constant.DefaultRedisDBConfName
+	ca := &RedisDb{
+		Client:       newClient(conf),
+		Ctx:          appCtx,
+		sf:           &singleflight.Group{},
+		lock:         &sync.RWMutex{},
+		level:        cache.Remote,
+		confPathname: basePath,
 	}
-	ca.confPathname = basePath
-	aConf := appCtx.GetConfig()
 
 	// 读取缓存保护配置
-	cacheProtection := aConf.Bool(basePath + ".protection.enable")
+	protection := conf.Protection
 
-	if cacheProtection {
+	if protection.Enable {
 		/**
 		注册默认保护器
 		*/
 		// 注册分片锁的布隆过滤器
 		appCtx.GetContainer().Register(constant.CacheProtectionKeyPrefix+"shardedBloomFilter", func() (interface{}, error) {
-			return cache.NewShardedBloomFilter(aConf.Int(basePath+".protection.shardedBloomFilter.shards", 16),
-				uint(aConf.Int(basePath+".protection.shardedBloomFilter.estPerShard", 100000)),
-				aConf.Float64(basePath+".protection.shardedBloomFilter.fpRate", 0.01),
-			), nil
+			bf := protection.ShardedBloomFilter
+			return cache.NewShardedBloomFilter(bf.Shards, bf.EstPerShard, bf.FpRate), nil
 		})
 		// 注册包装的熔断器
 		appCtx.GetContainer().Register(constant.CacheProtectionKeyPrefix+"wrapCircuitBreaker", func() (interface{}, error) {
-			name := aConf.String(basePath+".protection.wrapCircuitBreaker.name", "cacheCircuitBreaker")
-			return cache.NewCircuitBreakerWrap(name, &gobreaker.Settings{
-				MaxRequests:  uint32(aConf.Int(basePath+".protection.wrapCircuitBreaker.maxRequests", 5)),
-				Interval:     aConf.Duration(basePath+".protection.wrapCircuitBreaker.interval", 60) * time.Second,
-				Timeout:      aConf.Duration(basePath+".protection.wrapCircuitBreaker.timeout", 30) * time.Second,
-				BucketPeriod: aConf.Duration(basePath+".protection.wrapCircuitBreaker.bucketPeriod", 10) * time.Second,
+			cb := protection.WrapCircuitBreaker
+			return cache.NewCircuitBreakerWrap(cb.Name, &gobreaker.Settings{
+				MaxRequests:  cb.MaxRequests,
+				Interval:     time.Duration(cb.Interval) * time.Second,
+				Timeout:      time.Duration(cb.Timeout) * time.Second,
+				BucketPeriod: time.Duration(cb.BucketPeriod) * time.Second,
 			}), nil
 		})
 
 		// 获取配置type
-		bloomFilterType := aConf.String(basePath+".protection.type.bloomFilter.selected", "shardedBloomFilter")       // 默认稳定的布隆过滤器
-		circuitBreakerType := aConf.String(basePath+".protection.type.circuitBreaker.selected", "wrapCircuitBreaker") // 默认包装的熔断器
+		bloomFilterType := protection.Type.BloomFilter.Selected       // 默认稳定的布隆过滤器
+		circuitBreakerType := protection.Type.CircuitBreaker.Selected // 默认包装的熔断器
 
 		appCtx.GetLogger().Info(appCtx.GetConfig().LogOriginCache()).Msgf("Redis Cache Protection enabled, BloomFilter type: %s, CircuitBreaker type: %s", bloomFilterType, circuitBreakerType)
 
@@ -291,37 +288,51 @@ func (rd *RedisDb) Wait() error {
 }
 
 // NewClient 创建一个新的 Redis 客户端连接
+//
+// 配置不合规时记录错误日志，并以已成功绑定的配置项创建客户端；需要拿到错误时使用 NewRedisDb。
 func NewClient(appCtx fiberhouse.IContext, confPath ...string) *redis.Client {
-	var basePath string
-	if len(confPath) > 0 && confPath[0] != "" {
-		basePath = confPath[0]
-	} else {
-		basePath = constant.DefaultRedisDBConfName
+	conf, err := appconfig.Bind[Config](appCtx.GetConfig(), redisConfPath(confPath...))
+	if err != nil {
+		appCtx.GetLogger().Error(appCtx.GetConfig().LogOriginCache()).Err(err).Msg("redis configuration invalid")
 	}
+	return newClient(conf)
+}
 
-	// 读取配置
-	aConf := appCtx.GetConfig()
-	addr := aConf.String(basePath+".host") + ":" + aConf.String(basePath+".port")
-	return redis.NewClient(&redis.Options{
-		Addr:            addr,                                                      // Redis 服务器地址
-		Password:        aConf.String(basePath + ".password"),                      // Redis 服务器密码
-		DB:              aConf.Int(basePath + ".db"),                               // 使用的数据库编号
-		PoolSize:        aConf.Int(basePath + ".poolSize"),                         // 连接池大小
-		MinIdleConns:    aConf.Int(basePath + ".minIdleConns"),                     // 最小空闲连接数
-		DialTimeout:     aConf.Duration(basePath+".dialTimeout") * time.Second,     // 连接建立超时时间
-		ReadTimeout:     aConf.Duration(basePath+".readTimeout") * time.Second,     // 读操作超时时间
-		WriteTimeout:    aConf.Duration(basePath+".writeTimeout") * time.Second,    // 写操作超时时间
-		PoolTimeout:     aConf.Duration(basePath+".poolTimeout") * time.Second,     // 连接池最大等待时间
-		ConnMaxIdleTime: aConf.Duration(basePath+".connMaxIdleTime") * time.Second, // 空闲连接超时时间
-		ConnMaxLifetime: aConf.Duration(basePath+".connMaxLifetime") * time.Second, // 连接的最大生命周期
+func newClient(conf Config) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr:            conf.Host + ":" + conf.Port,                       // Redis 服务器地址
+		Password:        conf.Password,                                     // Redis 服务器密码
+		DB:              conf.DB,                                           // 使用的数据库编号
+		PoolSize:        conf.PoolSize,                                     // 连接池大小
+		MinIdleConns:    conf.MinIdleConns,                                 // 最小空闲连接数
+		DialTimeout:     time.Duration(conf.DialTimeout) * time.Second,     // 连接建立超时时间
+		ReadTimeout:     time.Duration(conf.ReadTimeout) * time.Second,     // 读操作超时时间
+		WriteTimeout:    time.Duration(conf.WriteTimeout) * time.Second,    // 写操作超时时间
+		PoolTimeout:     time.Duration(conf.PoolTimeout) * time.Second,     // 连接池最大等待时间
+		ConnMaxIdleTime: time.Duration(conf.ConnMaxIdleTime) * time.Second, // 空闲连接超时时间
+		ConnMaxLifetime: time.Duration(conf.ConnMaxLifetime) * time.Second, // 连接的最大生命周期
 	})
+	// 命令链路追踪，未启用 tracing 时为空操作
+	client.AddHook(tracing.RedisHook())
+	return client
+}
+
+func redisConfPath(confPath ...string) string {
+	if len(confPath) > 0 && confPath[0] != "" {
+		return confPath[0]
+	}
+	return constant.DefaultRedisDBConfName
 }
 
 // ReNewClient 重新创建 Redis 客户端连接
 func (rd *RedisDb) ReNewClient(confPath ...string) (*RedisDb, error) {
+	conf, err := appconfig.Bind[Config](rd.Ctx.GetConfig(), redisConfPath(confPath...))
+	if err != nil {
+		return nil, err
+	}
 	rd.lock.Lock()
 	defer rd.lock.Unlock()
-	rd.Client = NewClient(rd.Ctx, confPath...)
+	rd.Client = newClient(conf)
 	return rd, nil
 }
 
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package dbmysql
+
+import (
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/constant"
+)
+
+func init() {
+	appconfig.RegisterSchema(constant.DefaultMysqlDBConfName, Config{})
+}
+
+// Config mysql 配置段结构，对应配置路径 database.mysql
+type Config struct {
+	DSN  string     `koanf:"dsn" validate:"required"`
+	Gorm GormConfig `koanf:"gorm"`
+}
+
+// GormConfig GORM 连接池与日志配置
+type GormConfig struct {
+	MaxIdleConns    int              `koanf:"maxIdleConns" validate:"gte=0"`    // 最大空闲连接数
+	MaxOpenConns    int              `koanf:"maxOpenConns" validate:"gte=0"`    // 最大打开连接数，0 表示不限制
+	ConnMaxLifetime int              `koanf:"connMaxLifetime" validate:"gte=0"` // 连接最大生命周期，单位秒
+	ConnMaxIdleTime int              `koanf:"connMaxIdleTime" validate:"gte=0"` // 连接最大空闲时间，单位秒
+	Logger          GormLoggerConfig `koanf:"logger"`
+}
+
+// GormLoggerConfig GORM 日志器配置
+type GormLoggerConfig struct {
+	Level             string `koanf:"level" default:"error" validate:"oneof=silent error warn info"` // 日志级别
+	SlowThreshold     int    `koanf:"slowThreshold" validate:"gte=0"`                                // 慢SQL阈值，单位毫秒
+	Colorful          bool   `koanf:"colorful"`                                                      // 是否彩色输出
+	Enable            bool   `koanf:"enable"`                                                        // 是否启用日志记录
+	SkipDefaultFields bool   `koanf:"skipDefaultFields"`                                             // 参数化输出 SQL
+}
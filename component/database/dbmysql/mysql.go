@@ -13,6 +13,7 @@ import (
 	"github.com/lamxy/fiberhouse"
 	"github.com/lamxy/fiberhouse/appconfig"
 	"github.com/lamxy/fiberhouse/bootstrap"
+	"github.com/lamxy/fiberhouse/component/tracing"
 	"github.com/lamxy/fiberhouse/constant"
 	"github.com/rs/zerolog"
 	"gorm.io/driver/mysql"
@@ -96,35 +97,27 @@ func NewClient(appCtx fiberhouse.IContext, confPath ...string) (*gorm.DB, error)
 		basePath = constant.DefaultMysqlDBConfName
 	}
 
-	// 读取配置
-	aConf := appCtx.GetConfig()
-	var (
-		dsn             = aConf.String(basePath + ".dsn")
-		maxIdleConns    = aConf.Int(basePath + ".gorm.maxIdleConns")
-		maxOpenConns    = aConf.Int(basePath + ".gorm.maxOpenConns")
-		connMaxLifetime = aConf.Duration(basePath+".gorm.connMaxLifetime") * time.Second
-		connMaxIdleTime = aConf.Duration(basePath+".gorm.connMaxIdleTime") * time.Second
-		// 日志器配置
-		logLevel          = aConf.String(basePath + ".gorm.logger.level")
-		slowThreshold     = aConf.Duration(basePath+".gorm.logger.slowThreshold") * time.Millisecond
-		colorful          = aConf.Bool(basePath + ".gorm.logger.colorful")
-		enableLogger      = aConf.Bool(basePath + ".gorm.logger.enable")
-		skipDefaultFields = aConf.Bool(basePath + ".gorm.logger.skipDefaultFields")
-	)
-
-	// 验证必要配置
-	if dsn == "" {
-		err := fmt.Errorf("mysql dsn is required in config path: %s.dsn", basePath)
-		appCtx.GetLogger().Error(appCtx.GetConfig().LogOriginMysql()).Err(err).Msg("mysql dsn configuration missing")
+	// 读取并校验配置
+	conf, err := appconfig.Bind[Config](appCtx.GetConfig(), basePath)
+	if err != nil {
+		appCtx.GetLogger().Error(appCtx.GetConfig().LogOriginMysql()).Err(err).Msg("mysql configuration invalid")
 		return nil, err
 	}
+	var (
+		gormConf          = conf.Gorm
+		connMaxLifetime   = time.Duration(gormConf.ConnMaxLifetime) * time.Second
+		connMaxIdleTime   = time.Duration(gormConf.ConnMaxIdleTime) * time.Second
+		slowThreshold     = time.Duration(gormConf.Logger.SlowThreshold) * time.Millisecond
+		enableLogger      = gormConf.Logger.Enable
+		skipDefaultFields = gormConf.Logger.SkipDefaultFields
+	)
 
 	// 配置 GORM 日志器
 	var gormLogger logger.Interface
 	if enableLogger {
 		// 解析日志级别
 		var loggerLevel logger.LogLevel
-		switch strings.ToLower(logLevel) {
+		switch strings.ToLower(gormConf.Logger.Level) {
 		case "silent":
 			loggerLevel = logger.Silent
 		case "error":
@@ -145,7 +138,7 @@ func NewClient(appCtx fiberhouse.IContext, confPath ...string) (*gorm.DB, error)
 				LogLevel:                  loggerLevel,
 				IgnoreRecordNotFoundError: true,
 				ParameterizedQueries:      skipDefaultFields, // 对应 skipDefaultFields
-				Colorful:                  colorful,
+				Colorful:                  gormConf.Logger.Colorful,
 			},
 		)
 	} else {
@@ -153,7 +146,7 @@ func NewClient(appCtx fiberhouse.IContext, confPath ...string) (*gorm.DB, error)
 	}
 
 	// 创建数据库连接
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+	db, err := gorm.Open(mysql.Open(conf.DSN), &gorm.Config{
 		SkipDefaultTransaction: true,
 		PrepareStmt:            true,
 		Logger:                 gormLogger,
@@ -168,6 +161,12 @@ func NewClient(appCtx fiberhouse.IContext, confPath ...string) (*gorm.DB, error)
 		return nil, err
 	}
 
+	// 注册 GORM 回调链路追踪，未启用 tracing 时为空操作
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		appCtx.GetLogger().Error(appCtx.GetConfig().LogOriginMysql()).Err(err).Msg("gorm tracing plugin error")
+		return nil, err
+	}
+
 	// 配置连接池
 	sqlDb, err := db.DB()
 	if err != nil {
@@ -176,8 +175,8 @@ func NewClient(appCtx fiberhouse.IContext, confPath ...string) (*gorm.DB, error)
 	}
 
 	// 设置连接池参数
-	sqlDb.SetMaxOpenConns(maxOpenConns)
-	sqlDb.SetMaxIdleConns(maxIdleConns)
+	sqlDb.SetMaxOpenConns(gormConf.MaxOpenConns)
+	sqlDb.SetMaxIdleConns(gormConf.MaxIdleConns)
 	sqlDb.SetConnMaxLifetime(connMaxLifetime)
 	sqlDb.SetConnMaxIdleTime(connMaxIdleTime)
 
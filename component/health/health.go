@@ -0,0 +1,383 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+/*
+Package health 提供基于 GlobalManager 的健康、就绪与存活探针。
+
+Registry 汇总两类检查：GlobalManager 中已初始化且实现 globalmanager.HealthChecker 的全局对象，以及应用通过 Register 注册的自定义检查。
+每个检查带有关键程度 Criticality，决定其失败时影响哪些探针：
+
+	/livez    只执行 LivenessCritical 检查，失败返回 503
+	/readyz   执行 Critical 与 LivenessCritical 检查，失败或进入关闭链后返回 503
+	/healthz  执行全部检查并输出完整报告，Critical 与 LivenessCritical 失败返回 503，NonCritical 失败只标记 degraded
+
+Registry 同时实现 globalmanager.Observer，记录全局对象最近一次重建的时间与错误。
+*/
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/globalmanager"
+)
+
+// ConfPath 健康探针配置路径
+const ConfPath = "application.health"
+
+// 探针缺省路径
+const (
+	DefaultHealthPath = "/healthz"
+	DefaultReadyPath  = "/readyz"
+	DefaultLivePath   = "/livez"
+)
+
+// 报告状态
+const (
+	StatusOK       = "ok"       // 全部检查通过
+	StatusDegraded = "degraded" // 仅 NonCritical 检查失败
+	StatusFail     = "fail"     // 关键检查失败，或就绪探针在关闭链中
+)
+
+// 检查来源
+const (
+	SourceGlobal = "global" // GlobalManager 全局对象
+	SourceCustom = "custom" // 应用注册的自定义检查
+)
+
+func init() {
+	appconfig.RegisterSchema(ConfPath, Config{})
+}
+
+// Config 健康探针配置段结构，对应配置路径 application.health
+type Config struct {
+	Enable     bool          `koanf:"enable"`                               // 是否注册 /healthz、/readyz、/livez 路由
+	HealthPath string        `koanf:"healthPath" default:"/healthz"`        // 健康报告路径
+	ReadyPath  string        `koanf:"readyPath" default:"/readyz"`          // 就绪探针路径
+	LivePath   string        `koanf:"livePath" default:"/livez"`            // 存活探针路径
+	Timeout    time.Duration `koanf:"timeout" default:"2s" validate:"gt=0"` // 单次探针等待全部检查的超时
+	Optional   []string      `koanf:"optional"`                             // 视为 NonCritical 的全局对象 key，失败不影响就绪
+	Listener   string        `koanf:"listener"`                             // 挂载探针路由的附加监听器名称，空为主监听器
+}
+
+// Criticality 检查的关键程度
+type Criticality int
+
+const (
+	// NonCritical 失败只在 /healthz 报告中标记 degraded，不影响状态码
+	NonCritical Criticality = iota
+	// Critical 失败时 /healthz 与 /readyz 返回 503，全局对象检查的缺省值
+	Critical
+	// LivenessCritical 失败时 /livez 也返回 503，仅用于进程无法自愈、需要重启的情况
+	LivenessCritical
+)
+
+// String 返回关键程度名称
+func (c Criticality) String() string {
+	switch c {
+	case NonCritical:
+		return "non-critical"
+	case Critical:
+		return "critical"
+	case LivenessCritical:
+		return "liveness-critical"
+	default:
+		return "unknown"
+	}
+}
+
+// Probe 探针类型
+type Probe int
+
+const (
+	ProbeHealth Probe = iota // 完整健康报告
+	ProbeReady               // 就绪探针
+	ProbeLive                // 存活探针
+)
+
+// CheckFunc 自定义检查函数，返回 nil 表示健康；ctx 在探针超时后取消
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult 单项检查结果
+type CheckResult struct {
+	Name             string     `json:"name"`
+	Source           string     `json:"source"`
+	Criticality      string     `json:"criticality"`
+	Healthy          bool       `json:"healthy"`
+	Error            string     `json:"error,omitempty"`
+	LastRebuildAt    *time.Time `json:"lastRebuildAt,omitempty"`
+	LastRebuildError string     `json:"lastRebuildError,omitempty"`
+}
+
+// Report 探针报告
+type Report struct {
+	Status    string        `json:"status"`
+	Ready     bool          `json:"ready"`
+	CheckedAt time.Time     `json:"checkedAt"`
+	Checks    []CheckResult `json:"checks"`
+}
+
+// OK 报告是否对应 200 状态码
+func (r Report) OK() bool {
+	return r.Status != StatusFail
+}
+
+type customCheck struct {
+	criticality Criticality
+	check       CheckFunc
+}
+
+type rebuildRecord struct {
+	at  time.Time
+	err error
+}
+
+// Registry 健康检查注册表，并发安全
+type Registry struct {
+	mu       sync.RWMutex
+	custom   map[string]customCheck
+	levels   map[globalmanager.KeyName]Criticality
+	rebuilds map[globalmanager.KeyName]rebuildRecord
+	gm       atomic.Pointer[globalmanager.GlobalManager]
+	timeout  atomic.Int64
+	notReady atomic.Bool
+	holds    atomic.Int64
+}
+
+// NewRegistry 创建健康检查注册表，初始为就绪
+func NewRegistry() *Registry {
+	r := &Registry{
+		custom:   make(map[string]customCheck),
+		levels:   make(map[globalmanager.KeyName]Criticality),
+		rebuilds: make(map[globalmanager.KeyName]rebuildRecord),
+	}
+	r.timeout.Store(int64(2 * time.Second))
+	return r
+}
+
+var defaultRegistry = NewRegistry()
+
+// Default 返回进程级注册表，Fiber/Gin core 的探针路由与 FrameApplication 均使用该实例
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Register 注册自定义检查，同名检查被替换
+func (r *Registry) Register(name string, criticality Criticality, check CheckFunc) {
+	if check == nil {
+		panic(fmt.Sprintf("health: check '%s' is nil", name))
+	}
+	r.mu.Lock()
+	r.custom[name] = customCheck{criticality: criticality, check: check}
+	r.mu.Unlock()
+}
+
+// Unregister 移除自定义检查
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	delete(r.custom, name)
+	r.mu.Unlock()
+}
+
+// SetGlobalManager 设置需要检查的全局管理器，nil 表示不检查全局对象
+func (r *Registry) SetGlobalManager(gm *globalmanager.GlobalManager) {
+	r.gm.Store(gm)
+}
+
+// SetGlobalCriticality 设置全局对象检查的关键程度，缺省为 Critical
+func (r *Registry) SetGlobalCriticality(name globalmanager.KeyName, criticality Criticality) {
+	r.mu.Lock()
+	r.levels[name] = criticality
+	r.mu.Unlock()
+}
+
+// SetTimeout 设置单次探针等待全部检查的超时，非正数忽略
+func (r *Registry) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		r.timeout.Store(int64(timeout))
+	}
+}
+
+// SetReady 设置就绪状态；core 进入关闭链时置为 false，使 /readyz 返回 503
+func (r *Registry) SetReady(ready bool) {
+	r.notReady.Store(!ready)
+}
+
+// Ready 返回就绪状态，存在未释放的 Hold 时为 false
+func (r *Registry) Ready() bool {
+	return !r.notReady.Load() && r.holds.Load() == 0
+}
+
+// Hold 在释放前保持未就绪，用于监听后的预热、服务注册等启动后步骤；返回的释放函数可重复调用
+//
+// Hold 与 SetReady 相互独立：全部释放后就绪状态仍由 SetReady 决定，进入关闭链后释放不会恢复就绪。
+func (r *Registry) Hold() (release func()) {
+	r.holds.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(func() { r.holds.Add(-1) })
+	}
+}
+
+// HealthChecked 实现 globalmanager.Observer，健康状态由探针实时检查，此处不记录
+func (r *Registry) HealthChecked(globalmanager.KeyName, bool, error) {}
+
+// Rebuilt 实现 globalmanager.Observer，记录最近一次重建的时间与错误
+func (r *Registry) Rebuilt(name globalmanager.KeyName, err error) {
+	r.mu.Lock()
+	r.rebuilds[name] = rebuildRecord{at: time.Now(), err: err}
+	r.mu.Unlock()
+}
+
+// errCheckTimeout 检查未在探针超时内返回
+var errCheckTimeout = errors.New("health check timed out")
+
+// pendingCheck 待执行的单项检查
+type pendingCheck struct {
+	result CheckResult
+	level  Criticality
+	run    func(ctx context.Context) error
+}
+
+// Check 执行 probe 对应的检查并汇总报告
+//
+// 检查并发执行，超过超时仍未返回的检查记为失败；全局对象的 IsHealthy 不接收 ctx，超时后其 goroutine 在返回前继续运行。
+func (r *Registry) Check(ctx context.Context, probe Probe) Report {
+	pending := r.collect(probe)
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(r.timeout.Load()))
+	defer cancel()
+
+	errs := make([]error, len(pending))
+	done := make(chan int, len(pending))
+	for i, p := range pending {
+		go func() {
+			defer func() {
+				if rec := recover(); rec != nil {
+					errs[i] = fmt.Errorf("health check panic: %v", rec)
+				}
+				done <- i
+			}()
+			errs[i] = p.run(ctx)
+		}()
+	}
+	finished := make([]bool, len(pending))
+wait:
+	for range pending {
+		select {
+		case i := <-done:
+			finished[i] = true
+		case <-ctx.Done():
+			break wait
+		}
+	}
+
+	report := Report{Status: StatusOK, Ready: r.Ready(), CheckedAt: time.Now(), Checks: make([]CheckResult, 0, len(pending))}
+	for i, p := range pending {
+		err := errCheckTimeout
+		if finished[i] {
+			err = errs[i]
+		}
+		res := p.result
+		res.Healthy = err == nil
+		if err != nil {
+			res.Error = err.Error()
+			if p.level == NonCritical {
+				if report.Status == StatusOK {
+					report.Status = StatusDegraded
+				}
+			} else {
+				report.Status = StatusFail
+			}
+		}
+		report.Checks = append(report.Checks, res)
+	}
+	if probe == ProbeReady && !report.Ready {
+		report.Status = StatusFail
+	}
+	return report
+}
+
+// collect 按 probe 收集需要执行的检查，按来源与名称排序
+func (r *Registry) collect(probe Probe) []pendingCheck {
+	include := func(level Criticality) bool {
+		switch probe {
+		case ProbeLive:
+			return level == LivenessCritical
+		case ProbeReady:
+			return level >= Critical
+		default:
+			return true
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var pending []pendingCheck
+	if gm := r.gm.Load(); gm != nil {
+		gm.Range(func(key, _ interface{}) bool {
+			name, ok := key.(globalmanager.KeyName)
+			if !ok || !gm.IsInitialized(name) {
+				return true
+			}
+			instance, err := gm.Get(name)
+			if err != nil {
+				return true
+			}
+			if _, ok := instance.(globalmanager.HealthChecker); !ok {
+				return true
+			}
+			level, ok := r.levels[name]
+			if !ok {
+				level = Critical
+			}
+			if !include(level) {
+				return true
+			}
+			res := CheckResult{Name: name, Source: SourceGlobal, Criticality: level.String()}
+			if rb, ok := r.rebuilds[name]; ok {
+				at := rb.at
+				res.LastRebuildAt = &at
+				if rb.err != nil {
+					res.LastRebuildError = rb.err.Error()
+				}
+			}
+			pending = append(pending, pendingCheck{result: res, level: level, run: func(context.Context) error {
+				healthy, err := gm.CheckHealth(name)
+				if err != nil {
+					return err
+				}
+				if !healthy {
+					return errors.New("unhealthy")
+				}
+				return nil
+			}})
+			return true
+		})
+	}
+	for name, c := range r.custom {
+		if !include(c.criticality) {
+			continue
+		}
+		pending = append(pending, pendingCheck{
+			result: CheckResult{Name: name, Source: SourceCustom, Criticality: c.criticality.String()},
+			level:  c.criticality,
+			run:    c.check,
+		})
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		if pending[i].result.Source != pending[j].result.Source {
+			return pending[i].result.Source == SourceGlobal
+		}
+		return pending[i].result.Name < pending[j].result.Name
+	})
+	return pending
+}
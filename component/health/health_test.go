@@ -0,0 +1,201 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lamxy/fiberhouse/globalmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type toggleResource struct {
+	healthy     *atomic.Bool
+	failRebuild bool
+}
+
+func (r *toggleResource) IsHealthy() bool { return r.healthy.Load() }
+
+func (r *toggleResource) GetConfPath() string { return "" }
+
+func (r *toggleResource) Rebuild(...interface{}) (interface{}, error) {
+	if r.failRebuild {
+		return nil, errors.New("dial refused")
+	}
+	return r, nil
+}
+
+type plainResource struct{}
+
+func newCheckedManager(t *testing.T, r *Registry) (*globalmanager.GlobalManager, *atomic.Bool) {
+	t.Helper()
+	gm := globalmanager.NewGlobalManager()
+	healthy := &atomic.Bool{}
+	healthy.Store(true)
+	require.True(t, gm.Register("db", func() (interface{}, error) { return &toggleResource{healthy: healthy}, nil }))
+	require.True(t, gm.Register("cache", func() (interface{}, error) {
+		return &toggleResource{healthy: healthy, failRebuild: true}, nil
+	}))
+	require.True(t, gm.Register("plain", func() (interface{}, error) { return plainResource{}, nil }))
+	require.True(t, gm.Register("lazy", func() (interface{}, error) { return &toggleResource{healthy: healthy}, nil }))
+	for _, key := range []string{"db", "cache", "plain"} {
+		_, err := gm.Get(key)
+		require.NoError(t, err)
+	}
+	gm.SetObserver(r)
+	r.SetGlobalManager(gm)
+	return gm, healthy
+}
+
+func checkNames(report Report) []string {
+	names := make([]string, 0, len(report.Checks))
+	for _, c := range report.Checks {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func TestRegistry_ReportsInitializedHealthCheckersWithRebuildState(t *testing.T) {
+	r := NewRegistry()
+	gm, healthy := newCheckedManager(t, r)
+	r.SetGlobalCriticality("cache", NonCritical)
+
+	report := r.Check(context.Background(), ProbeHealth)
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Equal(t, []string{"cache", "db"}, checkNames(report), "only initialized HealthChecker entries")
+
+	require.NoError(t, gm.Rebuild("db"))
+	require.Error(t, gm.Rebuild("cache"))
+	healthy.Store(false)
+
+	report = r.Check(context.Background(), ProbeHealth)
+	assert.Equal(t, StatusFail, report.Status)
+	require.Len(t, report.Checks, 2)
+	cache, db := report.Checks[0], report.Checks[1]
+	assert.Equal(t, "non-critical", cache.Criticality)
+	assert.False(t, cache.Healthy)
+	assert.Contains(t, cache.LastRebuildError, "dial refused")
+	assert.Equal(t, "critical", db.Criticality)
+	assert.Equal(t, "unhealthy", db.Error)
+	require.NotNil(t, db.LastRebuildAt)
+	assert.Empty(t, db.LastRebuildError)
+
+	r.SetGlobalCriticality("db", NonCritical)
+	assert.Equal(t, StatusDegraded, r.Check(context.Background(), ProbeHealth).Status)
+	assert.Equal(t, StatusOK, r.Check(context.Background(), ProbeReady).Status, "non-critical checks are skipped by readiness")
+}
+
+func TestRegistry_CustomChecksFollowCriticalityPerProbe(t *testing.T) {
+	r := NewRegistry()
+	r.Register("queue", NonCritical, func(context.Context) error { return errors.New("backlog") })
+	r.Register("upstream", Critical, func(context.Context) error { return nil })
+	r.Register("deadlock", LivenessCritical, func(context.Context) error { return nil })
+
+	health := r.Check(context.Background(), ProbeHealth)
+	assert.Equal(t, StatusDegraded, health.Status)
+	assert.True(t, health.OK())
+	assert.Equal(t, []string{"deadlock", "queue", "upstream"}, checkNames(health))
+	assert.Equal(t, []string{"deadlock", "upstream"}, checkNames(r.Check(context.Background(), ProbeReady)))
+	assert.Equal(t, []string{"deadlock"}, checkNames(r.Check(context.Background(), ProbeLive)))
+
+	r.Register("deadlock", LivenessCritical, func(context.Context) error { panic("stuck") })
+	live := r.Check(context.Background(), ProbeLive)
+	assert.Equal(t, StatusFail, live.Status)
+	assert.Equal(t, "health check panic: stuck", live.Checks[0].Error)
+
+	r.Unregister("deadlock")
+	assert.Equal(t, StatusOK, r.Check(context.Background(), ProbeLive).Status)
+	assert.Panics(t, func() { r.Register("nil", Critical, nil) })
+}
+
+func TestRegistry_TimeoutAndReadiness(t *testing.T) {
+	r := NewRegistry()
+	r.SetTimeout(20 * time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	r.Register("slow", Critical, func(ctx context.Context) error {
+		select {
+		case <-release:
+		case <-time.After(time.Second):
+		}
+		return nil
+	})
+
+	start := time.Now()
+	report := r.Check(context.Background(), ProbeReady)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, errCheckTimeout.Error(), report.Checks[0].Error)
+
+	r.Unregister("slow")
+	assert.True(t, r.Ready())
+	r.SetReady(false)
+	assert.Equal(t, StatusFail, r.Check(context.Background(), ProbeReady).Status)
+	assert.Equal(t, StatusOK, r.Check(context.Background(), ProbeHealth).Status, "draining does not fail the health report")
+	assert.Equal(t, StatusOK, r.Check(context.Background(), ProbeLive).Status)
+	r.SetReady(true)
+	assert.Equal(t, StatusOK, r.Check(context.Background(), ProbeReady).Status)
+}
+
+func TestRegistry_HoldKeepsNotReadyUntilReleased(t *testing.T) {
+	r := NewRegistry()
+	first, second := r.Hold(), r.Hold()
+	assert.False(t, r.Ready())
+	assert.Equal(t, StatusFail, r.Check(context.Background(), ProbeReady).Status)
+	assert.Equal(t, StatusOK, r.Check(context.Background(), ProbeLive).Status)
+
+	first()
+	first()
+	assert.False(t, r.Ready(), "double release does not drop another hold")
+	second()
+	assert.True(t, r.Ready())
+
+	release := r.Hold()
+	r.SetReady(false)
+	release()
+	assert.False(t, r.Ready(), "releasing a hold does not undo shutdown")
+}
+
+func TestHandlers_WriteJSONReportAndStatusCode(t *testing.T) {
+	r := NewRegistry()
+	r.Register("upstream", Critical, func(context.Context) error { return nil })
+	r.SetReady(false)
+
+	app := fiber.New()
+	app.Get(DefaultReadyPath, r.FiberHandler(ProbeReady))
+	app.Get(DefaultHealthPath, r.FiberHandler(ProbeHealth))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, DefaultReadyPath, nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+	var report Report
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.False(t, report.Ready)
+	assert.Equal(t, StatusFail, report.Status)
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, DefaultHealthPath, nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET(DefaultReadyPath, r.GinHandler(ProbeReady))
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DefaultReadyPath, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+	assert.Contains(t, rec.Body.String(), `"name":"upstream"`)
+
+	r.SetReady(true)
+	rec = httptest.NewRecorder()
+	r.Handler(ProbeReady).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DefaultReadyPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"ok"`)
+}
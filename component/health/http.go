@@ -0,0 +1,63 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+)
+
+// statusCode 报告对应的 HTTP 状态码
+func statusCode(report Report) int {
+	if report.OK() {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
+
+// Handler 返回输出 probe 报告 JSON 的 http.Handler，报告失败时状态码为 503
+func (r *Registry) Handler(probe Probe) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := r.Check(req.Context(), probe)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(statusCode(report))
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+// FiberHandler 返回输出 probe 报告 JSON 的 Fiber 处理器
+func (r *Registry) FiberHandler(probe Probe) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		report := r.Check(c.UserContext(), probe)
+		body, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Status(statusCode(report)).Send(body)
+	}
+}
+
+// GinHandler 返回输出 probe 报告 JSON 的 Gin 处理器
+func (r *Registry) GinHandler(probe Probe) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := r.Check(c.Request.Context(), probe)
+		body, err := json.Marshal(report)
+		if err != nil {
+			_ = c.Error(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Header("Cache-Control", "no-store")
+		c.Data(statusCode(report), "application/json; charset=utf-8", body)
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lamxy/fiberhouse/appconfig"
+)
+
+// Message 一条消息在某一语言下的各复数形式，不区分复数的消息只有 other 形式
+type Message map[string]string
+
+// Text 返回 n 对应复数形式的模板，该形式缺失时取 other；n 为 0 且声明了 zero 形式时优先取 zero
+func (m Message) Text(form string, n int) string {
+	if n == 0 {
+		if text, ok := m[PluralZero]; ok {
+			return text
+		}
+	}
+	if text, ok := m[form]; ok {
+		return text
+	}
+	return m[PluralOther]
+}
+
+// catalogSource 消息来源，Reload 时按添加顺序重新读取
+type catalogSource struct {
+	// dir 目录来源的本地路径，非空时可被 Watch 监听
+	dir  string
+	load func() (map[string]map[string]Message, error)
+}
+
+// Catalog 并发安全的多语言消息目录
+//
+// 消息来自 Add/AddPlural 添加的静态消息与 LoadDir/LoadFS 读取的 YAML/JSON 文件（每种语言一个或多个文件，见 LoadFS），
+// 后添加的来源覆盖先添加的同语言同键消息。查找按 Chain 给出的回退链进行。
+type Catalog struct {
+	mu          sync.RWMutex
+	defaultLang string
+	fallbacks   map[string][]string
+	sources     []catalogSource
+	messages    map[string]map[string]Message
+	// generation 来源变更计数，添加来源与 Clear 时递增，Reload 据此判断读取期间来源是否变化
+	generation uint64
+}
+
+// NewCatalog 创建消息目录，defaultLang 为回退链的最后一级；内置回退 zh-tw → zh-cn、zh-hk → zh-tw
+func NewCatalog(defaultLang string) *Catalog {
+	if defaultLang == "" {
+		defaultLang = DefaultLang
+	}
+	return &Catalog{
+		defaultLang: Normalize(defaultLang),
+		fallbacks: map[string][]string{
+			"zh-tw": {"zh-cn"},
+			"zh-hk": {"zh-tw"},
+		},
+		messages: make(map[string]map[string]Message),
+	}
+}
+
+// DefaultLang 返回回退链的最后一级语言
+func (c *Catalog) DefaultLang() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.defaultLang
+}
+
+// SetDefaultLang 设置回退链的最后一级语言，为空时忽略
+func (c *Catalog) SetDefaultLang(lang string) *Catalog {
+	if lang = Normalize(lang); lang != "" {
+		c.mu.Lock()
+		c.defaultLang = lang
+		c.mu.Unlock()
+	}
+	return c
+}
+
+// SetFallbacks 设置 lang 缺少消息时依次回退的语言，覆盖该语言已有的设置；不传 chain 时删除设置
+//
+//	catalog.SetFallbacks("zh-hk", "zh-tw", "zh-cn")
+func (c *Catalog) SetFallbacks(lang string, chain ...string) *Catalog {
+	lang = Normalize(lang)
+	normalized := make([]string, 0, len(chain))
+	for _, l := range chain {
+		normalized = append(normalized, Normalize(l))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(normalized) == 0 {
+		delete(c.fallbacks, lang)
+	} else {
+		c.fallbacks[lang] = normalized
+	}
+	return c
+}
+
+// Chain 返回 lang 的查找顺序：lang 自身、SetFallbacks 设置的语言（递归展开）、主语言子标签，最后为 DefaultLang，已出现的语言不重复
+//
+// 内置设置下 zh-tw 的查找顺序为 zh-tw → zh-cn → zh → en。
+func (c *Catalog) Chain(lang string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.chainLocked(Normalize(lang))
+}
+
+func (c *Catalog) chainLocked(lang string) []string {
+	var chain []string
+	seen := make(map[string]bool)
+	var visit func(l string)
+	visit = func(l string) {
+		if l == "" || seen[l] {
+			return
+		}
+		seen[l] = true
+		chain = append(chain, l)
+		for _, next := range c.fallbacks[l] {
+			visit(next)
+		}
+		if primary, _, ok := strings.Cut(l, "-"); ok {
+			visit(primary)
+		}
+	}
+	visit(lang)
+	visit(c.defaultLang)
+	return chain
+}
+
+// Add 添加 lang 语言的静态消息，同一键后添加的覆盖先添加的；Reload 后保留
+func (c *Catalog) Add(lang string, messages map[string]string) *Catalog {
+	lang = Normalize(lang)
+	data := map[string]map[string]Message{lang: make(map[string]Message, len(messages))}
+	for key, text := range messages {
+		data[lang][key] = Message{PluralOther: text}
+	}
+	return c.addStatic(data)
+}
+
+// AddPlural 添加 lang 语言的一条复数消息，forms 的键为复数类别名，必须包含 other
+//
+//	catalog.AddPlural("en", "cart.items", i18n.Message{"one": "{count} item", "other": "{count} items"})
+func (c *Catalog) AddPlural(lang, key string, forms Message) *Catalog {
+	msg := make(Message, len(forms))
+	for form, text := range forms {
+		msg[form] = text
+	}
+	return c.addStatic(map[string]map[string]Message{Normalize(lang): {key: msg}})
+}
+
+func (c *Catalog) addStatic(data map[string]map[string]Message) *Catalog {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sources = append(c.sources, catalogSource{load: func() (map[string]map[string]Message, error) { return data, nil }})
+	c.generation++
+	mergeMessages(c.messages, data)
+	return c
+}
+
+// LoadDir 读取本地目录下的消息文件并记录为来源，规则同 LoadFS；同一目录重复调用时只重新读取
+func (c *Catalog) LoadDir(dir string) error {
+	dir = filepath.Clean(dir)
+	c.mu.RLock()
+	for _, src := range c.sources {
+		if src.dir == dir {
+			c.mu.RUnlock()
+			return c.Reload()
+		}
+	}
+	c.mu.RUnlock()
+	return c.addSource(catalogSource{dir: dir, load: func() (map[string]map[string]Message, error) {
+		return loadFS(os.DirFS(dir), ".")
+	}})
+}
+
+// LoadFS 读取 fsys 中 dir 目录（不含子目录）下的 .yaml、.yml、.json 消息文件并记录为来源，可用于 embed.FS
+//
+// 文件名去掉扩展名后最后一个 '.' 之后的部分为语言，如 en.yaml、validate.zh-cn.json；
+// 同一语言的多个文件按文件名顺序合并。任一文件读取或解析失败时返回错误，目录不变。
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	return c.addSource(catalogSource{load: func() (map[string]map[string]Message, error) {
+		return loadFS(fsys, dir)
+	}})
+}
+
+func (c *Catalog) addSource(src catalogSource) error {
+	data, err := src.load()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sources = append(c.sources, src)
+	c.generation++
+	mergeMessages(c.messages, data)
+	return nil
+}
+
+// Reload 按添加顺序重新读取全部来源并整体替换消息；任一来源失败时返回错误并保留原有消息
+//
+// 读取期间来源发生变化（添加来源或 Clear）时放弃本次结果，按新的来源重新读取。
+func (c *Catalog) Reload() error {
+	for {
+		c.mu.RLock()
+		sources := append([]catalogSource(nil), c.sources...)
+		generation := c.generation
+		c.mu.RUnlock()
+
+		messages := make(map[string]map[string]Message)
+		for _, src := range sources {
+			data, err := src.load()
+			if err != nil {
+				return err
+			}
+			mergeMessages(messages, data)
+		}
+
+		c.mu.Lock()
+		if c.generation == generation {
+			c.messages = messages
+			c.mu.Unlock()
+			return nil
+		}
+		c.mu.Unlock()
+	}
+}
+
+// Clear 删除全部来源与消息，回退设置保持不变
+func (c *Catalog) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sources = nil
+	c.generation++
+	c.messages = make(map[string]map[string]Message)
+}
+
+// WatchOptions Catalog.Watch 选项
+type WatchOptions struct {
+	// Debounce 合并文件变更事件的窗口，缺省 appconfig.DefaultWatchDebounce
+	Debounce time.Duration
+	// OnReload 每次重新加载后调用，err 为 Reload 的结果
+	OnReload func(err error)
+}
+
+// Watch 监听 LoadDir 记录的目录，目录内发生任意变更时（合并 Debounce 窗口内的事件）自动 Reload
+//
+// 不按扩展名过滤事件，以便覆盖 Kubernetes ConfigMap 挂载以 ..data 符号链接切换的更新方式。返回的 stop 停止监听并等待后台协程退出，可重复调用。没有目录来源时返回错误。
+func (c *Catalog) Watch(opts ...WatchOptions) (stop func() error, err error) {
+	var opt WatchOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	c.mu.RLock()
+	var dirs []string
+	for _, src := range c.sources {
+		if src.dir != "" {
+			dirs = append(dirs, src.dir)
+		}
+	}
+	c.mu.RUnlock()
+	if len(dirs) == 0 {
+		return nil, errors.New("i18n: no message directory to watch, call LoadDir first")
+	}
+
+	stop, err = appconfig.WatchDirs(dirs, opt.Debounce, nil, func() {
+		err := c.Reload()
+		if opt.OnReload != nil {
+			opt.OnReload(err)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("i18n: %w", err)
+	}
+	return stop, nil
+}
+
+// Langs 返回已有消息的语言，按字母序排列
+func (c *Catalog) Langs() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	langs := make([]string, 0, len(c.messages))
+	for lang := range c.messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// LookupLang 只在 lang 语言中查找 key，不回退
+func (c *Catalog) LookupLang(lang, key string) (Message, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	msg, ok := c.messages[Normalize(lang)][key]
+	return msg, ok
+}
+
+// Lookup 按 Chain(lang) 查找 key，返回消息及其所在的语言
+func (c *Catalog) Lookup(lang, key string) (Message, string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, l := range c.chainLocked(Normalize(lang)) {
+		if msg, ok := c.messages[l][key]; ok {
+			return msg, l, true
+		}
+	}
+	return nil, "", false
+}
+
+// Message 返回 key 在 lang 语言下按 args 插值后的消息，查找规则同 Lookup
+func (c *Catalog) Message(lang, key string, args map[string]interface{}) (string, bool) {
+	msg, _, ok := c.Lookup(lang, key)
+	if !ok {
+		return "", false
+	}
+	return Format(msg[PluralOther], args), true
+}
+
+// Plural 返回 key 在 lang 语言下 count 对应复数形式的消息，args 额外带有 count 参数
+//
+// 复数形式按消息实际所在的语言（回退后的语言）的复数规则计算，见 PluralFormOf 与 Message.Text。
+func (c *Catalog) Plural(lang, key string, count int, args map[string]interface{}) (string, bool) {
+	msg, found, ok := c.Lookup(lang, key)
+	if !ok {
+		return "", false
+	}
+	withCount := make(map[string]interface{}, len(args)+1)
+	for name, value := range args {
+		withCount[name] = value
+	}
+	withCount["count"] = count
+	return Format(msg.Text(PluralFormOf(found, count), count), withCount), true
+}
+
+// mergeMessages 把 src 合并到 dst，同语言同键覆盖
+func mergeMessages(dst, src map[string]map[string]Message) {
+	for lang, messages := range src {
+		if dst[lang] == nil {
+			dst[lang] = make(map[string]Message, len(messages))
+		}
+		for key, msg := range messages {
+			dst[lang][key] = msg
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeMessageFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
+
+func TestCatalog_LoadDirFallbackChainAndPlurals(t *testing.T) {
+	dir := t.TempDir()
+	writeMessageFile(t, dir, "en.yaml", `
+order:
+  notFound: "order {id} not found"
+  archived: "order {id} is archived"
+cart:
+  items:
+    zero: "cart is empty"
+    one: "{count} item"
+    other: "{count} items"
+`)
+	writeMessageFile(t, dir, "zh-cn.json", `{"order": {"notFound": "订单 {id} 不存在"}, "cart": {"items": {"other": "{count} 件商品"}}}`)
+	writeMessageFile(t, dir, "zh_TW.yml", `order: {notFound: "訂單 {id} 不存在"}`)
+	writeMessageFile(t, dir, "validate.ru.yaml", `
+cart:
+  items:
+    one: "{count} товар"
+    few: "{count} товара"
+    many: "{count} товаров"
+    other: "{count} товара"
+`)
+	writeMessageFile(t, dir, "notes.txt", "ignored")
+
+	c := NewCatalog("en")
+	require.NoError(t, c.LoadDir(dir))
+	assert.Equal(t, []string{"en", "ru", "zh-cn", "zh-tw"}, c.Langs())
+	assert.Equal(t, []string{"zh-tw", "zh-cn", "zh", "en"}, c.Chain("zh_TW"))
+
+	args := map[string]interface{}{"id": 7}
+	cases := []struct{ lang, key, want string }{
+		{"zh-tw", "order.notFound", "訂單 7 不存在"},
+		{"zh-TW", "order.archived", "order 7 is archived"},
+		{"zh-cn", "order.notFound", "订单 7 不存在"},
+		{"zh", "order.notFound", "order 7 not found"},
+		{"fr", "order.notFound", "order 7 not found"},
+	}
+	for _, tc := range cases {
+		got, ok := c.Message(tc.lang, tc.key, args)
+		assert.True(t, ok, tc.lang+" "+tc.key)
+		assert.Equal(t, tc.want, got, tc.lang+" "+tc.key)
+	}
+	_, ok := c.Message("en", "order.missing", nil)
+	assert.False(t, ok)
+
+	plurals := []struct {
+		lang  string
+		count int
+		want  string
+	}{
+		{"en", 0, "cart is empty"},
+		{"en", 1, "1 item"},
+		{"en", 5, "5 items"},
+		{"zh-tw", 1, "1 件商品"},
+		{"ru", 1, "1 товар"},
+		{"ru", 3, "3 товара"},
+		{"ru", 11, "11 товаров"},
+		{"ru", 22, "22 товара"},
+	}
+	for _, tc := range plurals {
+		got, ok := c.Plural(tc.lang, "cart.items", tc.count, nil)
+		assert.True(t, ok)
+		assert.Equal(t, tc.want, got, tc.lang)
+	}
+
+	c.SetFallbacks("zh-tw")
+	assert.Equal(t, []string{"zh-tw", "zh", "en"}, c.Chain("zh-tw"))
+	c.SetFallbacks("pt-br", "pt", "es").SetDefaultLang("zh-cn")
+	assert.Equal(t, []string{"pt-br", "pt", "es", "zh-cn", "zh"}, c.Chain("pt-BR"))
+}
+
+func TestCatalog_ReloadKeepsStaticMessagesAndRejectsBrokenFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeMessageFile(t, dir, "en.yaml", `greeting: "hello"`)
+
+	c := NewCatalog("en").Add("en", map[string]string{"static": "kept", "greeting": "overridden"})
+	require.NoError(t, c.LoadDir(dir))
+	c.AddPlural("en", "apples", Message{PluralOne: "one apple", PluralOther: "{count} apples"})
+	msg, _ := c.Message("en", "greeting", nil)
+	assert.Equal(t, "hello", msg)
+
+	writeMessageFile(t, dir, "en.yaml", `greeting: "hi"`)
+	require.NoError(t, c.Reload())
+	msg, _ = c.Message("en", "greeting", nil)
+	assert.Equal(t, "hi", msg)
+	msg, _ = c.Message("en", "static", nil)
+	assert.Equal(t, "kept", msg)
+	msg, _ = c.Plural("en", "apples", 2, nil)
+	assert.Equal(t, "2 apples", msg)
+
+	writeMessageFile(t, dir, "en.yaml", "greeting: [broken")
+	require.Error(t, c.Reload())
+	msg, _ = c.Message("en", "greeting", nil)
+	assert.Equal(t, "hi", msg)
+
+	writeMessageFile(t, dir, "en.yaml", "greeting: [a, b]")
+	assert.ErrorContains(t, c.Reload(), "is a list")
+
+	require.Error(t, NewCatalog("en").LoadDir(filepath.Join(dir, "missing")))
+	c.Clear()
+	assert.Empty(t, c.Langs())
+}
+
+// gatedFS 在 armed 时让下一次 ReadDir 通知 entered 并阻塞到 gate 关闭
+type gatedFS struct {
+	fstest.MapFS
+	armed   atomic.Bool
+	entered chan struct{}
+	gate    chan struct{}
+}
+
+func (f *gatedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if f.armed.CompareAndSwap(true, false) {
+		close(f.entered)
+		<-f.gate
+	}
+	return f.MapFS.ReadDir(name)
+}
+
+func TestCatalog_ReloadOverlappingClearOrAddUsesCurrentSources(t *testing.T) {
+	fsys := &gatedFS{
+		MapFS:   fstest.MapFS{"locales/en.yaml": {Data: []byte(`title: Orders`)}},
+		entered: make(chan struct{}),
+		gate:    make(chan struct{}),
+	}
+	c := NewCatalog("en").Add("en", map[string]string{"static": "kept"})
+	require.NoError(t, c.LoadFS(fsys, "locales"))
+
+	fsys.armed.Store(true)
+	reloaded := make(chan error, 1)
+	go func() { reloaded <- c.Reload() }()
+	<-fsys.entered
+	c.Clear()
+	c.Add("en", map[string]string{"after": "added"})
+	close(fsys.gate)
+	require.NoError(t, <-reloaded)
+
+	_, ok := c.Message("en", "title", nil)
+	assert.False(t, ok, "cleared file messages must not come back")
+	_, ok = c.Message("en", "static", nil)
+	assert.False(t, ok, "cleared static messages must not come back")
+	msg, ok := c.Message("en", "after", nil)
+	assert.True(t, ok)
+	assert.Equal(t, "added", msg)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = c.Reload()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				c.Clear()
+				_ = c.LoadFS(fsys.MapFS, "locales")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCatalog_LoadFSAndWatch(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.json":        {Data: []byte(`{"title": "Orders"}`)},
+		"locales/zh-cn.yaml":     {Data: []byte(`title: 订单`)},
+		"locales/nested/ja.yaml": {Data: []byte(`title: 注文`)},
+	}
+	c := NewCatalog("en")
+	require.NoError(t, c.LoadFS(fsys, "locales"))
+	assert.Equal(t, []string{"en", "zh-cn"}, c.Langs())
+
+	_, err := c.Watch()
+	require.Error(t, err, "LoadFS sources are not watchable")
+
+	dir := t.TempDir()
+	writeMessageFile(t, dir, "en.yaml", `title: "Orders v1"`)
+	require.NoError(t, c.LoadDir(dir))
+	reloaded := make(chan error, 8)
+	stop, err := c.Watch(WatchOptions{Debounce: 20 * time.Millisecond, OnReload: func(err error) { reloaded <- err }})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, stop()) }()
+
+	writeMessageFile(t, dir, "en.yaml", `title: "Orders v2"`)
+	select {
+	case err := <-reloaded:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("catalog was not reloaded after the message file changed")
+	}
+	msg, _ := c.Message("en", "title", nil)
+	assert.Equal(t, "Orders v2", msg)
+	require.NoError(t, stop())
+}
+
+func TestCatalog_WatchReloadsOnConfigMapSymlinkSwap(t *testing.T) {
+	// 模拟 Kubernetes ConfigMap 挂载：en.yaml -> ..data/en.yaml，..data -> ..v1，更新时原子替换 ..data
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v1"), 0o755))
+	writeMessageFile(t, filepath.Join(dir, "..v1"), "en.yaml", `title: "Orders v1"`)
+	require.NoError(t, os.Symlink("..v1", filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "en.yaml"), filepath.Join(dir, "en.yaml")))
+
+	c := NewCatalog("en")
+	require.NoError(t, c.LoadDir(dir))
+	msg, _ := c.Message("en", "title", nil)
+	require.Equal(t, "Orders v1", msg)
+
+	reloaded := make(chan error, 8)
+	stop, err := c.Watch(WatchOptions{Debounce: 20 * time.Millisecond, OnReload: func(err error) { reloaded <- err }})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, stop()) }()
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v2"), 0o755))
+	writeMessageFile(t, filepath.Join(dir, "..v2"), "en.yaml", `title: "Orders v2"`)
+	require.NoError(t, os.Symlink("..v2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+
+	require.Eventually(t, func() bool {
+		select {
+		case err := <-reloaded:
+			require.NoError(t, err)
+		default:
+		}
+		msg, _ := c.Message("en", "title", nil)
+		return msg == "Orders v2"
+	}, 5*time.Second, 10*time.Millisecond, "catalog was not reloaded after the ..data symlink swap")
+}
+
+func TestLocalizerOf_CachesRequestLanguage(t *testing.T) {
+	Default().Clear()
+	t.Cleanup(Default().Clear)
+	Default().Add("en", map[string]string{"hello": "hello {name}"}).
+		Add("zh-cn", map[string]string{"hello": "你好 {name}"}).
+		AddPlural("en", "files", Message{PluralOne: "{count} file", PluralOther: "{count} files"})
+
+	req := langRequest{header: map[string]string{"Accept-Language": "zh-TW, en;q=0.5"}, locals: map[string]interface{}{}}
+	l := LocalizerOf(req)
+	assert.Equal(t, "zh-cn", l.Lang())
+	assert.Same(t, l, LocalizerOf(req))
+	assert.Equal(t, "你好 li", l.T("hello", map[string]interface{}{"name": "li"}))
+	assert.Equal(t, "2 files", l.Plural("files", 2))
+	assert.Equal(t, "missing.key", l.T("missing.key"))
+
+	SetLocalizer(req, NewLocalizer(Default(), "en"))
+	assert.Equal(t, "hello li", LocalizerOf(req).T("hello", map[string]interface{}{"name": "li"}))
+}
+
+func TestPluralFormOf_RulesByLanguage(t *testing.T) {
+	cases := []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{"en-US", 1, PluralOne},
+		{"en", 0, PluralOther},
+		{"fr", 0, PluralOne},
+		{"zh-cn", 1, PluralOther},
+		{"pl", 22, PluralFew},
+		{"pl", 25, PluralMany},
+		{"cs", 3, PluralFew},
+		{"xx", 1, PluralOne},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, PluralFormOf(tc.lang, tc.n), tc.lang)
+	}
+	RegisterPluralRule(func(int) string { return PluralMany }, "x-test")
+	assert.Equal(t, PluralMany, PluralFormOf("X_Test", 1))
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format 以 args 替换模板中的 {name} 占位符
+//
+// 占位符名由字母、数字、'_' 与 '.' 组成，值按 fmt.Sprint 格式化；args 中不存在的占位符原样保留。
+//
+//	i18n.Format("order {id} not found", map[string]interface{}{"id": 7}) // order 7 not found
+func Format(tmpl string, args map[string]interface{}) string {
+	if len(args) == 0 || !strings.Contains(tmpl, "{") {
+		return tmpl
+	}
+	var b strings.Builder
+	b.Grow(len(tmpl))
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(tmpl[start+1:], '}')
+		if end < 0 {
+			break
+		}
+		end += start + 1
+		name := tmpl[start+1 : end]
+		b.WriteString(tmpl[:start])
+		if value, ok := args[name]; ok && isPlaceholderName(name) {
+			b.WriteString(fmt.Sprint(value))
+			tmpl = tmpl[end+1:]
+			continue
+		}
+		// 不是可替换的占位符：保留 '{' 并从下一个字符继续扫描
+		b.WriteByte('{')
+		tmpl = tmpl[start+1:]
+	}
+	b.WriteString(tmpl)
+	return b.String()
+}
+
+// isPlaceholderName 判断 name 是否为合法的占位符名
+func isPlaceholderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, ch := range name {
+		switch {
+		case ch == '_' || ch == '.' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+/*
+Package i18n 提供多语言消息目录、请求语言协商与消息插值。
+
+Catalog 保存按语言组织的消息，来源为代码添加的静态消息与每种语言一个或多个的 YAML/JSON 文件，支持复数形式（见 PluralFormOf）、
+回退链（内置 zh-tw → zh-cn → en）与文件变更后的热加载。Resolver 依次按配置的查询参数、请求头与 Accept-Language 解析请求语言，
+协商规则见 Negotiate；LocalizerOf 返回绑定了请求语言的请求级 Localizer。消息模板中的 {name} 占位符按参数插值，见 Format。
+
+Default 目录同时供 component/validate 的验证消息（键 validate.<tag>）与 exception 的异常消息（键 exception.<key>）查找。
+
+配置（application.i18n）：
+
+	application:
+	  i18n:
+	    defaultLang: en     # 未协商出语言时使用的语言，也是回退链的最后一级
+	    langQuery: lang     # 可选，优先读取语言的查询参数，如 ?lang=zh-cn
+	    langHeader: X-Lang  # 可选，优先于 Accept-Language 读取语言的请求头
+	    dirs:               # 可选，启动时加载到 Default 目录的消息文件目录
+	      - ./i18n
+	    watch: true         # 可选，消息文件变更时重新加载
+	    debounce: 200ms     # 合并文件变更事件的窗口
+	    fallbacks:          # 可选，追加或覆盖语言的回退设置
+	      zh-hk: [zh-tw, zh-cn]
+
+消息文件示例（i18n/zh-cn.yaml）：
+
+	order:
+	  notFound: "订单 {id} 不存在"
+	cart:
+	  items:
+	    other: "{count} 件商品"
+*/
+package i18n
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/lamxy/fiberhouse/appconfig"
+)
+
+// ConfPath 多语言配置路径
+const ConfPath = "application.i18n"
+
+// DefaultLang 缺省语言
+const DefaultLang = "en"
+
+func init() {
+	appconfig.RegisterSchema(ConfPath, Config{})
+}
+
+// Config 多语言配置段结构，对应配置路径 application.i18n
+type Config struct {
+	DefaultLang string              `koanf:"defaultLang" default:"en" validate:"required"`
+	LangQuery   string              `koanf:"langQuery"`
+	LangHeader  string              `koanf:"langHeader"`
+	Dirs        []string            `koanf:"dirs"`
+	Watch       bool                `koanf:"watch"`
+	Debounce    time.Duration       `koanf:"debounce" default:"200ms" validate:"gt=0"`
+	Fallbacks   map[string][]string `koanf:"fallbacks"`
+}
+
+var (
+	// defaultCatalog 进程级消息目录
+	defaultCatalog = NewCatalog(DefaultLang)
+	// defaultResolver LocalizerOf 使用的请求语言解析器
+	defaultResolver atomic.Pointer[Resolver]
+)
+
+func init() {
+	defaultResolver.Store(NewResolverWith(Config{}))
+}
+
+// Default 返回进程级消息目录，Web 应用启动时按 application.i18n 配置加载
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+// DefaultResolver 返回 LocalizerOf 使用的请求语言解析器，Web 应用启动时替换为按 application.i18n 配置创建的解析器
+func DefaultResolver() *Resolver {
+	return defaultResolver.Load()
+}
+
+// SetDefaultResolver 设置 LocalizerOf 使用的请求语言解析器，nil 时忽略
+func SetDefaultResolver(r *Resolver) {
+	if r != nil {
+		defaultResolver.Store(r)
+	}
+}
@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+type langRequest struct {
+	adaptorctx.ICoreContext
+	query  map[string]string
+	header map[string]string
+	locals map[string]interface{}
+}
+
+func (r langRequest) Locals(key string, value ...interface{}) interface{} {
+	if len(value) > 0 {
+		r.locals[key] = value[0]
+		return value[0]
+	}
+	return r.locals[key]
+}
+
+func (r langRequest) Query(key string, defaultValue ...string) string {
+	if v, ok := r.query[key]; ok {
+		return v
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return ""
+}
+
+func (r langRequest) GetHeader(key string) string { return r.header[key] }
+
+func TestNegotiate_KeepsSupportedSpellingAndFallback(t *testing.T) {
+	supported := []string{"en", "zh-CN", "zh_TW"}
+	cases := map[string]string{
+		"":                         "fallback",
+		"zh-cn":                    "zh-CN",
+		"zh-TW;q=0.9, zh-CN;q=0.5": "zh_TW",
+		"zh":                       "zh-CN",
+		"en-GB, zh;q=0.5":          "en",
+		"zh-CN;q=0, fr":            "fallback",
+		"*, zh-CN;q=0.1":           "fallback",
+	}
+	for header, want := range cases {
+		assert.Equal(t, want, Negotiate(header, supported, "fallback"), header)
+	}
+	assert.Equal(t, "", Negotiate("zh-CN", nil, ""))
+}
+
+func TestFormat_ReplacesKnownPlaceholders(t *testing.T) {
+	args := map[string]interface{}{"id": 7, "user.name": "li", "n": 2.5}
+	cases := map[string]string{
+		"order {id} not found":        "order 7 not found",
+		"{user.name} has {n} {items}": "li has 2.5 {items}",
+		"{{id}} and {id":              "{7} and {id",
+		"{ id } {}":                   "{ id } {}",
+		"no placeholders":             "no placeholders",
+	}
+	for tmpl, want := range cases {
+		assert.Equal(t, want, Format(tmpl, args), tmpl)
+	}
+	assert.Equal(t, "order {id}", Format("order {id}", nil))
+}
+
+func TestResolver_QueryHeaderThenAcceptLanguage(t *testing.T) {
+	cfg := appconfig.NewAppConfig().LoadDefault(map[string]interface{}{
+		"application.i18n.defaultLang": "zh-cn",
+		"application.i18n.langQuery":   "lang",
+		"application.i18n.langHeader":  "X-Lang",
+	})
+	r := NewResolver(cfg)
+	assert.Equal(t, Config{DefaultLang: "zh-cn", LangQuery: "lang", LangHeader: "X-Lang", Debounce: 200 * time.Millisecond}, r.Config())
+
+	supported := []string{"en", "zh-cn", "zh-tw"}
+	cases := []struct {
+		name string
+		req  langRequest
+		want string
+	}{
+		{name: "default", want: "zh-cn"},
+		{name: "accept-language", req: langRequest{header: map[string]string{"Accept-Language": "en-US"}}, want: "en"},
+		{name: "header", req: langRequest{header: map[string]string{"X-Lang": "zh-TW", "Accept-Language": "en"}}, want: "zh-tw"},
+		{name: "query", req: langRequest{query: map[string]string{"lang": "en"}, header: map[string]string{"X-Lang": "zh-tw"}}, want: "en"},
+		{name: "unsupported query", req: langRequest{query: map[string]string{"lang": "fr"}, header: map[string]string{"Accept-Language": "zh-tw"}}, want: "zh-tw"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, r.Resolve(tc.req, supported), tc.name)
+	}
+
+	assert.Equal(t, "en", NewResolver(nil).Resolve(langRequest{header: map[string]string{"X-Lang": "zh-tw"}}, supported))
+}
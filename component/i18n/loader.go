@@ -0,0 +1,139 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// messageFileExt 消息文件扩展名
+var messageFileExt = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// isMessageFile 判断文件名是否为消息文件
+func isMessageFile(name string) bool {
+	return messageFileExt[strings.ToLower(path.Ext(name))]
+}
+
+// fileLang 由消息文件名得出语言：去掉扩展名后最后一个 '.' 之后的部分，如 zh-cn.yaml、validate.zh-cn.yaml 均为 zh-cn
+func fileLang(name string) string {
+	base := strings.TrimSuffix(path.Base(name), path.Ext(name))
+	if i := strings.LastIndexByte(base, '.'); i >= 0 {
+		base = base[i+1:]
+	}
+	return Normalize(base)
+}
+
+// loadFS 读取 fsys 中 dir 目录（不含子目录）下的全部消息文件，按文件名排序依次合并
+func loadFS(fsys fs.FS, dir string) (map[string]map[string]Message, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("i18n: read message dir '%s': %w", dir, err)
+	}
+	out := make(map[string]map[string]Message)
+	for _, entry := range entries {
+		if entry.IsDir() || !isMessageFile(entry.Name()) {
+			continue
+		}
+		name := path.Join(dir, entry.Name())
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: read message file '%s': %w", name, err)
+		}
+		messages, err := parseMessages(entry.Name(), data)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: parse message file '%s': %w", name, err)
+		}
+		lang := fileLang(entry.Name())
+		if out[lang] == nil {
+			out[lang] = make(map[string]Message, len(messages))
+		}
+		for key, msg := range messages {
+			out[lang][key] = msg
+		}
+	}
+	return out, nil
+}
+
+// parseMessages 解析 YAML 或 JSON 消息文件
+//
+// 嵌套对象的键以 '.' 连接为消息键；键全部为复数类别名（zero/one/two/few/many/other）且含 other 的对象是一条复数消息。
+//
+//	order:
+//	  notFound: "order {id} not found"
+//	  items:
+//	    one: "{count} item"
+//	    other: "{count} items"
+func parseMessages(name string, data []byte) (map[string]Message, error) {
+	var raw map[string]interface{}
+	var err error
+	if strings.EqualFold(path.Ext(name), ".json") {
+		err = json.Unmarshal(data, &raw)
+	} else {
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]Message)
+	if err := flattenMessages("", raw, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func flattenMessages(prefix string, raw map[string]interface{}, out map[string]Message) error {
+	for key, value := range raw {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if msg, ok := pluralMessage(v); ok {
+				out[key] = msg
+				continue
+			}
+			if err := flattenMessages(key, v, out); err != nil {
+				return err
+			}
+		case []interface{}:
+			return fmt.Errorf("message '%s' is a list, want a string or an object", key)
+		case nil:
+			out[key] = Message{PluralOther: ""}
+		default:
+			out[key] = Message{PluralOther: fmt.Sprint(v)}
+		}
+	}
+	return nil
+}
+
+// pluralMessage 对象的键全部为复数类别名、值为标量且含 other 时返回复数消息
+func pluralMessage(raw map[string]interface{}) (Message, bool) {
+	if _, ok := raw[PluralOther]; !ok {
+		return nil, false
+	}
+	msg := make(Message, len(raw))
+	for form, value := range raw {
+		if !isPluralForm(form) {
+			return nil, false
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return nil, false
+		case nil:
+			msg[form] = ""
+		default:
+			msg[form] = fmt.Sprint(value)
+		}
+	}
+	return msg, true
+}
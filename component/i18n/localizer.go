@@ -0,0 +1,85 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package i18n
+
+import adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+
+// localizerLocalsKey 请求级 Localizer 在 ICoreContext.Locals 中的键
+const localizerLocalsKey = "fiberhouse.i18n.localizer"
+
+// Localizer 绑定了语言的消息查找器，通常为请求级对象，见 LocalizerOf
+type Localizer struct {
+	catalog *Catalog
+	lang    string
+}
+
+// NewLocalizer 创建以 lang 语言查找 catalog 的 Localizer
+func NewLocalizer(catalog *Catalog, lang string) *Localizer {
+	return &Localizer{catalog: catalog, lang: Normalize(lang)}
+}
+
+// Lang 返回 Localizer 的语言
+func (l *Localizer) Lang() string {
+	return l.lang
+}
+
+// Catalog 返回 Localizer 查找的消息目录
+func (l *Localizer) Catalog() *Catalog {
+	return l.catalog
+}
+
+// Message 返回 key 的本地化消息，查找规则见 Catalog.Message
+func (l *Localizer) Message(key string, args map[string]interface{}) (string, bool) {
+	return l.catalog.Message(l.lang, key, args)
+}
+
+// T 返回 key 的本地化消息，args 最多取第一个；消息不存在时返回 key
+//
+//	i18n.LocalizerOf(c).T("order.created", map[string]interface{}{"id": id})
+func (l *Localizer) T(key string, args ...map[string]interface{}) string {
+	var a map[string]interface{}
+	if len(args) > 0 {
+		a = args[0]
+	}
+	if msg, ok := l.catalog.Message(l.lang, key, a); ok {
+		return msg
+	}
+	return key
+}
+
+// Plural 返回 key 对应 count 复数形式的本地化消息，模板可使用 {count}；消息不存在时返回 key
+//
+//	i18n.LocalizerOf(c).Plural("cart.items", n)
+func (l *Localizer) Plural(key string, count int, args ...map[string]interface{}) string {
+	var a map[string]interface{}
+	if len(args) > 0 {
+		a = args[0]
+	}
+	if msg, ok := l.catalog.Plural(l.lang, key, count, a); ok {
+		return msg
+	}
+	return key
+}
+
+// LocalizerOf 返回本次请求的 Localizer
+//
+// 首次调用时以 DefaultResolver 在 Default 目录已有的语言中解析请求语言，并缓存到请求的 Locals；
+// 此后同一请求返回同一实例。SetLocalizer 可替换请求级实例。
+func LocalizerOf(c adaptorctx.ICoreContext) *Localizer {
+	if l, ok := c.Locals(localizerLocalsKey).(*Localizer); ok && l != nil {
+		return l
+	}
+	catalog := Default()
+	l := NewLocalizer(catalog, DefaultResolver().Resolve(c, catalog.Langs()))
+	c.Locals(localizerLocalsKey, l)
+	return l
+}
+
+// SetLocalizer 设置本次请求的 Localizer，如由中间件按用户偏好语言创建
+func SetLocalizer(c adaptorctx.ICoreContext, l *Localizer) {
+	c.Locals(localizerLocalsKey, l)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Normalize 规范化语言标签：小写，'_' 替换为 '-'，如 zh_CN 规范化为 zh-cn
+func Normalize(tag string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(tag)), "_", "-")
+}
+
+// Negotiate 按 Accept-Language 语法从 supported 中选出语言
+//
+// 按 q 值从高到低依次匹配，q=0 的语言被忽略，遇到 * 时停止；每个语言标签先精确匹配（不区分大小写），
+// 再以主语言子标签匹配，如 en-US 匹配 en、zh 匹配 zh-cn。全部未命中时返回 fallback。
+// 返回值取 supported 中的原始写法。
+//
+//	lang := i18n.Negotiate(c.GetHeader("Accept-Language"), []string{"en", "zh-cn"}, "en")
+func Negotiate(acceptLanguage string, supported []string, fallback string) string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = Normalize(tag)
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.TrimSpace(key) == "q" {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	for _, t := range tags {
+		if t.tag == "*" {
+			break
+		}
+		primary, _, _ := strings.Cut(t.tag, "-")
+		for _, lang := range supported {
+			if Normalize(lang) == t.tag {
+				return lang
+			}
+		}
+		for _, lang := range supported {
+			langPrimary, _, _ := strings.Cut(Normalize(lang), "-")
+			if langPrimary == primary {
+				return lang
+			}
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package i18n
+
+import (
+	"strings"
+	"sync"
+)
+
+// 复数形式，取 CLDR 的复数类别名
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// PluralRule 复数规则，返回整数 n 在某一语言下的复数形式
+type PluralRule func(n int) string
+
+var (
+	pluralMu    sync.RWMutex
+	pluralRules = map[string]PluralRule{}
+)
+
+func init() {
+	RegisterPluralRule(pluralOther, "zh", "ja", "ko", "vi", "th", "id", "ms")
+	RegisterPluralRule(pluralOneIfOne, "en", "de", "nl", "sv", "da", "no", "nb", "fi", "es", "it", "pt", "el", "hu", "tr")
+	RegisterPluralRule(pluralOneIfZeroOrOne, "fr", "pt-br")
+	RegisterPluralRule(pluralEastSlavic, "ru", "uk", "be")
+	RegisterPluralRule(pluralPolish, "pl")
+	RegisterPluralRule(pluralCzech, "cs", "sk")
+}
+
+// RegisterPluralRule 为语言注册复数规则，覆盖已注册的规则；语言标签按 Normalize 规范化
+func RegisterPluralRule(rule PluralRule, langs ...string) {
+	pluralMu.Lock()
+	defer pluralMu.Unlock()
+	for _, lang := range langs {
+		pluralRules[Normalize(lang)] = rule
+	}
+}
+
+// PluralFormOf 返回 n 在 lang 语言下的复数形式
+//
+// 先按完整语言标签、再按主语言子标签查找规则，都未注册时按英语规则（n 为 1 时为 one，否则为 other）。
+func PluralFormOf(lang string, n int) string {
+	lang = Normalize(lang)
+	primary, _, _ := strings.Cut(lang, "-")
+	pluralMu.RLock()
+	rule, ok := pluralRules[lang]
+	if !ok {
+		rule, ok = pluralRules[primary]
+	}
+	pluralMu.RUnlock()
+	if !ok {
+		rule = pluralOneIfOne
+	}
+	return rule(n)
+}
+
+func pluralOther(int) string {
+	return PluralOther
+}
+
+func pluralOneIfOne(n int) string {
+	if n == 1 || n == -1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralOneIfZeroOrOne(n int) string {
+	if n == 0 || n == 1 || n == -1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralEastSlavic(n int) string {
+	n = abs(n)
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func pluralPolish(n int) string {
+	n = abs(n)
+	mod10, mod100 := n%10, n%100
+	switch {
+	case n == 1:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func pluralCzech(n int) string {
+	switch abs(n) {
+	case 1:
+		return PluralOne
+	case 2, 3, 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// isPluralForm 判断 name 是否为复数类别名
+func isPluralForm(name string) bool {
+	switch name {
+	case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package i18n
+
+import (
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/lamxy/fiberhouse/appconfig"
+)
+
+// Resolver 请求语言解析器，按 Config 依次读取查询参数、请求头与 Accept-Language
+type Resolver struct {
+	cfg Config
+}
+
+// NewResolver 以 application.i18n 配置创建请求语言解析器，cfg 为 nil 或配置无效时使用缺省配置
+//
+// 配置无效时启动期的 appconfig.CheckSchemas 已报告问题，这里不再重复返回错误。
+func NewResolver(cfg appconfig.IAppConfig) *Resolver {
+	conf := Config{DefaultLang: DefaultLang}
+	if cfg != nil {
+		if bound, err := appconfig.Bind[Config](cfg, ConfPath); err == nil {
+			conf = bound
+		}
+	}
+	return NewResolverWith(conf)
+}
+
+// NewResolverWith 以指定配置创建请求语言解析器，DefaultLang 为空时取 DefaultLang 常量
+func NewResolverWith(conf Config) *Resolver {
+	if conf.DefaultLang == "" {
+		conf.DefaultLang = DefaultLang
+	}
+	return &Resolver{cfg: conf}
+}
+
+// Config 返回解析器使用的配置
+func (r *Resolver) Config() Config {
+	return r.cfg
+}
+
+// Resolve 解析请求语言并从 supported 中选出匹配的语言
+//
+// 依次尝试配置的查询参数 LangQuery、请求头 LangHeader 与 Accept-Language，每个来源按 Negotiate 匹配，
+// 第一个命中的来源决定结果；全部未命中时返回 DefaultLang。
+func (r *Resolver) Resolve(c adaptorctx.ICoreContext, supported []string) string {
+	if r.cfg.LangQuery != "" {
+		if lang := Negotiate(c.Query(r.cfg.LangQuery), supported, ""); lang != "" {
+			return lang
+		}
+	}
+	if r.cfg.LangHeader != "" {
+		if lang := Negotiate(c.GetHeader(r.cfg.LangHeader), supported, ""); lang != "" {
+			return lang
+		}
+	}
+	return Negotiate(c.GetHeader("Accept-Language"), supported, r.cfg.DefaultLang)
+}
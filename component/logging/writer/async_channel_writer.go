@@ -18,6 +18,14 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+// channelDroppedTotal 进程内全部 AsyncChannelWriter 丢弃的日志总条数，供指标采集
+var channelDroppedTotal atomic.Int64
+
+// ChannelDroppedLogsTotal 返回进程内全部 AsyncChannelWriter 因通道满而丢弃的日志总条数
+func ChannelDroppedLogsTotal() int64 {
+	return channelDroppedTotal.Load()
+}
+
 // AsyncChannelWriter 实现异步写日志功能，实现 io.Writer 接口
 type AsyncChannelWriter struct {
 	logChan        chan []byte        // 用于接收日志数据
@@ -117,6 +125,7 @@ func (a *AsyncChannelWriter) Write(p []byte) (int, error) {
 	case <-timer.C:
 		// 超过 1s 仍无法写入，丢弃并计数
 		dropped = atomic.AddInt64(&a.droppedLogs, 1)
+		channelDroppedTotal.Add(1)
 	}
 
 	a.finishWrite()
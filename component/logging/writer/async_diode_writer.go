@@ -19,6 +19,14 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+// diodeDroppedTotal 进程内全部 AsyncDiodeWriter 丢弃的日志总条数，供指标采集
+var diodeDroppedTotal atomic.Int64
+
+// DiodeDroppedLogsTotal 返回进程内全部 AsyncDiodeWriter 因 diode 满而丢弃的日志总条数
+func DiodeDroppedLogsTotal() int64 {
+	return diodeDroppedTotal.Load()
+}
+
 // AsyncDiodeWriter 实现异步写日志功能，实现 io.Writer 接口
 type AsyncDiodeWriter struct {
 	diode       diodes.Diode       // 二极管
@@ -61,6 +69,7 @@ func NewAsyncDiodeWriter(cfg appconfig.IAppConfig, filename string) *AsyncDiodeW
 
 	dd := diodes.NewManyToOne(diodeSize, diodes.AlertFunc(func(missed int) {
 		dropped := atomic.AddInt64(&aw.droppedLogs, int64(missed))
+		diodeDroppedTotal.Add(int64(missed))
 		_, _ = fmt.Fprintf(os.Stderr, "AsyncDiodeWriter: diode full, +%d dropped, total: %d\n", missed, dropped)
 	}))
 	aw.diode = dd
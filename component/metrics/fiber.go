@@ -0,0 +1,48 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+)
+
+// FiberMiddleware 记录 Fiber 请求耗时的中间件，skipPaths 中的路径（如指标端点自身）不记录
+//
+// 处理器返回 error 时按 fiber.Error 的状态码记录，其他 error 记为 500。
+func FiberMiddleware(skipPaths ...string) fiber.Handler {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, p := range skipPaths {
+		skip[p] = struct{}{}
+	}
+	return func(c *fiber.Ctx) error {
+		if _, ok := skip[c.Path()]; ok {
+			return c.Next()
+		}
+		start := time.Now()
+		err := c.Next()
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fe *fiber.Error
+			if errors.As(err, &fe) {
+				status = fe.Code
+			}
+		}
+		ObserveHTTPRequest("fiber", c.Method(), adaptorctx.FiberRoute(c), status, time.Since(start))
+		return err
+	}
+}
+
+// FiberHandler 以 Prometheus 文本格式导出指标的 Fiber 处理器
+func FiberHandler() fiber.Handler {
+	return adaptor.HTTPHandler(Handler())
+}
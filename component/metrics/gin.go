@@ -0,0 +1,35 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GinMiddleware 记录 Gin 请求耗时的中间件，skipPaths 中的路径（如指标端点自身）不记录
+func GinMiddleware(skipPaths ...string) gin.HandlerFunc {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, p := range skipPaths {
+		skip[p] = struct{}{}
+	}
+	return func(c *gin.Context) {
+		if _, ok := skip[c.Request.URL.Path]; ok {
+			c.Next()
+			return
+		}
+		start := time.Now()
+		c.Next()
+		ObserveHTTPRequest("gin", c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(start))
+	}
+}
+
+// GinHandler 以 Prometheus 文本格式导出指标的 Gin 处理器
+func GinHandler() gin.HandlerFunc {
+	return gin.WrapH(Handler())
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+/*
+Package metrics 提供框架运行指标的采集与 Prometheus 文本格式导出。
+
+指标注册在包级的独立 Registry 上，包括：
+
+	fiberhouse_http_request_duration_seconds       HTTP 请求耗时直方图，标签 core、method、route、status
+	fiberhouse_globalmanager_health_checks_total   全局对象健康检查次数，标签 key、result(healthy|unhealthy|error)
+	fiberhouse_globalmanager_rebuilds_total        全局对象重建次数，标签 key、result(success|failure)
+	fiberhouse_cache_requests_total                缓存读取次数，标签 level、result(hit|miss|bloom_rejected|breaker_open)
+	fiberhouse_tasks_enqueued_total                asynq 任务入队次数，标签 type、result(success|failure)
+	fiberhouse_tasks_processed_total               asynq 任务处理次数，标签 type、result(success|failure)
+	fiberhouse_log_dropped_total                   异步日志丢弃条数，标签 writer(chan|diode)，EnableLogMetrics 后注册
+
+以及 Go 运行时与进程指标。通过 Handler、FiberHandler 或 GinHandler 导出。
+*/
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lamxy/fiberhouse/component/logging/writer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "fiberhouse"
+
+// DefaultPath 指标端点默认路径
+const DefaultPath = "/metrics"
+
+// 缓存读取结果标签值
+const (
+	CacheHit           = "hit"
+	CacheMiss          = "miss"
+	CacheBloomRejected = "bloom_rejected"
+	CacheBreakerOpen   = "breaker_open"
+)
+
+// unmatchedRoute 未匹配任何路由的请求的 route 标签值，避免按原始路径产生无限标签
+const unmatchedRoute = "unmatched"
+
+var (
+	registry = prometheus.NewRegistry()
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"core", "method", "route", "status"})
+
+	healthChecks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "globalmanager",
+		Name:      "health_checks_total",
+		Help:      "GlobalManager health checks by key and result.",
+	}, []string{"key", "result"})
+
+	rebuilds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "globalmanager",
+		Name:      "rebuilds_total",
+		Help:      "GlobalManager rebuilds by key and result.",
+	}, []string{"key", "result"})
+
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Cache reads by level and result.",
+	}, []string{"level", "result"})
+
+	tasksEnqueued = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tasks",
+		Name:      "enqueued_total",
+		Help:      "Asynq tasks enqueued by type and result.",
+	}, []string{"type", "result"})
+
+	tasksProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tasks",
+		Name:      "processed_total",
+		Help:      "Asynq tasks processed by type and result.",
+	}, []string{"type", "result"})
+
+	logMetricsOnce sync.Once
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestDuration,
+		healthChecks,
+		rebuilds,
+		cacheRequests,
+		tasksEnqueued,
+		tasksProcessed,
+	)
+}
+
+// Registry 返回框架指标的 Registry，应用可在其上注册自定义指标
+func Registry() *prometheus.Registry {
+	return registry
+}
+
+// Handler 返回以 Prometheus 文本格式导出指标的 http.Handler
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// EnableLogMetrics 注册异步日志丢弃条数指标，重复调用无副作用
+func EnableLogMetrics() {
+	logMetricsOnce.Do(func() {
+		for name, total := range map[string]func() int64{
+			"chan":  writer.ChannelDroppedLogsTotal,
+			"diode": writer.DiodeDroppedLogsTotal,
+		} {
+			registry.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Namespace:   namespace,
+				Subsystem:   "log",
+				Name:        "dropped_total",
+				Help:        "Log entries dropped by async writers.",
+				ConstLabels: prometheus.Labels{"writer": name},
+			}, func() float64 { return float64(total()) }))
+		}
+	})
+}
+
+// ObserveHTTPRequest 记录一次 HTTP 请求耗时，route 为匹配的路由模板，空串表示未匹配
+func ObserveHTTPRequest(core, method, route string, status int, elapsed time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+	httpRequestDuration.WithLabelValues(core, method, route, strconv.Itoa(status)).Observe(elapsed.Seconds())
+}
+
+// ObserveCache 记录一次缓存读取结果，result 取 CacheHit、CacheMiss、CacheBloomRejected、CacheBreakerOpen
+func ObserveCache(level, result string) {
+	cacheRequests.WithLabelValues(level, result).Inc()
+}
+
+// ObserveTaskEnqueued 记录一次任务入队
+func ObserveTaskEnqueued(taskType string, err error) {
+	tasksEnqueued.WithLabelValues(taskType, resultOf(err)).Inc()
+}
+
+// ObserveTaskProcessed 记录一次任务处理
+func ObserveTaskProcessed(taskType string, err error) {
+	tasksProcessed.WithLabelValues(taskType, resultOf(err)).Inc()
+}
+
+func resultOf(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+// GlobalManagerObserver 实现 globalmanager.Observer，记录全局对象健康检查与重建次数
+type GlobalManagerObserver struct{}
+
+// HealthChecked 记录一次健康检查结果
+func (GlobalManagerObserver) HealthChecked(name string, healthy bool, err error) {
+	result := "healthy"
+	switch {
+	case err != nil:
+		result = "error"
+	case !healthy:
+		result = "unhealthy"
+	}
+	healthChecks.WithLabelValues(name, result).Inc()
+}
+
+// Rebuilt 记录一次重建结果
+func (GlobalManagerObserver) Rebuilt(name string, err error) {
+	rebuilds.WithLabelValues(name, resultOf(err)).Inc()
+}
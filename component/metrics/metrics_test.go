@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DefaultPath, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestFiberMiddleware_RecordsRouteTemplateAndStatus(t *testing.T) {
+	app := fiber.New()
+	app.Use(FiberMiddleware(DefaultPath))
+	app.Get(DefaultPath, FiberHandler())
+	app.Get("/fiber/users/:id", func(c *fiber.Ctx) error { return c.SendString(c.Params("id")) })
+	app.Get("/fiber/fail", func(c *fiber.Ctx) error { return fiber.NewError(fiber.StatusTeapot, "teapot") })
+	app.Get("/fiber/boom", func(c *fiber.Ctx) error { return errors.New("boom") })
+
+	for _, path := range []string{"/fiber/users/1", "/fiber/users/2", "/fiber/fail", "/fiber/boom", "/fiber/nowhere", DefaultPath} {
+		_, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		require.NoError(t, err)
+	}
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, DefaultPath, nil))
+	require.NoError(t, err)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/plain")
+
+	out := scrape(t)
+	assert.Contains(t, out, `fiberhouse_http_request_duration_seconds_count{core="fiber",method="GET",route="/fiber/users/:id",status="200"} 2`)
+	assert.Contains(t, out, `fiberhouse_http_request_duration_seconds_count{core="fiber",method="GET",route="/fiber/fail",status="418"} 1`)
+	assert.Contains(t, out, `fiberhouse_http_request_duration_seconds_count{core="fiber",method="GET",route="/fiber/boom",status="500"} 1`)
+	assert.Contains(t, out, `fiberhouse_http_request_duration_seconds_count{core="fiber",method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, out, `route="`+DefaultPath+`"`, "the metrics endpoint itself is skipped")
+	assert.NotContains(t, out, "/fiber/users/1")
+}
+
+func TestGinMiddleware_RecordsRouteTemplateAndStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(GinMiddleware(DefaultPath))
+	engine.GET(DefaultPath, GinHandler())
+	engine.GET("/gin/users/:id", func(c *gin.Context) { c.String(http.StatusOK, c.Param("id")) })
+	engine.POST("/gin/users", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/gin/users/1", nil),
+		httptest.NewRequest(http.MethodPost, "/gin/users", nil),
+		httptest.NewRequest(http.MethodGet, "/gin/nowhere", nil),
+		httptest.NewRequest(http.MethodGet, DefaultPath, nil),
+	} {
+		engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	out := scrape(t)
+	assert.Contains(t, out, `fiberhouse_http_request_duration_seconds_count{core="gin",method="GET",route="/gin/users/:id",status="200"} 1`)
+	assert.Contains(t, out, `fiberhouse_http_request_duration_seconds_count{core="gin",method="POST",route="/gin/users",status="201"} 1`)
+	assert.Contains(t, out, `fiberhouse_http_request_duration_seconds_count{core="gin",method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, out, "go_goroutines")
+}
+
+func TestCountersAndGlobalManagerObserver(t *testing.T) {
+	ObserveCache("level2", CacheHit)
+	ObserveCache("level2", CacheBloomRejected)
+	ObserveCache("remote", CacheBreakerOpen)
+	ObserveTaskEnqueued("email:send", nil)
+	ObserveTaskProcessed("email:send", errors.New("smtp down"))
+
+	observer := GlobalManagerObserver{}
+	observer.HealthChecked("db", true, nil)
+	observer.HealthChecked("db", false, nil)
+	observer.HealthChecked("db", true, errors.New("missing"))
+	observer.Rebuilt("db", nil)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheRequests.WithLabelValues("level2", CacheHit)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheRequests.WithLabelValues("level2", CacheBloomRejected)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheRequests.WithLabelValues("remote", CacheBreakerOpen)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(tasksEnqueued.WithLabelValues("email:send", "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(tasksProcessed.WithLabelValues("email:send", "failure")))
+	for _, result := range []string{"healthy", "unhealthy", "error"} {
+		assert.Equal(t, 1.0, testutil.ToFloat64(healthChecks.WithLabelValues("db", result)), result)
+	}
+	assert.Equal(t, 1.0, testutil.ToFloat64(rebuilds.WithLabelValues("db", "success")))
+
+	assert.NotContains(t, scrape(t), "fiberhouse_log_dropped_total")
+	EnableLogMetrics()
+	EnableLogMetrics()
+	out := scrape(t)
+	assert.Contains(t, out, `fiberhouse_log_dropped_total{writer="chan"} 0`)
+	assert.Contains(t, out, `fiberhouse_log_dropped_total{writer="diode"} 0`)
+}
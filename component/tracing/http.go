@@ -0,0 +1,122 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package tracing
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fiberHeaderCarrier 以 fasthttp 请求头实现 propagation.TextMapCarrier
+type fiberHeaderCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h fiberHeaderCarrier) Get(key string) string { return h.c.Get(key) }
+
+func (h fiberHeaderCarrier) Set(key, value string) { h.c.Request().Header.Set(key, value) }
+
+func (h fiberHeaderCarrier) Keys() []string {
+	keys := make([]string, 0)
+	for k := range h.c.GetReqHeaders() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// fiberResponseCarrier 写入响应头的 propagation.TextMapCarrier
+type fiberResponseCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h fiberResponseCarrier) Get(key string) string { return string(h.c.Response().Header.Peek(key)) }
+
+func (h fiberResponseCarrier) Set(key, value string) { h.c.Set(key, value) }
+
+func (h fiberResponseCarrier) Keys() []string { return nil }
+
+// FiberMiddleware 从请求头 traceparent 提取上游链路并创建服务端 span，span 上下文写入 c.UserContext()
+//
+// 当前 traceparent 写回响应头，便于客户端关联；span 名在处理完成后改为 "METHOD 路由模板"。
+func FiberMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		propagator := otel.GetTextMapPropagator()
+		ctx := propagator.Extract(c.UserContext(), fiberHeaderCarrier{c: c})
+		ctx, span := Tracer().Start(ctx, c.Method(), trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Method()),
+				attribute.String("url.path", c.Path()),
+			))
+		defer span.End()
+		propagator.Inject(ctx, fiberResponseCarrier{c: c})
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fe *fiber.Error
+			if errors.As(err, &fe) {
+				status = fe.Code
+			}
+			span.RecordError(err)
+		}
+		if route := adaptorctx.FiberRoute(c); route != "" {
+			span.SetName(c.Method() + " " + route)
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+		endHTTPSpan(span, status)
+		return err
+	}
+}
+
+// GinMiddleware 从请求头 traceparent 提取上游链路并创建服务端 span，span 上下文写入 c.Request.Context()
+//
+// 当前 traceparent 写回响应头，便于客户端关联；span 名为 "METHOD 路由模板"。
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		propagator := otel.GetTextMapPropagator()
+		ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		name := c.Request.Method
+		if route := c.FullPath(); route != "" {
+			name += " " + route
+		}
+		ctx, span := Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("url.path", c.Request.URL.Path),
+			))
+		defer span.End()
+		if route := c.FullPath(); route != "" {
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+		propagator.Inject(ctx, propagation.HeaderCarrier(c.Writer.Header()))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+		for _, e := range c.Errors {
+			span.RecordError(e.Err)
+		}
+		endHTTPSpan(span, c.Writer.Status())
+	}
+}
+
+// endHTTPSpan 记录响应状态码，5xx 时 span 状态置为 Error
+func endHTTPSpan(span trace.Span, status int) {
+	span.SetAttributes(attribute.Int("http.response.status_code", status))
+	if status >= 500 {
+		span.SetStatus(codes.Error, "")
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+/*
+Package tracing 基于 OpenTelemetry 提供分布式链路追踪。
+
+Setup 按 application.trace 配置安装全局 TracerProvider 与 W3C traceparent/baggage 传播器，导出器可选 stdout、file、otlp（HTTP）或 none。
+各组件的埋点只依赖 OpenTelemetry 全局 API，未调用 Setup 时为空操作：
+
+	FiberMiddleware、GinMiddleware   提取请求头 traceparent 创建服务端 span，并把 traceparent 写回响应头
+	RedisHook                        go-redis 命令与 pipeline span
+	GormPlugin                       GORM create/query/update/delete/row/raw 回调 span
+	MongoMonitor                     MongoDB 命令监听 span
+	InjectMap、ExtractMap            通过 asynq 任务头部在入队与处理之间传播上下文
+*/
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lamxy/fiberhouse/appconfig"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ConfPath 链路追踪配置路径
+const ConfPath = "application.trace"
+
+// InstrumentationName 框架埋点使用的 Tracer 名称
+const InstrumentationName = "github.com/lamxy/fiberhouse"
+
+// 导出器类型
+const (
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+	ExporterNone   = "none"
+)
+
+func init() {
+	appconfig.RegisterSchema(ConfPath, Config{})
+}
+
+// Config 链路追踪配置段结构，对应配置路径 application.trace
+type Config struct {
+	RequestID   string  `koanf:"requestID" default:"requestId"`                                    // 请求ID头部字段，供 recovery 与日志读取
+	Enable      bool    `koanf:"enable"`                                                           // 是否启用 OpenTelemetry 链路追踪
+	ServiceName string  `koanf:"serviceName"`                                                      // 服务名，缺省为应用名
+	Exporter    string  `koanf:"exporter" default:"stdout" validate:"oneof=stdout file otlp none"` // 导出器
+	File        string  `koanf:"file" default:"./logs/trace.jsonl"`                                // file 导出器的输出文件
+	Endpoint    string  `koanf:"endpoint" default:"localhost:4318"`                                // otlp 导出器的 host:port
+	Insecure    bool    `koanf:"insecure"`                                                         // otlp 导出器使用 HTTP 而非 HTTPS
+	SampleRatio float64 `koanf:"sampleRatio" default:"1" validate:"gte=0,lte=1"`                   // 根 span 采样率，子 span 跟随父 span
+}
+
+// Setup 按配置安装全局 TracerProvider 与 W3C 传播器，返回刷新并关闭导出器的函数
+//
+// serviceName 为空时使用 conf.ServiceName，二者都为空时为 "fiberhouse"。
+func Setup(conf Config, serviceName string) (shutdown func(context.Context) error, err error) {
+	exporter, closeOutput, err := newExporter(conf)
+	if err != nil {
+		return nil, err
+	}
+	if conf.ServiceName != "" {
+		serviceName = conf.ServiceName
+	}
+	if serviceName == "" {
+		serviceName = "fiberhouse"
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	if err != nil {
+		return nil, err
+	}
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRatio))),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closeOutput != nil {
+			err = errors.Join(err, closeOutput())
+		}
+		return err
+	}, nil
+}
+
+func newExporter(conf Config) (exporter sdktrace.SpanExporter, closeOutput func() error, err error) {
+	switch conf.Exporter {
+	case ExporterStdout, "":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterFile:
+		if err = os.MkdirAll(filepath.Dir(conf.File), 0o755); err != nil {
+			return nil, nil, err
+		}
+		var f *os.File
+		if f, err = os.OpenFile(conf.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644); err != nil {
+			return nil, nil, err
+		}
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(f)); err != nil {
+			_ = f.Close()
+			return nil, nil, err
+		}
+		closeOutput = f.Close
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(conf.Endpoint)}
+		if conf.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(context.Background(), opts...)
+	case ExporterNone:
+	default:
+		err = fmt.Errorf("unsupported trace exporter: %s", conf.Exporter)
+	}
+	return exporter, closeOutput, err
+}
+
+// Tracer 返回框架埋点使用的 Tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// RecordError 记录错误并把 span 状态置为 Error，err 为 nil 时不做处理
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// InjectMap 把 ctx 中的链路上下文写入 headers，如 asynq 任务头部
+func InjectMap(ctx context.Context, headers map[string]string) {
+	if headers == nil {
+		return
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(headers))
+}
+
+// ExtractMap 从 headers 提取链路上下文到 ctx
+func ExtractMap(ctx context.Context, headers map[string]string) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers))
+}
@@ -0,0 +1,235 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+const upstreamTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// installRecorder 安装同步导出到内存的全局 TracerProvider，测试结束后恢复原全局对象
+func installRecorder(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+	return exporter
+}
+
+func spanNamed(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, s := range exporter.GetSpans() {
+		if s.Name == name {
+			return s
+		}
+	}
+	require.Failf(t, "span not found", "name=%s spans=%v", name, exporter.GetSpans().Snapshots())
+	return tracetest.SpanStub{}
+}
+
+func attrValue(attrs []attribute.KeyValue, key string) (attribute.Value, bool) {
+	for _, kv := range attrs {
+		if string(kv.Key) == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestSetup_FileExporterWritesSpansOnShutdown(t *testing.T) {
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+
+	file := filepath.Join(t.TempDir(), "nested", "trace.jsonl")
+	shutdown, err := Setup(Config{Exporter: ExporterFile, File: file, SampleRatio: 1}, "setup-test")
+	require.NoError(t, err)
+	_, span := Tracer().Start(context.Background(), "setup.span")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"setup.span"`)
+	assert.Contains(t, string(data), "setup-test")
+
+	_, err = Setup(Config{Exporter: "zipkin"}, "")
+	assert.EqualError(t, err, "unsupported trace exporter: zipkin")
+}
+
+func TestInjectExtractMap_RoundTrip(t *testing.T) {
+	installRecorder(t)
+	ctx, span := Tracer().Start(context.Background(), "producer")
+	defer span.End()
+
+	InjectMap(ctx, nil)
+	headers := map[string]string{}
+	InjectMap(ctx, headers)
+	require.Contains(t, headers, "traceparent")
+
+	extracted := trace.SpanContextFromContext(ExtractMap(context.Background(), headers))
+	assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+	assert.True(t, extracted.IsRemote())
+	assert.Equal(t, context.Background(), ExtractMap(context.Background(), nil))
+}
+
+func TestFiberMiddleware_ContinuesUpstreamTraceAndInjectsResponse(t *testing.T) {
+	exporter := installRecorder(t)
+	app := fiber.New()
+	app.Use(FiberMiddleware())
+	var handlerTraceID trace.TraceID
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		handlerTraceID = trace.SpanContextFromContext(c.UserContext()).TraceID()
+		return c.SendString(c.Params("id"))
+	})
+	app.Get("/boom", func(c *fiber.Ctx) error { return errors.New("boom") })
+
+	req := httptest.NewRequest(http.MethodGet, "/users/7", nil)
+	req.Header.Set("traceparent", upstreamTraceparent)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", handlerTraceID.String())
+	assert.Contains(t, resp.Header.Get("traceparent"), "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	server := spanNamed(t, exporter, "GET /users/:id")
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	route, _ := attrValue(server.Attributes, "http.route")
+	assert.Equal(t, "/users/:id", route.AsString())
+
+	_, err = app.Test(httptest.NewRequest(http.MethodGet, "/boom", nil))
+	require.NoError(t, err)
+	failed := spanNamed(t, exporter, "GET /boom")
+	assert.Equal(t, codes.Error, failed.Status.Code)
+	status, _ := attrValue(failed.Attributes, "http.response.status_code")
+	assert.Equal(t, int64(http.StatusInternalServerError), status.AsInt64())
+}
+
+func TestGinMiddleware_ContinuesUpstreamTraceAndInjectsResponse(t *testing.T) {
+	exporter := installRecorder(t)
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(GinMiddleware())
+	var handlerTraceID trace.TraceID
+	engine.GET("/users/:id", func(c *gin.Context) {
+		handlerTraceID = trace.SpanContextFromContext(c.Request.Context()).TraceID()
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/7", nil)
+	req.Header.Set("traceparent", upstreamTraceparent)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", handlerTraceID.String())
+	assert.Contains(t, rec.Header().Get("traceparent"), "4bf92f3577b34da6a3ce929d0e0e4736")
+	server := spanNamed(t, exporter, "GET /users/:id")
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	status, _ := attrValue(server.Attributes, "http.response.status_code")
+	assert.Equal(t, int64(http.StatusNoContent), status.AsInt64())
+}
+
+func TestRedisHook_CreatesClientSpansAndIgnoresNil(t *testing.T) {
+	exporter := installRecorder(t)
+	hook := RedisHook()
+
+	ctx, parent := Tracer().Start(context.Background(), "parent")
+	process := hook.ProcessHook(func(context.Context, redis.Cmder) error { return redis.Nil })
+	require.ErrorIs(t, process(ctx, redis.NewStringCmd(ctx, "get", "k")), redis.Nil)
+	pipeline := hook.ProcessPipelineHook(func(context.Context, []redis.Cmder) error { return errors.New("conn reset") })
+	require.Error(t, pipeline(ctx, []redis.Cmder{redis.NewStringCmd(ctx, "get", "a"), redis.NewStringCmd(ctx, "get", "b")}))
+	parent.End()
+
+	get := spanNamed(t, exporter, "redis get")
+	assert.Equal(t, trace.SpanKindClient, get.SpanKind)
+	assert.Equal(t, parent.SpanContext().SpanID(), get.Parent.SpanID())
+	assert.Equal(t, codes.Unset, get.Status.Code)
+
+	pipe := spanNamed(t, exporter, "redis pipeline")
+	assert.Equal(t, codes.Error, pipe.Status.Code)
+	size, _ := attrValue(pipe.Attributes, "db.operation.batch.size")
+	assert.Equal(t, int64(2), size.AsInt64())
+}
+
+type tracedUser struct {
+	ID   uint
+	Name string
+}
+
+func TestGormPlugin_WrapsCallbacksInSpans(t *testing.T) {
+	exporter := installRecorder(t)
+	db, err := gorm.Open(mysql.New(mysql.Config{DSN: "user:pass@tcp(127.0.0.1:0)/test", SkipInitializeWithVersion: true}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(GormPlugin()))
+
+	ctx, parent := Tracer().Start(context.Background(), "parent")
+	var user tracedUser
+	db.WithContext(ctx).Where("name = ?", "alice").First(&user)
+	db.WithContext(ctx).Create(&tracedUser{Name: "bob"})
+	parent.End()
+
+	query := spanNamed(t, exporter, "gorm.query")
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent.SpanID())
+	table, _ := attrValue(query.Attributes, "db.collection.name")
+	assert.Equal(t, "traced_users", table.AsString())
+	sql, _ := attrValue(query.Attributes, "db.query.text")
+	assert.Contains(t, sql.AsString(), "WHERE name = ?")
+	assert.NotContains(t, sql.AsString(), "alice")
+	system, _ := attrValue(query.Attributes, "db.system.name")
+	assert.Equal(t, "mysql", system.AsString())
+
+	create := spanNamed(t, exporter, "gorm.create")
+	assert.Equal(t, codes.Unset, create.Status.Code)
+}
+
+func TestMongoMonitor_PairsStartedWithFinishedEvents(t *testing.T) {
+	exporter := installRecorder(t)
+	monitor := MongoMonitor()
+	command, err := bson.Marshal(bson.D{{Key: "find", Value: "users"}, {Key: "filter", Value: bson.D{}}})
+	require.NoError(t, err)
+
+	ctx, parent := Tracer().Start(context.Background(), "parent")
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: command, DatabaseName: "app", CommandName: "find", RequestID: 1})
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: command, DatabaseName: "app", CommandName: "insert", RequestID: 2})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 1}})
+	monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 2}, Failure: errors.New("duplicate key")})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 3}})
+	parent.End()
+
+	require.Len(t, exporter.GetSpans(), 3)
+	find := spanNamed(t, exporter, "mongodb find")
+	assert.Equal(t, parent.SpanContext().SpanID(), find.Parent.SpanID())
+	coll, _ := attrValue(find.Attributes, "db.collection.name")
+	assert.Equal(t, "users", coll.AsString())
+	assert.Equal(t, codes.Error, spanNamed(t, exporter, "mongodb insert").Status.Code)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package validate
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/lamxy/fiberhouse/appconfig"
+)
+
+// ConfigStructValidator 返回用于 appconfig.Bind 的配置结构体校验器
+//
+// 复用英文验证器与翻译器，字段名取配置 key 名（koanf 标签），校验消息为英文翻译，如 "dsn is a required field"。
+func ConfigStructValidator() appconfig.StructValidator {
+	en := NewEnValidate()
+	en.validator.RegisterTagNameFunc(appconfig.ConfigFieldName)
+	return func(schema interface{}) []appconfig.SchemaIssue {
+		err := en.validator.Struct(schema)
+		if err == nil {
+			return nil
+		}
+		var errs validator.ValidationErrors
+		if !errors.As(err, &errs) {
+			return []appconfig.SchemaIssue{{Message: err.Error()}}
+		}
+		issues := make([]appconfig.SchemaIssue, 0, len(errs))
+		for _, fe := range errs {
+			issues = append(issues, appconfig.SchemaIssue{
+				Key:     appconfig.ValidationKey(fe.Namespace()),
+				Message: fe.Translate(en.translator),
+			})
+		}
+		return issues
+	}
+}
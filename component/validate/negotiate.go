@@ -0,0 +1,19 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package validate
+
+import "github.com/lamxy/fiberhouse/component/i18n"
+
+// NegotiateLang 按 Accept-Language 请求头从 supported 中选出验证消息语言
+//
+// 协商规则见 i18n.Negotiate：按 q 值从高到低依次匹配，每个语言标签先精确匹配再以主语言子标签匹配，
+// 如 en-US 匹配 en、zh 匹配 zh-cn。全部未命中时返回 DefaultLang。
+//
+//	lang := validate.NegotiateLang(c.GetHeader("Accept-Language"), vw.GetLangList())
+func NegotiateLang(acceptLanguage string, supported []LangFlag) LangFlag {
+	return i18n.Negotiate(acceptLanguage, supported, DefaultLang)
+}
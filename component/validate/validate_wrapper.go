@@ -11,6 +11,7 @@ import (
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/component/i18n"
 	"github.com/lamxy/fiberhouse/exception"
 	"github.com/samber/lo"
 	"strings"
@@ -24,6 +25,7 @@ type ValidateWrapper interface {
 	GetTranslators() map[string]ut.Translator
 	RegisterCustomTags(tagRegisters []RegisterValidatorTagFunc) []error
 	GetLangList() []LangFlag
+	Translate(fe validator.FieldError, lang LangFlag) string
 	Errors(errs validator.ValidationErrors, lang LangFlag, snakeCase ...bool) *exception.ValidateException
 	ErrorsVar(errs validator.ValidationErrors, varName string, lang LangFlag, snakeCase ...bool) *exception.ValidateException
 	ErrorsMap(errsMap map[string]interface{}, lang LangFlag, snakeCase ...bool) *exception.ValidateException
@@ -50,6 +52,7 @@ type Wrap struct {
 	validators map[LangFlag]*validator.Validate `desc:"map for lang to validator"`
 	langList   []LangFlag                       `desc:"language list"`
 	transMap   map[LangFlag]ut.Translator       `desc:"language sign for translator"`
+	catalog    *i18n.Catalog                    `desc:"message catalog for validate.<tag> messages"`
 }
 
 // NewWrap 创建并初始化验证器包装器，根据配置文件语言标志设置对应的验证器和翻译器，未设置则使用默认英文。
@@ -58,6 +61,7 @@ func NewWrap(cfg appconfig.IAppConfig) *Wrap {
 		validators: make(map[string]*validator.Validate),
 		langList:   []LangFlag{},
 		transMap:   make(map[string]ut.Translator),
+		catalog:    i18n.Default(),
 	}
 
 	// 获取配置文件中设置的语言标志列表
@@ -147,6 +151,52 @@ func (vw *Wrap) RegisterCustomTags(tagRegisters []RegisterValidatorTagFunc) []er
 	return nil
 }
 
+// MessageKeyPrefix 验证消息在 i18n 消息目录中的键前缀，完整的键为 MessageKeyPrefix + tag
+const MessageKeyPrefix = "validate."
+
+// SetCatalog 设置查找验证消息的消息目录，nil 表示只使用翻译器；NewWrap 缺省使用 i18n.Default()
+func (vw *Wrap) SetCatalog(catalog *i18n.Catalog) {
+	vw.catalog = catalog
+}
+
+// GetCatalog 获取查找验证消息的消息目录
+func (vw *Wrap) GetCatalog() *i18n.Catalog {
+	return vw.catalog
+}
+
+// Translate 返回单个字段错误在 lang 语言下的消息
+//
+// 依次取消息目录中 lang 语言键为 "validate.<tag>" 的消息、lang 翻译器中注册的翻译、消息目录按回退链查找到的消息，
+// 都没有时为 validator 的原始错误文本。目录消息可使用 {field}、{param}、{tag}、{value} 占位符，
+// 因此自定义 tag 的多语言提示可以写在消息文件中，而不必逐语言调用 RegisterTranslation。
+func (vw *Wrap) Translate(fe validator.FieldError, lang LangFlag) string {
+	key := MessageKeyPrefix + fe.Tag()
+	if vw.catalog != nil {
+		if msg, ok := vw.catalog.LookupLang(lang, key); ok {
+			return i18n.Format(msg[i18n.PluralOther], fieldErrorArgs(fe))
+		}
+	}
+	if text := fe.Translate(vw.GetTranslator(lang)); text != fe.Error() {
+		return text
+	}
+	if vw.catalog != nil {
+		if msg, ok := vw.catalog.Message(lang, key, fieldErrorArgs(fe)); ok {
+			return msg
+		}
+	}
+	return fe.Error()
+}
+
+// fieldErrorArgs 验证消息模板的插值参数
+func fieldErrorArgs(fe validator.FieldError) map[string]interface{} {
+	return map[string]interface{}{
+		"field": fe.Field(),
+		"param": fe.Param(),
+		"tag":   fe.Tag(),
+		"value": fe.Value(),
+	}
+}
+
 // GetDefaultLang 获取默认语言
 func GetDefaultLang() LangFlag {
 	return DefaultLang
@@ -172,13 +222,13 @@ func (vw *Wrap) Errors(errs validator.ValidationErrors, lang LangFlag, snakeCase
 	if len(snakeCase) > 0 && snakeCase[0] {
 		for i := range errs {
 			// 蛇形风格命名
-			key, val := errs[i].StructField(), errs[i].Translate(vw.GetTranslator(lang))
+			key, val := errs[i].StructField(), vw.Translate(errs[i], lang)
 			errMap[lo.SnakeCase(key)] = strings.Replace(val, key, lo.CamelCase(key), 1)
 		}
 	} else {
 		for i := range errs {
 			// 驼峰风格命名
-			key, val := errs[i].StructField(), errs[i].Translate(vw.GetTranslator(lang))
+			key, val := errs[i].StructField(), vw.Translate(errs[i], lang)
 			errMap[lo.CamelCase(key)] = strings.Replace(val, key, lo.CamelCase(key), 1)
 		}
 	}
@@ -207,7 +257,7 @@ func (vw *Wrap) ErrorsVar(errs validator.ValidationErrors, varName string, lang
 			if key == "" {
 				key = varName
 			}
-			val := errs[i].Translate(vw.GetTranslator(lang))
+			val := vw.Translate(errs[i], lang)
 			errMap[lo.SnakeCase(key)] = lo.CamelCase(key) + " " + val
 		}
 	} else {
@@ -217,7 +267,7 @@ func (vw *Wrap) ErrorsVar(errs validator.ValidationErrors, varName string, lang
 			if key == "" {
 				key = varName
 			}
-			val := errs[i].Translate(vw.GetTranslator(lang))
+			val := vw.Translate(errs[i], lang)
 			errMap[lo.CamelCase(key)] = lo.CamelCase(key) + " " + val
 		}
 	}
@@ -252,7 +302,7 @@ func (vw *Wrap) ErrorsMap(errsMap map[string]interface{}, lang LangFlag, snakeCa
 					if key == "" {
 						key = field
 					}
-					val := vErrs[i].Translate(vw.GetTranslator(lang))
+					val := vw.Translate(vErrs[i], lang)
 					outMap[lo.SnakeCase(key)] = lo.CamelCase(key) + " " + val
 				}
 			} else {
@@ -262,7 +312,7 @@ func (vw *Wrap) ErrorsMap(errsMap map[string]interface{}, lang LangFlag, snakeCa
 					if key == "" {
 						key = field
 					}
-					val := vErrs[i].Translate(vw.GetTranslator(lang))
+					val := vw.Translate(vErrs[i], lang)
 					outMap[lo.CamelCase(key)] = lo.CamelCase(key) + " " + val
 				}
 			}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/component/i18n"
 	"github.com/lamxy/fiberhouse/constant"
 	"github.com/lamxy/fiberhouse/exception"
 	"github.com/lamxy/fiberhouse/globalmanager"
@@ -93,6 +94,44 @@ func TestValidate_StructVarAndMapTranslations(t *testing.T) {
 	}
 }
 
+func TestValidate_CatalogMessagesForCustomAndBuiltinTags(t *testing.T) {
+	installValidateTestExceptions(t)
+	w := newValidateTestWrap(LangEn, LangZhCN, LangZhTW)
+	w.SetCatalog(i18n.NewCatalog(LangEn).
+		Add(LangEn, map[string]string{"validate.evens": "{field} must hold an even number, got {value}"}).
+		Add(LangZhCN, map[string]string{"validate.evens": "{field} 必须为偶数", "validate.required": "请填写 {field}"}))
+	for _, v := range w.GetValidators() {
+		require.NoError(t, v.RegisterValidation("evens", func(fl validator.FieldLevel) bool { return fl.Field().Int()%2 == 0 }))
+	}
+	type request struct {
+		Count    int    `validate:"evens"`
+		UserName string `validate:"required"`
+	}
+
+	cases := map[LangFlag]map[string]string{
+		// 目录中的英文消息与内置英文翻译
+		LangEn: {"count": "count must hold an even number, got 3", "user_name": "userName is a required field"},
+		// 目录中的简体消息优先于内置翻译
+		LangZhCN: {"count": "count 必须为偶数", "user_name": "请填写 userName"},
+		// 繁体：内置翻译优先于回退到简体的目录消息，自定义 tag 沿 zh-tw → zh-cn 回退
+		LangZhTW: {"count": "count 必须为偶数", "user_name": "userName為必填欄位"},
+	}
+	for lang, want := range cases {
+		err := w.GetValidate(lang).Struct(request{Count: 3})
+		var validationErrors validator.ValidationErrors
+		require.ErrorAs(t, err, &validationErrors)
+		translated := w.Errors(validationErrors, lang, true)
+		assert.Equal(t, want, map[string]string(translated.Data.(map[string]string)), lang)
+		translated.Release()
+	}
+
+	w.SetCatalog(nil)
+	err := w.GetValidate(LangEn).Var(3, "evens")
+	var validationErrors validator.ValidationErrors
+	require.ErrorAs(t, err, &validationErrors)
+	assert.Equal(t, validationErrors[0].Error(), w.Translate(validationErrors[0], LangEn))
+}
+
 func TestValidate_RegisterCustomTagsAggregatesRealRegistryErrors(t *testing.T) {
 	w := newValidateTestWrap()
 	translator := w.GetTranslator()
@@ -178,3 +217,40 @@ func TestValidate_EnglishFallbackAlwaysAvailableWhenLangFlagsAllUnrecognized(t *
 	require.NotNil(t, w.GetValidate(), "GetValidate() with no lang arg must fall back to a non-nil English validator")
 	require.NotNil(t, w.GetTranslator(), "GetTranslator() with no lang arg must fall back to a non-nil English translator")
 }
+
+func TestValidate_ConfigStructValidatorUsesConfigKeys(t *testing.T) {
+	type pool struct {
+		Size int `koanf:"size" validate:"gt=0"`
+	}
+	type conf struct {
+		DSN  string `koanf:"dsn" validate:"required"`
+		Pool pool   `koanf:"pool"`
+	}
+	appconfig.SetStructValidator(ConfigStructValidator())
+	t.Cleanup(func() { appconfig.SetStructValidator(nil) })
+
+	cfg := appconfig.NewAppConfig().LoadDefault(map[string]interface{}{"db.pool.size": 0})
+	_, err := appconfig.Bind[conf](cfg, "db")
+	require.Error(t, err)
+	assert.Equal(t, "invalid config:\n  - db.dsn: dsn is a required field\n  - db.pool.size: size must be greater than 0", err.Error())
+}
+
+func TestNegotiateLang_QualityAndPrimarySubtag(t *testing.T) {
+	supported := []LangFlag{LangEn, LangZhCN, LangZhTW}
+	cases := map[string]LangFlag{
+		"":                          DefaultLang,
+		"zh-TW":                     LangZhTW,
+		"zh-CN;q=0.5, zh-TW;q=0.9":  LangZhTW,
+		"fr-FR, en-US;q=0.8":        LangEn,
+		"zh":                        LangZhCN,
+		"zh_CN":                     LangZhCN,
+		"zh-CN;q=0, en;q=0.1":       LangEn,
+		"fr, de;q=0.9":              DefaultLang,
+		"*":                         DefaultLang,
+		"en;q=invalid, zh-tw;q=0.5": LangEn,
+	}
+	for header, want := range cases {
+		assert.Equal(t, want, NegotiateLang(header, supported), header)
+	}
+	assert.Equal(t, DefaultLang, NegotiateLang("zh-CN", nil))
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/lamxy/fiberhouse/appconfig"
+)
+
+// PrintConfigFlag 打印配置并退出的命令行参数
+const PrintConfigFlag = "--print-config"
+
+// PrintConfigRequested 命令行参数中是否包含 PrintConfigFlag
+func PrintConfigRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == PrintConfigFlag {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintConfig 打印配置来源优先级与合并后的全部配置项，敏感值已脱敏，见 appconfig.Dump
+func (fh *FiberHouse) PrintConfig(w io.Writer) error {
+	_, cfg, _ := fh.resolveGlobalTools()
+	return appconfig.Dump(cfg, w)
+}
+
+// Providers-----------------------------------------------------------------------------------
+
+// IConfigSourceProvider 配置来源提供者接口，Initialize 返回 appconfig.Source
+type IConfigSourceProvider interface {
+	IProvider
+	// Priority 配置来源优先级，数值大的来源覆盖数值小的来源
+	Priority() appconfig.SourcePriority
+}
+
+// ConfigSourceProvider 配置来源提供者，提供者名称为来源名称
+type ConfigSourceProvider struct {
+	IProvider
+	source   appconfig.Source
+	priority appconfig.SourcePriority
+}
+
+// NewConfigSourceProvider 创建配置来源提供者
+//
+// 常用优先级：appconfig.PriorityFile（JSON/TOML 文件）、appconfig.PriorityConfD（conf.d 目录）、appconfig.PriorityRemote（HTTP、KV）。
+func NewConfigSourceProvider(source appconfig.Source, priority appconfig.SourcePriority) *ConfigSourceProvider {
+	p := &ConfigSourceProvider{
+		IProvider: NewProvider().
+			SetName(source.Name()).
+			SetType(ProviderTypeDefault().GroupConfigSourceType),
+		source:   source,
+		priority: priority,
+	}
+	p.MountToParent(p)
+	return p
+}
+
+// Priority 配置来源优先级
+func (p *ConfigSourceProvider) Priority() appconfig.SourcePriority {
+	return p.priority
+}
+
+// Initialize 返回配置来源
+func (p *ConfigSourceProvider) Initialize(ctx IContext, initFunc ...ProviderInitFunc) (any, error) {
+	return p.source, nil
+}
+
+// PManager-----------------------------------------------------------------------------------
+
+// ConfigSourcePManager 配置来源管理器
+// 绑定到引导配置位置点（位于提供者注册之后、其他管理器加载之前），按优先级记录已分发的配置来源并 Reload 一次使其生效
+type ConfigSourcePManager struct {
+	IProviderManager
+	mu    sync.Mutex
+	added map[string]struct{}
+}
+
+// NewConfigSourcePManager 创建配置来源管理器
+func NewConfigSourcePManager(ctx IContext) *ConfigSourcePManager {
+	m := &ConfigSourcePManager{
+		IProviderManager: NewProviderManager(ctx).
+			SetName("ConfigSourcePManager").
+			SetType(ProviderTypeDefault().GroupConfigSourceType),
+		added: make(map[string]struct{}),
+	}
+	m.MountToParent(m).SetOrBindToLocation(ProviderLocationDefault().LocationBootStrapConfig, true)
+	return m
+}
+
+// MountToParent 挂载到父级管理器
+func (m *ConfigSourcePManager) MountToParent(son ...IProviderManager) IProviderManager {
+	if len(son) > 0 {
+		m.IProviderManager.MountToParent(son[0])
+		return m
+	}
+	m.IProviderManager.MountToParent(m)
+	return m
+}
+
+// LoadProvider 按优先级（相同优先级按名称）记录配置来源并重新加载配置
+//
+// 新配置同样经过热更新校验器（含启动期结构校验），任一来源读取失败或校验失败时当前配置保持不变并返回错误。
+// 已记录的来源不重复记录，之后的 Reload 会重新读取全部来源。
+func (m *ConfigSourcePManager) LoadProvider(loadFunc ...ProviderLoadFunc) (any, error) {
+	m.Check()
+	appCtx, ok := m.GetContext().(IApplicationContext)
+	if !ok || appCtx == nil {
+		return nil, fmt.Errorf("context is not IApplicationContext")
+	}
+	rc, ok := appCtx.GetConfig().(appconfig.IReloadableConfig)
+	if !ok {
+		return nil, fmt.Errorf("config does not implement appconfig.IReloadableConfig")
+	}
+
+	providers := make([]IConfigSourceProvider, 0, len(m.List()))
+	for _, provider := range m.List() {
+		p, ok := provider.(IConfigSourceProvider)
+		if !ok {
+			return nil, fmt.Errorf("provider '%s' does not implement IConfigSourceProvider", provider.Name())
+		}
+		providers = append(providers, p)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		if providers[i].Priority() != providers[j].Priority() {
+			return providers[i].Priority() < providers[j].Priority()
+		}
+		return providers[i].Name() < providers[j].Name()
+	})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var removes []func()
+	for _, p := range providers {
+		if _, exists := m.added[p.Name()]; exists {
+			continue
+		}
+		result, err := m.InitializeProvider(p)
+		if err != nil {
+			for _, remove := range removes {
+				remove()
+			}
+			return nil, err
+		}
+		source, ok := result.(appconfig.Source)
+		if !ok {
+			for _, remove := range removes {
+				remove()
+			}
+			return nil, fmt.Errorf("provider '%s' did not return an appconfig.Source", p.Name())
+		}
+		removes = append(removes, rc.AddSource(source, p.Priority()))
+	}
+	if len(removes) == 0 {
+		return rc, nil
+	}
+	if err := rc.Reload(); err != nil {
+		for _, remove := range removes {
+			remove()
+		}
+		return nil, err
+	}
+	for _, p := range providers {
+		m.added[p.Name()] = struct{}{}
+	}
+	appCtx.GetLogger().InfoWith(rc.LogOriginFrame()).Int("sources", len(removes)).Msg("config sources loaded")
+	return rc, nil
+}
@@ -0,0 +1,66 @@
+package fiberhouse
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintConfigRequested(t *testing.T) {
+	assert.True(t, PrintConfigRequested([]string{"-x", PrintConfigFlag}))
+	assert.False(t, PrintConfigRequested([]string{"--print-configs"}))
+	assert.False(t, PrintConfigRequested(nil))
+}
+
+func TestConfigSourcePManager_LoadsSourcesByPriority(t *testing.T) {
+	isolateTask7ProviderGlobals(t)
+	ctx := newTask4InternalAppContext(t, map[string]interface{}{
+		"cache.redis.host":     "default",
+		"cache.redis.password": "secret",
+	})
+	manager := NewConfigSourcePManager(ctx)
+	assert.Same(t, ProviderLocationDefault().LocationBootStrapConfig, manager.Location())
+	assert.Contains(t, ProviderLocationDefault().LocationBootStrapConfig.GetManagers(), IProviderManager(manager))
+
+	store := appconfig.NewMemoryKVStore()
+	store.Put("app/cache/redis/host", "kv")
+	dir := t.TempDir()
+	confD := appconfig.NewDirSource(dir)
+	require.NoError(t, manager.Register(NewConfigSourceProvider(appconfig.NewKVSource(store, "app/"), appconfig.PriorityRemote)))
+	require.NoError(t, manager.Register(NewConfigSourceProvider(confD, appconfig.PriorityConfD)))
+
+	result, err := manager.LoadProvider()
+	require.NoError(t, err)
+	rc := result.(appconfig.IReloadableConfig)
+	assert.Equal(t, "kv", rc.String("cache.redis.host"))
+
+	layers := ctx.GetConfig().(appconfig.ILayeredConfig).Layers()
+	require.Len(t, layers, 3)
+	assert.Equal(t, confD.Name(), layers[1].Name)
+	assert.Equal(t, "kv:app/", layers[2].Name)
+
+	// 重复加载不重复记录来源
+	_, err = manager.LoadProvider()
+	require.NoError(t, err)
+	assert.Len(t, ctx.GetConfig().(appconfig.ILayeredConfig).Layers(), 3)
+
+	var buf bytes.Buffer
+	require.NoError(t, appconfig.Dump(ctx.GetConfig(), &buf))
+	assert.Contains(t, buf.String(), `cache.redis.host = "kv"  # kv:app/`)
+	assert.Contains(t, buf.String(), `cache.redis.password = "******"`)
+}
+
+func TestConfigSourcePManager_FailedSourceKeepsCurrentConfig(t *testing.T) {
+	isolateTask7ProviderGlobals(t)
+	ctx := newTask4InternalAppContext(t, map[string]interface{}{"cache.redis.host": "default"})
+	manager := NewConfigSourcePManager(ctx)
+	require.NoError(t, manager.Register(NewConfigSourceProvider(appconfig.NewFileSource(t.TempDir()+"/missing.json"), appconfig.PriorityFile)))
+
+	_, err := manager.LoadProvider()
+	require.Error(t, err)
+	assert.Equal(t, "default", ctx.GetConfig().(appconfig.IReloadableConfig).String("cache.redis.host"))
+	assert.Len(t, ctx.GetConfig().(appconfig.ILayeredConfig).Layers(), 1)
+}
@@ -58,7 +58,12 @@ const (
 	FrameTypeWithDefaultFrameStarter = "DefaultFrameStarter"
 	CoreTypeWithFiber                = "fiber"
 	CoreTypeWithGin                  = "gin"
+	CoreTypeWithHertz                = "hertz"
 	TrafficCodecWithStd              = "std_json_codec"
 	TrafficCodecWithSonic            = "sonic_json_codec"
 	TrafficCodecWithGoJson           = "go_json_codec"
+
+	// 错误响应信封类型标识
+	ErrorEnvelopeWithRespInfo = "respinfo"
+	ErrorEnvelopeWithProblem  = "problem"
 )
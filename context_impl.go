@@ -12,6 +12,7 @@ import (
 	"github.com/lamxy/fiberhouse/appconfig"
 	"github.com/lamxy/fiberhouse/bootstrap"
 	"github.com/lamxy/fiberhouse/component/container"
+	"github.com/lamxy/fiberhouse/component/i18n"
 	"github.com/lamxy/fiberhouse/component/validate"
 	"github.com/lamxy/fiberhouse/constant"
 	"github.com/lamxy/fiberhouse/globalmanager"
@@ -38,6 +39,7 @@ type AppContext struct {
 	appState     bool
 	appStateOnce sync.Once
 	vw           *validate.Wrap
+	langResolver *i18n.Resolver
 	storage      map[string]interface{}
 	lock         sync.RWMutex
 	bootCfg      *BootConfig
@@ -54,6 +56,7 @@ func NewAppContext(cfg appconfig.IAppConfig, logger bootstrap.LoggerWrapper) IAp
 		appState:     false,
 		appStateOnce: sync.Once{},
 		vw:           validate.NewWrap(cfg),
+		langResolver: i18n.NewResolver(cfg),
 	}
 }
 
@@ -134,6 +137,11 @@ func (c *AppContext) GetContainer() *globalmanager.GlobalManager {
 	return c.container
 }
 
+// GetLangResolver 获取请求语言解析器
+func (c *AppContext) GetLangResolver() *i18n.Resolver {
+	return c.langResolver
+}
+
 // GetValidateWrap 获取全局验证包装器
 func (c *AppContext) GetValidateWrap() validate.ValidateWrapper {
 	return c.vw
@@ -10,6 +10,7 @@ import (
 	"github.com/lamxy/fiberhouse/appconfig"
 	"github.com/lamxy/fiberhouse/bootstrap"
 	"github.com/lamxy/fiberhouse/component/container"
+	"github.com/lamxy/fiberhouse/component/i18n"
 	"github.com/lamxy/fiberhouse/component/validate"
 	"github.com/lamxy/fiberhouse/globalmanager"
 	"github.com/rs/zerolog"
@@ -48,6 +49,8 @@ type IApplicationContext interface {
 	GetBootConfig() *BootConfig
 	// RegisterBootConfig 注册启动配置
 	RegisterBootConfig(bc *BootConfig)
+	// GetLangResolver 获取按 application.i18n 配置解析请求语言的解析器
+	GetLangResolver() *i18n.Resolver
 }
 
 // IApplicationContext 框架命令行应用上下文接口
@@ -7,12 +7,23 @@
 package fiberhouse
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofiber/contrib/fiberzerolog"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
 	adaptorerrorhandler "github.com/lamxy/fiberhouse/adaptor/errorhandler"
+	"github.com/lamxy/fiberhouse/component/health"
+	"github.com/lamxy/fiberhouse/component/metrics"
+	"github.com/lamxy/fiberhouse/component/tracing"
+	"github.com/lamxy/fiberhouse/globalmanager"
 	"github.com/rs/zerolog"
 )
 
@@ -22,6 +33,15 @@ type CoreWithFiber struct {
 	CoreCfg *fiber.Config
 	coreApp *fiber.App
 	json    JsonWrapper
+	// globalsCloseErr 关闭钩子中全局对象关闭的聚合错误，由 Shutdown 返回
+	globalsCloseErr error
+	// inflight 在途请求计数，coordinator 为 Shutdown 中正在执行的关闭协调器
+	inflight    inflightCounter
+	coordinator *shutdownCoordinator
+	// listeners application.listeners 配置的附加监听器，各自持有独立的 fiber.App
+	listeners listenerGroup
+	// tls 主监听器的 TLS 证书热加载状态，未启用 TLS 时为空；AppCoreRun 写入、Shutdown 读取
+	tls atomic.Pointer[serverTLS]
 }
 
 // NewCoreWithFiber 创建一个应用核心启动器对象
@@ -49,6 +69,19 @@ func (cf *CoreWithFiber) GetCoreApp() interface{} {
 	return cf.coreApp
 }
 
+// GetListenerApp 获取附加监听器的 *fiber.App，未配置时返回 nil
+func (cf *CoreWithFiber) GetListenerApp(name string) interface{} {
+	if app, ok := cf.listeners.app(name); ok {
+		return app
+	}
+	return nil
+}
+
+// ListenerNames 获取已配置的附加监听器名称
+func (cf *CoreWithFiber) ListenerNames() []string {
+	return cf.listeners.names()
+}
+
 // InitCoreApp 初始化应用核心（框架应用基于 fiber.App）
 func (cf *CoreWithFiber) InitCoreApp(fs FrameStarter, managers ...IProviderManager) {
 	if cf.GetAppContext().GetAppState() {
@@ -81,6 +114,7 @@ func (cf *CoreWithFiber) InitCoreApp(fs FrameStarter, managers ...IProviderManag
 		if fs != nil {
 			cf.json = cf.resolveJSONCodec(fs, managers...)
 		}
+		cf.initListeners()
 		return
 	}
 
@@ -99,6 +133,9 @@ func (cf *CoreWithFiber) InitCoreApp(fs FrameStarter, managers ...IProviderManag
 		StrictRouting: cfg.Bool("application.server.strictRouting"),
 		// 设置服务器头部信息
 		ServerHeader: cfg.String("application.server.appServerHeader"),
+		// 设置读取客户端 IP 的代理头，为空时取连接对端地址；开启校验以从 X-Forwarded-For 列表中取第一个合法 IP
+		ProxyHeader:        cfg.String("application.server.proxyHeader"),
+		EnableIPValidation: true,
 		// 设置自定义错误处理函数
 		// 该函数会在请求处理过程中发生错误时被调用
 		ErrorHandler: adaptorerrorhandler.FiberErrorHandler(eh.ErrorHandler),
@@ -132,6 +169,30 @@ func (cf *CoreWithFiber) InitCoreApp(fs FrameStarter, managers ...IProviderManag
 		StreamRequestBody: cfg.Bool("application.server.streamRequestBody"), // 默认false
 		// more...
 	})
+	cf.initListeners()
+}
+
+// initListeners 按 application.listeners 配置为每个附加监听器创建独立的 fiber.App，沿用主应用配置
+func (cf *CoreWithFiber) initListeners() {
+	confs, err := loadListenerConfigs(cf.GetAppContext().GetConfig())
+	if err != nil {
+		cf.GetAppContext().GetLogger().ErrorWith(cf.GetAppContext().GetConfig().LogOriginFrame()).Err(err).Msg("bind listeners config failed")
+		cf.listeners.fail(err)
+		return
+	}
+	for _, conf := range confs {
+		config := cf.coreApp.Config()
+		config.DisableStartupMessage = true
+		config.EnablePrintRoutes = false
+		config.Prefork = false
+		app := fiber.New(config)
+		cf.listeners.servers = append(cf.listeners.servers, &listenerServer{
+			conf:     conf,
+			app:      app,
+			serve:    app.Listener,
+			shutdown: app.ShutdownWithContext,
+		})
+	}
 }
 
 // resolveJSONCodec 解析JSON编解码器实例。
@@ -223,9 +284,28 @@ func (cf *CoreWithFiber) RegisterAppMiddleware(fs FrameStarter, managers ...IPro
 		Logger:            cf.GetAppContext().GetLogger(),
 		Stdout:            false,
 		JsonCodec:         cf.json.Marshal,
-		DebugMode:         debugMode, // true开启调试模式，将详细错误信息显示给客户端，否则隐藏细节，只能通过日志文件查看。生产环境关闭该调式模式。
+		DebugMode:         debugMode,                                                                    // true开启调试模式，将详细错误信息显示给客户端，否则隐藏细节，只能通过日志文件查看。生产环境关闭该调式模式。
+		DebugModeFunc:     func() bool { return cf.GetAppContext().GetConfig().GetRecover().DebugMode }, // 配置热更新后按新的调试模式响应
 	})
 
+	// 注册在途请求计数中间件，关闭时据此报告被截断的请求
+	cf.coreApp.Use(cf.inflightMiddleware())
+
+	// 注册请求作用域中间件，位于最外层，确保 panic 恢复后仍释放作用域
+	cf.coreApp.Use(cf.requestScopeMiddleware())
+
+	// 注册附加监听器的独立中间件链
+	cf.registerListenerMiddleware(MustRecoverMiddleware[fiber.Handler](recoverHandler))
+
+	// 注册链路追踪中间件，服务端 span 覆盖指标、恢复与业务处理
+	cf.registerTracing()
+
+	// 注册请求指标中间件与指标端点，位于恢复中间件之外，panic 请求按恢复后的状态码记录
+	cf.registerMetrics()
+
+	// 注册健康、就绪与存活探针路由，位于恢复与请求日志中间件之外
+	cf.registerHealth()
+
 	// 注册核心应用(coreApp/fiber App)全局错误捕获中间件
 	cf.coreApp.Use(MustRecoverMiddleware[fiber.Handler](recoverHandler))
 
@@ -252,6 +332,86 @@ func (cf *CoreWithFiber) RegisterAppMiddleware(fs FrameStarter, managers ...IPro
 	}
 }
 
+// registerListenerMiddleware 为附加监听器注册在途计数、请求作用域与恢复中间件，按配置挂载 pprof 路由；不注册请求日志与指标中间件
+func (cf *CoreWithFiber) registerListenerMiddleware(recoverHandler fiber.Handler) {
+	for _, s := range cf.listeners.servers {
+		app := s.app.(*fiber.App)
+		app.Use(cf.inflightMiddleware(), cf.requestScopeMiddleware(), recoverHandler)
+		if s.conf.Pprof {
+			app.Use(pprof.New())
+		}
+	}
+}
+
+// listenerRouter 返回挂载内置端点的路由器，name 为空时为主应用；未配置的监听器记录错误并由 AppCoreRun 返回
+func (cf *CoreWithFiber) listenerRouter(name string) (fiber.Router, bool) {
+	if name == "" {
+		return cf.coreApp, true
+	}
+	if app, ok := cf.listeners.app(name); ok {
+		return app.(*fiber.App), true
+	}
+	err := fmt.Errorf("listener %s is not configured in %s", name, ListenersConfPath)
+	cf.GetAppContext().GetLogger().ErrorWith(cf.GetAppContext().GetConfig().LogOriginFrame()).Err(err).Msg("mount endpoint failed")
+	cf.listeners.fail(err)
+	return nil, false
+}
+
+// registerTracing application.trace.enable 为 true 时注册链路追踪中间件
+func (cf *CoreWithFiber) registerTracing() {
+	if cf.GetAppContext().GetConfig().Bool("application.trace.enable") {
+		cf.coreApp.Use(tracing.FiberMiddleware())
+	}
+}
+
+// registerHealth 按 application.health 配置注册 /healthz、/readyz、/livez 路由
+func (cf *CoreWithFiber) registerHealth() {
+	conf, ok := healthEndpoints(cf.GetAppContext())
+	if !ok {
+		return
+	}
+	router, ok := cf.listenerRouter(conf.Listener)
+	if !ok {
+		return
+	}
+	registry := health.Default()
+	router.Get(conf.HealthPath, registry.FiberHandler(health.ProbeHealth))
+	router.Get(conf.ReadyPath, registry.FiberHandler(health.ProbeReady))
+	router.Get(conf.LivePath, registry.FiberHandler(health.ProbeLive))
+}
+
+// registerMetrics 按 application.metrics 配置注册请求指标中间件与指标端点
+func (cf *CoreWithFiber) registerMetrics() {
+	path, listener, ok := metricsEndpoint(cf.GetAppContext().GetConfig())
+	if !ok {
+		return
+	}
+	cf.coreApp.Use(metrics.FiberMiddleware(path))
+	if router, ok := cf.listenerRouter(listener); ok {
+		router.Get(path, metrics.FiberHandler())
+	}
+}
+
+// inflightMiddleware 在途请求计数中间件
+func (cf *CoreWithFiber) inflightMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		cf.inflight.add()
+		defer cf.inflight.done()
+		return c.Next()
+	}
+}
+
+// requestScopeMiddleware 请求作用域中间件，为每个请求挂载延迟创建的 GlobalManager 子作用域，处理返回后释放
+func (cf *CoreWithFiber) requestScopeMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scope := globalmanager.NewLazyScope(cf.GetAppContext().GetContainer())
+		c.Locals(globalmanager.ScopeLocalsKey, scope)
+		c.SetUserContext(globalmanager.ContextWithScope(c.UserContext(), scope))
+		defer releaseRequestScope(cf.GetAppContext(), scope)
+		return c.Next()
+	}
+}
+
 // RegisterModuleInitialize 注册应用模块/子系统级的中间件、路由处理器、etc...
 func (cf *CoreWithFiber) RegisterModuleInitialize(fs FrameStarter, managers ...IProviderManager) {
 	if cf.GetAppContext().GetAppState() {
@@ -357,7 +517,14 @@ func (cf *CoreWithFiber) RegisterAppHooks(fs FrameStarter, managers ...IProvider
 	cf.coreApp.Hooks().OnShutdown(func() error {
 		// 应用Shutdown时回调，回收/关闭相关资源，如后台程序(等待关闭信号)、异步任务(等待关闭信号)、连接池（关闭连接池）、中间件（封装实现Closable接口）等
 		//fa.GetContext().GetContainer().ReleaseAll(true) // 释放资源
-		clearApplicationGlobals(cf.GetAppContext()) // 停止保活后清空全局对象
+		// 由 Shutdown 协调时，先报告被截断的请求并等待任务 worker 排空，再关闭其依赖的全局对象
+		if sc := cf.coordinator; sc != nil {
+			sc.endDrain(&cf.inflight)
+		}
+		// 停止保活后按初始化逆序关闭并清空全局对象
+		if cf.globalsCloseErr = clearApplicationGlobals(cf.GetAppContext()); cf.globalsCloseErr != nil {
+			cf.GetAppContext().GetLogger().ErrorWith(cf.GetAppContext().GetConfig().LogOriginFrame()).Str("applicationStarter", "FrameApplication").Err(cf.globalsCloseErr).Msg("Close application globals failed")
+		}
 		cf.GetAppContext().GetLogger().InfoWith(cf.GetAppContext().GetConfig().LogOriginFrame()).Str("applicationStarter", "FrameApplication").Str("appShutdown", "ok").Msg("")
 		_ = cf.GetAppContext().GetLogger().Close() // 日志器Close
 		return nil
@@ -387,8 +554,71 @@ func (cf *CoreWithFiber) AppCoreRun(managers ...IProviderManager) error {
 
 	host, port := cf.GetAppContext().GetConfig().String("application.server.host"), cf.GetAppContext().GetConfig().String("application.server.port")
 
-	if err = cf.coreApp.Listen(host + ":" + port); err != nil {
+	// 监听绑定后执行 ServerRunAfter 管理器，注入实际地址
+	cf.coreApp.Hooks().OnListen(func(listenData fiber.ListenData) error {
+		if fiber.IsChild() {
+			return nil
+		}
+		scheme := "http"
+		if listenData.TLS {
+			scheme = "https"
+		}
+		addr, err := net.ResolveTCPAddr("tcp", net.JoinHostPort(strings.Trim(listenData.Host, "[]"), listenData.Port))
+		if err != nil {
+			cf.GetAppContext().GetLogger().ErrorWith(cf.GetAppContext().GetConfig().LogOriginFrame()).Err(err).Msg("resolve listen address failed")
+			return nil
+		}
+		startServerRunAfter(cf.GetAppContext(), &ServerInfo{Starter: cf, Addr: addr, Scheme: scheme}, managers)
+		return nil
+	})
+
+	// 启用 TLS 时自行绑定监听器，证书经 GetCertificate 热加载；自定义监听器不支持预派生
+	var tlsListener net.Listener
+	tlsConf, err := bindTLSConfig(cf.GetAppContext().GetConfig(), FiberTLSConfPath)
+	if err != nil {
+		return err
+	}
+	if tlsConf.Enable {
+		st, err := newServerTLS(cf.GetAppContext(), tlsConf)
+		if err != nil {
+			cf.GetAppContext().GetLogger().ErrorWith(cf.GetAppContext().GetConfig().LogOriginFrame()).Str("applicationStarter", "FrameApplication").Err(err).Msg("Failed to load TLS certificates")
+			return err
+		}
+		ln, err := net.Listen("tcp", net.JoinHostPort(host, port))
+		if err != nil {
+			st.close()
+			cf.GetAppContext().GetLogger().ErrorWith(cf.GetAppContext().GetConfig().LogOriginFrame()).Str("applicationStarter", "FrameApplication").Msg("App listen failed")
+			return err
+		}
+		cf.tls.Store(st)
+		tlsListener = tls.NewListener(ln, st.Config())
+	}
+
+	// 预派生子进程只服务主监听器，附加监听器由主进程绑定
+	if !fiber.IsChild() {
+		if err = cf.listeners.bind(cf.GetAppContext()); err != nil {
+			cf.GetAppContext().GetLogger().ErrorWith(cf.GetAppContext().GetConfig().LogOriginFrame()).Str("applicationStarter", "FrameApplication").Err(err).Msg("App listeners bind failed")
+			if tlsListener != nil {
+				_ = tlsListener.Close()
+				cf.tls.Load().close()
+			}
+			return err
+		}
+		cf.listeners.serve(cf.GetAppContext())
+	}
+
+	if tlsListener != nil {
+		err = cf.coreApp.Listener(tlsListener)
+	} else {
+		err = cf.coreApp.Listen(host + ":" + port)
+	}
+	if err != nil {
 		cf.GetAppContext().GetLogger().ErrorWith(cf.GetAppContext().GetConfig().LogOriginFrame()).Str("applicationStarter", "FrameApplication").Msg("App listen failed")
+		cf.tls.Load().close()
+		cf.listeners.abort()
+		return errors.Join(err, cf.listeners.wait())
+	}
+	if err = cf.listeners.wait(); err != nil {
 		return err
 	}
 
@@ -398,11 +628,17 @@ func (cf *CoreWithFiber) AppCoreRun(managers ...IProviderManager) error {
 }
 
 // Shutdown 关闭应用
+//
+// 整个关闭链共享 application.shutdown.timeout 预算：停止接收连接后等待在途请求与任务处理器，
+// 再以剩余时间执行 ServerShutdownAfter 提供者，超出预算的部分记录日志后截断。
 func (cf *CoreWithFiber) Shutdown(managers ...IProviderManager) error {
 	if cf.GetAppContext().GetAppState() {
 		return nil
 	}
 
+	// 进入关闭链即标记未就绪，/readyz 在 ServerShutdownBefore 提供者执行期间及之后返回 503
+	health.Default().SetReady(false)
+
 	_, replaced, err := LoadProviderManagersAtLocation(
 		managers,
 		ProviderLocationDefault().LocationServerShutdown,
@@ -415,6 +651,9 @@ func (cf *CoreWithFiber) Shutdown(managers ...IProviderManager) error {
 		return nil
 	}
 
+	sc := newShutdownCoordinator(cf.GetAppContext())
+	defer sc.close()
+
 	_, _, err = LoadProviderManagersAtLocation(
 		managers,
 		ProviderLocationDefault().LocationServerShutdownBefore,
@@ -425,21 +664,34 @@ func (cf *CoreWithFiber) Shutdown(managers ...IProviderManager) error {
 	}
 
 	cf.GetAppContext().GetLogger().InfoWith(cf.GetAppContext().GetConfig().LogOriginFrame()).Str("applicationStarter", "FrameApplication").Msg("Fiber app Shutting down...")
-	err = cf.coreApp.Shutdown()
-	if err != nil {
+	sc.beginDrain()
+	cf.coordinator = sc
+	// 附加监听器先于主应用关闭：主应用的 OnShutdown 钩子会关闭全局对象
+	listenersErr := cf.listeners.stop(sc.Context())
+	err = errors.Join(cf.coreApp.ShutdownWithContext(sc.Context()), listenersErr)
+	cf.tls.Load().close()
+	sc.endDrain(&cf.inflight)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
 		cf.GetAppContext().GetLogger().ErrorWith(cf.GetAppContext().GetConfig().LogOriginFrame()).Str("applicationStarter", "FrameApplication").Err(err).Msg("Fiber app Shutdown failed.")
-		return err
+		return errors.Join(err, cf.globalsCloseErr)
 	}
 
-	_, _, err = LoadProviderManagersAtLocation(
-		managers,
-		ProviderLocationDefault().LocationServerShutdownAfter,
-		cf,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to load post-shutdown providers: %w", err)
+	afterErr := sc.runAfter("ServerShutdownAfter", func() error {
+		_, _, err := LoadProviderManagersAtLocation(
+			managers,
+			ProviderLocationDefault().LocationServerShutdownAfter,
+			cf,
+		)
+		return err
+	})
+	if afterErr != nil {
+		if !errors.Is(afterErr, context.DeadlineExceeded) {
+			return errors.Join(err, fmt.Errorf("failed to load post-shutdown providers: %w", afterErr), cf.globalsCloseErr)
+		}
+		// 预算耗尽时仍关闭日志器，截断的提供者在后台继续运行
+		err = errors.Join(err, afterErr)
 	}
 
 	// 关闭日志器
-	return cf.GetAppContext().GetLogger().Close()
+	return errors.Join(err, cf.globalsCloseErr, cf.GetAppContext().GetLogger().Close())
 }
@@ -8,11 +8,11 @@ package fiberhouse
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/pprof"
 	"sync/atomic"
 	"time"
 
@@ -20,6 +20,10 @@ import (
 	adaptorerrorhandler "github.com/lamxy/fiberhouse/adaptor/errorhandler"
 	adaptorlogging "github.com/lamxy/fiberhouse/adaptor/logging"
 	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/component/health"
+	"github.com/lamxy/fiberhouse/component/metrics"
+	"github.com/lamxy/fiberhouse/component/tracing"
+	"github.com/lamxy/fiberhouse/globalmanager"
 
 	"github.com/gin-gonic/gin"
 )
@@ -33,6 +37,9 @@ type CoreWithGin struct {
 	ginLoggerLease      *adaptorlogging.GinLoggerLease
 	initErr             error
 	shutdownCoordinated atomic.Bool
+	inflight            inflightCounter
+	listeners           listenerGroup // application.listeners 配置的附加监听器，各自持有独立的 gin.Engine 与 http.Server
+	tls                 *serverTLS    // 主服务器的 TLS 证书热加载状态，未启用 TLS 或由选项函数设置 TLSConfig 时为 nil
 }
 
 // NewCoreWithGin 创建一个基于Gin的应用核心启动器对象
@@ -132,6 +139,7 @@ func (cg *CoreWithGin) InitCoreApp(fs FrameStarter, managers ...IProviderManager
 
 	// 创建Gin引擎
 	cg.coreApp = gin.New(cg.OptionFuncList...)
+	cg.configureClientIP(cg.coreApp, cfg)
 
 	// 配置JSON序列化器
 	if len(managers) == 0 {
@@ -166,14 +174,61 @@ func (cg *CoreWithGin) InitCoreApp(fs FrameStarter, managers ...IProviderManager
 		}
 	}
 
-	// 初始化HTTP Server
+	// 初始化HTTP Server，TLS 或 HTTP/2 配置无效时由 AppCoreRun 返回错误
 	cg.initHttpServer(cfg)
+	if cg.initializationFailed() {
+		return
+	}
 	if cg.httpServer.ErrorLog == nil {
 		cg.httpServer.ErrorLog = adapter.HTTPServerErrorLogger()
 	}
+	cg.initListeners()
 	initialized = true
 }
 
+// configureClientIP 按 application.plugins.engine.servers.gin.proxyHeader 设置 Context.ClientIP 读取的代理头，
+// 为空时保留引擎自身的设置（gin 缺省值或 OptionFuncList 中的配置）
+func (cg *CoreWithGin) configureClientIP(engine *gin.Engine, cfg appconfig.IAppConfig) {
+	header := cfg.String("application.plugins.engine.servers.gin.proxyHeader")
+	if header == "" {
+		return
+	}
+	engine.ForwardedByClientIP = true
+	engine.RemoteIPHeaders = []string{header}
+}
+
+// initListeners 按 application.listeners 配置为每个附加监听器创建独立的 gin.Engine，http.Server 沿用主服务器的超时设置
+func (cg *CoreWithGin) initListeners() {
+	confs, err := loadListenerConfigs(cg.GetAppContext().GetConfig())
+	if err != nil {
+		cg.GetAppContext().GetLogger().ErrorWith(cg.GetAppContext().GetConfig().LogOriginFrame()).Err(err).Msg("bind listeners config failed")
+		cg.listeners.fail(err)
+		return
+	}
+	for _, conf := range confs {
+		engine := gin.New(cg.OptionFuncList...)
+		cg.configureClientIP(engine, cg.GetAppContext().GetConfig())
+		server := &http.Server{
+			Handler:           engine,
+			ReadTimeout:       cg.httpServer.ReadTimeout,
+			WriteTimeout:      cg.httpServer.WriteTimeout,
+			IdleTimeout:       cg.httpServer.IdleTimeout,
+			MaxHeaderBytes:    cg.httpServer.MaxHeaderBytes,
+			ReadHeaderTimeout: cg.httpServer.ReadHeaderTimeout,
+			ErrorLog:          cg.httpServer.ErrorLog,
+			Protocols:         cg.httpServer.Protocols,
+			HTTP2:             cg.httpServer.HTTP2,
+		}
+		cg.listeners.servers = append(cg.listeners.servers, &listenerServer{
+			conf:       conf,
+			app:        engine,
+			serve:      server.Serve,
+			shutdown:   server.Shutdown,
+			nextProtos: serverNextProtos(server),
+		})
+	}
+}
+
 // initHttpServer 初始化HTTP服务器
 func (cg *CoreWithGin) initHttpServer(cfg appconfig.IAppConfig) {
 	// NewCoreWithGin的选项参数已初始化httpServer,此处无需重复初始化
@@ -221,47 +276,60 @@ func (cg *CoreWithGin) initHttpServer(cfg appconfig.IAppConfig) {
 		},
 	}
 
-	// 配置TLS/HTTPS（如果启用）
-	if cg.httpServer.TLSConfig == nil && cfg.Bool("application.plugins.engine.servers.gin.tls.enable") {
-		// 如果配置了HTTPS相关参数，则启用TLS
-		certFile := cfg.String("application.plugins.engine.servers.gin.tls.certFile", "")
-		keyFile := cfg.String("application.plugins.engine.servers.gin.tls.keyFile", "")
-
-		if certFile != "" && keyFile != "" {
-			msg := fmt.Sprintf("Enabling TLS/HTTPS with certFile: %s and keyFile: %s", certFile, keyFile)
-			cg.GetAppContext().GetLogger().InfoWith(cfg.LogOriginFrame()).
-				Str("applicationStarter", "GinApplication").
-				Msg(msg)
+	// 配置TLS/HTTPS（如果启用），证书经 GetCertificate 提供并在文件变更时热加载
+	if cg.httpServer.TLSConfig == nil {
+		cg.initServerTLS(cfg)
+		if cg.initializationFailed() {
+			return
 		}
-		// 加载TLS证书配置
-		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
-		if err != nil {
-			cg.GetAppContext().GetLogger().ErrorWith(cfg.LogOriginFrame()).
-				Str("applicationStarter", "GinApplication").
-				Err(err).
-				Msg("Failed to load TLS certificates")
-			if certFile != "" && keyFile != "" {
-				panic(err)
-			}
-		} else {
-			cg.httpServer.TLSConfig = &tls.Config{
-				Certificates: []tls.Certificate{cert},
-				// 最小TLS版本（默认TLS 1.2）
-				MinVersion: tls.VersionTLS12,
-				// 密码套件配置
-				CipherSuites: []uint16{
-					tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
-					tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
-					tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
-					tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
-				},
-			}
+	}
 
-			cg.GetAppContext().GetLogger().InfoWith(cfg.LogOriginFrame()).
-				Str("applicationStarter", "GinApplication").
-				Msg("TLS/HTTPS enabled")
-		}
+	// 配置 HTTP/2：TLS 下经 ALPN 协商，h2c 启用时明文连接同样接受 HTTP/2
+	http2Conf, err := bindHTTP2Config(cfg, GinHTTP2ConfPath)
+	if err != nil {
+		cg.initErr = err
+		cg.GetAppContext().GetLogger().ErrorWith(cfg.LogOriginFrame()).
+			Str("applicationStarter", "GinApplication").
+			Err(err).
+			Msg("Invalid HTTP/2 config")
+		return
+	}
+	ConfigureHTTP2(cg.httpServer, http2Conf)
+}
+
+// initServerTLS 按 application.plugins.engine.servers.gin.tls 配置装配主服务器的 TLS
+func (cg *CoreWithGin) initServerTLS(cfg appconfig.IAppConfig) {
+	tlsConf, err := bindTLSConfig(cfg, GinTLSConfPath)
+	if err != nil {
+		cg.initErr = err
+		cg.GetAppContext().GetLogger().ErrorWith(cfg.LogOriginFrame()).
+			Str("applicationStarter", "GinApplication").
+			Err(err).
+			Msg("Invalid TLS config")
+		return
 	}
+	if !tlsConf.Enable {
+		return
+	}
+	msg := fmt.Sprintf("Enabling TLS/HTTPS with certFile: %s and keyFile: %s", tlsConf.CertFile, tlsConf.KeyFile)
+	cg.GetAppContext().GetLogger().InfoWith(cfg.LogOriginFrame()).
+		Str("applicationStarter", "GinApplication").
+		Msg(msg)
+	st, err := newServerTLS(cg.GetAppContext(), tlsConf)
+	if err != nil {
+		cg.initErr = err
+		cg.GetAppContext().GetLogger().ErrorWith(cfg.LogOriginFrame()).
+			Str("applicationStarter", "GinApplication").
+			Err(err).
+			Msg("Failed to load TLS certificates")
+		return
+	}
+	cg.tls = st
+	cg.httpServer.TLSConfig = st.Config()
+
+	cg.GetAppContext().GetLogger().InfoWith(cfg.LogOriginFrame()).
+		Str("applicationStarter", "GinApplication").
+		Msg("TLS/HTTPS enabled")
 }
 
 // RegisterAppMiddleware 注册应用级的中间件
@@ -307,9 +375,31 @@ func (cg *CoreWithGin) RegisterAppMiddleware(fs FrameStarter, managers ...IProvi
 		Logger:            cg.GetAppContext().GetLogger(),
 		Stdout:            false,
 		JsonCodec:         ginJson.API.Marshal,
-		DebugMode:         debugMode, // true开启调试模式，将详细错误信息显示给客户端，否则隐藏细节，只能通过日志文件查看。生产环境关闭该调式模式。
+		DebugMode:         debugMode,                                                                    // true开启调试模式，将详细错误信息显示给客户端，否则隐藏细节，只能通过日志文件查看。生产环境关闭该调式模式。
+		DebugModeFunc:     func() bool { return cg.GetAppContext().GetConfig().GetRecover().DebugMode }, // 配置热更新后按新的调试模式响应
 	})
 
+	// 注册在途请求计数中间件，关闭时据此报告被截断的请求
+	cg.coreApp.Use(cg.inflightMiddleware())
+
+	// 注册请求作用域中间件，位于最外层，确保 panic 恢复后仍释放作用域
+	cg.coreApp.Use(cg.requestScopeMiddleware())
+
+	// 注册附加监听器的独立中间件链
+	cg.registerListenerMiddleware(
+		MustRecoverMiddleware[func(ctx *gin.Context)](recoverHandler),
+		adaptorerrorhandler.GinErrorHandler(eh.ErrorHandler),
+	)
+
+	// 注册链路追踪中间件，服务端 span 覆盖指标、恢复与业务处理
+	cg.registerTracing()
+
+	// 注册请求指标中间件与指标端点，位于恢复中间件之外，panic 请求按恢复后的状态码记录
+	cg.registerMetrics()
+
+	// 注册健康、就绪与存活探针路由，位于恢复与请求日志中间件之外
+	cg.registerHealth()
+
 	// 注册panic恢复中间件
 	//cg.coreApp.Use(recoverHandler.(func(ctx *gin.Context)))
 	cg.coreApp.Use(MustRecoverMiddleware[func(ctx *gin.Context)](recoverHandler))
@@ -327,6 +417,98 @@ func (cg *CoreWithGin) RegisterAppMiddleware(fs FrameStarter, managers ...IProvi
 	}
 }
 
+// registerListenerMiddleware 为附加监听器注册在途计数、请求作用域、恢复与错误处理中间件，按配置挂载 pprof 路由；不注册请求日志与指标中间件
+func (cg *CoreWithGin) registerListenerMiddleware(recoverHandler, errorHandler gin.HandlerFunc) {
+	for _, s := range cg.listeners.servers {
+		engine := s.app.(*gin.Engine)
+		engine.Use(cg.inflightMiddleware(), cg.requestScopeMiddleware(), recoverHandler, errorHandler)
+		if s.conf.Pprof {
+			registerGinPprof(engine)
+		}
+	}
+}
+
+// registerGinPprof 在 /debug/pprof 下挂载 net/http/pprof 处理器
+func registerGinPprof(r gin.IRouter) {
+	group := r.Group("/debug/pprof")
+	group.GET("/", gin.WrapF(pprof.Index))
+	group.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+	group.GET("/profile", gin.WrapF(pprof.Profile))
+	group.GET("/symbol", gin.WrapF(pprof.Symbol))
+	group.POST("/symbol", gin.WrapF(pprof.Symbol))
+	group.GET("/trace", gin.WrapF(pprof.Trace))
+	group.GET("/:name", gin.WrapF(pprof.Index))
+}
+
+// listenerRouter 返回挂载内置端点的路由器，name 为空时为主引擎；未配置的监听器记录错误并由 AppCoreRun 返回
+func (cg *CoreWithGin) listenerRouter(name string) (gin.IRouter, bool) {
+	if name == "" {
+		return cg.coreApp, true
+	}
+	if engine, ok := cg.listeners.app(name); ok {
+		return engine.(*gin.Engine), true
+	}
+	err := fmt.Errorf("listener %s is not configured in %s", name, ListenersConfPath)
+	cg.GetAppContext().GetLogger().ErrorWith(cg.GetAppContext().GetConfig().LogOriginFrame()).Err(err).Msg("mount endpoint failed")
+	cg.listeners.fail(err)
+	return nil, false
+}
+
+// registerTracing application.trace.enable 为 true 时注册链路追踪中间件
+func (cg *CoreWithGin) registerTracing() {
+	if cg.GetAppContext().GetConfig().Bool("application.trace.enable") {
+		cg.coreApp.Use(tracing.GinMiddleware())
+	}
+}
+
+// registerHealth 按 application.health 配置注册 /healthz、/readyz、/livez 路由
+func (cg *CoreWithGin) registerHealth() {
+	conf, ok := healthEndpoints(cg.GetAppContext())
+	if !ok {
+		return
+	}
+	router, ok := cg.listenerRouter(conf.Listener)
+	if !ok {
+		return
+	}
+	registry := health.Default()
+	router.GET(conf.HealthPath, registry.GinHandler(health.ProbeHealth))
+	router.GET(conf.ReadyPath, registry.GinHandler(health.ProbeReady))
+	router.GET(conf.LivePath, registry.GinHandler(health.ProbeLive))
+}
+
+// registerMetrics 按 application.metrics 配置注册请求指标中间件与指标端点
+func (cg *CoreWithGin) registerMetrics() {
+	path, listener, ok := metricsEndpoint(cg.GetAppContext().GetConfig())
+	if !ok {
+		return
+	}
+	cg.coreApp.Use(metrics.GinMiddleware(path))
+	if router, ok := cg.listenerRouter(listener); ok {
+		router.GET(path, metrics.GinHandler())
+	}
+}
+
+// inflightMiddleware 在途请求计数中间件
+func (cg *CoreWithGin) inflightMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cg.inflight.add()
+		defer cg.inflight.done()
+		c.Next()
+	}
+}
+
+// requestScopeMiddleware 请求作用域中间件，为每个请求挂载延迟创建的 GlobalManager 子作用域，处理返回后释放
+func (cg *CoreWithGin) requestScopeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope := globalmanager.NewLazyScope(cg.GetAppContext().GetContainer())
+		c.Set(globalmanager.ScopeLocalsKey, scope)
+		c.Request = c.Request.WithContext(globalmanager.ContextWithScope(c.Request.Context(), scope))
+		defer releaseRequestScope(cg.GetAppContext(), scope)
+		c.Next()
+	}
+}
+
 // loggerMiddleware HTTP请求日志中间件
 func (cg *CoreWithGin) loggerMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -510,10 +692,35 @@ func (cg *CoreWithGin) AppCoreRun(managers ...IProviderManager) error {
 
 	cg.GetAppContext().GetLogger().InfoWith(cg.GetAppContext().GetConfig().LogOriginFrame()).Str("applicationStarter", "FrameApplication").Msg("App: Manager for application processing server runtime")
 
-	if cg.httpServer.TLSConfig != nil {
-		err = cg.httpServer.ListenAndServeTLS("", "")
+	// 先绑定监听再服务，绑定后执行 ServerRunAfter 管理器并注入实际地址
+	addr := cg.httpServer.Addr
+	if addr == "" {
+		addr = ":" + scheme
+	}
+	if err = cg.listeners.bind(cg.GetAppContext()); err != nil {
+		cg.GetAppContext().GetLogger().ErrorWith(cfg.LogOriginFrame()).
+			Str("applicationStarter", "GinApplication").
+			Err(err).
+			Msg("Failed to bind Gin listeners")
+		return err
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		cg.listeners.close()
 	} else {
-		err = cg.httpServer.ListenAndServe()
+		cg.listeners.serve(cg.GetAppContext())
+		startServerRunAfter(cg.GetAppContext(), &ServerInfo{Starter: cg, Addr: listener.Addr(), Scheme: scheme}, managers)
+		if cg.httpServer.TLSConfig != nil {
+			err = cg.httpServer.ServeTLS(listener, "", "")
+		} else {
+			err = cg.httpServer.Serve(listener)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			cg.listeners.abort()
+		} else {
+			err = nil
+		}
+		err = errors.Join(err, cg.listeners.wait())
 	}
 	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		cg.GetAppContext().GetLogger().ErrorWith(cfg.LogOriginFrame()).
@@ -526,7 +733,7 @@ func (cg *CoreWithGin) AppCoreRun(managers ...IProviderManager) error {
 	return nil
 }
 
-// Shutdown 关闭应用
+// Shutdown 关闭应用，关闭链共享 application.shutdown.timeout 预算，见 CoreWithFiber.Shutdown
 func (cg *CoreWithGin) Shutdown(managers ...IProviderManager) error {
 	defer cg.releaseGinLogger()
 
@@ -538,6 +745,9 @@ func (cg *CoreWithGin) Shutdown(managers ...IProviderManager) error {
 		return nil
 	}
 
+	// 进入关闭链即标记未就绪，/readyz 在 ServerShutdownBefore 提供者执行期间及之后返回 503
+	health.Default().SetReady(false)
+
 	_, replaced, err := LoadProviderManagersAtLocation(
 		managers,
 		ProviderLocationDefault().LocationServerShutdown,
@@ -550,6 +760,9 @@ func (cg *CoreWithGin) Shutdown(managers ...IProviderManager) error {
 		return nil
 	}
 
+	sc := newShutdownCoordinator(cg.GetAppContext())
+	defer sc.close()
+
 	_, _, err = LoadProviderManagersAtLocation(
 		managers,
 		ProviderLocationDefault().LocationServerShutdownBefore,
@@ -564,40 +777,59 @@ func (cg *CoreWithGin) Shutdown(managers ...IProviderManager) error {
 		Str("applicationStarter", "GinApplication").
 		Msg("Shutting down Gin server gracefully...")
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
+	sc.beginDrain()
 	cg.shutdownCoordinated.Store(true)
-	if err = cg.httpServer.Shutdown(shutdownCtx); err != nil {
+	// 附加监听器先于主服务器关闭，与 Fiber core 的顺序一致
+	listenersErr := cg.listeners.stop(sc.Context())
+	err = errors.Join(cg.httpServer.Shutdown(sc.Context()), listenersErr)
+	cg.tls.close()
+	sc.endDrain(&cg.inflight)
+
+	// 清理资源：与 Fiber core 的 OnShutdown 钩子顺序一致，服务器停止且任务排空后即关闭全局对象，
+	// 先于 ServerShutdownAfter 提供者，预算耗尽时被截断的提供者不会与全局对象的关闭交错
+	cg.GetAppContext().GetLogger().InfoWith(cg.GetAppContext().GetConfig().LogOriginFrame()).
+		Str("applicationStarter", "GinApplication").
+		Msg("Cleaning up resources...")
+
+	closeErr := clearApplicationGlobals(cg.GetAppContext())
+	if closeErr != nil {
+		cg.GetAppContext().GetLogger().ErrorWith(cg.GetAppContext().GetConfig().LogOriginFrame()).
+			Str("applicationStarter", "GinApplication").
+			Err(closeErr).
+			Msg("Close application globals failed")
+	}
+
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
 		cg.GetAppContext().GetLogger().ErrorWith(cg.GetAppContext().GetConfig().LogOriginFrame()).
 			Str("applicationStarter", "GinApplication").
 			Err(err).
 			Msg("Gin server forced to shutdown")
-		return err
+		return errors.Join(err, closeErr)
 	}
 	cg.releaseGinLogger()
 
-	_, _, err = LoadProviderManagersAtLocation(
-		managers,
-		ProviderLocationDefault().LocationServerShutdownAfter,
-		cg,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to load post-shutdown providers: %w", err)
+	afterErr := sc.runAfter("ServerShutdownAfter", func() error {
+		_, _, err := LoadProviderManagersAtLocation(
+			managers,
+			ProviderLocationDefault().LocationServerShutdownAfter,
+			cg,
+		)
+		return err
+	})
+	if afterErr != nil {
+		if !errors.Is(afterErr, context.DeadlineExceeded) {
+			return errors.Join(err, fmt.Errorf("failed to load post-shutdown providers: %w", afterErr), closeErr)
+		}
+		// 预算耗尽时仍关闭日志器，截断的提供者在后台继续运行
+		err = errors.Join(err, afterErr)
 	}
 
-	// 清理资源
-	cg.GetAppContext().GetLogger().InfoWith(cg.GetAppContext().GetConfig().LogOriginFrame()).
-		Str("applicationStarter", "GinApplication").
-		Msg("Cleaning up resources...")
-
-	clearApplicationGlobals(cg.GetAppContext())
 	cg.GetAppContext().GetLogger().InfoWith(cg.GetAppContext().GetConfig().LogOriginFrame()).
 		Str("applicationStarter", "GinApplication").
 		Msg("Gin server shutdown complete")
 
 	// 关闭日志器
-	return cg.GetAppContext().GetLogger().Close()
+	return errors.Join(err, closeErr, cg.GetAppContext().GetLogger().Close())
 }
 
 // GetAppContext 获取应用上下文
@@ -609,3 +841,16 @@ func (cg *CoreWithGin) GetAppContext() IApplicationContext {
 func (cg *CoreWithGin) GetCoreApp() interface{} {
 	return cg.coreApp
 }
+
+// GetListenerApp 获取附加监听器的 *gin.Engine，未配置时返回 nil
+func (cg *CoreWithGin) GetListenerApp(name string) interface{} {
+	if engine, ok := cg.listeners.app(name); ok {
+		return engine
+	}
+	return nil
+}
+
+// ListenerNames 获取已配置的附加监听器名称
+func (cg *CoreWithGin) ListenerNames() []string {
+	return cg.listeners.names()
+}
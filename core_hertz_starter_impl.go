@@ -0,0 +1,632 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	adaptorerrorhandler "github.com/lamxy/fiberhouse/adaptor/errorhandler"
+	adaptorlogging "github.com/lamxy/fiberhouse/adaptor/logging"
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/component/health"
+	"github.com/lamxy/fiberhouse/globalmanager"
+)
+
+// hertzConfigPrefix Hertz 核心配置前缀，沿用 application.plugins.engine.servers.<coreType> 约定
+const hertzConfigPrefix = "application.plugins.engine.servers.hertz."
+
+// CoreWithHertz 基于Hertz的核心应用启动器
+type CoreWithHertz struct {
+	ctx                 IApplicationContext
+	Options             []config.Option
+	coreApp             *server.Hertz
+	json                JsonWrapper
+	hertzLoggerLease    *adaptorlogging.HertzLoggerLease
+	initErr             error
+	shutdownCoordinated atomic.Bool
+	inflight            inflightCounter
+}
+
+// NewCoreWithHertz 创建一个基于Hertz的应用核心启动器对象
+func NewCoreWithHertz(ctx IApplicationContext, opts ...CoreStarterOption) CoreStarter {
+	core := &CoreWithHertz{
+		ctx: ctx,
+	}
+
+	for _, opt := range opts {
+		opt(core)
+	}
+
+	return core
+}
+
+// releaseHertzLogger 归还引擎日志所有权，须在框架日志器关闭前调用
+func (ch *CoreWithHertz) releaseHertzLogger() {
+	if ch.hertzLoggerLease != nil {
+		ch.hertzLoggerLease.Release()
+	}
+}
+
+func (ch *CoreWithHertz) initializationFailed() bool {
+	return ch.initErr != nil
+}
+
+func (ch *CoreWithHertz) initializationTerminal() bool {
+	return ch.initializationFailed() ||
+		ch.coreApp != nil ||
+		ch.hertzLoggerLease != nil
+}
+
+// GetJsonCodec 获取已解析的JSON编解码器，供中间件与路由注册方复用
+func (ch *CoreWithHertz) GetJsonCodec() JsonWrapper {
+	return ch.json
+}
+
+// InitCoreApp 初始化应用核心
+func (ch *CoreWithHertz) InitCoreApp(fs FrameStarter, managers ...IProviderManager) {
+	if ch.initializationTerminal() {
+		return
+	}
+	if ch.GetAppContext().GetAppState() {
+		return
+	}
+
+	_, replaced, err := LoadProviderManagersAtLocation(
+		managers,
+		ProviderLocationDefault().LocationCoreEngineInit,
+		ch,
+	)
+	if err != nil {
+		ch.GetAppContext().GetLogger().ErrorWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+			Err(err).
+			Msg("InitCoreApp providers failed")
+		return
+	}
+	if replaced {
+		return
+	}
+
+	ch.GetAppContext().GetLogger().InfoWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+		Str("applicationStarter", "HertzApplication").
+		Msg("InitCoreApp starting...")
+
+	cfg := ch.GetAppContext().GetConfig()
+
+	// 接管引擎日志：须在 server.New 之前安装，否则启动期的路由注册输出会绕过框架日志器
+	lease, err := adaptorlogging.InstallHertzLogger(
+		adaptorlogging.NewHertzLoggerAdapter(
+			ch.GetAppContext().GetLogger(),
+			cfg.LogOriginFrame(),
+		),
+	)
+	if err != nil {
+		ch.initErr = fmt.Errorf("install Hertz framework logger: %w", err)
+		ch.GetAppContext().GetLogger().
+			ErrorWith(cfg.LogOriginFrame()).
+			Err(ch.initErr).
+			Msg("InitCoreApp Hertz logger bridge failed")
+		return
+	}
+	ch.hertzLoggerLease = lease
+
+	initialized := false
+	defer func() {
+		if !initialized {
+			ch.releaseHertzLogger()
+		}
+	}()
+
+	ch.json = ch.resolveJSONCodec(fs, managers...)
+
+	// 选项函数未提供服务端配置时，按全局配置组装
+	opts := ch.Options
+	if len(opts) == 0 {
+		opts = ch.buildServerOptions(cfg)
+	}
+	ch.coreApp = server.New(opts...)
+	initialized = true
+}
+
+// buildServerOptions 依全局配置组装Hertz服务端选项
+func (ch *CoreWithHertz) buildServerOptions(cfg appconfig.IAppConfig) []config.Option {
+	// 引擎 Shutdown 会以 ExitWaitTime 再次截断关闭 context，未显式配置时取关闭链预算，由 application.shutdown.timeout 统一控制
+	exitWaitTime := cfg.Duration(hertzConfigPrefix+"exitWaitTime", 0) * time.Second
+	if exitWaitTime <= 0 {
+		exitWaitTime = loadShutdownConfig(ch.GetAppContext()).Timeout
+	}
+
+	opts := []config.Option{
+		server.WithHostPorts(ch.listenAddr()),
+		// 读取超时时间（默认30秒）
+		server.WithReadTimeout(cfg.Duration(hertzConfigPrefix+"readTimeout", 30) * time.Second),
+		// 写入超时时间（默认30秒）
+		server.WithWriteTimeout(cfg.Duration(hertzConfigPrefix+"writeTimeout", 30) * time.Second),
+		// 空闲超时时间（默认60秒）
+		server.WithIdleTimeout(cfg.Duration(hertzConfigPrefix+"idleTimeout", 60) * time.Second),
+		// 请求体最大字节数（默认4MB）
+		server.WithMaxRequestBodySize(cfg.Int(hertzConfigPrefix+"maxRequestBodySize", 4*1024*1024)),
+		// 优雅退出等待上限（默认取 application.shutdown.timeout）
+		server.WithExitWaitTime(exitWaitTime),
+		// 请求体流式读取
+		server.WithStreamBody(cfg.Bool(hertzConfigPrefix + "streamRequestBody")),
+	}
+
+	if tlsCfg := ch.buildTLSConfig(cfg); tlsCfg != nil {
+		opts = append(opts, server.WithTLS(tlsCfg))
+	}
+	return opts
+}
+
+// buildTLSConfig 依配置加载TLS证书，未启用时返回 nil
+func (ch *CoreWithHertz) buildTLSConfig(cfg appconfig.IAppConfig) *tls.Config {
+	if !cfg.Bool(hertzConfigPrefix + "tls.enable") {
+		return nil
+	}
+
+	certFile := cfg.String(hertzConfigPrefix+"tls.certFile", "")
+	keyFile := cfg.String(hertzConfigPrefix+"tls.keyFile", "")
+
+	// 加载TLS证书配置，与 Gin 核心一致：已配置证书路径但加载失败时终止启动
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		ch.GetAppContext().GetLogger().ErrorWith(cfg.LogOriginFrame()).
+			Str("applicationStarter", "HertzApplication").
+			Err(err).
+			Msg("Failed to load TLS certificates")
+		if certFile != "" && keyFile != "" {
+			panic(err)
+		}
+		return nil
+	}
+
+	ch.GetAppContext().GetLogger().InfoWith(cfg.LogOriginFrame()).
+		Str("applicationStarter", "HertzApplication").
+		Msg("TLS/HTTPS enabled")
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		// 最小TLS版本（默认TLS 1.2）
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+// resolveJSONCodec 解析JSON编解码器实例；未提供或未找到编解码提供者管理器时使用默认编解码器，
+// 管理器加载失败或加载结果不是 JsonWrapper 时 panic，与 Fiber 核心一致
+func (ch *CoreWithHertz) resolveJSONCodec(fs FrameStarter, managers ...IProviderManager) JsonWrapper {
+	if len(managers) == 0 {
+		// 使用默认的JSON编解码提供者
+		ch.GetAppContext().GetLogger().InfoWith(ch.GetAppContext().GetConfig().LogOriginFrame()).Msg("No JSON codec manager provided, using default JSON codec")
+		return GetMustInstance[JsonWrapper](fs.GetApplication().GetDefaultTrafficCodecKey())
+	}
+
+	var jsonCodecManager IProviderManager
+
+	for _, manager := range managers {
+		if manager.Location().GetLocationID() == ProviderLocationDefault().LocationCoreCodecInit.GetLocationID() &&
+			manager.Type().GetTypeID() == ProviderTypeDefault().GroupTrafficCodecChoose.GetTypeID() {
+			jsonCodecManager = manager
+			break
+		}
+	}
+
+	if jsonCodecManager == nil {
+		ch.GetAppContext().GetLogger().WarnWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+			Str("applicationStarter", "HertzApplication").
+			Msg("No JSON codec manager found in provided managers, using default JSON codec")
+		return GetMustInstance[JsonWrapper](fs.GetApplication().GetDefaultTrafficCodecKey())
+	}
+
+	jcodec, err := jsonCodecManager.LoadProvider()
+	if err != nil {
+		ch.GetAppContext().GetLogger().ErrorWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+			Str("applicationStarter", "HertzApplication").
+			Err(err).
+			Msg("Failed to load providers into Hertz application starter")
+		panic(err)
+	}
+
+	json, ok := jcodec.(JsonWrapper)
+	if !ok {
+		panic("Loaded JSON codec provider does not implement JsonWrapper interface")
+	}
+	return json
+}
+
+// RegisterAppMiddleware 注册应用级的中间件
+func (ch *CoreWithHertz) RegisterAppMiddleware(fs FrameStarter, managers ...IProviderManager) {
+	if ch.initializationFailed() {
+		return
+	}
+	if ch.GetAppContext().GetAppState() {
+		return
+	}
+
+	_, replaced, err := LoadProviderManagersAtLocation(
+		managers,
+		ProviderLocationDefault().LocationAppMiddlewareInit,
+		ch,
+	)
+	if err != nil {
+		ch.GetAppContext().GetLogger().ErrorWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+			Err(err).
+			Msg("RegisterAppMiddleware providers failed")
+		return
+	}
+	if replaced {
+		return
+	}
+
+	ch.GetAppContext().GetLogger().InfoWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+		Str("applicationStarter", "HertzApplication").
+		Msg("RegisterAppMiddleware")
+
+	// IErrorHandler接口实例，恢复中间件由恢复管理器依据核心类型选择
+	eh := NewErrorHandlerOnce(ch.GetAppContext())
+
+	recoverHandler := eh.RecoverMiddleware(RecoverConfig{
+		AppCtx:            ch.GetAppContext(),
+		EnableStackTrace:  true,
+		StackTraceHandler: eh.DefaultStackTraceHandler,
+		Logger:            ch.GetAppContext().GetLogger(),
+		Stdout:            false,
+		JsonCodec:         ch.json.Marshal,
+		DebugMode:         ch.GetAppContext().GetConfig().GetRecover().DebugMode,                        // true开启调试模式，将详细错误信息显示给客户端，生产环境关闭
+		DebugModeFunc:     func() bool { return ch.GetAppContext().GetConfig().GetRecover().DebugMode }, // 配置热更新后按新的调试模式响应
+	})
+
+	// 注册在途请求计数中间件，关闭时据此报告被截断的请求
+	ch.coreApp.Use(ch.inflightMiddleware())
+
+	// 注册请求作用域中间件，位于最外层，确保 panic 恢复后仍释放作用域
+	ch.coreApp.Use(ch.requestScopeMiddleware())
+
+	// 注册panic恢复中间件
+	ch.coreApp.Use(MustRecoverMiddleware[app.HandlerFunc](recoverHandler))
+
+	// 注册错误处理器中间件
+	ch.coreApp.Use(adaptorerrorhandler.HertzErrorHandler(eh.ErrorHandler))
+
+	// 注册HTTP请求日志中间件
+	ch.coreApp.Use(ch.loggerMiddleware())
+
+	if fs.GetApplication() != nil {
+		// 注册项目应用注册器全局中间件
+		fs.GetApplication().(ApplicationRegister).RegisterAppMiddleware(ch)
+	}
+}
+
+// inflightMiddleware 在途请求计数中间件
+func (ch *CoreWithHertz) inflightMiddleware() app.HandlerFunc {
+	return func(c context.Context, reqCtx *app.RequestContext) {
+		ch.inflight.add()
+		defer ch.inflight.done()
+		reqCtx.Next(c)
+	}
+}
+
+// requestScopeMiddleware 请求作用域中间件，为每个请求挂载延迟创建的 GlobalManager 子作用域，处理返回后释放
+func (ch *CoreWithHertz) requestScopeMiddleware() app.HandlerFunc {
+	return func(c context.Context, reqCtx *app.RequestContext) {
+		scope := globalmanager.NewLazyScope(ch.GetAppContext().GetContainer())
+		reqCtx.Set(globalmanager.ScopeLocalsKey, scope)
+		defer releaseRequestScope(ch.GetAppContext(), scope)
+		reqCtx.Next(globalmanager.ContextWithScope(c, scope))
+	}
+}
+
+// loggerMiddleware HTTP请求日志中间件
+func (ch *CoreWithHertz) loggerMiddleware() app.HandlerFunc {
+	return func(c context.Context, reqCtx *app.RequestContext) {
+		ms := ch.GetAppContext().GetConfig().GetMiddlewareSwitch("coreHttp")
+		if !ms {
+			reqCtx.Next(c)
+			return
+		}
+
+		start := time.Now()
+		path := string(reqCtx.Path())
+		query := string(reqCtx.QueryArgs().QueryString())
+
+		reqCtx.Next(c)
+
+		logEvent := ch.GetAppContext().GetLogger().InfoWith(ch.GetAppContext().GetConfig().LogOriginCoreHttp()).
+			Str("Component", "Hertz").
+			Str("method", string(reqCtx.Method())).
+			Str("path", path).
+			Int("status", reqCtx.Response.StatusCode()).
+			Dur("latency", time.Since(start)).
+			Str("ip", reqCtx.ClientIP()).
+			Int("bodySize", len(reqCtx.Response.Body()))
+
+		if query != "" {
+			logEvent.Str("query", query)
+		}
+
+		if len(reqCtx.Errors) > 0 {
+			logEvent.Str("errors", reqCtx.Errors.String())
+		}
+
+		logEvent.Msg("HTTP Request")
+	}
+}
+
+// RegisterModuleInitialize 注册应用模块/子系统级的中间件、路由处理器等
+func (ch *CoreWithHertz) RegisterModuleInitialize(fs FrameStarter, managers ...IProviderManager) {
+	if ch.initializationFailed() {
+		return
+	}
+	if ch.GetAppContext().GetAppState() {
+		return
+	}
+
+	_, _, moduleErr := LoadProviderManagersAtLocation(
+		managers,
+		ProviderLocationDefault().LocationModuleMiddlewareInit,
+		ch,
+	)
+	if moduleErr != nil {
+		fs.GetContext().GetLogger().ErrorWith(fs.GetContext().GetConfig().LogOriginFrame()).
+			Err(moduleErr).
+			Msg("RegisterModuleInitialize module middleware providers failed")
+	}
+
+	routeHandled, _, routeErr := LoadProviderManagersAtLocation(
+		managers,
+		ProviderLocationDefault().LocationRouteRegisterInit,
+		ch,
+	)
+	if routeErr != nil {
+		fs.GetContext().GetLogger().ErrorWith(fs.GetContext().GetConfig().LogOriginFrame()).
+			Err(routeErr).
+			Msg("RegisterModuleInitialize route providers failed")
+	}
+
+	if !routeHandled && fs.GetModule() != nil {
+		// 注册模块/子系统路由处理器
+		fs.GetModule().RegisterModuleRouteHandlers(ch)
+	}
+}
+
+// RegisterModuleSwagger 注册模块/子系统级的swagger
+func (ch *CoreWithHertz) RegisterModuleSwagger(fs FrameStarter, managers ...IProviderManager) {
+	if ch.initializationFailed() {
+		return
+	}
+	if ch.GetAppContext().GetAppState() {
+		return
+	}
+
+	_, replaced, err := LoadProviderManagersAtLocation(
+		managers,
+		ProviderLocationDefault().LocationModuleSwaggerInit,
+		ch,
+	)
+	if err != nil {
+		ch.GetAppContext().GetLogger().ErrorWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+			Err(err).
+			Msg("RegisterModuleSwagger providers failed")
+		return
+	}
+	if replaced {
+		return
+	}
+
+	registerOrNot := ch.GetAppContext().GetConfig().Bool("application.swagger.enable")
+	if registerOrNot {
+		if fs.GetModule() != nil {
+			// 注册模块系统的swagger
+			fs.GetModule().RegisterSwagger(ch)
+		}
+	}
+}
+
+// RegisterAppHooks 注册核心应用的生命周期钩子函数
+func (ch *CoreWithHertz) RegisterAppHooks(fs FrameStarter, managers ...IProviderManager) {
+	if ch.initializationFailed() {
+		return
+	}
+	if ch.GetAppContext().GetAppState() {
+		return
+	}
+
+	_, replaced, err := LoadProviderManagersAtLocation(
+		managers,
+		ProviderLocationDefault().LocationCoreHookInit,
+		ch,
+	)
+	if err != nil {
+		ch.GetAppContext().GetLogger().ErrorWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+			Err(err).
+			Msg("RegisterAppHooks providers failed")
+		return
+	}
+	if replaced {
+		return
+	}
+
+	// 注册应用注册器的钩子函数
+	if fs.GetApplication() != nil {
+		fs.GetApplication().(ApplicationRegister).RegisterCoreHook(ch)
+	}
+
+	// 框架默认钩子：监听启动日志
+	ch.coreApp.OnRun = append(ch.coreApp.OnRun, func(c context.Context) error {
+		ch.GetAppContext().GetLogger().InfoWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+			Str("applicationStarter", "HertzApplication").
+			Msg("Hertz app listening on " + ch.listenAddr())
+		return nil
+	})
+}
+
+// listenAddr 获取配置的监听地址
+func (ch *CoreWithHertz) listenAddr() string {
+	cfg := ch.GetAppContext().GetConfig()
+	return cfg.String(hertzConfigPrefix+"host", "0.0.0.0") + ":" + cfg.String(hertzConfigPrefix+"port", "8080")
+}
+
+// AppCoreRun 启动Hertz应用
+//
+// 使用 Run() 而非 Spin()：RunServer 已统一接管系统信号与优雅关闭，Spin() 会重复监听信号并自行关闭服务。
+func (ch *CoreWithHertz) AppCoreRun(managers ...IProviderManager) error {
+	defer func() {
+		if !ch.shutdownCoordinated.Load() {
+			ch.releaseHertzLogger()
+		}
+	}()
+
+	if ch.initializationFailed() {
+		return ch.initErr
+	}
+
+	if ch.GetAppContext().GetAppState() {
+		return nil
+	}
+
+	_, replaced, err := LoadProviderManagersAtLocation(
+		managers,
+		ProviderLocationDefault().LocationServerRun,
+		ch,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load server run providers: %w", err)
+	}
+	if replaced {
+		return nil
+	}
+
+	ch.GetAppContext().GetLogger().InfoWith(ch.GetAppContext().GetConfig().LogOriginFrame()).Str("applicationStarter", "FrameApplication").Msg("App: Manager for application processing server runtime")
+
+	if listenersConfigured(ch.GetAppContext().GetConfig()) {
+		ch.GetAppContext().GetLogger().WarnWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+			Str("applicationStarter", "HertzApplication").
+			Msg("application.listeners is not supported by Hertz core, only the main listener is served")
+	}
+
+	if err = ch.coreApp.Run(); err != nil {
+		ch.GetAppContext().GetLogger().ErrorWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+			Str("applicationStarter", "HertzApplication").
+			Err(err).
+			Msg("Failed to start Hertz server")
+		return err
+	}
+	ch.GetAppContext().RegisterAppState(true)
+	return nil
+}
+
+// Shutdown 关闭应用，关闭链共享 application.shutdown.timeout 预算，见 CoreWithFiber.Shutdown
+func (ch *CoreWithHertz) Shutdown(managers ...IProviderManager) error {
+	defer ch.releaseHertzLogger()
+
+	if ch.initializationFailed() {
+		return ch.initErr
+	}
+
+	if ch.GetAppContext().GetAppState() {
+		return nil
+	}
+
+	// 进入关闭链即标记未就绪，/readyz 在 ServerShutdownBefore 提供者执行期间及之后返回 503
+	health.Default().SetReady(false)
+
+	_, replaced, err := LoadProviderManagersAtLocation(
+		managers,
+		ProviderLocationDefault().LocationServerShutdown,
+		ch,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load server shutdown providers: %w", err)
+	}
+	if replaced {
+		return nil
+	}
+
+	sc := newShutdownCoordinator(ch.GetAppContext())
+	defer sc.close()
+
+	_, _, err = LoadProviderManagersAtLocation(
+		managers,
+		ProviderLocationDefault().LocationServerShutdownBefore,
+		ch,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load pre-shutdown providers: %w", err)
+	}
+
+	// 执行优雅关闭
+	ch.GetAppContext().GetLogger().InfoWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+		Str("applicationStarter", "HertzApplication").
+		Msg("Shutting down Hertz server gracefully...")
+
+	sc.beginDrain()
+	ch.shutdownCoordinated.Store(true)
+	err = ch.coreApp.Shutdown(sc.Context())
+	sc.endDrain(&ch.inflight)
+
+	// 清理资源：与 Fiber core 的 OnShutdown 钩子顺序一致，服务器停止且任务排空后即关闭全局对象，
+	// 先于 ServerShutdownAfter 提供者，预算耗尽时被截断的提供者不会与全局对象的关闭交错
+	ch.GetAppContext().GetLogger().InfoWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+		Str("applicationStarter", "HertzApplication").
+		Msg("Cleaning up resources...")
+
+	closeErr := clearApplicationGlobals(ch.GetAppContext())
+	if closeErr != nil {
+		ch.GetAppContext().GetLogger().ErrorWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+			Str("applicationStarter", "HertzApplication").
+			Err(closeErr).
+			Msg("Close application globals failed")
+	}
+
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		ch.GetAppContext().GetLogger().ErrorWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+			Str("applicationStarter", "HertzApplication").
+			Err(err).
+			Msg("Hertz server forced to shutdown")
+		return errors.Join(err, closeErr)
+	}
+
+	afterErr := sc.runAfter("ServerShutdownAfter", func() error {
+		_, _, err := LoadProviderManagersAtLocation(
+			managers,
+			ProviderLocationDefault().LocationServerShutdownAfter,
+			ch,
+		)
+		return err
+	})
+	if afterErr != nil {
+		if !errors.Is(afterErr, context.DeadlineExceeded) {
+			return errors.Join(err, fmt.Errorf("failed to load post-shutdown providers: %w", afterErr), closeErr)
+		}
+		// 预算耗尽时仍关闭日志器，截断的提供者在后台继续运行
+		err = errors.Join(err, afterErr)
+	}
+
+	ch.GetAppContext().GetLogger().InfoWith(ch.GetAppContext().GetConfig().LogOriginFrame()).
+		Str("applicationStarter", "HertzApplication").
+		Msg("Hertz server shutdown complete")
+
+	// 关闭框架日志器前归还引擎日志所有权，避免引擎后续日志写入已关闭的 writer
+	ch.releaseHertzLogger()
+	return errors.Join(err, closeErr, ch.GetAppContext().GetLogger().Close())
+}
+
+// GetAppContext 获取应用上下文
+func (ch *CoreWithHertz) GetAppContext() IApplicationContext {
+	return ch.ctx
+}
+
+// GetCoreApp 获取核心Hertz实例
+func (ch *CoreWithHertz) GetCoreApp() interface{} {
+	return ch.coreApp
+}
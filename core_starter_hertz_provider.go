@@ -0,0 +1,47 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	"fmt"
+
+	"github.com/lamxy/fiberhouse/constant"
+)
+
+// CoreStarterHertzProvider 核心Hertz提供者
+type CoreStarterHertzProvider struct {
+	IProvider
+}
+
+func NewCoreStarterHertzProvider() *CoreStarterHertzProvider {
+	return &CoreStarterHertzProvider{
+		IProvider: NewProvider().SetName("CoreHertzProvider").SetTarget(constant.CoreTypeWithHertz).SetType(ProviderTypeDefault().GroupCoreStarterChoose),
+	}
+}
+
+// Initialize 重载初始化核心Hertz提供者
+func (p *CoreStarterHertzProvider) Initialize(ctx IContext, initFunc ...ProviderInitFunc) (any, error) {
+	if len(initFunc) == 0 {
+		return NewCoreWithHertz(ctx.(IApplicationContext)), nil
+	}
+
+	anything, err := initFunc[0](p) // 匿名函数参数获取核心启动器初始化的选项参数切片
+	if err != nil {
+		return nil, fmt.Errorf("CoreHertzProvider initialize failed: %w", err)
+	}
+
+	var (
+		coreStarterOptions []CoreStarterOption
+		ok                 bool
+	)
+
+	if coreStarterOptions, ok = anything.([]CoreStarterOption); ok {
+		return NewCoreWithHertz(ctx.(IApplicationContext), coreStarterOptions...), nil
+	}
+
+	return anything, err
+}
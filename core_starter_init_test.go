@@ -13,6 +13,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"math/big"
 	"net"
@@ -25,13 +26,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
 	"github.com/gin-gonic/gin"
 	ginJson "github.com/gin-gonic/gin/codec/json"
 	"github.com/gofiber/fiber/v2"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
 	adaptorlogging "github.com/lamxy/fiberhouse/adaptor/logging"
 	"github.com/lamxy/fiberhouse/appconfig"
 	"github.com/lamxy/fiberhouse/bootstrap"
 	jsoncodec "github.com/lamxy/fiberhouse/component/codec/json"
+	"github.com/lamxy/fiberhouse/component/health"
+	"github.com/lamxy/fiberhouse/globalmanager"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -191,6 +199,7 @@ func cleanupTask4GinCore(t *testing.T, core *CoreWithGin) {
 			location: ProviderLocationDefault().LocationServerRun,
 		}
 		_ = core.AppCoreRun(replacement)
+		core.tls.close()
 	})
 }
 
@@ -331,6 +340,7 @@ func TestCoreRegisterModuleInitialize_LoadsRouteManagerOnlyOnce(t *testing.T) {
 	}{
 		{name: "fiber", core: func(ctx IApplicationContext) CoreStarter { return &CoreWithFiber{ctx: ctx} }},
 		{name: "gin", core: func(ctx IApplicationContext) CoreStarter { return &CoreWithGin{ctx: ctx} }},
+		{name: "hertz", core: func(ctx IApplicationContext) CoreStarter { return &CoreWithHertz{ctx: ctx} }},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			ctx := newTask4InternalAppContext(t, nil)
@@ -356,6 +366,7 @@ func TestCoreRegisterModuleInitialize_ReplacementIsScopedToItsLocation(t *testin
 	}{
 		{name: "fiber", core: func(ctx IApplicationContext) CoreStarter { return &CoreWithFiber{ctx: ctx} }},
 		{name: "gin", core: func(ctx IApplicationContext) CoreStarter { return &CoreWithGin{ctx: ctx} }},
+		{name: "hertz", core: func(ctx IApplicationContext) CoreStarter { return &CoreWithHertz{ctx: ctx} }},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			ctx := newTask4InternalAppContext(t, nil)
@@ -384,6 +395,7 @@ func TestCoreAppCoreRun_SuccessfulReplacementReturnsNil(t *testing.T) {
 	}{
 		{name: "fiber", core: func(ctx IApplicationContext) CoreStarter { return &CoreWithFiber{ctx: ctx} }},
 		{name: "gin", core: func(ctx IApplicationContext) CoreStarter { return &CoreWithGin{ctx: ctx} }},
+		{name: "hertz", core: func(ctx IApplicationContext) CoreStarter { return &CoreWithHertz{ctx: ctx} }},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			ctx := newTask4InternalAppContext(t, nil)
@@ -970,6 +982,67 @@ func generateTask4SelfSignedCert(t *testing.T) (certFile, keyFile string) {
 	return certFile, keyFile
 }
 
+func TestCoreInit_ClientIPFollowsConfiguredProxyHeader(t *testing.T) {
+	isolateTask4ErrorHandlerSingleton(t)
+	preserveTask4GinMode(t)
+	for _, tc := range []struct {
+		name       string
+		header     string
+		ginOptions []gin.OptionFunc
+		fiberWant  string
+		ginWant    string
+	}{
+		{name: "proxy header", header: "X-Forwarded-For", fiberWant: "203.0.113.7", ginWant: "203.0.113.7"},
+		// 未配置时 fiber 取连接对端地址（app.Test 为 0.0.0.0），gin 保留引擎缺省的代理头设置
+		{name: "engine defaults", header: "", fiberWant: "0.0.0.0", ginWant: "203.0.113.7"},
+		// 未配置时 OptionFuncList 中的设置不被覆盖，httptest 请求的对端地址为 192.0.2.1
+		{name: "engine options", header: "", ginOptions: []gin.OptionFunc{func(e *gin.Engine) { e.ForwardedByClientIP = false }}, fiberWant: "0.0.0.0", ginWant: "192.0.2.1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := newTask4InternalAppContext(t, map[string]interface{}{
+				"application.server.proxyHeader":                     tc.header,
+				"application.plugins.engine.servers.gin.proxyHeader": tc.header,
+				"application.listeners.admin.address":                "127.0.0.1:0",
+			})
+			request := func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/ip", nil)
+				r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+				return r
+			}
+
+			fiberCore := NewCoreWithFiber(ctx).(*CoreWithFiber)
+			fiberCore.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+			fiberApps := []*fiber.App{fiberCore.coreApp, fiberCore.GetListenerApp("admin").(*fiber.App)}
+			for _, app := range fiberApps {
+				app.Get("/ip", func(c *fiber.Ctx) error {
+					wrapped := adaptorctx.WithFiberContext(c)
+					return wrapped.Send(http.StatusOK, []byte(wrapped.ClientIP()))
+				})
+				resp, err := app.Test(request())
+				require.NoError(t, err)
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Equal(t, tc.fiberWant, string(body))
+			}
+
+			ginCore := NewCoreWithGin(ctx).(*CoreWithGin)
+			ginCore.OptionFuncList = append(ginCore.OptionFuncList, tc.ginOptions...)
+			ginCore.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+			cleanupTask4GinCore(t, ginCore)
+			ginEngines := []*gin.Engine{ginCore.coreApp, ginCore.GetListenerApp("admin").(*gin.Engine)}
+			for _, engine := range ginEngines {
+				engine.GET("/ip", func(c *gin.Context) {
+					wrapped := adaptorctx.WithGinContext(c)
+					_ = wrapped.Send(http.StatusOK, []byte(wrapped.ClientIP()))
+				})
+				recorder := httptest.NewRecorder()
+				engine.ServeHTTP(recorder, request())
+				assert.Equal(t, tc.ginWant, recorder.Body.String())
+			}
+		})
+	}
+}
+
 func TestCoreInit_GinTLSLoadsConfiguredCertificate(t *testing.T) {
 	preserveTask4GinMode(t)
 	certFile, keyFile := generateTask4SelfSignedCert(t)
@@ -986,7 +1059,11 @@ func TestCoreInit_GinTLSLoadsConfiguredCertificate(t *testing.T) {
 	})
 	cleanupTask4GinCore(t, core)
 	require.NotNil(t, core.httpServer.TLSConfig)
-	require.Len(t, core.httpServer.TLSConfig.Certificates, 1)
+	require.NotNil(t, core.httpServer.TLSConfig.GetCertificate)
+	cert, err := core.httpServer.TLSConfig.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	require.NotNil(t, cert)
+	require.Equal(t, uint16(tls.VersionTLS12), core.httpServer.TLSConfig.MinVersion)
 }
 
 func TestCoreInit_GinTLSRejectsInvalidConfiguredCertificate(t *testing.T) {
@@ -1004,9 +1081,13 @@ func TestCoreInit_GinTLSRejectsInvalidConfiguredCertificate(t *testing.T) {
 	})
 	core := NewCoreWithGin(ctx).(*CoreWithGin)
 
-	require.Panics(t, func() {
+	// 证书加载失败与 TLS 配置错误一致，由 AppCoreRun 返回而不是 panic
+	require.NotPanics(t, func() {
 		core.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
 	})
+	t.Cleanup(core.releaseGinLogger)
+	require.Error(t, core.initErr)
+	assert.Equal(t, core.initErr, core.AppCoreRun())
 }
 
 // TestCoreInit_GinLoopbackTLSHandshake 验证 Gin server 在启用 TLS 后，
@@ -1108,6 +1189,9 @@ func TestCoreRegistration_ModuleSwaggerAndApplicationHooks(t *testing.T) {
 		{name: "gin", core: func(ctx IApplicationContext) CoreStarter {
 			return &CoreWithGin{ctx: ctx}
 		}},
+		{name: "hertz", core: func(ctx IApplicationContext) CoreStarter {
+			return &CoreWithHertz{ctx: ctx, coreApp: server.New(server.WithHostPorts("127.0.0.1:0"))}
+		}},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			ctx := newTask4InternalAppContext(t, map[string]interface{}{"application.swagger.enable": true})
@@ -1133,4 +1217,507 @@ func TestCoreRegistration_ModuleSwaggerAndApplicationHooks(t *testing.T) {
 	disabledFiberModule := &task4Module{}
 	NewCoreWithFiber(disabledCtx).RegisterModuleSwagger(&task4Frame{module: disabledFiberModule})
 	assert.Zero(t, disabledFiberModule.swaggerCalls)
+
+	disabledHertzModule := &task4Module{}
+	NewCoreWithHertz(disabledCtx).RegisterModuleSwagger(&task4Frame{module: disabledHertzModule})
+	assert.Zero(t, disabledHertzModule.swaggerCalls)
+}
+
+func installTask4HertzLoggerProbe(t *testing.T) (*adaptorlogging.HertzLoggerLease, error) {
+	t.Helper()
+	logger := zerolog.Nop()
+	adapter := adaptorlogging.NewHertzLoggerAdapter(
+		bootstrap.NewLoggerWrap(&logger),
+		appconfig.LogOrigin("Frame"),
+	)
+	return adaptorlogging.InstallHertzLogger(adapter)
+}
+
+func TestCoreInit_HertzUsesSelectedManagerAndConfiguredOptions(t *testing.T) {
+	isolateTask4ErrorHandlerSingleton(t)
+	ctx := newTask4InternalAppContext(t, nil)
+	wrong, manager := task4WrongManager(), task4GoodCodecManager()
+	core := NewCoreWithHertz(ctx).(*CoreWithHertz)
+	core.Options = []config.Option{server.WithHostPorts("127.0.0.1:0")}
+	t.Cleanup(core.releaseHertzLogger)
+
+	core.InitCoreApp(&task4Frame{}, wrong, manager)
+	require.NotNil(t, core.coreApp)
+	assert.Same(t, core.coreApp, core.GetCoreApp())
+	assert.Same(t, manager.result, core.GetJsonCodec())
+	assert.Zero(t, wrong.loadCalls)
+	assert.Equal(t, 1, manager.loadCalls)
+	assert.Equal(t, "127.0.0.1:0", core.coreApp.GetOptions().Addr)
+
+	// 重复初始化不会重建引擎
+	existing := core.coreApp
+	core.InitCoreApp(&task4Frame{}, manager)
+	assert.Same(t, existing, core.coreApp)
+	assert.Equal(t, 1, manager.loadCalls)
+
+	// 初始化期间持有引擎日志所有权
+	lease, err := installTask4HertzLoggerProbe(t)
+	if lease != nil {
+		lease.Release()
+	}
+	require.ErrorIs(t, err, adaptorlogging.ErrHertzLoggerAlreadyInstalled)
+}
+
+func TestCoreInit_HertzFallsBackToDefaultCodecWhenNoManagerMatches(t *testing.T) {
+	isolateTask4ErrorHandlerSingleton(t)
+	ctx := newTask4InternalAppContext(t, nil)
+	key := "task4-hertz-default-codec"
+	defaultCodec := jsoncodec.StdJsonDefault()
+	container := ctx.GetContainer()
+	container.Unregister(key)
+	t.Cleanup(func() { container.Unregister(key) })
+	require.True(t, container.Register(key, func() (interface{}, error) {
+		return defaultCodec, nil
+	}))
+	frame := &task4Frame{application: &task4Application{defaultKey: key}}
+	wrong := task4WrongManager()
+	core := NewCoreWithHertz(ctx).(*CoreWithHertz)
+	core.Options = []config.Option{server.WithHostPorts("127.0.0.1:0")}
+	t.Cleanup(core.releaseHertzLogger)
+
+	require.NotPanics(t, func() { core.InitCoreApp(frame, wrong) })
+	require.NotNil(t, core.coreApp)
+	assert.Same(t, defaultCodec, core.GetJsonCodec())
+	assert.Zero(t, wrong.loadCalls)
+}
+
+func TestCoreInit_HertzServerOptionsFollowConfiguration(t *testing.T) {
+	ctx := newTask4InternalAppContext(t, map[string]interface{}{
+		"application.plugins.engine.servers.hertz.host":               "127.0.0.1",
+		"application.plugins.engine.servers.hertz.port":               "18089",
+		"application.plugins.engine.servers.hertz.readTimeout":        7,
+		"application.plugins.engine.servers.hertz.maxRequestBodySize": 1024,
+	})
+	core := NewCoreWithHertz(ctx).(*CoreWithHertz)
+
+	h := server.New(core.buildServerOptions(ctx.GetConfig())...)
+	assert.Equal(t, "127.0.0.1:18089", h.GetOptions().Addr)
+	assert.Equal(t, 7*time.Second, h.GetOptions().ReadTimeout)
+	assert.Equal(t, 1024, h.GetOptions().MaxRequestBodySize)
+	assert.Nil(t, h.GetOptions().TLS)
+	assert.Equal(t, 30*time.Second, h.GetOptions().ExitWaitTimeout)
+}
+
+func TestCoreInit_HertzExitWaitTimeFollowsShutdownBudget(t *testing.T) {
+	ctx := newTask4InternalAppContext(t, map[string]interface{}{
+		"application.shutdown.timeout": "12s",
+	})
+	core := NewCoreWithHertz(ctx).(*CoreWithHertz)
+	h := server.New(core.buildServerOptions(ctx.GetConfig())...)
+	assert.Equal(t, 12*time.Second, h.GetOptions().ExitWaitTimeout)
+
+	// 显式配置时覆盖关闭预算
+	ctx = newTask4InternalAppContext(t, map[string]interface{}{
+		"application.shutdown.timeout":                          "12s",
+		"application.plugins.engine.servers.hertz.exitWaitTime": 3,
+	})
+	core = NewCoreWithHertz(ctx).(*CoreWithHertz)
+	h = server.New(core.buildServerOptions(ctx.GetConfig())...)
+	assert.Equal(t, 3*time.Second, h.GetOptions().ExitWaitTimeout)
+}
+
+func TestCoreRun_HertzShutdownHonorsLocationChainAndReleasesLogger(t *testing.T) {
+	isolateTask4ErrorHandlerSingleton(t)
+	ctx := newTask4InternalAppContext(t, nil)
+	core := NewCoreWithHertz(ctx).(*CoreWithHertz)
+	core.Options = []config.Option{
+		server.WithHostPorts("127.0.0.1:0"),
+		server.WithExitWaitTime(10 * time.Millisecond),
+	}
+	t.Cleanup(core.releaseHertzLogger)
+	core.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+	require.NotNil(t, core.coreApp)
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- core.AppCoreRun() }()
+	require.Eventually(t, core.coreApp.IsRunning, 5*time.Second, 10*time.Millisecond)
+
+	before := &task4LifecycleManager{
+		typ:      ProviderTypeDefault().GroupProviderAutoRun,
+		location: ProviderLocationDefault().LocationServerShutdownBefore,
+	}
+	after := &task4LifecycleManager{
+		typ:      ProviderTypeDefault().GroupProviderAutoRun,
+		location: ProviderLocationDefault().LocationServerShutdownAfter,
+	}
+	require.NoError(t, core.Shutdown(before, after))
+	assert.Equal(t, 1, before.loadCalls)
+	assert.Equal(t, 1, after.loadCalls)
+
+	select {
+	case err := <-runErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("AppCoreRun did not return after Shutdown")
+	}
+
+	// 关闭后引擎日志所有权已归还
+	lease, err := installTask4HertzLoggerProbe(t)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	lease.Release()
+}
+
+type requestScopeProbe struct {
+	mu     sync.Mutex
+	closed []string
+}
+
+type requestScopedUnit struct {
+	id    int
+	probe *requestScopeProbe
+}
+
+func (u *requestScopedUnit) Close() error {
+	u.probe.mu.Lock()
+	defer u.probe.mu.Unlock()
+	u.probe.closed = append(u.probe.closed, fmt.Sprintf("uow-%d", u.id))
+	return nil
+}
+
+func (p *requestScopeProbe) list() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.closed...)
+}
+
+func newRequestScopeTestContext(t *testing.T) (IApplicationContext, *requestScopeProbe) {
+	t.Helper()
+	ctx := newTask4InternalAppContext(t, nil)
+	manager := isolateFrameHealthManager(t, ctx)
+	probe := &requestScopeProbe{}
+	var next int
+	var mu sync.Mutex
+	require.True(t, manager.RegisterScoped("uow", func(*globalmanager.GlobalManager) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		next++
+		return &requestScopedUnit{id: next, probe: probe}, nil
+	}))
+	return ctx, probe
+}
+
+// requireRequestScope 校验核心上下文与 context.Context 取得同一请求作用域，且作用域对象在请求内唯一
+func requireRequestScope(t *testing.T, coreCtx adaptorctx.ICoreContext, stdCtx context.Context, root *globalmanager.GlobalManager) {
+	t.Helper()
+	scope := coreCtx.Scope()
+	require.NotNil(t, scope)
+	assert.Same(t, scope, globalmanager.ScopeFromContext(stdCtx))
+	assert.Same(t, root, scope.Parent())
+	first, err := scope.Get("uow")
+	require.NoError(t, err)
+	second, err := scope.Get("uow")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+}
+
+func TestCoreRequestScopeMiddleware_ReleasesScopeWhenHandlerReturns(t *testing.T) {
+	preserveTask4GinMode(t)
+	gin.SetMode(gin.TestMode)
+
+	t.Run("fiber", func(t *testing.T) {
+		ctx, probe := newRequestScopeTestContext(t)
+		core := &CoreWithFiber{ctx: ctx}
+		fiberApp := fiber.New()
+		fiberApp.Use(core.requestScopeMiddleware())
+		fiberApp.Get("/scope", func(c *fiber.Ctx) error {
+			requireRequestScope(t, adaptorctx.WithFiberContext(c), c.UserContext(), ctx.GetContainer())
+			return c.SendStatus(http.StatusNoContent)
+		})
+		for i := 0; i < 2; i++ {
+			resp, err := fiberApp.Test(httptest.NewRequest(http.MethodGet, "/scope", nil))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		}
+		assert.Equal(t, []string{"uow-1", "uow-2"}, probe.list())
+	})
+
+	t.Run("gin", func(t *testing.T) {
+		ctx, probe := newRequestScopeTestContext(t)
+		core := &CoreWithGin{ctx: ctx}
+		engine := gin.New()
+		engine.Use(core.requestScopeMiddleware())
+		engine.GET("/scope", func(c *gin.Context) {
+			requireRequestScope(t, adaptorctx.WithGinContext(c), c.Request.Context(), ctx.GetContainer())
+			c.Status(http.StatusNoContent)
+		})
+		engine.GET("/unused", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+		for _, path := range []string{"/scope", "/unused"} {
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+			require.Equal(t, http.StatusNoContent, recorder.Code)
+		}
+		// 未使用作用域的请求不创建作用域对象
+		assert.Equal(t, []string{"uow-1"}, probe.list())
+	})
+
+	t.Run("hertz", func(t *testing.T) {
+		ctx, probe := newRequestScopeTestContext(t)
+		core := &CoreWithHertz{ctx: ctx}
+		h := server.New()
+		h.Use(core.requestScopeMiddleware())
+		// 恢复中间件位于作用域中间件内层，panic 被恢复后作用域仍被释放
+		h.Use(func(c context.Context, reqCtx *app.RequestContext) {
+			defer func() {
+				if recover() != nil {
+					reqCtx.AbortWithStatus(http.StatusInternalServerError)
+				}
+			}()
+			reqCtx.Next(c)
+		})
+		h.GET("/scope", func(c context.Context, reqCtx *app.RequestContext) {
+			requireRequestScope(t, adaptorctx.WithHertzContext(reqCtx), c, ctx.GetContainer())
+			panic("handler failed")
+		})
+		recorder := ut.PerformRequest(h.Engine, http.MethodGet, "/scope", nil)
+		assert.Equal(t, http.StatusInternalServerError, recorder.Result().StatusCode())
+		assert.Equal(t, []string{"uow-1"}, probe.list())
+	})
+}
+
+func TestCoreRegisterMetrics_ExposesConfiguredEndpoint(t *testing.T) {
+	isolateTask4ErrorHandlerSingleton(t)
+	preserveTask4GinMode(t)
+	ctx := newTask4InternalAppContext(t, map[string]interface{}{
+		"application.metrics.enable": true,
+		"application.metrics.path":   "/ops/metrics",
+	})
+	frame := &task4Frame{}
+
+	fiberCore := NewCoreWithFiber(ctx).(*CoreWithFiber)
+	fiberCore.InitCoreApp(frame, task4GoodCodecManager())
+	fiberCore.registerMetrics()
+	fiberCore.coreApp.Get("/metrics-probe/:id", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusNoContent) })
+	_, err := fiberCore.coreApp.Test(httptest.NewRequest(http.MethodGet, "/metrics-probe/1", nil))
+	require.NoError(t, err)
+
+	ginCore := NewCoreWithGin(ctx).(*CoreWithGin)
+	ginCore.InitCoreApp(frame, task4GoodCodecManager())
+	cleanupTask4GinCore(t, ginCore)
+	ginCore.registerMetrics()
+	ginCore.coreApp.GET("/metrics-probe/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	ginCore.coreApp.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics-probe/1", nil))
+
+	resp, err := fiberCore.coreApp.Test(httptest.NewRequest(http.MethodGet, "/ops/metrics", nil))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `fiberhouse_http_request_duration_seconds_count{core="fiber",method="GET",route="/metrics-probe/:id",status="204"} 1`)
+	assert.Contains(t, string(body), `fiberhouse_http_request_duration_seconds_count{core="gin",method="GET",route="/metrics-probe/:id",status="204"} 1`)
+
+	recorder := httptest.NewRecorder()
+	ginCore.coreApp.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ops/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), `route="/ops/metrics"`)
+}
+
+func TestCoreRegisterMetrics_DisabledByDefault(t *testing.T) {
+	isolateTask4ErrorHandlerSingleton(t)
+	ctx := newTask4InternalAppContext(t, nil)
+	frame := &task4Frame{}
+	core := NewCoreWithFiber(ctx).(*CoreWithFiber)
+	core.InitCoreApp(frame, task4GoodCodecManager())
+	core.registerMetrics()
+
+	for _, route := range core.coreApp.GetRoutes() {
+		assert.NotEqual(t, "/metrics", route.Path)
+	}
+}
+
+// task12ReadinessManager 记录 ServerShutdownBefore 提供者执行时的就绪状态
+type task12ReadinessManager struct {
+	task4LifecycleManager
+	readyDuringLoad bool
+}
+
+func (m *task12ReadinessManager) LoadProvider(loadFunc ...ProviderLoadFunc) (any, error) {
+	m.readyDuringLoad = health.Default().Ready()
+	return m.task4LifecycleManager.LoadProvider(loadFunc...)
+}
+
+func TestCoreRegisterHealth_ExposesProbesWithReadiness(t *testing.T) {
+	isolateTask4ErrorHandlerSingleton(t)
+	preserveTask4GinMode(t)
+	registry := health.Default()
+	t.Cleanup(func() {
+		registry.Unregister("task12-upstream")
+		registry.SetReady(true)
+	})
+	// 进程级注册表可能残留其他测试的关闭状态与全局管理器
+	registry.SetReady(true)
+	registry.SetGlobalManager(nil)
+	registry.Register("task12-upstream", health.Critical, func(context.Context) error { return nil })
+	ctx := newTask4InternalAppContext(t, map[string]interface{}{
+		"application.health.enable":    true,
+		"application.health.readyPath": "/ops/ready",
+	})
+	frame := &task4Frame{}
+
+	fiberCore := NewCoreWithFiber(ctx).(*CoreWithFiber)
+	fiberCore.InitCoreApp(frame, task4GoodCodecManager())
+	fiberCore.registerHealth()
+	ginCore := NewCoreWithGin(ctx).(*CoreWithGin)
+	ginCore.InitCoreApp(frame, task4GoodCodecManager())
+	cleanupTask4GinCore(t, ginCore)
+	ginCore.registerHealth()
+
+	probe := func(path string) (fiberStatus, ginStatus int, body string) {
+		resp, err := fiberCore.coreApp.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		require.NoError(t, err)
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		ginCore.coreApp.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return resp.StatusCode, recorder.Code, string(data)
+	}
+
+	for _, path := range []string{health.DefaultHealthPath, "/ops/ready", health.DefaultLivePath} {
+		fiberStatus, ginStatus, _ := probe(path)
+		assert.Equal(t, http.StatusOK, fiberStatus, path)
+		assert.Equal(t, http.StatusOK, ginStatus, path)
+	}
+	_, _, body := probe(health.DefaultHealthPath)
+	assert.Contains(t, body, `"name":"task12-upstream"`)
+
+	before := &task12ReadinessManager{task4LifecycleManager: task4LifecycleManager{
+		typ:      ProviderTypeDefault().GroupProviderAutoRun,
+		location: ProviderLocationDefault().LocationServerShutdownBefore,
+	}}
+	require.NoError(t, (&CoreWithFiber{ctx: ctx, coreApp: fiber.New()}).Shutdown(before))
+	assert.False(t, before.readyDuringLoad, "readiness flips before ServerShutdownBefore providers run")
+
+	fiberStatus, ginStatus, _ := probe("/ops/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, fiberStatus)
+	assert.Equal(t, http.StatusServiceUnavailable, ginStatus)
+	fiberStatus, _, _ = probe(health.DefaultLivePath)
+	assert.Equal(t, http.StatusOK, fiberStatus)
+}
+
+func TestCoreRegisterHealth_DisabledByDefault(t *testing.T) {
+	isolateTask4ErrorHandlerSingleton(t)
+	ctx := newTask4InternalAppContext(t, nil)
+	core := NewCoreWithFiber(ctx).(*CoreWithFiber)
+	core.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+	core.registerHealth()
+
+	for _, route := range core.coreApp.GetRoutes() {
+		assert.NotContains(t, []string{health.DefaultHealthPath, health.DefaultReadyPath, health.DefaultLivePath}, route.Path)
+	}
+}
+
+// task14RunAfterProbe 记录 ServerRunAfter 注入的服务器信息，并在加载时确认端口已接受连接且就绪被保持
+type task14RunAfterProbe struct {
+	task4LifecycleManager
+	infos chan *ServerInfo
+}
+
+func (m *task14RunAfterProbe) LoadProvider(loadFunc ...ProviderLoadFunc) (any, error) {
+	dependency, err := loadFunc[0](m)
+	if err != nil {
+		return nil, err
+	}
+	info := dependency.(*ServerInfo)
+	conn, dialErr := net.DialTimeout("tcp", info.Addr.String(), time.Second)
+	if dialErr != nil {
+		return nil, dialErr
+	}
+	_ = conn.Close()
+	if health.Default().Ready() {
+		return nil, errors.New("readiness not held during ServerRunAfter")
+	}
+	m.infos <- info
+	return nil, nil
+}
+
+func TestCoreRun_RunAfterReceivesBoundAddressAndHoldsReadiness(t *testing.T) {
+	isolateTask4ErrorHandlerSingleton(t)
+	preserveTask4GinMode(t)
+	registry := health.Default()
+	registry.SetReady(true)
+	t.Cleanup(func() { registry.SetReady(true) })
+
+	for _, testCase := range []struct {
+		name  string
+		start func(t *testing.T, ctx IApplicationContext, managers ...IProviderManager) (CoreStarter, func())
+	}{
+		{
+			name: "fiber",
+			start: func(t *testing.T, ctx IApplicationContext, managers ...IProviderManager) (CoreStarter, func()) {
+				core := &CoreWithFiber{ctx: ctx, coreApp: fiber.New(fiber.Config{DisableStartupMessage: true})}
+				go func() { _ = core.AppCoreRun(managers...) }()
+				return core, func() { _ = core.coreApp.Shutdown() }
+			},
+		},
+		{
+			name: "gin",
+			start: func(t *testing.T, ctx IApplicationContext, managers ...IProviderManager) (CoreStarter, func()) {
+				core := NewCoreWithGin(ctx).(*CoreWithGin)
+				core.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+				t.Cleanup(core.releaseGinLogger)
+				go func() { _ = core.AppCoreRun(managers...) }()
+				return core, func() { _ = core.httpServer.Close() }
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := newTask4InternalAppContext(t, map[string]interface{}{
+				"application.server.host": "127.0.0.1",
+				"application.server.port": "0",
+			})
+			probe := &task14RunAfterProbe{
+				task4LifecycleManager: task4LifecycleManager{
+					typ:      ProviderTypeDefault().GroupProviderAutoRun,
+					location: ProviderLocationDefault().LocationServerRunAfter,
+				},
+				infos: make(chan *ServerInfo, 1),
+			}
+			core, stop := testCase.start(t, ctx, probe)
+			defer stop()
+
+			var info *ServerInfo
+			select {
+			case info = <-probe.infos:
+			case <-time.After(3 * time.Second):
+				t.Fatal("ServerRunAfter managers were not invoked after bind")
+			}
+			assert.Same(t, core, info.Starter)
+			assert.Equal(t, "http", info.Scheme)
+			tcp, ok := info.Addr.(*net.TCPAddr)
+			require.True(t, ok)
+			assert.NotZero(t, tcp.Port, "resolved address carries the assigned port")
+			assert.Equal(t, "http://"+info.Addr.String(), info.URL())
+			assert.Eventually(t, registry.Ready, time.Second, 10*time.Millisecond, "readiness released after managers return")
+		})
+	}
+}
+
+func TestStartServerRunAfter_SkipsWithoutManagersAndLogsFailures(t *testing.T) {
+	registry := health.Default()
+	registry.SetReady(true)
+	ctx, output := newTask4LoggingAppContext(t, nil)
+	info := &ServerInfo{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}, Scheme: "http"}
+
+	startServerRunAfter(ctx, info, []IProviderManager{&task4LifecycleManager{
+		typ:      ProviderTypeDefault().GroupProviderAutoRun,
+		location: ProviderLocationDefault().LocationServerRun,
+	}})
+	assert.True(t, registry.Ready(), "no hold without ServerRunAfter managers")
+
+	failing := &task4LifecycleManager{
+		typ:      ProviderTypeDefault().GroupProviderAutoRun,
+		location: ProviderLocationDefault().LocationServerRunAfter,
+		err:      errors.New("registry unavailable"),
+	}
+	startServerRunAfter(ctx, info, []IProviderManager{failing})
+	require.Eventually(t, registry.Ready, time.Second, 10*time.Millisecond)
+	records := task4LogRecordsWithMessage(t, output, "ServerRunAfter managers load failed")
+	require.Len(t, records, 1)
+	assert.Equal(t, "registry unavailable", records[0]["error"])
+	assert.Equal(t, "127.0.0.1:8080", records[0]["addr"])
 }
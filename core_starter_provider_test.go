@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/cloudwego/hertz/pkg/app/server"
 	"github.com/gofiber/fiber/v2"
 	"github.com/lamxy/fiberhouse"
 	"github.com/lamxy/fiberhouse/appconfig"
@@ -30,6 +31,10 @@ func TestCoreStarterProviders_NoCallbackSelectsRequestedCore(t *testing.T) {
 	ginCore, err := fiberhouse.NewCoreStarterGinProvider().Initialize(ctx)
 	require.NoError(t, err)
 	assert.IsType(t, &fiberhouse.CoreWithGin{}, ginCore)
+
+	hertzCore, err := fiberhouse.NewCoreStarterHertzProvider().Initialize(ctx)
+	require.NoError(t, err)
+	assert.IsType(t, &fiberhouse.CoreWithHertz{}, hertzCore)
 }
 
 func TestCoreStarterProviders_EmptyOptionsConstructRequestedCore(t *testing.T) {
@@ -45,6 +50,10 @@ func TestCoreStarterProviders_EmptyOptionsConstructRequestedCore(t *testing.T) {
 	ginCore, err := fiberhouse.NewCoreStarterGinProvider().Initialize(ctx, emptyOptions)
 	require.NoError(t, err)
 	assert.IsType(t, &fiberhouse.CoreWithGin{}, ginCore)
+
+	hertzCore, err := fiberhouse.NewCoreStarterHertzProvider().Initialize(ctx, emptyOptions)
+	require.NoError(t, err)
+	assert.IsType(t, &fiberhouse.CoreWithHertz{}, hertzCore)
 }
 
 func TestCoreStarterProviders_CallbackErrorsIdentifyProvider(t *testing.T) {
@@ -59,6 +68,10 @@ func TestCoreStarterProviders_CallbackErrorsIdentifyProvider(t *testing.T) {
 	_, err = fiberhouse.NewCoreStarterGinProvider().Initialize(ctx, failingOptions)
 	require.ErrorIs(t, err, sentinel)
 	assert.ErrorContains(t, err, "CoreGinProvider")
+
+	_, err = fiberhouse.NewCoreStarterHertzProvider().Initialize(ctx, failingOptions)
+	require.ErrorIs(t, err, sentinel)
+	assert.ErrorContains(t, err, "CoreHertzProvider")
 }
 
 func TestCoreStarterProviders_WrongPayloadIsReturnedToCaller(t *testing.T) {
@@ -73,6 +86,10 @@ func TestCoreStarterProviders_WrongPayloadIsReturnedToCaller(t *testing.T) {
 	ginResult, err := fiberhouse.NewCoreStarterGinProvider().Initialize(ctx, wrongPayload)
 	require.NoError(t, err)
 	assert.Same(t, payload, ginResult)
+
+	hertzResult, err := fiberhouse.NewCoreStarterHertzProvider().Initialize(ctx, wrongPayload)
+	require.NoError(t, err)
+	assert.Same(t, payload, hertzResult)
 }
 
 func TestCoreWithFiber_WithCoreCfgControlsCreatedApp(t *testing.T) {
@@ -91,3 +108,19 @@ func TestCoreWithFiber_WithCoreCfgControlsCreatedApp(t *testing.T) {
 	assert.Equal(t, configured.CaseSensitive, app.Config().CaseSensitive)
 	assert.Equal(t, configured.StrictRouting, app.Config().StrictRouting)
 }
+
+func TestCoreWithHertz_WithHertzOptionsReplacesConfiguredServerOptions(t *testing.T) {
+	ctx := newTask4ExternalAppContext(t)
+	core := fiberhouse.NewCoreWithHertz(ctx, option.WithHertzOptions(
+		server.WithHostPorts("127.0.0.1:0"),
+		server.WithExitWaitTime(0),
+	))
+
+	hertzCore, ok := core.(*fiberhouse.CoreWithHertz)
+	require.True(t, ok)
+	assert.Len(t, hertzCore.Options, 2)
+
+	// 非Hertz核心忽略该选项
+	ginCore := fiberhouse.NewCoreWithGin(ctx, option.WithHertzOptions(server.WithHostPorts("127.0.0.1:0")))
+	assert.IsType(t, &fiberhouse.CoreWithGin{}, ginCore)
+}
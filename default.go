@@ -37,14 +37,21 @@ func DefaultProviders() *DefaultProviderCollection {
 			NewFrameDefaultProvider(),     // 默认框架启动器提供者
 			NewCoreStarterFiberProvider(), // 核心启动器Fiber提供者
 			NewCoreStarterGinProvider(),   // 核心启动器Gin提供者
+			NewCoreStarterHertzProvider(), // 核心启动器Hertz提供者
 			NewJsonJCodecFiberProvider(),  // JSON编解码Fiber提供者
 			NewJsonJCodecGinProvider(),    // JSON编解码Gin提供者
+			NewJsonJCodecHertzProvider(),  // JSON编解码Hertz提供者
 			NewSonicJCodecFiberProvider(), // Sonic编解码Fiber提供者
 			NewSonicJCodecGinProvider(),   // Sonic编解码Gin提供者
+			NewSonicJCodecHertzProvider(), // Sonic编解码Hertz提供者
 			NewFiberRecoveryProvider(),    // Fiber恢复提供者（框架默认提供）
-			NewGinRecoveryProvider(),      // Gin恢复提供者（框架默认提供）、及其他更多的基于自定义框架的恢复提供者
+			NewGinRecoveryProvider(),      // Gin恢复提供者（框架默认提供）
+			NewHertzRecoveryProvider(),    // Hertz恢复提供者（框架默认提供）、及其他更多的基于自定义框架的恢复提供者
 			NewRespInfoProtobufProvider(), // Protobuf响应编解码提供者
 			NewRespInfoMsgpackProvider(),  // Msgpack响应编解码提供者
+			NewRespInfoCBORProvider(),     // CBOR响应编解码提供者
+			NewRespInfoXMLProvider(),      // XML响应编解码提供者
+			NewRespInfoYAMLProvider(),     // YAML响应编解码提供者
 			// more...
 		)
 	})
@@ -152,6 +159,8 @@ func DefaultPManagers(ctx IApplicationContext) *DefaultPManagerCollection {
 			NewRecoveryPManagerOnce(ctx),
 			// 响应编解码管理器
 			NewRespInfoPManager(ctx),
+			// 配置来源管理器（绑定引导配置位点）
+			NewConfigSourcePManager(ctx),
 			// more...
 		)
 	})
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/lamxy/fiberhouse/constant"
+	"github.com/lamxy/fiberhouse/exception"
+	"github.com/lamxy/fiberhouse/response"
+)
+
+// errorEnvelopeLocalsKey UseErrorEnvelope 写入请求级数据的键
+const errorEnvelopeLocalsKey = "fiberhouse.errorEnvelope"
+
+// ErrorDetail 错误处理器与恢复中间件归类后的错误，交给 ErrorEnvelope 写入响应
+type ErrorDetail struct {
+	// Status HTTP 状态码
+	Status int
+	// Code 业务错误码
+	Code int
+	// Msg 错误消息
+	Msg string
+	// Data 错误数据，非调试模式下业务异常与未知错误的数据已被清空
+	Data interface{}
+	// Type 问题类型 URI，来自 ExceptionMap 定义，可为空
+	Type string
+	// Validation 是否为验证异常，此时 Data 通常为 ValidateWrapper.Errors 生成的字段到消息的映射
+	Validation bool
+	// Err 原始错误，panic 值不是 error 时为 nil；发送返回后其中的异常对象会被放回对象池，不可保存
+	Err error
+}
+
+// ErrorEnvelope 错误响应信封，决定错误以何种结构写入响应
+type ErrorEnvelope interface {
+	// SendError 把 detail 以 detail.Status 状态码写入响应
+	SendError(c adaptorctx.ICoreContext, detail *ErrorDetail) error
+}
+
+// RespInfoEnvelope 默认的 {code,msg,data} 信封，经响应 facade 按 Accept 协商响应格式
+type RespInfoEnvelope struct{}
+
+// SendError 实现 ErrorEnvelope
+func (RespInfoEnvelope) SendError(c adaptorctx.ICoreContext, detail *ErrorDetail) error {
+	return Response().Reset(detail.Code, detail.Msg, detail.Data).SendWithCtx(c, detail.Status)
+}
+
+// ProblemEnvelope RFC 7807 信封，以 application/problem+json 响应
+//
+// type 取异常定义的 Type，未声明时为 about:blank，此时 title 为状态码的标准描述、detail 为错误消息；
+// 声明了 Type 时 title 为错误消息，字符串 Data 作为 detail。instance 为请求路径，业务错误码作为 code 扩展成员。
+// 验证异常的字段消息映射按字段名排序后作为 errors 扩展成员，其余未使用的 Data 作为 data 扩展成员。
+type ProblemEnvelope struct {
+	// Extensions 返回附加的扩展成员，如 traceId；与标准成员同名的键被忽略
+	Extensions func(c adaptorctx.ICoreContext, detail *ErrorDetail) map[string]interface{}
+}
+
+// SendError 实现 ErrorEnvelope
+func (e ProblemEnvelope) SendError(c adaptorctx.ICoreContext, detail *ErrorDetail) error {
+	problem := NewProblemDetails(c, detail)
+	if e.Extensions != nil {
+		for key, value := range e.Extensions(c, detail) {
+			problem.Extensions[key] = value
+		}
+	}
+	body, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	c.SetHeader("Content-Type", response.MIMEApplicationProblemJSON)
+	return c.Send(detail.Status, body)
+}
+
+// NewProblemDetails 按 ProblemEnvelope 的映射规则由 detail 构造问题详情
+func NewProblemDetails(c adaptorctx.ICoreContext, detail *ErrorDetail) *response.ProblemDetails {
+	problem := &response.ProblemDetails{
+		Type:       detail.Type,
+		Status:     detail.Status,
+		Instance:   c.Path(),
+		Extensions: map[string]interface{}{"code": detail.Code},
+	}
+	data := detail.Data
+	if detail.Type == "" {
+		problem.Title = http.StatusText(detail.Status)
+		problem.Detail = detail.Msg
+	} else {
+		problem.Title = detail.Msg
+		if s, ok := data.(string); ok {
+			problem.Detail, data = s, nil
+		}
+	}
+	if detail.Validation {
+		if fields, ok := problemFieldErrors(data); ok {
+			problem.Extensions["errors"], data = fields, nil
+		}
+	}
+	if data != nil {
+		problem.Extensions["data"] = data
+	}
+	return problem
+}
+
+// problemFieldErrors 把字段到消息的映射转换为按字段名排序的 errors 成员
+func problemFieldErrors(data interface{}) ([]response.ProblemFieldError, bool) {
+	var fields map[string]string
+	switch m := data.(type) {
+	case map[string]string:
+		fields = m
+	case exception.ErrorData:
+		fields = m
+	default:
+		return nil, false
+	}
+	out := make([]response.ProblemFieldError, 0, len(fields))
+	for field, msg := range fields {
+		out = append(out, response.ProblemFieldError{Field: field, Detail: msg})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Field < out[j].Field })
+	return out, true
+}
+
+// UseErrorEnvelope 返回为后续处理器指定错误响应信封的中间件，优先于 BootConfig.ErrorEnvelope
+//
+//	api := router.Group("/api/v2", fiberhouse.UseErrorEnvelope(fiberhouse.ProblemEnvelope{}))
+//
+// 只作用于经过该中间件的请求；未匹配路由等在中间件之前产生的错误仍使用配置的信封。
+func UseErrorEnvelope(envelope ErrorEnvelope) HandlerFunc {
+	return func(c adaptorctx.ICoreContext) error {
+		c.Locals(errorEnvelopeLocalsKey, envelope)
+		return c.Next()
+	}
+}
+
+// errorEnvelopeOf 解析本次请求的错误响应信封：请求级信封优先，其次为 BootConfig.ErrorEnvelope，默认 RespInfoEnvelope
+func errorEnvelopeOf(c adaptorctx.ICoreContext, appCtx IApplicationContext) ErrorEnvelope {
+	if envelope, ok := c.Locals(errorEnvelopeLocalsKey).(ErrorEnvelope); ok && envelope != nil {
+		return envelope
+	}
+	if appCtx == nil {
+		appCtx = applicationContext
+	}
+	if appCtx != nil && appCtx.GetBootConfig() != nil && appCtx.GetBootConfig().ErrorEnvelope == constant.ErrorEnvelopeWithProblem {
+		return ProblemEnvelope{}
+	}
+	return RespInfoEnvelope{}
+}
+
+// statusOr 返回 status，为 0 时返回 fallback
+func statusOr(status, fallback int) int {
+	if status == 0 {
+		return fallback
+	}
+	return status
+}
@@ -0,0 +1,182 @@
+package fiberhouse
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	adaptorerrorhandler "github.com/lamxy/fiberhouse/adaptor/errorhandler"
+	"github.com/lamxy/fiberhouse/constant"
+	"github.com/lamxy/fiberhouse/exception"
+	"github.com/lamxy/fiberhouse/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envelopeTestOrderType = "https://errors.example.com/order-not-found"
+
+func installEnvelopeTestExceptions(t *testing.T, ctx IApplicationContext) {
+	t.Helper()
+	key := constant.RegisterKeyPrefix + "exceptions"
+	wasRegistered := ctx.GetContainer().IsRegistered(key)
+	var previous interface{}
+	if wasRegistered {
+		var err error
+		previous, err = ctx.GetContainer().Get(key)
+		require.NoError(t, err)
+	}
+	ctx.GetContainer().Unregister(key)
+	require.True(t, ctx.GetContainer().Register(key, func() (interface{}, error) {
+		return exception.ExceptionMap{
+			"OrderNotFound":   {Code: 4404, Msg: "order not found", Status: http.StatusNotFound, Type: envelopeTestOrderType},
+			"InputParamError": {Code: 4000, Msg: "invalid input", Status: http.StatusUnprocessableEntity},
+			"UnknownError":    {Code: constant.UnknownErrCode, Msg: constant.UnknownErrMsg},
+		}, nil
+	}))
+	t.Cleanup(func() {
+		ctx.GetContainer().Unregister(key)
+		if wasRegistered {
+			require.True(t, ctx.GetContainer().Register(key, func() (interface{}, error) {
+				return previous, nil
+			}))
+		}
+	})
+}
+
+// registerEnvelopeTestRoutes /v2 分组使用 problem 信封并附加 traceId 扩展成员，其余路由使用配置的信封
+func registerEnvelopeTestRoutes(r Router) {
+	routes := func(g Router) {
+		g.Get("/orders/:id", func(adaptorctx.ICoreContext) error {
+			return exception.Get("OrderNotFound").RespData("order is archived")
+		})
+		g.Get("/items", func(adaptorctx.ICoreContext) error {
+			return exception.VeGet("InputParamError").RespData(map[string]string{"name": "name is required", "age": "age must be positive"})
+		})
+		g.Get("/fail", func(adaptorctx.ICoreContext) error {
+			return errors.New("database detail")
+		})
+		g.Get("/panic", func(adaptorctx.ICoreContext) error {
+			panic(exception.Get("OrderNotFound"))
+		})
+	}
+	routes(r)
+	routes(r.Group("/v2", UseErrorEnvelope(ProblemEnvelope{
+		Extensions: func(c adaptorctx.ICoreContext, detail *ErrorDetail) map[string]interface{} {
+			return map[string]interface{}{"traceId": c.GetHeader("X-Trace-ID"), "status": 0}
+		},
+	})))
+}
+
+func TestErrorEnvelope_ProblemDetailsByConfigAndRouteGroup(t *testing.T) {
+	for _, core := range []string{"fiber", "gin"} {
+		for _, configured := range []string{constant.ErrorEnvelopeWithRespInfo, constant.ErrorEnvelopeWithProblem} {
+			t.Run(core+"/"+configured, func(t *testing.T) {
+				ctx := newTask5AppContext(t, false, false)
+				ctx.GetBootConfig().ErrorEnvelope = configured
+				installTask5ResponseManager(t, ctx)
+				installEnvelopeTestExceptions(t, ctx)
+				handler := newTask5ErrorHandler(ctx, NewFiberRecovery(ctx))
+				cfg := RecoverConfig{AppCtx: ctx, StackTraceHandler: func(adaptorctx.ICoreContext, interface{}) {}}
+
+				var serve func(path string) (int, string, []byte)
+				switch core {
+				case "fiber":
+					app := fiber.New(fiber.Config{ErrorHandler: adaptorerrorhandler.FiberErrorHandler(handler.ErrorHandler)})
+					app.Use(NewFiberRecovery(ctx).RecoverPanic(cfg).(fiber.Handler))
+					r, err := NewRouter(app)
+					require.NoError(t, err)
+					registerEnvelopeTestRoutes(r)
+					serve = func(path string) (int, string, []byte) {
+						req := httptest.NewRequest(http.MethodGet, path, nil)
+						req.Header.Set("X-Trace-ID", "trace-1")
+						resp, err := app.Test(req)
+						require.NoError(t, err)
+						body, err := io.ReadAll(resp.Body)
+						require.NoError(t, err)
+						return resp.StatusCode, resp.Header.Get("Content-Type"), body
+					}
+				case "gin":
+					preserveTask4GinMode(t)
+					gin.SetMode(gin.TestMode)
+					engine := gin.New()
+					engine.Use(gin.HandlerFunc(NewGinRecovery(ctx).RecoverPanic(cfg).(func(*gin.Context))))
+					engine.Use(adaptorerrorhandler.GinErrorHandler(handler.ErrorHandler))
+					r, err := NewRouter(engine)
+					require.NoError(t, err)
+					registerEnvelopeTestRoutes(r)
+					serve = func(path string) (int, string, []byte) {
+						req := httptest.NewRequest(http.MethodGet, path, nil)
+						req.Header.Set("X-Trace-ID", "trace-1")
+						recorder := httptest.NewRecorder()
+						engine.ServeHTTP(recorder, req)
+						return recorder.Code, recorder.Header().Get("Content-Type"), recorder.Body.Bytes()
+					}
+				}
+
+				for _, prefix := range []string{"", "/v2"} {
+					problem := prefix == "/v2" || configured == constant.ErrorEnvelopeWithProblem
+					extension := ""
+					if prefix == "/v2" {
+						extension = `,"traceId":"trace-1"`
+					}
+					cases := []struct {
+						path, problem, envelope string
+						status                  int
+					}{
+						{
+							path:     "/orders/7",
+							status:   http.StatusNotFound,
+							problem:  `{"type":"` + envelopeTestOrderType + `","title":"order not found","status":404,"instance":"` + prefix + `/orders/7","code":4404` + extension + `}`,
+							envelope: `{"code":4404,"msg":"order not found","data":null}`,
+						},
+						{
+							path:     "/items",
+							status:   http.StatusUnprocessableEntity,
+							problem:  `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"invalid input","instance":"` + prefix + `/items","code":4000,"errors":[{"field":"age","detail":"age must be positive"},{"field":"name","detail":"name is required"}]` + extension + `}`,
+							envelope: `{"code":4000,"msg":"invalid input","data":{"age":"age must be positive","name":"name is required"}}`,
+						},
+						{
+							path:     "/fail",
+							status:   http.StatusInternalServerError,
+							problem:  `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"` + constant.UnknownErrMsg + `","instance":"` + prefix + `/fail","code":500000` + extension + `}`,
+							envelope: `{"code":500000,"msg":"` + constant.UnknownErrMsg + `","data":null}`,
+						},
+						{
+							path:     "/panic",
+							status:   http.StatusNotFound,
+							problem:  `{"type":"` + envelopeTestOrderType + `","title":"order not found","status":404,"instance":"` + prefix + `/panic","code":4404` + extension + `}`,
+							envelope: `{"code":4404,"msg":"order not found","data":null}`,
+						},
+					}
+					for _, tc := range cases {
+						status, contentType, body := serve(prefix + tc.path)
+						assert.Equal(t, tc.status, status, prefix+tc.path)
+						if problem {
+							assert.Equal(t, response.MIMEApplicationProblemJSON, contentType, prefix+tc.path)
+							assert.JSONEq(t, tc.problem, string(body), prefix+tc.path)
+						} else {
+							assert.Contains(t, contentType, "application/json", prefix+tc.path)
+							assert.JSONEq(t, tc.envelope, string(body), prefix+tc.path)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestProblemDetails_MarshalKeepsStandardMembers(t *testing.T) {
+	body, err := json.Marshal(&response.ProblemDetails{
+		Title:      "Bad Request",
+		Status:     http.StatusBadRequest,
+		Extensions: map[string]interface{}{"type": "ignored", "detail": "ignored", "balance": 30},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"about:blank","title":"Bad Request","status":400,"balance":30}`, string(body))
+}
@@ -1,10 +1,10 @@
 package example
 
 import (
-	"github.com/gofiber/fiber/v2"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
 )
 
 // HealthApiIFace 健康检查
 type HealthApiIFace interface {
-	Liveness(c *fiber.Ctx) error
+	Liveness(c adaptorctx.ICoreContext) error
 }
@@ -108,6 +108,25 @@ func (app *Application) ConfigGlobalInitializers() globalmanager.InitializerMap
 	}
 }
 
+// ConfigGlobalDependencies 配置全局对象之间的依赖（实现可选接口 fiberhouse.GlobalDependencyConfigurer）
+// 框架启动时校验依赖图并按依赖并行初始化；重建 redis 时级联重建远程缓存与二级缓存，释放时依赖方先于其依赖
+func (app *Application) ConfigGlobalDependencies() globalmanager.DependencyMap {
+	return globalmanager.DependencyMap{
+		KEY_REMOTE_CACHE: {KEY_REDIS},
+		KEY_LEVEL2_CACHE: {KEY_LOCAL_CACHE, KEY_REMOTE_CACHE},
+	}
+}
+
+// ConfigReloadRebuilds 配置热更新时需要重建的全局对象（实现可选接口 fiberhouse.ConfigReloadConfigurer）
+// 对应配置段变更后重建已初始化的连接对象，redis 重建后按依赖图级联重建远程缓存与二级缓存
+func (app *Application) ConfigReloadRebuilds() map[string][]globalmanager.KeyName {
+	return map[string][]globalmanager.KeyName{
+		"cache.redis":      {KEY_REDIS},
+		"database.mysql":   {KEY_MYSQL},
+		"database.mongodb": {KEY_MONGODB},
+	}
+}
+
 // ConfigRequiredGlobalKeys 配置并返回全局管理容器中在启动时必须初始化的key
 // 应用启动阶段必须完成初始化的全局对象清单，此处列举后，框架启动阶段自动完成对象创建的初始化工作
 // 可交给全局对象初始化提供者实现
@@ -6,27 +6,27 @@ import (
 
 	"github.com/cloudwego/hertz/pkg/app/server"
 	"github.com/lamxy/fiberhouse"
-	hertzconst "github.com/lamxy/fiberhouse/example_application/hertzcore/constant"
+	"github.com/lamxy/fiberhouse/constant"
 )
 
-// HertzAppHookProvider 基於 hertz 的生命週期鉤子提供者
+// HertzAppHookProvider 基于 hertz 的生命周期钩子提供者
 type HertzAppHookProvider struct {
 	fiberhouse.IProvider
 }
 
-// NewHertzAppHookProvider 建立 hertz 生命週期鉤子提供者
+// NewHertzAppHookProvider 创建 hertz 生命周期钩子提供者
 func NewHertzAppHookProvider() *HertzAppHookProvider {
 	son := &HertzAppHookProvider{
 		IProvider: fiberhouse.NewProvider().
 			SetName("HertzAppHookProvider").
-			SetTarget(hertzconst.CoreTypeWithHertz).
+			SetTarget(constant.CoreTypeWithHertz).
 			SetType(fiberhouse.ProviderTypeDefault().GroupCoreHookChoose),
 	}
 	son.MountToParent(son)
 	return son
 }
 
-// Initialize 註冊 hertz 生命週期鉤子
+// Initialize 注册 hertz 生命周期钩子
 func (p *HertzAppHookProvider) Initialize(ctx fiberhouse.IContext, initFunc ...fiberhouse.ProviderInitFunc) (any, error) {
 	if len(initFunc) == 0 {
 		return nil, fmt.Errorf("provider '%s': initFunc must not be empty", p.Name())
@@ -46,7 +46,7 @@ func (p *HertzAppHookProvider) Initialize(ctx fiberhouse.IContext, initFunc ...f
 	return nil, nil
 }
 
-// RegisterHertzAppCoreHook 註冊應用自定義的 hertz 生命週期鉤子
+// RegisterHertzAppCoreHook 注册应用自定义的 hertz 生命周期钩子
 func RegisterHertzAppCoreHook(appCtx fiberhouse.IApplicationContext, cs fiberhouse.CoreStarter) {
 	h, ok := cs.GetCoreApp().(*server.Hertz)
 	if !ok {
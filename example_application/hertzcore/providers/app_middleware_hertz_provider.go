@@ -8,34 +8,34 @@ import (
 	"github.com/cloudwego/hertz/pkg/app/server"
 	"github.com/google/uuid"
 	"github.com/lamxy/fiberhouse"
-	hertzconst "github.com/lamxy/fiberhouse/example_application/hertzcore/constant"
+	"github.com/lamxy/fiberhouse/constant"
 )
 
-// traceIDKey 追蹤 ID 在 hertz 上下文與回應頭中的鍵名，
-// 與 hertzcore/recovery 的取值鍵保持一致
+// traceIDKey 追踪 ID 在 hertz 上下文与响应头中的键名，
+// 与框架 HertzRecovery 的取值键保持一致
 const (
 	traceIDKey    = "traceId"
 	traceIDHeader = "X-Request-Id"
 )
 
-// HertzAppMiddlewareProvider 基於 hertz 的應用級中間件提供者
+// HertzAppMiddlewareProvider 基于 hertz 的应用级中间件提供者
 type HertzAppMiddlewareProvider struct {
 	fiberhouse.IProvider
 }
 
-// NewHertzAppMiddlewareProvider 建立 hertz 應用中間件提供者
+// NewHertzAppMiddlewareProvider 创建 hertz 应用中间件提供者
 func NewHertzAppMiddlewareProvider() *HertzAppMiddlewareProvider {
 	son := &HertzAppMiddlewareProvider{
 		IProvider: fiberhouse.NewProvider().
 			SetName("HertzAppMiddlewareProvider").
-			SetTarget(hertzconst.CoreTypeWithHertz).
+			SetTarget(constant.CoreTypeWithHertz).
 			SetType(fiberhouse.ProviderTypeDefault().GroupMiddlewareRegisterType),
 	}
 	son.MountToParent(son)
 	return son
 }
 
-// Initialize 註冊應用級中間件到 hertz 引擎
+// Initialize 注册应用级中间件到 hertz 引擎
 func (p *HertzAppMiddlewareProvider) Initialize(ctx fiberhouse.IContext, initFunc ...fiberhouse.ProviderInitFunc) (any, error) {
 	if len(initFunc) == 0 {
 		return nil, fmt.Errorf("provider '%s': initFunc must not be empty", p.Name())
@@ -56,18 +56,18 @@ func (p *HertzAppMiddlewareProvider) Initialize(ctx fiberhouse.IContext, initFun
 		return nil, fmt.Errorf("provider '%s': core app must be *server.Hertz, got %T", p.Name(), cs.GetCoreApp())
 	}
 
-	// 註冊 TraceId 中間件：hertz 無內建 requestid，此處以 uuid 產生並回寫回應頭
+	// 注册 TraceId 中间件：hertz 无内置 requestid，此处以 uuid 生成并回写响应头
 	h.Use(requestIDMiddleware())
 
-	// 其他中間件...
+	// 其他中间件...
 
 	p.SetStatus(fiberhouse.StateLoaded)
 	return h, nil
 }
 
-// requestIDMiddleware 為每個請求產生追蹤 ID，供恢復中間件與日誌鏈路取用。
+// requestIDMiddleware 为每个请求生成追踪 ID，供恢复中间件与日志链路取用。
 //
-// 已帶入 X-Request-Id 請求頭時沿用該值，以支援跨服務的鏈路串接。
+// 已携带 X-Request-Id 请求头时沿用该值，以支持跨服务的链路串联。
 func requestIDMiddleware() app.HandlerFunc {
 	return func(c ctxpkg.Context, reqCtx *app.RequestContext) {
 		traceID := string(reqCtx.GetHeader(traceIDHeader))
@@ -6,29 +6,30 @@ import (
 	"github.com/cloudwego/hertz/pkg/app/server"
 	hertzSwagger "github.com/hertz-contrib/swagger"
 	"github.com/lamxy/fiberhouse"
-	hertzconst "github.com/lamxy/fiberhouse/example_application/hertzcore/constant"
+	"github.com/lamxy/fiberhouse/constant"
 	exampleHertzApi "github.com/lamxy/fiberhouse/example_application/module/example-hertzapi-module/api"
+	exampleApi "github.com/lamxy/fiberhouse/example_application/module/example-module/api"
 	swaggerFiles "github.com/swaggo/files"
 )
 
-// HertzRouteRegisterProvider 基於 hertz 的模組路由與 swagger 註冊提供者
+// HertzRouteRegisterProvider 基于 hertz 的模块路由与 swagger 注册提供者
 type HertzRouteRegisterProvider struct {
 	fiberhouse.IProvider
 }
 
-// NewHertzRouteRegisterProvider 建立 hertz 路由註冊提供者
+// NewHertzRouteRegisterProvider 创建 hertz 路由注册提供者
 func NewHertzRouteRegisterProvider() *HertzRouteRegisterProvider {
 	son := &HertzRouteRegisterProvider{
 		IProvider: fiberhouse.NewProvider().
 			SetName("HertzRouteRegisterProvider").
-			SetTarget(hertzconst.CoreTypeWithHertz).
+			SetTarget(constant.CoreTypeWithHertz).
 			SetType(fiberhouse.ProviderTypeDefault().GroupRouteRegisterType),
 	}
 	son.MountToParent(son)
 	return son
 }
 
-// Initialize 註冊 hertz 路由處理器
+// Initialize 注册 hertz 路由处理器
 func (p *HertzRouteRegisterProvider) Initialize(ctx fiberhouse.IContext, initFunc ...fiberhouse.ProviderInitFunc) (any, error) {
 	if len(initFunc) == 0 {
 		return nil, fmt.Errorf("provider '%s': no initFunc provided", p.Name())
@@ -46,33 +47,40 @@ func (p *HertzRouteRegisterProvider) Initialize(ctx fiberhouse.IContext, initFun
 
 	appCtx := ctx.(fiberhouse.IApplicationContext)
 
-	// 註冊路由
+	// 注册路由
 	RegisterHertzRouteHandlers(appCtx, cs)
 
-	// 註冊 Swagger 路由
+	// 注册 Swagger 路由
 	RegisterHertzSwagger(appCtx, cs)
 
 	return nil, nil
 }
 
-// RegisterHertzRouteHandlers 註冊各業務模組的 hertz 路由處理器
+// RegisterHertzRouteHandlers 注册各业务模块的 hertz 路由处理器
 func RegisterHertzRouteHandlers(ctx fiberhouse.IApplicationContext, cs fiberhouse.CoreStarter) {
 	h, ok := cs.GetCoreApp().(*server.Hertz)
 	if !ok {
 		return
 	}
 
-	// 註冊 example 模組的路由處理器
+	// 注册 example 模块的路由处理器
 	exampleHertzApi.RegisterRouteHandlers(ctx, h)
 
-	// TODO 註冊更多業務模組路由處理器 ...
+	// 注册与核心无关的路由处理器（含 CI 冒烟探测的 GET /health/livez），与 Fiber、Gin 核心共用同一份实现
+	router, err := fiberhouse.NewRouter(h)
+	if err != nil {
+		panic(err)
+	}
+	exampleApi.RegisterCoreRouteHandlers(ctx, router)
+
+	// TODO 注册更多业务模块路由处理器 ...
 }
 
-// RegisterHertzSwagger 註冊 Swagger UI 路由
+// RegisterHertzSwagger 注册 Swagger UI 路由
 //
-// 與 Fiber/Gin 適配器一致，由 application.swagger.enable 配置開關控制。
-// 注意：swaggo 註解單一來源於 Fiber handler（見 example_application/docs/README.md），
-// 本適配器僅負責掛載 UI 路由，不重複標註 @Router，避免 swag init 產生重複路由衝突。
+// 与 Fiber/Gin 适配器一致，由 application.swagger.enable 配置开关控制。
+// 注意：swaggo 注解单一来源于 Fiber handler（见 example_application/docs/README.md），
+// 本适配器仅负责挂载 UI 路由，不重复标注 @Router，避免 swag init 产生重复路由冲突。
 func RegisterHertzSwagger(ctx fiberhouse.IApplicationContext, cs fiberhouse.CoreStarter) {
 	h, ok := cs.GetCoreApp().(*server.Hertz)
 	if !ok {
@@ -85,5 +93,5 @@ func RegisterHertzSwagger(ctx fiberhouse.IApplicationContext, cs fiberhouse.Core
 
 	h.GET("/swagger/*any", hertzSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// TODO 設置安全訪問配置
+	// TODO 设置安全访问配置
 }
@@ -1,8 +1,10 @@
 package api
 
 import (
-	"github.com/gofiber/fiber/v2"
+	"net/http"
+
 	"github.com/lamxy/fiberhouse"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
 	"github.com/lamxy/fiberhouse/example_application/module/constant"
 	"github.com/lamxy/fiberhouse/example_application/module/example-module/service"
 	"github.com/lamxy/fiberhouse/response"
@@ -25,7 +27,8 @@ func GetKeyHealthHandler(ns ...string) string {
 	return fiberhouse.RegisterKeyName("HealthHandler", fiberhouse.GetNamespace([]string{constant.NameModuleExample}, ns...)...)
 }
 
-func (ha *HealthHandler) Liveness(c *fiber.Ctx) error {
+// Liveness 处理 GET /health/livez，只依赖 ICoreContext，Fiber、Gin 与 Hertz 核心共用
+func (ha *HealthHandler) Liveness(c adaptorctx.ICoreContext) error {
 	result := ha.Service.GetHealth()
-	return c.Status(fiber.StatusOK).JSON(response.SuccessWithData(result))
+	return c.JSON(http.StatusOK, response.SuccessWithData(result))
 }
@@ -12,13 +12,22 @@ func RegisterRouteHandlers(ctx fiberhouse.IApplicationContext, app fiber.Router)
 	exampleAPI, _ := InjectExampleApi(ctx) // 存在构造注入依赖，内部依赖定位层组件，由 wire 编译期解决依赖后注入
 	registerExampleRoutes(app, exampleAPI)
 
-	healthAPI, _ := InjectHealthApi(ctx) // 同上。备注：框架根下 component/container/ 目录中提供了基于 dig 封装的依赖注入容器组件，可以替换 wire 注入。但 dig 推荐仅用于应用启动阶段
-	registerHealthRoutes(app, healthAPI)
+	router, err := fiberhouse.NewRouter(app)
+	if err != nil {
+		panic(err)
+	}
+	RegisterCoreRouteHandlers(ctx, router)
 
 	commonAPI := NewCommonHandler(ctx) // 直接 New 构造，无需依赖注入(Wire)，内部依赖走全局管理器延迟初始化和获取依赖组件，见 common_api.go: api.CommonHandler
 	registerCommonRoutes(app, commonAPI)
 }
 
+// RegisterCoreRouteHandlers 注册只依赖 fiberhouse.Router 的路由，Fiber、Gin 与 Hertz 的路由注册器共用同一份实现
+func RegisterCoreRouteHandlers(ctx fiberhouse.IApplicationContext, router fiberhouse.Router) {
+	healthAPI, _ := InjectHealthApi(ctx) // 存在构造注入依赖，由 wire 编译期解决。备注：框架根下 component/container/ 目录中提供了基于 dig 封装的依赖注入容器组件，可以替换 wire 注入。但 dig 推荐仅用于应用启动阶段
+	registerHealthRoutes(router, healthAPI)
+}
+
 // registerExampleRoutes 注册样例模块路由
 func registerExampleRoutes(router fiber.Router, handler *ExampleHandler) {
 	router.Post("/examples", handler.Create)
@@ -29,9 +38,11 @@ func registerExampleRoutes(router fiber.Router, handler *ExampleHandler) {
 }
 
 // registerHealthRoutes 注册与应用健康状态的路由
-func registerHealthRoutes(router fiber.Router, handler *HealthHandler) {
+//
+// CI 冒烟测试探测 GET /health/livez，任何作为 example_main 默认核心的适配器都必须提供该路由。
+func registerHealthRoutes(router fiberhouse.Router, handler *HealthHandler) {
 	healthGroup := router.Group("/health")
-	healthGroup.Get("/livez", handler.Liveness).Name("health_liveness")
+	healthGroup.Get("/livez", handler.Liveness)
 }
 
 // registerCommonRoutes 注册公共部分的路由
@@ -38,5 +38,5 @@ func NewExampleChangedTask(ctx fiberhouse.IContext, payload ExampleChangedPayloa
 	if err != nil {
 		return nil, err
 	}
-	return asynq.NewTask(TypeExampleChanged, encoded), nil
+	return fiberhouse.NewTask(TypeExampleChanged, encoded), nil
 }
@@ -99,6 +99,7 @@ func (ta *TaskAsync) RegisterTaskServerToContainer() {
 }
 
 // RegisterKeyTaskDispatcher 注册异步任务分发器初始化器到全局容器
+// 声明依赖 redis，重建 redis 时级联重建任务分发器
 func (ta *TaskAsync) RegisterTaskDispatcherToContainer() {
 	if !ta.Ctx.GetConfig().Bool("application.task.enableServer") {
 		return
@@ -118,7 +119,7 @@ func (ta *TaskAsync) RegisterTaskDispatcherToContainer() {
 			return nil, fmt.Errorf("construct task dispatcher from redis instance %q", redisKey)
 		}
 		return dispatcher, nil
-	})
+	}, ta.Ctx.GetStarterApp().GetApplication().GetRedisKey())
 }
 
 func isNilRedisClient(client cache.IRedisClient) bool {
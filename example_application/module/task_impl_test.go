@@ -65,9 +65,10 @@ func TestTaskAsyncDispatcherInitializerRejectsInvalidRedisInstances(t *testing.T
 		wantErr  string
 	}{
 		{
+			// 分发器声明依赖 redis，依赖缺失在执行初始化器前即被依赖图拒绝
 			name:     "lookup failure",
 			register: func(*testing.T, *TaskAsync, string) {},
-			wantErr:  "get redis instance",
+			wantErr:  "global object dependency not registered",
 		},
 		{
 			name: "wrong type",
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/lamxy/fiberhouse"
 	exampleGinApi "github.com/lamxy/fiberhouse/example_application/module/example-ginapi-module/api"
+	exampleApi "github.com/lamxy/fiberhouse/example_application/module/example-module/api"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -14,6 +15,13 @@ func RegisterGinRouteHandlers(ctx fiberhouse.IApplicationContext, cs fiberhouse.
 	// 注册example模块的路由处理器
 	exampleGinApi.RegisterRouteHandlers(ctx, app)
 
+	// 注册与核心无关的路由处理器，与 Fiber 核心共用同一份实现
+	router, err := fiberhouse.NewCoreRouter(cs)
+	if err != nil {
+		panic(err)
+	}
+	exampleApi.RegisterCoreRouteHandlers(ctx, router)
+
 	// TODO 注册更多业务模块路由处理器 ...
 
 }
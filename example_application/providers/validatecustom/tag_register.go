@@ -5,11 +5,11 @@ import (
 	tags2 "github.com/lamxy/fiberhouse/example_application/providers/validatecustom/tags"
 )
 
-// GetValidatorTagFuncs 获取注册指定或自定义tag及翻译提示
+// GetValidatorTagFuncs 获取注册指定或自定义tag
+//
+// tag 的翻译提示不在此处注册，由 application.i18n.dirs 加载的消息文件（键 validate.<tag>）提供，如 example_config/i18n/zh-cn.yaml
 func GetValidatorTagFuncs() []validate.RegisterValidatorTagFunc {
 	return []validate.RegisterValidatorTagFunc{
-		tags2.StartswithRegisterTranslation,
 		tags2.HascoursesRegisterValidation,
-		tags2.HascoursesRegisterTranslation,
 	}
 }
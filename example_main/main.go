@@ -1,10 +1,12 @@
 package main
 
 import (
+	"os"
+
 	"github.com/lamxy/fiberhouse"
+	"github.com/lamxy/fiberhouse/appconfig"
 	"github.com/lamxy/fiberhouse/constant"
 	_ "github.com/lamxy/fiberhouse/example_application/docs" // swagger docs
-	hertzconst "github.com/lamxy/fiberhouse/example_application/hertzcore/constant"
 	hertzproviders "github.com/lamxy/fiberhouse/example_application/hertzcore/providers"
 	"github.com/lamxy/fiberhouse/example_application/providers/apphook"
 	"github.com/lamxy/fiberhouse/example_application/providers/middleware"
@@ -60,7 +62,7 @@ func main() {
 		AppName:                     "Default FiberHouse Application",          // 应用名称
 		Version:                     Version,                                   // 应用版本
 		FrameType:                   constant.FrameTypeWithDefaultFrameStarter, // 默认提供的框架启动器标识: DefaultFrameStarter
-		CoreType:                    hertzconst.CoreTypeWithHertz,              // fiber | gin | hertz | ... // 如果需要切换核心框架，只需修改此处；如果框架支持设置编译标签，编译时指定了核心，那么此处必须跟标签核心一致
+		CoreType:                    constant.CoreTypeWithHertz,                // fiber | gin | hertz | ... // 如果需要切换核心框架，只需修改此处；如果框架支持设置编译标签，编译时指定了核心，那么此处必须跟标签核心一致
 		TrafficCodec:                constant.TrafficCodecWithSonic,            // 传输流量的编解码器: sonic_json_codec|std_json_codec|go_json_codec|pb...
 		EnableBinaryProtocolSupport: true,                                      // 是否启用二进制协议支持，如Protobuf等
 		ConfigPath:                  "./example_config",                        // 应用全局配置路径
@@ -92,20 +94,17 @@ func main() {
 		// 更多基于其他核心框架的模块路由注册提供者
 		// ...
 
-		// ===== hertz 核心引擎扩展（全部位于 example_application 之下，未改动框架本身）=====
-		// 核心启动器提供者：由框架的 CoreStarterPManager 依 CoreType 选中
-		hertzproviders.NewCoreStarterHertzProvider(),
-		// JSON 编解码提供者：框架的 JsonCodecPManager 同时按 TrafficCodec 与 CoreType 匹配
-		hertzproviders.NewSonicJCodecHertzProvider(),
-		hertzproviders.NewStdJCodecHertzProvider(),
-		// 恢复中间件提供者：由框架的 RecoveryPManager 依 CoreType 选中
-		hertzproviders.NewHertzRecoveryProvider(),
+		// ===== hertz 应用级扩展（核心启动器、JSON 编解码与恢复中间件提供者已由框架默认提供）=====
 		// 基于 hertz 的应用级中间件提供者
 		hertzproviders.NewHertzAppMiddlewareProvider(),
 		// 基于 hertz 的生命周期 hook 注册提供者
 		hertzproviders.NewHertzAppHookProvider(),
 		// hertz 模块路由注册提供者
 		hertzproviders.NewHertzRouteRegisterProvider(),
+
+		// 额外的配置来源（由默认的配置来源管理器在引导配置位点按优先级加载），可用 --print-config 打印合并结果与来源
+		fiberhouse.NewConfigSourceProvider(appconfig.NewFileSource("./example_config/config.json"), appconfig.PriorityFile),
+		fiberhouse.NewConfigSourceProvider(appconfig.NewDirSource("./example_config/conf.d"), appconfig.PriorityConfD),
 	)
 	managers := fiberhouse.DefaultPManagers(fh.AppCtx).AndMore(
 		// 框架选项初始化管理器，获取框架启动器初始化的选项函数列表
@@ -122,6 +121,8 @@ func main() {
 		// ...
 	)
 
-	// 收集提供者和管理器并运行服务器
-	fh.WithProviders(providers...).WithPManagers(managers...).RunServer()
+	// 收集提供者和管理器并运行服务器，运行或关闭失败（含资源关闭失败）时以非零状态退出
+	if err := fh.WithProviders(providers...).WithPManagers(managers...).RunServer(); err != nil {
+		os.Exit(1)
+	}
 }
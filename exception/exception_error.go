@@ -9,12 +9,15 @@
 package exception
 
 import (
+	"errors"
 	"fmt"
 	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/lamxy/fiberhouse/component/i18n"
 	"github.com/lamxy/fiberhouse/constant"
 	"github.com/lamxy/fiberhouse/globalmanager"
 	"github.com/lamxy/fiberhouse/response"
 	"net/http"
+	"sort"
 )
 
 /*
@@ -29,6 +32,8 @@ app.Get("/recover", func(c *fiber.Ctx) error {
 		exception.Panic("InputParamError") // panic抛出业务异常
 		// or
 		exception.Throw("InputParamError") // panic抛出业务异常
+	} else if p == "error" {
+		return exception.Err("InputParamError") // 以 error 返回业务异常，按异常定义的 Status 响应，不经过 recover
 	} else if p == "default" {
 		return errors.New("default error return")  // 直接返回错误
 	}
@@ -56,42 +61,164 @@ func (e *Exception) Release() {
 	(*response.RespInfo)(e).Release()
 }
 
-// Get 方法用于获取业务异常（直接 panic 后可被 recover 捕获）。
-func Get(key string) *Exception {
-	//exceptions := GetGlobalExceptions()
-	gm := globalmanager.NewGlobalManagerOnce()
+// lookup 从全局管理器注册的异常表读取 key 的定义，异常表未注册时返回错误
+func lookup(key string) (Exception, bool, error) {
 	k := constant.RegisterKeyPrefix + "exceptions"
-	v, err := gm.Get(k)
+	v, err := globalmanager.NewGlobalManagerOnce().Get(k)
 	if err != nil || v == nil {
-		panic(fmt.Errorf("exceptions: %s not found, please make sure you have registered exceptions in global manager", k))
+		return Exception{}, false, fmt.Errorf("exceptions: %s not found, please make sure you have registered exceptions in global manager", k)
 	}
-	exceptions := v.(ExceptionMap)
-	if respInfo, ok := exceptions[key]; ok {
-		return New(respInfo.Code, respInfo.Msg, respInfo.Data)
+	exceptions, ok := v.(ExceptionMap)
+	if !ok {
+		return Exception{}, false, fmt.Errorf("exceptions: %s is %T, want exception.ExceptionMap", k, v)
+	}
+	respInfo, ok := exceptions[key]
+	return respInfo, ok, nil
+}
+
+// Get 方法用于获取业务异常（直接 panic 后可被 recover 捕获）。
+func Get(key string) *Exception {
+	respInfo, ok, err := lookup(key)
+	if err != nil {
+		panic(err)
+	}
+	if ok {
+		return New(respInfo.Code, respInfo.Msg, respInfo.Data).withDefinition(key, &respInfo)
 	}
 	return New(constant.UnknownErrCode, constant.UnknownErrMsg)
 }
 
 // Throw 方法用于抛出业务异常（直接 panic）。
 func Throw(key string, d ...interface{}) {
-	gm := globalmanager.NewGlobalManagerOnce()
-	k := constant.RegisterKeyPrefix + "exceptions"
-	v, err := gm.Get(k)
-	if err != nil || v == nil {
-		panic(fmt.Errorf("exceptions: %s not found, please make sure you have registered exceptions in global manager", k))
+	respInfo, ok, err := lookup(key)
+	if err != nil {
+		panic(err)
+	}
+	if ok {
+		panic(New(respInfo.Code, respInfo.Msg, respInfo.Data).withDefinition(key, &respInfo).RespData(d...))
+	}
+	panic(New(constant.UnknownErrCode, constant.UnknownErrMsg))
+}
+
+// Err 返回 key 对应的业务异常，供处理器作为 error 返回，不会 panic
+//
+// 异常表未注册或 key 不存在时返回 HTTP 状态码为 500 的未知异常，并以 Cause 记录原因。
+// 错误处理器按异常的 Status（未声明时为 400）响应，errors.Is(err, exception.Key(key)) 可匹配返回的异常。
+//
+//	if order == nil {
+//		return exception.Err("OrderNotFound", id)
+//	}
+func Err(key string, d ...interface{}) *Exception {
+	respInfo, ok, err := lookup(key)
+	if err == nil && !ok {
+		err = fmt.Errorf("exceptions: key %q is not registered", key)
+	}
+	if err != nil {
+		e := New(constant.UnknownErrCode, constant.UnknownErrMsg)
+		e.Status = http.StatusInternalServerError
+		e.Cause = err
+		return e
 	}
-	exceptions := v.(ExceptionMap)
-	if respInfo, ok := exceptions[key]; ok {
-		if len(d) > 0 {
-			if errData, ok := d[0].(error); ok {
-				respInfo.Data = errData.Error()
-			} else {
-				respInfo.Data = d[0]
+	return New(respInfo.Code, respInfo.Msg, respInfo.Data).withDefinition(key, &respInfo).RespData(d...)
+}
+
+// Wrap 同 Err，并以 cause 作为原始错误保留在错误链中，日志输出 Error() 时包含其消息
+//
+//	if err := repo.Save(ctx, order); err != nil {
+//		return exception.Wrap(err, "OrderSaveFailed")
+//	}
+func Wrap(cause error, key string, d ...interface{}) *Exception {
+	e := Err(key, d...)
+	switch {
+	case cause == nil:
+	case e.Cause == nil:
+		e.Cause = cause
+	default:
+		// key 未能解析时同时保留原始错误与解析失败原因
+		e.Cause = errors.Join(cause, e.Cause)
+	}
+	return e
+}
+
+// withDefinition 记录异常键，并复制 ExceptionMap 定义中的 HTTP 状态码、问题类型与本地化消息
+func (e *Exception) withDefinition(key string, def *Exception) *Exception {
+	e.Key = key
+	e.Status = def.Status
+	e.Type = def.Type
+	e.Messages = def.Messages
+	return e
+}
+
+// WithArgs 设置插值消息模板 {name} 占位符的参数，与已设置的参数合并
+//
+//	return exception.Err("OrderNotFound").WithArgs(exception.Args{"id": id})
+func (e *Exception) WithArgs(args Args) *Exception {
+	if e.Args == nil {
+		e.Args = make(map[string]interface{}, len(args))
+	}
+	for name, value := range args {
+		e.Args[name] = value
+	}
+	return e
+}
+
+// Langs 返回可本地化该异常的语言，按字母序排列，供请求语言协商：异常定义 Messages 声明的语言，
+// 以及 i18n.Default() 目录中有 exception.<Key> 消息的语言
+func (e *Exception) Langs() []string {
+	langs := make([]string, 0, len(e.Messages))
+	seen := make(map[string]bool, len(e.Messages))
+	for lang := range e.Messages {
+		langs = append(langs, lang)
+		seen[i18n.Normalize(lang)] = true
+	}
+	if e.Key != "" {
+		catalog := i18n.Default()
+		for _, lang := range catalog.Langs() {
+			if _, ok := catalog.LookupLang(lang, MessageKeyPrefix+e.Key); ok && !seen[lang] {
+				langs = append(langs, lang)
 			}
 		}
-		panic(New(respInfo.Code, respInfo.Msg, respInfo.Data))
 	}
-	panic(New(constant.UnknownErrCode, constant.UnknownErrMsg))
+	sort.Strings(langs)
+	return langs
+}
+
+// MessageKeyPrefix 异常消息在 i18n 消息目录中的键前缀，完整的键为 MessageKeyPrefix + 异常键
+const MessageKeyPrefix = "exception."
+
+// Localize 返回 lang 语言的异常消息，并以 Args 插值占位符
+//
+// lang 通常为 i18n.Resolver 协商的请求语言。依次取异常定义 Messages 中 lang（不区分大小写）的消息、
+// i18n.Default() 目录中键为 "exception.<Key>" 的消息（按目录的回退链查找），都没有时使用 Msg。
+func (e *Exception) Localize(lang string) string {
+	msg := e.Msg
+	if m, ok := e.definedMessage(lang); ok {
+		msg = m
+	} else if e.Key != "" {
+		if m, _, ok := i18n.Default().Lookup(lang, MessageKeyPrefix+e.Key); ok {
+			msg = m[i18n.PluralOther]
+		}
+	}
+	return i18n.Format(msg, e.Args)
+}
+
+// definedMessage 返回异常定义 Messages 中 lang 的消息
+func (e *Exception) definedMessage(lang string) (string, bool) {
+	if lang = i18n.Normalize(lang); lang == "" {
+		return "", false
+	}
+	for l, m := range e.Messages {
+		if i18n.Normalize(l) == lang {
+			return m, true
+		}
+	}
+	return "", false
+}
+
+// WithStatus 设置错误处理器响应的 HTTP 状态码，覆盖异常定义中的 Status
+func (e *Exception) WithStatus(status int) *Exception {
+	e.Status = status
+	return e
 }
 
 // RespData 方法用于响应错误，并可添加数据参数
@@ -224,41 +351,61 @@ func NewVE(c int, m string, d ...interface{}) *ValidateException {
 
 // VeGet 方法用于获取验证异常（直接 panic 后可被 recover 捕获）。
 func VeGet(key string) *ValidateException {
-	gm := globalmanager.NewGlobalManagerOnce()
-	k := constant.RegisterKeyPrefix + "exceptions"
-	v, err := gm.Get(k)
-	if err != nil || v == nil {
-		panic(fmt.Errorf("exceptions: %s not found, please make sure you have registered exceptions in global manager", k))
+	respInfo, ok, err := lookup(key)
+	if err != nil {
+		panic(err)
 	}
-	exceptions := v.(ExceptionMap)
-	if respInfo, ok := exceptions[key]; ok {
-		return NewVE(respInfo.Code, respInfo.Msg, respInfo.Data)
+	if ok {
+		return NewVE(respInfo.Code, respInfo.Msg, respInfo.Data).withDefinition(key, &respInfo)
 	}
 	return NewVE(constant.UnknownErrCode, constant.UnknownErrMsg)
 }
 
 // VeThrow 方法用于抛出验证异常（直接 panic）。
 func VeThrow(key string, d ...interface{}) {
-	gm := globalmanager.NewGlobalManagerOnce()
-	k := constant.RegisterKeyPrefix + "exceptions"
-	v, err := gm.Get(k)
-	if err != nil || v == nil {
-		panic(fmt.Errorf("exceptions: %s not found, please make sure you have registered exceptions in global manager", k))
+	respInfo, ok, err := lookup(key)
+	if err != nil {
+		panic(err)
 	}
-	exceptions := v.(ExceptionMap)
-	if respInfo, ok := exceptions[key]; ok {
-		if len(d) > 0 {
-			if errData, ok := d[0].(error); ok {
-				respInfo.Data = errData.Error()
-			} else {
-				respInfo.Data = d[0]
-			}
-		}
-		panic(NewVE(respInfo.Code, respInfo.Msg, respInfo.Data))
+	if ok {
+		panic(NewVE(respInfo.Code, respInfo.Msg, respInfo.Data).withDefinition(key, &respInfo).RespData(d...))
 	}
 	panic(NewVE(constant.UnknownErrCode, constant.UnknownErrMsg))
 }
 
+// VeErr 返回 key 对应的验证异常，供处理器作为 error 返回，不会 panic；失败时的行为同 Err
+func VeErr(key string, d ...interface{}) *ValidateException {
+	return (*ValidateException)(Err(key, d...))
+}
+
+// withDefinition 记录异常键，并复制 ExceptionMap 定义中的 HTTP 状态码、问题类型与本地化消息
+func (e *ValidateException) withDefinition(key string, def *Exception) *ValidateException {
+	(*Exception)(e).withDefinition(key, def)
+	return e
+}
+
+// WithArgs 设置插值消息模板 {name} 占位符的参数，与已设置的参数合并
+func (e *ValidateException) WithArgs(args Args) *ValidateException {
+	(*Exception)(e).WithArgs(args)
+	return e
+}
+
+// Langs 返回可本地化该异常的语言，规则同 Exception.Langs
+func (e *ValidateException) Langs() []string {
+	return (*Exception)(e).Langs()
+}
+
+// Localize 返回 lang 语言的异常消息，并以 Args 插值占位符，规则同 Exception.Localize
+func (e *ValidateException) Localize(lang string) string {
+	return (*Exception)(e).Localize(lang)
+}
+
+// WithStatus 设置错误处理器响应的 HTTP 状态码，覆盖异常定义中的 Status
+func (e *ValidateException) WithStatus(status int) *ValidateException {
+	e.Status = status
+	return e
+}
+
 // RespData 方法用于响应错误，并可添加数据参数
 func (e *ValidateException) RespData(d ...interface{}) *ValidateException {
 	if len(d) > 0 {
@@ -1,10 +1,16 @@
 package exception
 
 import (
+	"crypto/x509"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/lamxy/fiberhouse/component/i18n"
 	"github.com/lamxy/fiberhouse/constant"
 	"github.com/lamxy/fiberhouse/globalmanager"
 	"github.com/lamxy/fiberhouse/response"
@@ -45,6 +51,7 @@ func recoverPanic(t *testing.T, fn func()) interface{} {
 }
 
 type exceptionContextRecorder struct {
+	adaptorctx.ICoreContext
 	status int
 	code   int
 	msg    string
@@ -52,9 +59,11 @@ type exceptionContextRecorder struct {
 	body   []byte
 }
 
-func (c *exceptionContextRecorder) GetCtx() interface{}      { return nil }
-func (c *exceptionContextRecorder) GetHeader(string) string  { return "" }
-func (c *exceptionContextRecorder) SetHeader(string, string) {}
+func (c *exceptionContextRecorder) GetCtx() interface{}                  { return nil }
+func (c *exceptionContextRecorder) Scope() *globalmanager.GlobalManager  { return nil }
+func (c *exceptionContextRecorder) ClientCertificate() *x509.Certificate { return nil }
+func (c *exceptionContextRecorder) GetHeader(string) string              { return "" }
+func (c *exceptionContextRecorder) SetHeader(string, string)             {}
 func (c *exceptionContextRecorder) Send(status int, body []byte) error {
 	c.status = status
 	c.body = append([]byte(nil), body...)
@@ -157,6 +166,159 @@ func TestGetAndVeGet_KnownUnknownAndConvenienceKeys(t *testing.T) {
 	}
 }
 
+func TestGetAndThrow_CopyStatusAndProblemType(t *testing.T) {
+	installExceptionMap(t, ExceptionMap{
+		"conflict": {Code: 4091, Msg: "conflict", Status: http.StatusConflict, Type: "https://errors.example.com/conflict"},
+	})
+
+	exception := Get("conflict")
+	if exception.Status != http.StatusConflict || exception.Type != "https://errors.example.com/conflict" {
+		t.Fatalf("Get(conflict) = %#v", exception)
+	}
+	exception.Release()
+	validation := VeGet("conflict")
+	if validation.Status != http.StatusConflict || validation.Type != "https://errors.example.com/conflict" {
+		t.Fatalf("VeGet(conflict) = %#v", validation)
+	}
+	validation.Release()
+	thrown := recoverPanic(t, func() { Throw("conflict") }).(*Exception)
+	if thrown.Status != http.StatusConflict || thrown.Type == "" {
+		t.Fatalf("Throw(conflict) = %#v", thrown)
+	}
+	thrown.Release()
+	veThrown := recoverPanic(t, func() { VeThrow("conflict") }).(*ValidateException)
+	if veThrown.Status != http.StatusConflict || veThrown.Type == "" {
+		t.Fatalf("VeThrow(conflict) = %#v", veThrown)
+	}
+	veThrown.Release()
+
+	reused := response.GetRespInfo()
+	defer reused.Release()
+	if reused.Status != 0 || reused.Type != "" {
+		t.Fatalf("pooled RespInfo kept exception metadata: %#v", reused)
+	}
+}
+
+func TestErrAndWrap_ReturnErrorsMatchedByKey(t *testing.T) {
+	installExceptionMap(t, ExceptionMap{
+		"conflict": {Code: 4091, Msg: "conflict", Status: http.StatusConflict},
+	})
+
+	returned := Err("conflict", errors.New("version 3"))
+	chain := fmt.Errorf("save order: %w", returned)
+	if !errors.Is(chain, Key("conflict")) || errors.Is(chain, Key("other")) {
+		t.Fatalf("errors.Is by key failed for %v", chain)
+	}
+	var matched *Exception
+	if !errors.As(chain, &matched) || matched != returned || matched.Data != "version 3" || matched.Status != http.StatusConflict {
+		t.Fatalf("errors.As = %#v", matched)
+	}
+	if returned.WithStatus(http.StatusPreconditionFailed).Status != http.StatusPreconditionFailed {
+		t.Fatalf("WithStatus did not override the definition")
+	}
+	returned.Release()
+
+	wrapped := Wrap(io.ErrUnexpectedEOF, "conflict")
+	if !errors.Is(wrapped, io.ErrUnexpectedEOF) || !errors.Is(wrapped, Key("conflict")) {
+		t.Fatalf("Wrap lost the cause chain: %v", wrapped)
+	}
+	if wrapped.Error() != "conflict: unexpected EOF" {
+		t.Fatalf("Wrap Error() = %q", wrapped.Error())
+	}
+	wrapped.Release()
+
+	validation := VeErr("conflict")
+	if !errors.Is(validation, Key("conflict")) || validation.Code != 4091 {
+		t.Fatalf("VeErr = %#v", validation)
+	}
+	validation.Release()
+
+	unresolved := Wrap(io.EOF, "missing")
+	if unresolved.Code != constant.UnknownErrCode || unresolved.Status != http.StatusInternalServerError ||
+		!errors.Is(unresolved, io.EOF) || errors.Is(unresolved, Key("missing")) ||
+		!strings.Contains(unresolved.Error(), `key "missing" is not registered`) {
+		t.Fatalf("unresolved key = %#v (%v)", unresolved, unresolved)
+	}
+	unresolved.Release()
+}
+
+func TestLocalize_PicksDefinitionMessageAndInterpolatesArgs(t *testing.T) {
+	installExceptionMap(t, ExceptionMap{
+		"notFound": {Code: 4404, Msg: "order {id} not found", Messages: map[string]string{"zh-CN": "订单 {id} 不存在"}},
+	})
+
+	returned := Err("notFound").WithArgs(Args{"id": 7}).WithArgs(Args{"user": "li"})
+	if langs := returned.Langs(); len(langs) != 1 || langs[0] != "zh-CN" {
+		t.Fatalf("Langs() = %v", langs)
+	}
+	cases := map[string]string{
+		"":      "order 7 not found",
+		"zh-cn": "订单 7 不存在",
+		"zh_CN": "订单 7 不存在",
+		"en":    "order 7 not found",
+	}
+	for lang, want := range cases {
+		if got := returned.Localize(lang); got != want {
+			t.Fatalf("Localize(%q) = %q, want %q", lang, got, want)
+		}
+	}
+	if returned.Error() != "order 7 not found" || returned.Args["user"] != "li" {
+		t.Fatalf("Error() = %q, Args = %v", returned.Error(), returned.Args)
+	}
+	returned.Release()
+
+	validation := VeGet("notFound").WithArgs(Args{"id": 8})
+	if got := validation.Localize("zh-cn"); got != "订单 8 不存在" {
+		t.Fatalf("ValidateException.Localize = %q", got)
+	}
+	validation.Release()
+
+	reused := response.GetRespInfo()
+	defer reused.Release()
+	if reused.Messages != nil || reused.Args != nil {
+		t.Fatalf("pooled RespInfo kept localization metadata: %#v", reused)
+	}
+}
+
+func TestLocalize_FallsBackToCatalogMessages(t *testing.T) {
+	installExceptionMap(t, ExceptionMap{
+		"notFound": {Code: 4404, Msg: "order {id} not found", Messages: map[string]string{"ja": "注文 {id} が見つかりません"}},
+		"plain":    {Code: 4000, Msg: "plain"},
+	})
+	i18n.Default().Clear()
+	t.Cleanup(i18n.Default().Clear)
+	i18n.Default().Add("zh-cn", map[string]string{MessageKeyPrefix + "notFound": "订单 {id} 不存在"}).
+		Add("zh-tw", map[string]string{MessageKeyPrefix + "notFound": "訂單 {id} 不存在"}).
+		Add("fr", map[string]string{"validate.required": "{field} est obligatoire"})
+
+	e := Err("notFound").WithArgs(Args{"id": 7})
+	defer e.Release()
+	if langs := e.Langs(); strings.Join(langs, ",") != "ja,zh-cn,zh-tw" {
+		t.Fatalf("Langs() = %v", langs)
+	}
+	cases := map[string]string{
+		"ja":    "注文 7 が見つかりません",
+		"zh-TW": "訂單 7 不存在",
+		"zh-hk": "訂單 7 不存在",
+		"zh_CN": "订单 7 不存在",
+		"en":    "order 7 not found",
+	}
+	for lang, want := range cases {
+		if got := e.Localize(lang); got != want {
+			t.Fatalf("Localize(%q) = %q, want %q", lang, got, want)
+		}
+	}
+
+	plain := Err("plain")
+	defer plain.Release()
+	if langs := plain.Langs(); len(langs) != 0 {
+		t.Fatalf("Langs() without catalog messages for the key = %v", langs)
+	}
+	if got := plain.Localize("zh-cn"); got != "plain" {
+		t.Fatalf("Localize without catalog message = %q", got)
+	}
+}
+
 func TestGet_MissingRegistryPanicsWithUsefulError(t *testing.T) {
 	manager := globalmanager.NewGlobalManagerOnce()
 	registryKey := constant.RegisterKeyPrefix + "exceptions"
@@ -187,6 +349,12 @@ func TestGet_MissingRegistryPanicsWithUsefulError(t *testing.T) {
 			}
 		})
 	}
+
+	returned := Err("known")
+	defer returned.Release()
+	if returned.Code != constant.UnknownErrCode || returned.Status != http.StatusInternalServerError || returned.Cause == nil {
+		t.Fatalf("Err without registry = %#v", returned)
+	}
 }
 
 func TestException_ResponseLifecycleAndContextStatus(t *testing.T) {
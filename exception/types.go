@@ -6,7 +6,10 @@
 
 package exception
 
-import "github.com/lamxy/fiberhouse/response"
+import (
+	"github.com/lamxy/fiberhouse/component/i18n"
+	"github.com/lamxy/fiberhouse/response"
+)
 
 type Exception response.RespInfo
 
@@ -16,12 +19,51 @@ type ExceptionMap map[string]Exception
 
 type ErrorData map[string]string
 
-// Exception Error 实现 error 接口
+// Args 插值异常消息模板 {name} 占位符的参数，见 Exception.WithArgs
+type Args map[string]interface{}
+
+// Key 已注册异常键的哨兵错误
+//
+// errors.Is(err, exception.Key("OrderNotFound")) 匹配错误链中以该键创建的 Exception 或 ValidateException；
+// 直接作为错误返回时，错误处理器按键解析为对应的异常。
+type Key string
+
+// Error 实现 error 接口
+func (k Key) Error() string {
+	return "exception: " + string(k)
+}
+
+// Exception Error 实现 error 接口，消息按 Args 插值，包装了原始错误时追加其消息
 func (e *Exception) Error() string {
-	return e.Msg
+	msg := i18n.Format(e.Msg, e.Args)
+	if e.Cause != nil {
+		return msg + ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+// Unwrap 返回包装的原始错误
+func (e *Exception) Unwrap() error {
+	return e.Cause
 }
 
-// ValidateException Error 实现 error 接口
+// Is 支持 errors.Is 按异常键匹配
+func (e *Exception) Is(target error) bool {
+	key, ok := target.(Key)
+	return ok && e.Key != "" && e.Key == string(key)
+}
+
+// ValidateException Error 实现 error 接口，包装了原始错误时追加其消息
 func (e *ValidateException) Error() string {
-	return e.Msg
+	return (*Exception)(e).Error()
+}
+
+// Unwrap 返回包装的原始错误
+func (e *ValidateException) Unwrap() error {
+	return e.Cause
+}
+
+// Is 支持 errors.Is 按异常键匹配
+func (e *ValidateException) Is(target error) bool {
+	return (*Exception)(e).Is(target)
 }
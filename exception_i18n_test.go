@@ -0,0 +1,142 @@
+package fiberhouse
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	adaptorerrorhandler "github.com/lamxy/fiberhouse/adaptor/errorhandler"
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/component/i18n"
+	"github.com/lamxy/fiberhouse/constant"
+	"github.com/lamxy/fiberhouse/exception"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func installLocalizedTestExceptions(t *testing.T, ctx IApplicationContext) {
+	t.Helper()
+	key := constant.RegisterKeyPrefix + "exceptions"
+	wasRegistered := ctx.GetContainer().IsRegistered(key)
+	var previous interface{}
+	if wasRegistered {
+		var err error
+		previous, err = ctx.GetContainer().Get(key)
+		require.NoError(t, err)
+	}
+	ctx.GetContainer().Unregister(key)
+	require.True(t, ctx.GetContainer().Register(key, func() (interface{}, error) {
+		return exception.ExceptionMap{
+			"OrderNotFound": {Code: 4404, Msg: "order {id} not found", Status: http.StatusNotFound, Messages: map[string]string{
+				"zh-cn": "订单 {id} 不存在",
+				"zh-TW": "訂單 {id} 不存在",
+			}},
+			"UnknownError": {Code: constant.UnknownErrCode, Msg: constant.UnknownErrMsg},
+		}, nil
+	}))
+	t.Cleanup(func() {
+		ctx.GetContainer().Unregister(key)
+		if wasRegistered {
+			require.True(t, ctx.GetContainer().Register(key, func() (interface{}, error) {
+				return previous, nil
+			}))
+		}
+	})
+}
+
+func TestErrorHandler_LocalizesExceptionMessagesByRequestLanguage(t *testing.T) {
+	for _, core := range []string{"fiber", "gin"} {
+		t.Run(core, func(t *testing.T) {
+			ctx := newTask5AppContext(t, false, false)
+			ctx.(*AppContext).langResolver = i18n.NewResolver(appconfig.NewAppConfig().LoadDefault(map[string]interface{}{
+				"application.i18n.langQuery":  "lang",
+				"application.i18n.langHeader": "X-Lang",
+			}))
+			installTask5ResponseManager(t, ctx)
+			installLocalizedTestExceptions(t, ctx)
+			handler := newTask5ErrorHandler(ctx, NewFiberRecovery(ctx))
+			cfg := RecoverConfig{AppCtx: ctx, StackTraceHandler: func(adaptorctx.ICoreContext, interface{}) {}}
+
+			routes := map[string]HandlerFunc{
+				"/returned": func(adaptorctx.ICoreContext) error {
+					return exception.Err("OrderNotFound").WithArgs(exception.Args{"id": 7})
+				},
+				"/panic": func(adaptorctx.ICoreContext) error {
+					panic(exception.Get("OrderNotFound").WithArgs(exception.Args{"id": 7}))
+				},
+			}
+			var serve func(path string, header map[string]string) (int, string, string)
+			switch core {
+			case "fiber":
+				app := fiber.New(fiber.Config{ErrorHandler: adaptorerrorhandler.FiberErrorHandler(handler.ErrorHandler)})
+				app.Use(NewFiberRecovery(ctx).RecoverPanic(cfg).(fiber.Handler))
+				r, err := NewRouter(app)
+				require.NoError(t, err)
+				for path, h := range routes {
+					r.Get(path, h)
+				}
+				serve = func(path string, header map[string]string) (int, string, string) {
+					req := httptest.NewRequest(http.MethodGet, path, nil)
+					for k, v := range header {
+						req.Header.Set(k, v)
+					}
+					resp, err := app.Test(req)
+					require.NoError(t, err)
+					defer resp.Body.Close()
+					var envelope struct{ Msg string }
+					require.NoError(t, json.NewDecoder(resp.Body).Decode(&envelope))
+					return resp.StatusCode, resp.Header.Get("Vary"), envelope.Msg
+				}
+			case "gin":
+				preserveTask4GinMode(t)
+				gin.SetMode(gin.TestMode)
+				engine := gin.New()
+				engine.Use(gin.HandlerFunc(NewGinRecovery(ctx).RecoverPanic(cfg).(func(*gin.Context))))
+				engine.Use(adaptorerrorhandler.GinErrorHandler(handler.ErrorHandler))
+				r, err := NewRouter(engine)
+				require.NoError(t, err)
+				for path, h := range routes {
+					r.Get(path, h)
+				}
+				serve = func(path string, header map[string]string) (int, string, string) {
+					req := httptest.NewRequest(http.MethodGet, path, nil)
+					for k, v := range header {
+						req.Header.Set(k, v)
+					}
+					recorder := httptest.NewRecorder()
+					engine.ServeHTTP(recorder, req)
+					var envelope struct{ Msg string }
+					require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &envelope))
+					return recorder.Code, recorder.Header().Get("Vary"), envelope.Msg
+				}
+			}
+
+			cases := []struct {
+				name   string
+				query  string
+				header map[string]string
+				msg    string
+			}{
+				{name: "default", msg: "order 7 not found"},
+				{name: "accept-language", header: map[string]string{"Accept-Language": "zh-CN,zh;q=0.9,en;q=0.8"}, msg: "订单 7 不存在"},
+				{name: "quality", header: map[string]string{"Accept-Language": "zh-cn;q=0.5, zh-tw"}, msg: "訂單 7 不存在"},
+				{name: "unsupported", header: map[string]string{"Accept-Language": "fr, de;q=0.5"}, msg: "order 7 not found"},
+				{name: "header", header: map[string]string{"X-Lang": "zh_TW", "Accept-Language": "zh-CN"}, msg: "訂單 7 不存在"},
+				{name: "unsupported header", header: map[string]string{"X-Lang": "fr", "Accept-Language": "zh-CN"}, msg: "订单 7 不存在"},
+				{name: "query", query: "?lang=zh-cn", header: map[string]string{"X-Lang": "zh-tw"}, msg: "订单 7 不存在"},
+			}
+			for _, path := range []string{"/returned", "/panic"} {
+				for _, tc := range cases {
+					status, vary, msg := serve(path+tc.query, tc.header)
+					assert.Equal(t, http.StatusNotFound, status, path+" "+tc.name)
+					assert.Equal(t, tc.msg, msg, path+" "+tc.name)
+					assert.Contains(t, vary, "Accept-Language", path+" "+tc.name)
+				}
+			}
+		})
+	}
+}
@@ -11,11 +11,20 @@ package fiberhouse
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/component/health"
+	"github.com/lamxy/fiberhouse/component/i18n"
+	"github.com/lamxy/fiberhouse/component/metrics"
+	"github.com/lamxy/fiberhouse/component/tracing"
 	"github.com/lamxy/fiberhouse/component/validate"
+	"github.com/lamxy/fiberhouse/constant"
+	"github.com/lamxy/fiberhouse/globalmanager"
 )
 
 // FrameApplication 框架应用启动器实现，实现了 fiberhouse.ApplicationStarter 接口
@@ -27,6 +36,15 @@ type FrameApplication struct {
 	healthMu     sync.Mutex
 	healthCancel context.CancelFunc
 	healthWG     sync.WaitGroup
+	watchMu      sync.Mutex
+	watchStop    func() error
+	i18nMu       sync.Mutex
+	i18nStop     func() error
+	traceMu      sync.Mutex
+	traceStop    func(context.Context) error
+	taskMu       sync.Mutex
+	taskWorker   *TaskWorker
+	taskDrained  chan struct{}
 }
 
 type healthCheckStopper interface {
@@ -47,9 +65,107 @@ func stopFrameHealthCheck(ctx IApplicationContext) {
 	}
 }
 
-func clearApplicationGlobals(ctx IApplicationContext) {
+type configWatchStopper interface {
+	stopConfigWatch()
+}
+
+func stopFrameConfigWatch(ctx IApplicationContext) {
+	if ctx == nil {
+		return
+	}
+	starter := ctx.GetStarterApp()
+	if starter == nil {
+		return
+	}
+	if stopper, ok := starter.GetFrameApp().(configWatchStopper); ok {
+		stopper.stopConfigWatch()
+	}
+}
+
+type i18nWatchStopper interface {
+	stopI18nWatch()
+}
+
+func stopFrameI18nWatch(ctx IApplicationContext) {
+	if ctx == nil {
+		return
+	}
+	starter := ctx.GetStarterApp()
+	if starter == nil {
+		return
+	}
+	if stopper, ok := starter.GetFrameApp().(i18nWatchStopper); ok {
+		stopper.stopI18nWatch()
+	}
+}
+
+type tracingStopper interface {
+	stopTracing(ctx context.Context) error
+}
+
+func stopFrameTracing(ctx IApplicationContext, timeout time.Duration) error {
+	if ctx == nil {
+		return nil
+	}
+	starter := ctx.GetStarterApp()
+	if starter == nil {
+		return nil
+	}
+	stopper, ok := starter.GetFrameApp().(tracingStopper)
+	if !ok {
+		return nil
+	}
+	stopCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return stopper.stopTracing(stopCtx)
+}
+
+type taskDrainer interface {
+	drainTasks(ctx context.Context)
+}
+
+// drainFrameTasks 排空框架启动的任务 worker，已在排空时等待其结束
+func drainFrameTasks(ctx IApplicationContext, drainCtx context.Context) {
+	if ctx == nil {
+		return
+	}
+	starter := ctx.GetStarterApp()
+	if starter == nil {
+		return
+	}
+	if drainer, ok := starter.GetFrameApp().(taskDrainer); ok {
+		drainer.drainTasks(drainCtx)
+	}
+}
+
+// clearApplicationGlobals 停止配置监听与保活后按初始化逆序关闭全局对象，再清空全局容器并刷新链路追踪导出器，返回关闭失败的聚合错误
+//
+// 关闭前先等待任务 worker 排空，未经关闭协调器排空时以 closeTimeout 为限；
+// 单个资源的关闭超时由 application.globalManage.closeTimeout（单位秒，缺省10）控制；
+// 日志写入器由日志器负责关闭，此处跳过。
+func clearApplicationGlobals(ctx IApplicationContext) error {
+	stopFrameConfigWatch(ctx)
+	stopFrameI18nWatch(ctx)
 	stopFrameHealthCheck(ctx)
+	timeout := ctx.GetConfig().Duration("application.globalManage.closeTimeout", 10) * time.Second
+	drainCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	drainFrameTasks(ctx, drainCtx)
+	cancel()
+	err := ctx.GetContainer().CloseAll(context.Background(), globalmanager.CloseOptions{
+		Timeout: timeout,
+		Skip: func(name globalmanager.KeyName) bool {
+			return strings.HasPrefix(name, constant.LogWriterKeyPrefix)
+		},
+	})
 	ctx.GetContainer().ClearAll(true)
+	return errors.Join(err, stopFrameTracing(ctx, timeout))
+}
+
+// releaseRequestScope 释放请求作用域，失败时记录错误日志
+func releaseRequestScope(ctx IApplicationContext, scope *globalmanager.LazyScope) {
+	if err := scope.Release(context.Background()); err != nil {
+		ctx.GetLogger().ErrorWith(ctx.GetConfig().LogOriginCoreHttp()).Err(err).Msg("release request scope failed")
+	}
 }
 
 // NewFrameApplication 创建一个应用启动器对象
@@ -160,6 +276,194 @@ func (fa *FrameApplication) RegisterApplicationGlobals(managers ...IProviderMana
 		fa.GetTask().RegisterTaskServerToContainer()     // 异步任务服务器/服务端
 		fa.GetTask().RegisterTaskDispatcherToContainer() // 异步任务分发器/客户端
 	}
+
+	// 绑定配置热更新的全局对象重建，并按配置启动配置目录监听
+	fa.registerConfigReload()
+
+	// 按 application.i18n 配置加载消息目录，并按配置监听消息文件
+	fa.registerI18n()
+
+	// 按 application.trace 配置安装链路追踪导出器
+	fa.registerTracing()
+
+	// 采集全局对象健康检查与重建指标、为健康探针记录重建状态，并按配置采集异步日志丢弃指标
+	registry := health.Default()
+	registry.SetGlobalManager(fa.GetContext().GetContainer())
+	registry.SetReady(true)
+	fa.GetContext().GetContainer().SetObserver(globalmanager.Observers(metrics.GlobalManagerObserver{}, registry))
+	if fa.GetContext().GetConfig().GetAppLog().EnableMetrics {
+		metrics.EnableLogMetrics()
+	}
+}
+
+// registerConfigReload 绑定配置前缀变更时需要重建的全局对象，application.configWatch.enable 为 true 时启动配置目录监听
+func (fa *FrameApplication) registerConfigReload() {
+	cfg, log := fa.GetContext().GetConfig(), fa.GetContext().GetLogger()
+	rc, ok := cfg.(appconfig.IReloadableConfig)
+	if !ok {
+		return
+	}
+	if configurer, ok := fa.GetApplication().(ConfigReloadConfigurer); ok {
+		rebuilds := configurer.ConfigReloadRebuilds()
+		prefixes := make([]string, 0, len(rebuilds))
+		for prefix := range rebuilds {
+			prefixes = append(prefixes, prefix)
+		}
+		sort.Strings(prefixes)
+		for _, prefix := range prefixes {
+			rc.RebuildOnChange(prefix, rebuilds[prefix]...)
+		}
+	}
+	if !cfg.Bool("application.configWatch.enable") {
+		return
+	}
+
+	fa.watchMu.Lock()
+	defer fa.watchMu.Unlock()
+	if fa.watchStop != nil {
+		return
+	}
+	stop, err := rc.Watch(appconfig.WatchOptions{
+		Debounce: cfg.Duration("application.configWatch.debounce", 200) * time.Millisecond,
+		OnReload: func(err error) {
+			if err != nil {
+				log.ErrorWith(cfg.LogOriginFrame()).Err(err).Msg("config reload failed")
+				return
+			}
+			log.InfoWith(cfg.LogOriginFrame()).Msg("config reloaded")
+		},
+	})
+	if err != nil {
+		log.ErrorWith(cfg.LogOriginFrame()).Err(err).Msg("start config watch failed")
+		return
+	}
+	fa.watchStop = stop
+}
+
+func (fa *FrameApplication) stopConfigWatch() {
+	fa.watchMu.Lock()
+	stop := fa.watchStop
+	fa.watchStop = nil
+	fa.watchMu.Unlock()
+	if stop != nil {
+		_ = stop()
+	}
+}
+
+// registerI18n 按 application.i18n 配置设置 i18n.Default 目录的缺省语言与回退链并加载消息文件目录，watch 为 true 时监听消息文件变更
+func (fa *FrameApplication) registerI18n() {
+	cfg, log := fa.GetContext().GetConfig(), fa.GetContext().GetLogger()
+	conf, err := appconfig.Bind[i18n.Config](cfg, i18n.ConfPath)
+	if err != nil {
+		log.ErrorWith(cfg.LogOriginFrame()).Err(err).Msg("bind i18n config failed")
+		return
+	}
+	i18n.SetDefaultResolver(fa.GetContext().GetLangResolver())
+
+	catalog := i18n.Default().SetDefaultLang(conf.DefaultLang)
+	for lang, chain := range conf.Fallbacks {
+		catalog.SetFallbacks(lang, chain...)
+	}
+	for _, dir := range conf.Dirs {
+		if err := catalog.LoadDir(dir); err != nil {
+			log.ErrorWith(cfg.LogOriginFrame()).Err(err).Str("dir", dir).Msg("load i18n messages failed")
+		}
+	}
+	if !conf.Watch || len(conf.Dirs) == 0 {
+		return
+	}
+
+	fa.i18nMu.Lock()
+	defer fa.i18nMu.Unlock()
+	if fa.i18nStop != nil {
+		return
+	}
+	stop, err := catalog.Watch(i18n.WatchOptions{
+		Debounce: conf.Debounce,
+		OnReload: func(err error) {
+			if err != nil {
+				log.ErrorWith(cfg.LogOriginFrame()).Err(err).Msg("i18n messages reload failed")
+				return
+			}
+			log.InfoWith(cfg.LogOriginFrame()).Msg("i18n messages reloaded")
+		},
+	})
+	if err != nil {
+		log.ErrorWith(cfg.LogOriginFrame()).Err(err).Msg("start i18n watch failed")
+		return
+	}
+	fa.i18nStop = stop
+}
+
+func (fa *FrameApplication) stopI18nWatch() {
+	fa.i18nMu.Lock()
+	stop := fa.i18nStop
+	fa.i18nStop = nil
+	fa.i18nMu.Unlock()
+	if stop != nil {
+		_ = stop()
+	}
+}
+
+// registerTracing application.trace.enable 为 true 时安装全局 TracerProvider，导出器在 clearApplicationGlobals 中刷新关闭
+func (fa *FrameApplication) registerTracing() {
+	cfg, log := fa.GetContext().GetConfig(), fa.GetContext().GetLogger()
+	conf, err := appconfig.Bind[tracing.Config](cfg, tracing.ConfPath)
+	if err != nil {
+		log.ErrorWith(cfg.LogOriginFrame()).Err(err).Msg("bind trace config failed")
+		return
+	}
+	if !conf.Enable {
+		return
+	}
+
+	fa.traceMu.Lock()
+	defer fa.traceMu.Unlock()
+	if fa.traceStop != nil {
+		return
+	}
+	stop, err := tracing.Setup(conf, cfg.GetAppName())
+	if err != nil {
+		log.ErrorWith(cfg.LogOriginFrame()).Err(err).Msg("setup tracing failed")
+		return
+	}
+	fa.traceStop = stop
+}
+
+// drainTasks 排空 RegisterTaskServer 启动的任务 worker，只执行一次，并发调用等待同一次排空结束
+func (fa *FrameApplication) drainTasks(ctx context.Context) {
+	fa.taskMu.Lock()
+	worker, done := fa.taskWorker, fa.taskDrained
+	if worker == nil {
+		fa.taskMu.Unlock()
+		return
+	}
+	if done != nil {
+		fa.taskMu.Unlock()
+		<-done
+		return
+	}
+	done = make(chan struct{})
+	fa.taskDrained = done
+	fa.taskMu.Unlock()
+
+	defer close(done)
+	if remaining := worker.Drain(ctx); remaining > 0 {
+		fa.GetContext().GetLogger().WarnWith(fa.GetContext().GetConfig().LogOriginFrame()).
+			Int64("runningTasks", remaining).
+			Msg("task handlers cut off by shutdown budget")
+	}
+}
+
+func (fa *FrameApplication) stopTracing(ctx context.Context) error {
+	fa.traceMu.Lock()
+	stop := fa.traceStop
+	fa.traceStop = nil
+	fa.traceMu.Unlock()
+	if stop == nil {
+		return nil
+	}
+	return stop(ctx)
 }
 
 // RegisterGlobalInitializers 注册全局对象初始化器
@@ -173,10 +477,21 @@ func (fa *FrameApplication) RegisterGlobalInitializers() {
 	}
 
 	appRegister := fa.GetApplication().(ApplicationRegister)
-	fa.GetContext().GetContainer().Registers(appRegister.ConfigGlobalInitializers())
+	gm := fa.GetContext().GetContainer()
+	gm.Registers(appRegister.ConfigGlobalInitializers())
+
+	// 声明全局对象之间的依赖
+	if configurer, ok := appRegister.(GlobalDependencyConfigurer); ok {
+		if missing := gm.SetDependencyMap(configurer.ConfigGlobalDependencies()); len(missing) > 0 {
+			fa.GetContext().GetLogger().ErrorWith(fa.GetContext().GetConfig().LogOriginFrame()).
+				Strs("keyNames", missing).Msg("ApplicationRegister ConfigGlobalDependencies declares dependencies for unregistered keys")
+		}
+	}
 }
 
 // InitializeGlobalRequired 初始化应用启动时必要的全局对象
+//
+// 先校验全局对象依赖图，再按依赖并行初始化必需对象及其依赖，互不依赖的对象并行初始化。
 func (fa *FrameApplication) InitializeGlobalRequired() {
 	if fa.GetContext().GetAppState() {
 		return
@@ -184,12 +499,14 @@ func (fa *FrameApplication) InitializeGlobalRequired() {
 	if fa.GetApplication() != nil {
 		appRegister := fa.GetApplication().(ApplicationRegister)
 		gm := fa.GetContext().GetContainer()
-		for _, name := range appRegister.ConfigRequiredGlobalKeys() {
-			_, err := gm.Get(name)
-			if err != nil {
-				fa.GetContext().GetLogger().ErrorWith(fa.GetContext().GetConfig().LogOriginFrame()).Err(err).Msgf("ApplicationRegister InitializeGlobalRequired error, keyName: %s", name)
-				//panic(err)
-			}
+		// 依赖图存在循环或缺失的依赖时终止启动
+		if err := gm.Validate(); err != nil {
+			fa.GetContext().GetLogger().ErrorWith(fa.GetContext().GetConfig().LogOriginFrame()).Err(err).Msg("ApplicationRegister InitializeGlobalRequired invalid global dependency graph")
+			panic(fmt.Errorf("invalid global dependency graph: %w", err))
+		}
+		if err := gm.InitializeAll(appRegister.ConfigRequiredGlobalKeys()...); err != nil {
+			fa.GetContext().GetLogger().ErrorWith(fa.GetContext().GetConfig().LogOriginFrame()).Err(err).Msgf("ApplicationRegister InitializeGlobalRequired error, keyNames: %v", appRegister.ConfigRequiredGlobalKeys())
+			//panic(err)
 		}
 	}
 }
@@ -287,7 +604,10 @@ func (fa *FrameApplication) RegisterTaskServer(managers ...IProviderManager) {
 		}
 		// 获取并注册批量任务处理器
 		worker.RegisterHandlers(fa.GetTask().GetTaskHandlerMap())
-		// 启动异步任务处理服务
+		// 启动异步任务处理服务，关闭时由 drainTasks 排空
+		fa.taskMu.Lock()
+		fa.taskWorker = worker
+		fa.taskMu.Unlock()
 		worker.RunServer()
 	}
 }
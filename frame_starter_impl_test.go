@@ -2,8 +2,12 @@ package fiberhouse
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -11,11 +15,15 @@ import (
 
 	"github.com/lamxy/fiberhouse/appconfig"
 	"github.com/lamxy/fiberhouse/bootstrap"
+	"github.com/lamxy/fiberhouse/component/i18n"
+	"github.com/lamxy/fiberhouse/component/tracing"
 	"github.com/lamxy/fiberhouse/component/validate"
+	"github.com/lamxy/fiberhouse/constant"
 	"github.com/lamxy/fiberhouse/globalmanager"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
 )
 
 type frameTestApplication struct {
@@ -252,6 +260,62 @@ func TestFrameApplication_ApplicationGlobalsInitializesObservableContracts(t *te
 	}
 }
 
+type frameTestDependentApplication struct {
+	*frameTestApplication
+	dependencies globalmanager.DependencyMap
+}
+
+func (a *frameTestDependentApplication) ConfigGlobalDependencies() globalmanager.DependencyMap {
+	return a.dependencies
+}
+
+func TestFrameApplication_InitializeGlobalRequiredFollowsDeclaredDependencies(t *testing.T) {
+	ctx, logs := newFrameTestContext(t, nil)
+	manager := isolateFrameHealthManager(t, ctx)
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) globalmanager.InitializerFunc {
+		return func() (interface{}, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return name, nil
+		}
+	}
+	application := &frameTestDependentApplication{
+		frameTestApplication: &frameTestApplication{
+			initializers: globalmanager.InitializerMap{
+				"redis":  record("redis"),
+				"remote": record("remote"),
+				"level2": record("level2"),
+				"lazy":   record("lazy"),
+			},
+			required: []globalmanager.KeyName{"level2"},
+		},
+		dependencies: globalmanager.DependencyMap{
+			"remote": {"redis"},
+			"level2": {"remote"},
+			"ghost":  {"redis"},
+		},
+	}
+	frame := &FrameApplication{Ctx: ctx, application: application}
+
+	frame.RegisterGlobalInitializers()
+	frame.InitializeGlobalRequired()
+
+	assert.Equal(t, []string{"redis", "remote", "level2"}, order)
+	assert.Equal(t, []globalmanager.KeyName{"level2"}, manager.Dependents("remote"))
+	assert.Contains(t, logs.String(), "ghost")
+
+	// 非法依赖图在启动时记录错误并终止启动
+	manager.SetDependencies("redis", "level2")
+	order = nil
+	assert.PanicsWithError(t, "invalid global dependency graph: "+manager.Validate().Error(), frame.InitializeGlobalRequired)
+	assert.Empty(t, order, "no global is initialized from a broken graph")
+	assert.Contains(t, logs.String(), "invalid global dependency graph")
+	assert.Contains(t, logs.String(), "level2 -> remote -> redis -> level2")
+}
+
 func TestFrameApplication_GuardsAvoidStartupSideEffects(t *testing.T) {
 	t.Run("missing application panics", func(t *testing.T) {
 		ctx, _ := newFrameTestContext(t, nil)
@@ -478,6 +542,120 @@ func TestClearApplicationGlobalsStopsMountedFrameBeforeClearingContainer(t *test
 	}
 }
 
+type frameOrderedClosable struct {
+	name   string
+	closed *[]string
+	err    error
+}
+
+func (c *frameOrderedClosable) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return c.err
+}
+
+func TestClearApplicationGlobalsClosesResourcesInReverseOrderAndSkipsLogWriters(t *testing.T) {
+	ctx, _ := newFrameTestContext(t, nil)
+	manager := isolateFrameHealthManager(t, ctx)
+	var closed []string
+	closeErr := errors.New("redis pool close failed")
+	require.True(t, manager.Register(constant.LogWriterKeyPrefix+"chan", func() (interface{}, error) {
+		return &frameOrderedClosable{name: "writer", closed: &closed}, nil
+	}))
+	require.True(t, manager.Register("mysql", func() (interface{}, error) {
+		return &frameOrderedClosable{name: "mysql", closed: &closed}, nil
+	}))
+	require.True(t, manager.Register("redis", func() (interface{}, error) {
+		return &frameOrderedClosable{name: "redis", closed: &closed, err: closeErr}, nil
+	}))
+	for _, key := range []string{constant.LogWriterKeyPrefix + "chan", "mysql", "redis"} {
+		_, err := manager.Get(key)
+		require.NoError(t, err)
+	}
+
+	err := clearApplicationGlobals(ctx)
+
+	assert.ErrorIs(t, err, closeErr)
+	assert.Equal(t, []string{"redis", "mysql"}, closed)
+	assert.False(t, manager.IsRegistered("mysql"))
+	assert.False(t, manager.IsRegistered(constant.LogWriterKeyPrefix+"chan"))
+}
+
+func TestClearApplicationGlobalsFlushesTraceExporter(t *testing.T) {
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+	file := filepath.Join(t.TempDir(), "trace.jsonl")
+	ctx, logs := newFrameTestContext(t, map[string]interface{}{
+		"application.trace.enable":   true,
+		"application.trace.exporter": "file",
+		"application.trace.file":     file,
+	})
+	isolateFrameHealthManager(t, ctx)
+	frame := &FrameApplication{Ctx: ctx}
+	ctx.RegisterStarterApp(&WebApplication{
+		FrameStarter: frame,
+		CoreStarter: &lifecycleRecordingStarter{
+			managerCalls: make(map[string][]IProviderManager),
+		},
+	})
+
+	frame.registerTracing()
+	frame.registerTracing()
+	require.Empty(t, logs.String())
+	_, span := tracing.Tracer().Start(context.Background(), "frame.span")
+	span.End()
+
+	require.NoError(t, clearApplicationGlobals(ctx))
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), `"Name":"frame.span"`))
+	assert.NoError(t, frame.stopTracing(context.Background()), "stop is idempotent")
+}
+
+func TestFrameApplication_RegisterI18nLoadsCatalogAndStopsWatchOnClear(t *testing.T) {
+	prevResolver := i18n.DefaultResolver()
+	t.Cleanup(func() {
+		i18n.SetDefaultResolver(prevResolver)
+		i18n.Default().Clear()
+		i18n.Default().SetDefaultLang(i18n.DefaultLang).SetFallbacks("pt-br")
+	})
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "zh-cn.yaml"), []byte(`order: {notFound: "订单 {id} 不存在"}`), 0o644))
+	ctx, logs := newFrameTestContext(t, map[string]interface{}{
+		"application.i18n.defaultLang": "zh-cn",
+		"application.i18n.langHeader":  "X-Lang",
+		"application.i18n.dirs":        []string{dir},
+		"application.i18n.watch":       true,
+		"application.i18n.fallbacks":   map[string]interface{}{"pt-br": []string{"pt"}},
+	})
+	isolateFrameHealthManager(t, ctx)
+	frame := &FrameApplication{Ctx: ctx}
+	ctx.RegisterStarterApp(&WebApplication{
+		FrameStarter: frame,
+		CoreStarter: &lifecycleRecordingStarter{
+			managerCalls: make(map[string][]IProviderManager),
+		},
+	})
+
+	frame.registerI18n()
+	frame.registerI18n()
+	require.Empty(t, logs.String())
+	assert.Same(t, ctx.GetLangResolver(), i18n.DefaultResolver())
+	assert.Equal(t, []string{"pt-br", "pt", "zh-cn", "zh"}, i18n.Default().Chain("pt-br"))
+	msg, ok := i18n.Default().Message("zh-tw", "order.notFound", map[string]interface{}{"id": 7})
+	assert.True(t, ok)
+	assert.Equal(t, "订单 7 不存在", msg)
+	require.NotNil(t, frame.i18nStop)
+
+	require.NoError(t, clearApplicationGlobals(ctx))
+	frame.i18nMu.Lock()
+	assert.Nil(t, frame.i18nStop)
+	frame.i18nMu.Unlock()
+	assert.NotPanics(t, frame.stopI18nWatch, "stop is idempotent")
+}
+
 func TestStopFrameHealthCheckIgnoresMissingStarter(t *testing.T) {
 	ctx, _ := newFrameTestContext(t, nil)
 	assert.NotPanics(t, func() { stopFrameHealthCheck(ctx) })
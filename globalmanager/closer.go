@@ -0,0 +1,292 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package globalmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrCloseTimeout 全局对象在关闭超时内未完成关闭（含等待借用方归还）
+var ErrCloseTimeout = errors.New("global object close timed out")
+
+// CloseOptions 统一关闭选项
+type CloseOptions struct {
+	// Timeout 单个资源的默认关闭超时，包含等待借用方归还与 Close 执行时间；<=0 表示不设超时
+	// 可通过 SetCloseTimeout 按条目覆盖
+	Timeout time.Duration
+	// Skip 返回 true 的条目由其他所有者负责关闭（如日志写入器由日志器关闭），CloseAll 跳过
+	Skip func(name KeyName) bool
+}
+
+func newStoredValue(value interface{}) *storedValue {
+	return &storedValue{
+		value:  value,
+		closed: make(chan struct{}),
+	}
+}
+
+// borrow 增加借用计数，实例已退役时撤销并返回 false
+func (s *storedValue) borrow() bool {
+	s.borrows.Add(1)
+	if s.retiring.Load() {
+		s.giveBack()
+		return false
+	}
+	return true
+}
+
+// giveBack 归还借用，已退役且借用归零时异步关闭
+func (s *storedValue) giveBack() {
+	if s.borrows.Add(-1) == 0 && s.retiring.Load() {
+		go s.finalize()
+	}
+}
+
+// retire 退役实例，无借用时立即异步关闭；返回关闭完成通道
+func (s *storedValue) retire() <-chan struct{} {
+	s.retiring.Store(true)
+	if s.borrows.Load() == 0 {
+		go s.finalize()
+	}
+	return s.closed
+}
+
+func (s *storedValue) finalize() {
+	s.retireOnce.Do(func() {
+		defer close(s.closed)
+		closable, ok := s.value.(Closable)
+		if !ok {
+			return
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				s.closeErr = fmt.Errorf("panic occurred while closing: %v", r)
+			}
+		}()
+		s.closeErr = closable.Close()
+	})
+}
+
+// retire 登记并退役被 Rebuild 替换的旧实例，关闭成功后移除登记，失败的保留给 CloseAll 汇总
+func (gm *GlobalManager) retire(name KeyName, stored *storedValue) {
+	if stored == nil {
+		return
+	}
+	gm.retiring.Store(stored, name)
+	done := stored.retire()
+	go func() {
+		<-done
+		if stored.closeErr == nil {
+			gm.retiring.Delete(stored)
+		}
+	}()
+}
+
+// sameInstance 判断重建前后是否为同一实例，不可比较的类型视为不同实例
+func sameInstance(a, b interface{}) (same bool) {
+	if a == nil || b == nil || reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
+}
+
+// Borrow 借用全局对象实例，使用结束后必须调用一次 release 归还（重复调用无副作用）
+//
+// 与 Get 相同，首次借用会触发延迟初始化。借用期间实例被 Rebuild 替换或被 CloseAll 关闭时，
+// 该实例会等到全部借用方归还后才关闭，因此持有跨越重建周期的资源引用应使用 Borrow 而非 Get。
+func (gm *GlobalManager) Borrow(name KeyName) (instance interface{}, release func(), err error) {
+	if gm.released.Load() {
+		return nil, nil, fmt.Errorf("%w: borrow '%s'", ErrScopeReleased, name)
+	}
+	origin, ok := gm.container.Load(name)
+	if !ok {
+		materialized, parent, resolveErr := gm.resolveMissing(name)
+		switch {
+		case resolveErr != nil:
+			return nil, nil, resolveErr
+		case parent != nil:
+			return parent.Borrow(name)
+		case materialized:
+			origin, ok = gm.container.Load(name)
+		}
+	}
+	if !ok {
+		return nil, nil, fmt.Errorf("entry '%s' not found for borrowing", name)
+	}
+	entity, ok := origin.(*entry)
+	if !ok {
+		return nil, nil, fmt.Errorf("assertion failure for '%s' entry", name)
+	}
+	for {
+		if _, err = gm.Get(name); err != nil {
+			return nil, nil, err
+		}
+		stored := entity.instance.Load()
+		if stored == nil || !stored.borrow() {
+			continue // 并发关闭或重建，重新获取当前实例
+		}
+		var once sync.Once
+		return stored.value, func() { once.Do(stored.giveBack) }, nil
+	}
+}
+
+// SetCloseTimeout 设置条目级关闭超时，覆盖 CloseOptions.Timeout；key 不存在时返回 false
+func (gm *GlobalManager) SetCloseTimeout(name KeyName, timeout time.Duration) bool {
+	origin, ok := gm.container.Load(name)
+	if !ok {
+		return false
+	}
+	entity, ok := origin.(*entry)
+	if !ok {
+		return false
+	}
+	entity.closeTimeout.Store(int64(timeout))
+	return true
+}
+
+// CloseAll 按依赖图逆序关闭全部已初始化且实现 Closable 的全局对象，并等待 Rebuild 退役的旧实例关闭
+//
+// 声明了依赖的对象先于其依赖关闭，其余对象按初始化逆序关闭（依赖方总是晚于其依赖完成初始化）。
+// 每个对象先从条目中摘除（条目保留，后续 Get 可重新初始化），再等待借用方归还后关闭；多个条目持有同一实例时
+// 只在最后一个条目处关闭一次。单个对象超过超时时间记录 ErrCloseTimeout 并继续关闭下一个，其关闭在后台继续完成。
+// 返回全部失败的聚合错误。
+func (gm *GlobalManager) CloseAll(ctx context.Context, opts CloseOptions) error {
+	type target struct {
+		entity *entry
+		value  interface{}
+	}
+	targets := map[KeyName]target{}
+	var names []KeyName
+	gm.container.Range(func(key, value interface{}) bool {
+		name, _ := key.(string)
+		entity, ok := value.(*entry)
+		if !ok || (opts.Skip != nil && opts.Skip(name)) {
+			return true
+		}
+		if stored := entity.instance.Load(); stored != nil {
+			if _, ok := stored.value.(Closable); ok {
+				targets[name] = target{entity: entity, value: stored.value}
+				names = append(names, name)
+			}
+		}
+		return true
+	})
+	names = gm.releaseOrder(names)
+
+	var errs []error
+	for i, name := range names {
+		sharedLater := false
+		for _, later := range names[i+1:] {
+			if sameInstance(targets[name].value, targets[later].value) {
+				sharedLater = true
+				break
+			}
+		}
+		if err := gm.closeEntry(ctx, name, targets[name].entity, opts.Timeout, !sharedLater); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	gm.retiring.Range(func(key, value interface{}) bool {
+		stored := key.(*storedValue)
+		name := value.(KeyName)
+		if opts.Skip != nil && opts.Skip(name) {
+			return true
+		}
+		timeout := opts.Timeout
+		if origin, ok := gm.container.Load(name); ok {
+			if entity, ok := origin.(*entry); ok {
+				timeout = entity.timeout(opts.Timeout)
+			}
+		}
+		if err := awaitRetired(ctx, name, stored, timeout); err != nil {
+			errs = append(errs, fmt.Errorf("retired instance: %w", err))
+		}
+		if isClosed(stored) {
+			gm.retiring.Delete(stored)
+		}
+		return true
+	})
+	return errors.Join(errs...)
+}
+
+// closeEntry 摘除单个条目的当前实例，closeInstance 为 false 时仅摘除（实例由持有同一实例的其他条目关闭）
+func (gm *GlobalManager) closeEntry(ctx context.Context, name KeyName, entity *entry, defaultTimeout time.Duration, closeInstance bool) error {
+	if err := entity.beginMaintenance(name); err != nil {
+		return err
+	}
+	defer entity.endMaintenance()
+
+	entity.mu.Lock()
+	stored := entity.instance.Load()
+	if stored == nil {
+		entity.mu.Unlock()
+		return nil
+	}
+	// 先摘除实例并重置状态，确保后续借用方不再取得即将关闭的实例
+	entity.instance.Store(nil)
+	entity.initErr.Store(nil)
+	atomic.StoreInt32(&entity.initialized, 0)
+	entity.once.Store(&sync.Once{})
+	entity.mu.Unlock()
+
+	if !closeInstance {
+		return nil
+	}
+	stored.retire()
+	return awaitRetired(ctx, name, stored, entity.timeout(defaultTimeout))
+}
+
+// timeout 返回条目生效的关闭超时
+func (e *entry) timeout(defaultTimeout time.Duration) time.Duration {
+	if t := time.Duration(e.closeTimeout.Load()); t > 0 {
+		return t
+	}
+	return defaultTimeout
+}
+
+// awaitRetired 在超时内等待已退役实例完成关闭
+func awaitRetired(ctx context.Context, name KeyName, stored *storedValue, timeout time.Duration) error {
+	waitCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	select {
+	case <-stored.closed:
+		if stored.closeErr != nil {
+			return fmt.Errorf("unable to close object resource by key '%s' : %w", name, stored.closeErr)
+		}
+		return nil
+	case <-waitCtx.Done():
+		if ctx.Err() != nil {
+			return fmt.Errorf("global object '%s' close aborted (borrowed: %d): %w", name, stored.borrows.Load(), ctx.Err())
+		}
+		return fmt.Errorf("global object '%s' not closed within %s (borrowed: %d): %w", name, timeout, stored.borrows.Load(), ErrCloseTimeout)
+	}
+}
+
+func isClosed(stored *storedValue) bool {
+	select {
+	case <-stored.closed:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,280 @@
+package globalmanager
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type closeRecorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (r *closeRecorder) add(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.order = append(r.order, name)
+}
+
+func (r *closeRecorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.order...)
+}
+
+type recordingClosable struct {
+	name     string
+	recorder *closeRecorder
+	err      error
+	block    <-chan struct{}
+	closed   chan struct{}
+	rebuild  func() (interface{}, error)
+}
+
+func newRecordingClosable(name string, recorder *closeRecorder) *recordingClosable {
+	return &recordingClosable{name: name, recorder: recorder, closed: make(chan struct{})}
+}
+
+func (c *recordingClosable) Close() error {
+	if c.block != nil {
+		<-c.block
+	}
+	c.recorder.add(c.name)
+	close(c.closed)
+	return c.err
+}
+
+func (c *recordingClosable) Rebuild(...interface{}) (interface{}, error) { return c.rebuild() }
+func (c *recordingClosable) GetConfPath() string                         { return "config.yml" }
+
+func TestCloseAll_ClosesInReverseInitializationOrder(t *testing.T) {
+	manager := NewGlobalManager()
+	recorder := &closeRecorder{}
+	manager.Register("db", func() (interface{}, error) { return newRecordingClosable("db", recorder), nil })
+	manager.Register("cache", func() (interface{}, error) {
+		if _, err := manager.Get("db"); err != nil {
+			return nil, err
+		}
+		return newRecordingClosable("cache", recorder), nil
+	})
+	manager.Register("service", func() (interface{}, error) {
+		if _, err := manager.Get("cache"); err != nil {
+			return nil, err
+		}
+		return newRecordingClosable("service", recorder), nil
+	})
+	manager.Register("plain", func() (interface{}, error) { return "not closable", nil })
+	manager.Register("lazy", func() (interface{}, error) { return newRecordingClosable("lazy", recorder), nil })
+
+	if _, err := manager.Get("service"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Get("plain"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.CloseAll(context.Background(), CloseOptions{Timeout: lifecycleTestTimeout}); err != nil {
+		t.Fatalf("CloseAll() error = %v", err)
+	}
+	if got, want := strings.Join(recorder.list(), ","), "service,cache,db"; got != want {
+		t.Fatalf("close order = %s, want %s", got, want)
+	}
+	if !manager.IsRegistered("db") {
+		t.Fatal("CloseAll removed entry, want entry retained")
+	}
+	// 条目保留，后续 Get 重新初始化
+	value, err := manager.Get("db")
+	if err != nil || value.(*recordingClosable).name != "db" {
+		t.Fatalf("Get after CloseAll = (%v, %v)", value, err)
+	}
+}
+
+func TestCloseAll_SkipsExternallyOwnedAndAggregatesErrors(t *testing.T) {
+	manager := NewGlobalManager()
+	recorder := &closeRecorder{}
+	failing := newRecordingClosable("failing", recorder)
+	failing.err = errors.New("pool close failed")
+	manager.Register("failing", func() (interface{}, error) { return failing, nil })
+	manager.Register("owned-elsewhere", func() (interface{}, error) { return newRecordingClosable("owned-elsewhere", recorder), nil })
+	manager.Register("ok", func() (interface{}, error) { return newRecordingClosable("ok", recorder), nil })
+	for _, key := range []string{"failing", "owned-elsewhere", "ok"} {
+		if _, err := manager.Get(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := manager.CloseAll(context.Background(), CloseOptions{
+		Timeout: lifecycleTestTimeout,
+		Skip:    func(name KeyName) bool { return name == "owned-elsewhere" },
+	})
+
+	if !errors.Is(err, failing.err) {
+		t.Fatalf("CloseAll() error = %v, want wrapped %v", err, failing.err)
+	}
+	if got, want := strings.Join(recorder.list(), ","), "ok,failing"; got != want {
+		t.Fatalf("close order = %s, want %s", got, want)
+	}
+}
+
+func TestCloseAll_PerResourceTimeoutContinuesWithNextResource(t *testing.T) {
+	manager := NewGlobalManager()
+	recorder := &closeRecorder{}
+	unblock := make(chan struct{})
+	defer close(unblock)
+	slow := newRecordingClosable("slow", recorder)
+	slow.block = unblock
+	manager.Register("fast", func() (interface{}, error) { return newRecordingClosable("fast", recorder), nil })
+	manager.Register("slow", func() (interface{}, error) { return slow, nil })
+	for _, key := range []string{"fast", "slow"} {
+		if _, err := manager.Get(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !manager.SetCloseTimeout("slow", 20*time.Millisecond) {
+		t.Fatal("SetCloseTimeout() = false")
+	}
+	if manager.SetCloseTimeout("missing", time.Second) {
+		t.Fatal("SetCloseTimeout(missing) = true")
+	}
+
+	start := time.Now()
+	err := manager.CloseAll(context.Background(), CloseOptions{Timeout: lifecycleTestTimeout})
+
+	if !errors.Is(err, ErrCloseTimeout) || !strings.Contains(err.Error(), "'slow'") {
+		t.Fatalf("CloseAll() error = %v, want slow ErrCloseTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > lifecycleTestTimeout/2 {
+		t.Fatalf("CloseAll() took %s, want per-resource timeout applied", elapsed)
+	}
+	if got := recorder.list(); len(got) != 1 || got[0] != "fast" {
+		t.Fatalf("closed = %v, want [fast]", got)
+	}
+}
+
+func TestCloseAll_WaitsForBorrowersBeforeClosing(t *testing.T) {
+	manager := NewGlobalManager()
+	recorder := &closeRecorder{}
+	resource := newRecordingClosable("db", recorder)
+	manager.Register("db", func() (interface{}, error) { return resource, nil })
+
+	value, release, err := manager.Borrow("db")
+	if err != nil || value != resource {
+		t.Fatalf("Borrow() = (%v, %v)", value, err)
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- manager.CloseAll(context.Background(), CloseOptions{Timeout: lifecycleTestTimeout})
+	}()
+
+	select {
+	case <-resource.closed:
+		t.Fatal("resource closed while still borrowed")
+	case <-time.After(30 * time.Millisecond):
+	}
+	release()
+	release() // 重复归还无副作用
+	if err := lifecycleReceive(t, result, "CloseAll"); err != nil {
+		t.Fatalf("CloseAll() error = %v", err)
+	}
+	lifecycleAwait(t, resource.closed, "resource close")
+}
+
+func TestRebuild_RetiresOldInstanceAfterBorrowersRelease(t *testing.T) {
+	manager := NewGlobalManager()
+	recorder := &closeRecorder{}
+	old := newRecordingClosable("old", recorder)
+	replacement := newRecordingClosable("new", recorder)
+	old.rebuild = func() (interface{}, error) { return replacement, nil }
+	manager.Register("db", func() (interface{}, error) { return old, nil })
+
+	borrowed, release, err := manager.Borrow("db")
+	if err != nil || borrowed != old {
+		t.Fatalf("Borrow() = (%v, %v)", borrowed, err)
+	}
+	if err := manager.Rebuild("db"); err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+
+	current, releaseCurrent, err := manager.Borrow("db")
+	if err != nil || current != replacement {
+		t.Fatalf("Borrow() after Rebuild = (%v, %v), want replacement", current, err)
+	}
+	releaseCurrent()
+
+	select {
+	case <-old.closed:
+		t.Fatal("old instance closed while still borrowed")
+	case <-time.After(30 * time.Millisecond):
+	}
+	release()
+	lifecycleAwait(t, old.closed, "old instance close")
+	if got := recorder.list(); len(got) != 1 || got[0] != "old" {
+		t.Fatalf("closed = %v, want [old]", got)
+	}
+}
+
+func TestRebuild_SameInstanceIsNotRetired(t *testing.T) {
+	manager := NewGlobalManager()
+	recorder := &closeRecorder{}
+	resource := newRecordingClosable("db", recorder)
+	resource.rebuild = func() (interface{}, error) { return resource, nil }
+	manager.Register("db", func() (interface{}, error) { return resource, nil })
+	if _, err := manager.Get("db"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.Rebuild("db"); err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	select {
+	case <-resource.closed:
+		t.Fatal("instance returned by its own Rebuild was closed")
+	case <-time.After(30 * time.Millisecond):
+	}
+}
+
+func TestCloseAll_ReportsRetiredInstanceCloseFailure(t *testing.T) {
+	manager := NewGlobalManager()
+	recorder := &closeRecorder{}
+	old := newRecordingClosable("old", recorder)
+	old.err = errors.New("old close failed")
+	replacement := newRecordingClosable("new", recorder)
+	old.rebuild = func() (interface{}, error) { return replacement, nil }
+	manager.Register("db", func() (interface{}, error) { return old, nil })
+	if _, err := manager.Get("db"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Rebuild("db"); err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	lifecycleAwait(t, old.closed, "old instance close")
+
+	err := manager.CloseAll(context.Background(), CloseOptions{Timeout: lifecycleTestTimeout})
+
+	if !errors.Is(err, old.err) {
+		t.Fatalf("CloseAll() error = %v, want retired close error", err)
+	}
+	if got, want := strings.Join(recorder.list(), ","), "old,new"; got != want {
+		t.Fatalf("close order = %s, want %s", got, want)
+	}
+	// 已汇总的退役错误不重复上报
+	if err := manager.CloseAll(context.Background(), CloseOptions{}); err != nil {
+		t.Fatalf("second CloseAll() error = %v", err)
+	}
+}
+
+func TestBorrow_MissingKeyAndInitializationError(t *testing.T) {
+	manager := NewGlobalManager()
+	if _, _, err := manager.Borrow("missing"); err == nil {
+		t.Fatal("Borrow(missing) error = nil")
+	}
+	manager.Register("broken", func() (interface{}, error) { return nil, errors.New("dial failed") })
+	if _, release, err := manager.Borrow("broken"); err == nil || release != nil {
+		t.Fatalf("Borrow(broken) = (release=%v, %v), want error", release != nil, err)
+	}
+}
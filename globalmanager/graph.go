@@ -0,0 +1,482 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package globalmanager
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrDependencyMissing 声明的依赖 key 未注册
+	ErrDependencyMissing = errors.New("global object dependency not registered")
+	// ErrDependencyCycle 全局对象之间存在循环依赖
+	ErrDependencyCycle = errors.New("global object dependency cycle")
+	// ErrDependencyFailed 依赖对象初始化或重建失败，依赖方被跳过
+	ErrDependencyFailed = errors.New("global object dependency failed")
+)
+
+// SetDependencies 声明已注册条目依赖的 key，覆盖之前的声明；key 不存在时返回 false
+//
+// 适用于通过 Registers 批量注册、无法在 Register 时传入依赖的条目。
+func (gm *GlobalManager) SetDependencies(name KeyName, dependsOn ...KeyName) bool {
+	entity := gm.entryOf(name)
+	if entity == nil {
+		return false
+	}
+	gm.graphMu.Lock()
+	entity.dependsOn = normalizeDependencies(dependsOn)
+	gm.graphMu.Unlock()
+	return true
+}
+
+// SetDependencyMap 批量声明依赖，返回未注册而无法声明的 key（已排序）
+func (gm *GlobalManager) SetDependencyMap(dependencies DependencyMap) []KeyName {
+	var missing []KeyName
+	for name, dependsOn := range dependencies {
+		if !gm.SetDependencies(name, dependsOn...) {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// Dependencies 返回条目声明的直接依赖
+func (gm *GlobalManager) Dependencies(name KeyName) []KeyName {
+	entity := gm.entryOf(name)
+	if entity == nil {
+		return nil
+	}
+	gm.graphMu.RLock()
+	defer gm.graphMu.RUnlock()
+	return append([]KeyName(nil), entity.dependsOn...)
+}
+
+// Dependents 返回直接依赖该条目的 key（已排序）
+func (gm *GlobalManager) Dependents(name KeyName) []KeyName {
+	var dependents []KeyName
+	for key, dependsOn := range gm.graph() {
+		for _, dep := range dependsOn {
+			if dep == name {
+				dependents = append(dependents, key)
+				break
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// Validate 校验依赖图：依赖的 key 必须已注册且不存在循环依赖，返回全部问题的聚合错误
+func (gm *GlobalManager) Validate() error {
+	graph := gm.graph()
+	names := sortedKeys(graph)
+
+	var errs []error
+	for _, name := range names {
+		for _, dep := range graph[name] {
+			if _, ok := graph[dep]; !ok && !gm.resolvable(dep) {
+				errs = append(errs, fmt.Errorf("%w: '%s' depends on '%s'", ErrDependencyMissing, name, dep))
+			}
+		}
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	marks := make(map[KeyName]int, len(graph))
+	var path []KeyName
+	var visit func(name KeyName)
+	visit = func(name KeyName) {
+		marks[name] = visiting
+		path = append(path, name)
+		for _, dep := range graph[name] {
+			if _, ok := graph[dep]; !ok {
+				continue
+			}
+			switch marks[dep] {
+			case visiting:
+				errs = append(errs, cycleError(path, dep))
+			case 0:
+				visit(dep)
+			}
+		}
+		path = path[:len(path)-1]
+		marks[name] = visited
+	}
+	for _, name := range names {
+		if marks[name] == 0 {
+			visit(name)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// InitializeAll 按依赖图并行初始化指定 key 及其传递依赖，未指定 key 时初始化全部已注册条目
+//
+// 未注册的 key 记录错误后忽略；依赖图非法（依赖缺失或循环）时不初始化任何对象；互不依赖的对象并行初始化，每个对象等待其依赖完成后再初始化，
+// 依赖失败的对象被跳过（ErrDependencyFailed），其余对象继续。返回全部失败的聚合错误。
+func (gm *GlobalManager) InitializeAll(names ...KeyName) error {
+	graph := gm.graph()
+	if len(names) == 0 {
+		names = sortedKeys(graph)
+	}
+	var errs []error
+	roots := make([]KeyName, 0, len(names))
+	for _, name := range names {
+		if _, ok := graph[name]; !ok && !gm.resolvable(name) {
+			errs = append(errs, fmt.Errorf("entry '%s' not found for loading", name))
+			continue
+		}
+		roots = append(roots, name)
+	}
+	order, err := topologicalOrder(graph, roots, gm.resolvable)
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+
+	type result struct {
+		done chan struct{}
+		err  error
+	}
+	results := make(map[KeyName]*result, len(order))
+	for _, name := range order {
+		results[name] = &result{done: make(chan struct{})}
+	}
+	var wg sync.WaitGroup
+	for _, name := range order {
+		wg.Add(1)
+		go func(name KeyName, r *result) {
+			defer wg.Done()
+			defer close(r.done)
+			for _, dep := range graph[name] {
+				<-results[dep].done
+				if results[dep].err != nil {
+					r.err = fmt.Errorf("global object '%s' skipped, dependency '%s' failed: %w", name, dep, ErrDependencyFailed)
+					return
+				}
+			}
+			_, r.err = gm.Get(name)
+		}(name, results[name])
+	}
+	wg.Wait()
+
+	for _, name := range order {
+		if results[name].err != nil {
+			errs = append(errs, results[name].err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// initDependencies 在条目初始化前按依赖顺序初始化其声明的传递依赖
+func (gm *GlobalManager) initDependencies(name KeyName, entity *entry) error {
+	gm.graphMu.RLock()
+	declared := len(entity.dependsOn) > 0
+	gm.graphMu.RUnlock()
+	if !declared {
+		return nil
+	}
+	order, err := topologicalOrder(gm.graph(), []KeyName{name}, gm.resolvable)
+	if err != nil {
+		return err
+	}
+	for _, dep := range order {
+		if dep == name {
+			continue
+		}
+		if _, err := gm.Get(dep); err != nil {
+			return fmt.Errorf("dependency '%s' of global object '%s': %w", dep, name, err)
+		}
+	}
+	return nil
+}
+
+// rebuildDependents 级联重建已初始化的传递依赖方，按依赖顺序重新执行其初始化器
+//
+// 依赖方总是通过初始化器（而非其 Rebuilder）重建，以便取得新的依赖实例。retired 为已退役的旧实例，
+// 依赖方的旧实例与之相同时（如直接返回依赖实例的别名条目）不再重复退役。
+func (gm *GlobalManager) rebuildDependents(name KeyName, retired interface{}) error {
+	graph := gm.graph()
+	affected := map[KeyName]bool{}
+	keys := sortedKeys(graph)
+	queue := []KeyName{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, key := range keys {
+			if affected[key] {
+				continue
+			}
+			for _, dep := range graph[key] {
+				if dep == current {
+					affected[key] = true
+					queue = append(queue, key)
+					break
+				}
+			}
+		}
+	}
+	if len(affected) == 0 {
+		return nil
+	}
+
+	order, err := topologicalOrder(graph, sortedKeys(affected), gm.resolvable)
+	if err != nil {
+		return err
+	}
+	failed := map[KeyName]bool{}
+	retiredValues := []interface{}{retired}
+	var errs []error
+	for _, key := range order {
+		if !affected[key] {
+			continue
+		}
+		if dep := firstFailed(graph[key], failed); dep != "" {
+			failed[key] = true
+			errs = append(errs, fmt.Errorf("global object '%s' not rebuilt, dependency '%s' failed: %w", key, dep, ErrDependencyFailed))
+			continue
+		}
+		old, err := gm.reinitialize(key, retiredValues)
+		if err != nil {
+			failed[key] = true
+			errs = append(errs, err)
+			continue
+		}
+		if old != nil {
+			retiredValues = append(retiredValues, old)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reinitialize 重新执行已初始化条目的初始化器并替换实例，返回被替换的旧实例；未初始化的条目跳过
+func (gm *GlobalManager) reinitialize(name KeyName, retiredValues []interface{}) (old interface{}, err error) {
+	entity := gm.entryOf(name)
+	if entity == nil {
+		return nil, fmt.Errorf("global key '%s' not found in GlobalManager with rebuild cascade", name)
+	}
+	current := entity.instance.Load()
+	if current == nil {
+		return nil, nil // 未初始化，后续 Get 时自然取得新的依赖
+	}
+	if err = entity.beginMaintenance(name); err != nil {
+		return nil, err
+	}
+	defer entity.endMaintenance()
+
+	newInstance, err := func() (instance interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic occurred: %v", r)
+			}
+		}()
+		return entity.initializer()
+	}()
+	if err == nil && newInstance == nil {
+		err = fmt.Errorf("initializer returned nil instance")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild dependent global object '%s': %w", name, err)
+	}
+
+	entity.instance.Store(newStoredValue(newInstance))
+	entity.initSeq.Store(gm.initSeq.Add(1))
+	if sameInstance(current.value, newInstance) {
+		return nil, nil
+	}
+	for _, value := range retiredValues {
+		if sameInstance(current.value, value) {
+			return current.value, nil // 别名实例已随其所有者退役
+		}
+	}
+	gm.retire(name, current)
+	return current.value, nil
+}
+
+// releaseOrder 按依赖图计算释放顺序：依赖方先于其依赖，图中无约束的对象按初始化逆序
+func (gm *GlobalManager) releaseOrder(names []KeyName) []KeyName {
+	graph := gm.graph()
+	seq := make(map[KeyName]uint64, len(graph))
+	pending := make(map[KeyName]int, len(graph)) // 尚未释放的依赖方数量
+	for name, dependsOn := range graph {
+		if entity := gm.entryOf(name); entity != nil {
+			seq[name] = entity.initSeq.Load()
+		}
+		for _, dep := range dependsOn {
+			if _, ok := graph[dep]; ok {
+				pending[dep]++
+			}
+		}
+	}
+
+	ordered := make([]KeyName, 0, len(graph))
+	emitted := make(map[KeyName]bool, len(graph))
+	for len(ordered) < len(graph) {
+		next, found := "", false
+		for name := range graph {
+			if emitted[name] || pending[name] > 0 {
+				continue
+			}
+			if !found || seq[name] > seq[next] || (seq[name] == seq[next] && name < next) {
+				next, found = name, true
+			}
+		}
+		if !found {
+			// 存在循环依赖，剩余对象按初始化逆序
+			for name := range graph {
+				if !emitted[name] && (!found || seq[name] > seq[next] || (seq[name] == seq[next] && name < next)) {
+					next, found = name, true
+				}
+			}
+		}
+		emitted[next] = true
+		ordered = append(ordered, next)
+		for _, dep := range graph[next] {
+			pending[dep]--
+		}
+	}
+
+	wanted := make(map[KeyName]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	result := make([]KeyName, 0, len(names))
+	for _, name := range ordered {
+		if wanted[name] {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// graph 返回依赖图快照: key -> 直接依赖
+func (gm *GlobalManager) graph() map[KeyName][]KeyName {
+	graph := map[KeyName][]KeyName{}
+	gm.graphMu.RLock()
+	defer gm.graphMu.RUnlock()
+	gm.container.Range(func(key, value interface{}) bool {
+		name, _ := key.(string)
+		if entity, ok := value.(*entry); ok {
+			graph[name] = entity.dependsOn
+		}
+		return true
+	})
+	return graph
+}
+
+func (gm *GlobalManager) entryOf(name KeyName) *entry {
+	origin, ok := gm.container.Load(name)
+	if !ok {
+		return nil
+	}
+	entity, _ := origin.(*entry)
+	return entity
+}
+
+// topologicalOrder 返回 roots 及其传递依赖的拓扑序（依赖在前），依赖缺失或循环时返回错误
+//
+// external 返回 true 的未登记 key（如子作用域由祖先解析的对象）视为无依赖的叶子节点。
+func topologicalOrder(graph map[KeyName][]KeyName, roots []KeyName, external func(KeyName) bool) ([]KeyName, error) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	marks := make(map[KeyName]int, len(graph))
+	var order, path []KeyName
+	var visit func(name KeyName) error
+	visit = func(name KeyName) error {
+		dependsOn, ok := graph[name]
+		if !ok && external != nil && external(name) {
+			marks[name] = visited
+			order = append(order, name)
+			return nil
+		}
+		if !ok {
+			if len(path) == 0 {
+				return fmt.Errorf("%w: '%s'", ErrDependencyMissing, name)
+			}
+			return fmt.Errorf("%w: '%s' depends on '%s'", ErrDependencyMissing, path[len(path)-1], name)
+		}
+		marks[name] = visiting
+		path = append(path, name)
+		for _, dep := range dependsOn {
+			switch marks[dep] {
+			case visiting:
+				return cycleError(path, dep)
+			case 0:
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		marks[name] = visited
+		order = append(order, name)
+		return nil
+	}
+	for _, name := range roots {
+		if marks[name] == 0 {
+			if err := visit(name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return order, nil
+}
+
+// cycleError 从路径中截取以 dep 开始的环
+func cycleError(path []KeyName, dep KeyName) error {
+	start := 0
+	for i, name := range path {
+		if name == dep {
+			start = i
+			break
+		}
+	}
+	cycle := append(append([]KeyName(nil), path[start:]...), dep)
+	return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+}
+
+func normalizeDependencies(dependsOn []KeyName) []KeyName {
+	if len(dependsOn) == 0 {
+		return nil
+	}
+	seen := make(map[KeyName]bool, len(dependsOn))
+	result := make([]KeyName, 0, len(dependsOn))
+	for _, dep := range dependsOn {
+		if dep == "" || seen[dep] {
+			continue
+		}
+		seen[dep] = true
+		result = append(result, dep)
+	}
+	return result
+}
+
+func firstFailed(dependsOn []KeyName, failed map[KeyName]bool) KeyName {
+	for _, dep := range dependsOn {
+		if failed[dep] {
+			return dep
+		}
+	}
+	return ""
+}
+
+func sortedKeys[V any](m map[KeyName]V) []KeyName {
+	keys := make([]KeyName, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,307 @@
+package globalmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestValidate_ReportsMissingDependenciesAndCycles(t *testing.T) {
+	manager := NewGlobalManager()
+	noop := func() (interface{}, error) { return struct{}{}, nil }
+	manager.Register("a", noop, "b")
+	manager.Register("b", noop, "c")
+	manager.Register("c", noop, "a")
+	manager.Register("d", noop, "ghost")
+	manager.Register("e", noop)
+
+	err := manager.Validate()
+
+	if !errors.Is(err, ErrDependencyMissing) || !strings.Contains(err.Error(), "'d' depends on 'ghost'") {
+		t.Fatalf("Validate() error = %v, want missing dependency d -> ghost", err)
+	}
+	if !errors.Is(err, ErrDependencyCycle) || !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Fatalf("Validate() error = %v, want cycle a -> b -> c -> a", err)
+	}
+
+	valid := NewGlobalManager()
+	valid.Register("db", noop)
+	valid.Register("cache", noop, "db", "db")
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() on acyclic graph error = %v", err)
+	}
+	if got := valid.Dependencies("cache"); len(got) != 1 || got[0] != "db" {
+		t.Fatalf("Dependencies(cache) = %v, want [db]", got)
+	}
+	if got := valid.Dependents("db"); len(got) != 1 || got[0] != "cache" {
+		t.Fatalf("Dependents(db) = %v, want [cache]", got)
+	}
+}
+
+func TestSetDependencies_DeclaresForBatchRegisteredEntries(t *testing.T) {
+	manager := NewGlobalManager()
+	var order []string
+	manager.Registers(InitializerMap{
+		"db":    func() (interface{}, error) { order = append(order, "db"); return "db", nil },
+		"cache": func() (interface{}, error) { order = append(order, "cache"); return "cache", nil },
+	})
+	if missing := manager.SetDependencyMap(DependencyMap{"cache": {"db"}, "ghost": {"db"}}); len(missing) != 1 || missing[0] != "ghost" {
+		t.Fatalf("SetDependencyMap() missing = %v, want [ghost]", missing)
+	}
+
+	if _, err := manager.Get("cache"); err != nil {
+		t.Fatalf("Get(cache) error = %v", err)
+	}
+	if got := strings.Join(order, ","); got != "db,cache" {
+		t.Fatalf("init order = %s, want db,cache", got)
+	}
+}
+
+func TestGet_DependencyFailureDoesNotConsumeInitialization(t *testing.T) {
+	manager := NewGlobalManager()
+	var dbCalls, cacheCalls atomic.Int32
+	manager.Register("db", func() (interface{}, error) {
+		if dbCalls.Add(1) == 1 {
+			return nil, errors.New("dial failed")
+		}
+		return "db", nil
+	})
+	manager.Register("cache", func() (interface{}, error) {
+		cacheCalls.Add(1)
+		return "cache", nil
+	}, "db")
+
+	if _, err := manager.Get("cache"); err == nil || !strings.Contains(err.Error(), "dependency 'db' of global object 'cache'") {
+		t.Fatalf("Get(cache) error = %v, want dependency failure", err)
+	}
+	if cacheCalls.Load() != 0 {
+		t.Fatalf("cache initializer calls = %d, want 0", cacheCalls.Load())
+	}
+	value, err := manager.Get("cache")
+	if err != nil || value != "cache" {
+		t.Fatalf("Get(cache) retry = (%v, %v)", value, err)
+	}
+
+	cyclic := NewGlobalManager()
+	cyclic.Register("a", func() (interface{}, error) { return "a", nil }, "b")
+	cyclic.Register("b", func() (interface{}, error) { return "b", nil }, "a")
+	if _, err := cyclic.Get("a"); !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("Get(a) error = %v, want ErrDependencyCycle", err)
+	}
+}
+
+func TestInitializeAll_RunsIndependentEntriesInParallel(t *testing.T) {
+	manager := NewGlobalManager()
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	both := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(both)
+	}()
+	// 两个互不依赖的对象必须同时处于初始化中，串行初始化会超时
+	parallel := func(name string) InitializerFunc {
+		return func() (interface{}, error) {
+			arrived.Done()
+			select {
+			case <-both:
+				return name, nil
+			case <-time.After(lifecycleTestTimeout):
+				return nil, errors.New("initialized sequentially")
+			}
+		}
+	}
+	manager.Register("mongo", parallel("mongo"))
+	manager.Register("redis", parallel("redis"))
+	var redisReady atomic.Bool
+	manager.Register("level2", func() (interface{}, error) {
+		if _, err := manager.Get("redis"); err != nil {
+			return nil, err
+		}
+		redisReady.Store(true)
+		return "level2", nil
+	}, "redis")
+	manager.Register("unrelated", func() (interface{}, error) { return nil, errors.New("must not initialize") })
+
+	if err := manager.InitializeAll("level2", "mongo"); err != nil {
+		t.Fatalf("InitializeAll() error = %v", err)
+	}
+	if !redisReady.Load() {
+		t.Fatal("level2 initialized without its redis dependency")
+	}
+}
+
+func TestInitializeAll_SkipsDependentsOfFailedEntries(t *testing.T) {
+	manager := NewGlobalManager()
+	dialErr := errors.New("dial failed")
+	var dependentCalls atomic.Int32
+	manager.Register("redis", func() (interface{}, error) { return nil, dialErr })
+	manager.Register("level2", func() (interface{}, error) { dependentCalls.Add(1); return "level2", nil }, "redis")
+	manager.Register("dispatcher", func() (interface{}, error) { dependentCalls.Add(1); return "dispatcher", nil }, "level2")
+	manager.Register("mongo", func() (interface{}, error) { return "mongo", nil })
+
+	err := manager.InitializeAll()
+
+	if !strings.Contains(err.Error(), dialErr.Error()) || !errors.Is(err, ErrDependencyFailed) {
+		t.Fatalf("InitializeAll() error = %v, want redis failure and skipped dependents", err)
+	}
+	if !strings.Contains(err.Error(), "'dispatcher' skipped") {
+		t.Fatalf("InitializeAll() error = %v, want transitive dependent skipped", err)
+	}
+	if dependentCalls.Load() != 0 {
+		t.Fatalf("dependent initializer calls = %d, want 0", dependentCalls.Load())
+	}
+	if value, err := manager.Get("mongo"); err != nil || value != "mongo" {
+		t.Fatalf("independent entry = (%v, %v), want initialized", value, err)
+	}
+
+	invalid := NewGlobalManager()
+	invalid.Register("a", func() (interface{}, error) { return "a", nil }, "ghost")
+	if err := invalid.InitializeAll(); !errors.Is(err, ErrDependencyMissing) {
+		t.Fatalf("InitializeAll() error = %v, want ErrDependencyMissing", err)
+	}
+}
+
+func TestCloseAll_DeclaredDependenciesOverrideInitializationOrder(t *testing.T) {
+	manager := NewGlobalManager()
+	recorder := &closeRecorder{}
+	manager.Register("db", func() (interface{}, error) { return newRecordingClosable("db", recorder), nil })
+	manager.Register("service", func() (interface{}, error) { return newRecordingClosable("service", recorder), nil }, "db")
+	if _, err := manager.Get("service"); err != nil {
+		t.Fatal(err)
+	}
+	// db 释放后重新初始化，初始化序号晚于依赖方
+	if err := manager.Release("db"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Get("db"); err != nil {
+		t.Fatal(err)
+	}
+	recorder.order = nil
+
+	if err := manager.CloseAll(context.Background(), CloseOptions{Timeout: lifecycleTestTimeout}); err != nil {
+		t.Fatalf("CloseAll() error = %v", err)
+	}
+	if got, want := strings.Join(recorder.list(), ","), "service,db"; got != want {
+		t.Fatalf("close order = %s, want %s", got, want)
+	}
+}
+
+func TestCloseAll_ClosesSharedInstanceOnce(t *testing.T) {
+	manager := NewGlobalManager()
+	recorder := &closeRecorder{}
+	manager.Register("redis", func() (interface{}, error) { return newRecordingClosable("redis", recorder), nil })
+	manager.Register("remote", func() (interface{}, error) { return manager.Get("redis") }, "redis")
+	if _, err := manager.Get("remote"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.CloseAll(context.Background(), CloseOptions{Timeout: lifecycleTestTimeout}); err != nil {
+		t.Fatalf("CloseAll() error = %v", err)
+	}
+	if got := recorder.list(); len(got) != 1 || got[0] != "redis" {
+		t.Fatalf("closed = %v, want [redis]", got)
+	}
+	if _, err := manager.Get("remote"); err != nil {
+		t.Fatalf("Get(remote) after CloseAll error = %v", err)
+	}
+}
+
+func TestRebuild_CascadesToInitializedDependents(t *testing.T) {
+	manager := NewGlobalManager()
+	recorder := &closeRecorder{}
+	var redisGen, level2Gen atomic.Int32
+	newRedis := func() *recordingClosable {
+		redis := newRecordingClosable(fmt.Sprintf("redis#%d", redisGen.Add(1)), recorder)
+		redis.rebuild = func() (interface{}, error) {
+			return newRecordingClosable(fmt.Sprintf("redis#%d", redisGen.Add(1)), recorder), nil
+		}
+		return redis
+	}
+	manager.Register("redis", func() (interface{}, error) { return newRedis(), nil })
+	manager.Register("remote", func() (interface{}, error) { return manager.Get("redis") }, "redis")
+	manager.Register("level2", func() (interface{}, error) {
+		remote, err := manager.Get("remote")
+		if err != nil {
+			return nil, err
+		}
+		return newRecordingClosable(fmt.Sprintf("level2#%d@%s", level2Gen.Add(1), remote.(*recordingClosable).name), recorder), nil
+	}, "remote")
+	var lazyCalls atomic.Int32
+	manager.Register("dispatcher", func() (interface{}, error) { lazyCalls.Add(1); return "dispatcher", nil }, "redis")
+	if _, err := manager.Get("level2"); err != nil {
+		t.Fatal(err)
+	}
+	oldLevel2, _ := manager.Get("level2")
+
+	if err := manager.Rebuild("redis"); err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+
+	redis, _ := manager.Get("redis")
+	remote, _ := manager.Get("remote")
+	level2, _ := manager.Get("level2")
+	if remote != redis {
+		t.Fatalf("remote = %v, want rebuilt redis %v", remote, redis)
+	}
+	if got, want := level2.(*recordingClosable).name, "level2#2@redis#2"; got != want {
+		t.Fatalf("level2 = %s, want %s", got, want)
+	}
+	lifecycleAwait(t, oldLevel2.(*recordingClosable).closed, "old level2 close")
+	// 未初始化的依赖方不在级联中初始化
+	if lazyCalls.Load() != 0 {
+		t.Fatalf("dispatcher initializer calls = %d, want 0", lazyCalls.Load())
+	}
+	if err := manager.CloseAll(context.Background(), CloseOptions{Timeout: lifecycleTestTimeout}); err != nil {
+		t.Fatalf("CloseAll() error = %v", err)
+	}
+	closed := recorder.list()
+	counts := map[string]int{}
+	for _, name := range closed {
+		counts[name]++
+	}
+	for _, name := range []string{"redis#1", "level2#1@redis#1", "level2#2@redis#2", "redis#2"} {
+		if counts[name] != 1 {
+			t.Fatalf("closed = %v, want %s closed exactly once", closed, name)
+		}
+	}
+}
+
+func TestRebuild_CascadeFailureSkipsTransitiveDependents(t *testing.T) {
+	manager := NewGlobalManager()
+	recorder := &closeRecorder{}
+	redis := newRecordingClosable("redis", recorder)
+	redis.rebuild = func() (interface{}, error) { return newRecordingClosable("redis#2", recorder), nil }
+	var level2Calls, dispatcherCalls atomic.Int32
+	manager.Register("redis", func() (interface{}, error) { return redis, nil })
+	manager.Register("level2", func() (interface{}, error) {
+		if level2Calls.Add(1) > 1 {
+			return nil, errors.New("warmup failed")
+		}
+		return "level2", nil
+	}, "redis")
+	manager.Register("dispatcher", func() (interface{}, error) { dispatcherCalls.Add(1); return "dispatcher", nil }, "level2")
+	if _, err := manager.Get("dispatcher"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := manager.Rebuild("redis")
+
+	if err == nil || !strings.Contains(err.Error(), "warmup failed") || !errors.Is(err, ErrDependencyFailed) {
+		t.Fatalf("Rebuild() error = %v, want cascade failure", err)
+	}
+	if dispatcherCalls.Load() != 1 {
+		t.Fatalf("dispatcher initializer calls = %d, want 1", dispatcherCalls.Load())
+	}
+	if value, _ := manager.Get("redis"); value.(*recordingClosable).name != "redis#2" {
+		t.Fatalf("redis = %v, want rebuilt instance kept", value)
+	}
+	if value, _ := manager.Get("level2"); value != "level2" {
+		t.Fatalf("level2 = %v, want previous instance kept", value)
+	}
+}
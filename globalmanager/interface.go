@@ -21,3 +21,34 @@ type Rebuilder interface {
 	Rebuild(...interface{}) (interface{}, error)
 	GetConfPath() string // 获取配置路径
 }
+
+// Observer 观察全局对象的健康检查与重建结果，用于指标采集等，实现须并发安全且不阻塞
+type Observer interface {
+	HealthChecked(name KeyName, healthy bool, err error) // CheckHealth 返回后调用
+	Rebuilt(name KeyName, err error)                     // Rebuild 返回后调用，err 为 Rebuild 的返回值
+}
+
+// Observers 组合多个观察者，按顺序逐个通知，nil 项忽略
+func Observers(observers ...Observer) Observer {
+	list := make(multiObserver, 0, len(observers))
+	for _, o := range observers {
+		if o != nil {
+			list = append(list, o)
+		}
+	}
+	return list
+}
+
+type multiObserver []Observer
+
+func (m multiObserver) HealthChecked(name KeyName, healthy bool, err error) {
+	for _, o := range m {
+		o.HealthChecked(name, healthy, err)
+	}
+}
+
+func (m multiObserver) Rebuilt(name KeyName, err error) {
+	for _, o := range m {
+		o.Rebuilt(name, err)
+	}
+}
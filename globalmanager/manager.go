@@ -20,6 +20,11 @@ Package globalmanager 提供全局对象管理功能，用于管理应用程序
 		return &Database{}, nil
 	})
 
+	// 注册依赖其他对象的初始化器，Get 时先初始化其依赖
+	gm.Register("cache", func() (interface{}, error) {
+		return &Cache{}, nil
+	}, "database")
+
 	// 获取对象实例
 	db, err := gm.Get("database")
 	if err != nil {
@@ -42,21 +47,36 @@ import (
 //
 // 注意：该全局管理容器适用于读多写少场景
 type GlobalManager struct {
-	container sync.Map // 存储所有全局对象实例
+	container sync.Map       // 存储所有全局对象实例
+	initSeq   atomic.Uint64  // 初始化成功序号生成器，用于按初始化逆序关闭
+	retiring  sync.Map       // 被 Rebuild 替换、等待借用方归还后关闭的旧实例: *storedValue -> KeyName
+	graphMu   sync.RWMutex   // 保护各条目的依赖声明
+	parent    *GlobalManager // 父管理器，仅子作用域非 nil
+	scoped    sync.Map       // 作用域对象初始化器: KeyName -> *scopedTemplate
+	released  atomic.Bool    // 子作用域已释放
+	observer  atomic.Pointer[Observer]
 }
 
 type entry struct {
-	initializer InitializerFunc           // 对象初始化器函数，用于延迟实例化
-	once        atomic.Pointer[sync.Once] // 实现对象单例化，原子指针，确保读安全性，重置once的写时使用锁(多条原子操作)
-	instance    atomic.Pointer[storedValue]
-	initErr     atomic.Pointer[storedError]
-	maintenance atomic.Bool
-	initialized int32      // 原子标志位：0 未初始化，1 初始化成功，-1 初始化失败，使用atomic原子操作
-	mu          sync.Mutex // 用于保护重置操作(如多条原子操作)
+	initializer  InitializerFunc           // 对象初始化器函数，用于延迟实例化
+	once         atomic.Pointer[sync.Once] // 实现对象单例化，原子指针，确保读安全性，重置once的写时使用锁(多条原子操作)
+	instance     atomic.Pointer[storedValue]
+	initErr      atomic.Pointer[storedError]
+	maintenance  atomic.Bool
+	initialized  int32         // 原子标志位：0 未初始化，1 初始化成功，-1 初始化失败，使用atomic原子操作
+	mu           sync.Mutex    // 用于保护重置操作(如多条原子操作)
+	initSeq      atomic.Uint64 // 最近一次初始化成功的序号，依赖方总是晚于其依赖完成初始化
+	closeTimeout atomic.Int64  // 条目级关闭超时（纳秒），0 表示使用 CloseOptions.Timeout
+	dependsOn    []KeyName     // 声明的直接依赖，由 GlobalManager.graphMu 保护
 }
 
 type storedValue struct {
-	value interface{}
+	value      interface{}
+	borrows    atomic.Int64  // 借用计数
+	retiring   atomic.Bool   // 已退役：不再接受新的借用，借用归零后关闭
+	retireOnce sync.Once     // 确保退役关闭只执行一次
+	closed     chan struct{} // 退役关闭完成后关闭该通道
+	closeErr   error         // 退役关闭的错误，closed 关闭后只读
 }
 
 type storedError struct {
@@ -97,8 +117,10 @@ func NewGlobalManagerOnce() *GlobalManager {
 	return globalManager
 }
 
-// Register 注册一个全局对象的初始化器
-func (gm *GlobalManager) Register(name KeyName, initializer InitializerFunc) bool {
+// Register 注册一个全局对象的初始化器，dependsOn 声明其依赖的其他全局对象 key
+//
+// 声明的依赖在该对象初始化前先被初始化，重建依赖时级联重建该对象，释放/关闭时该对象先于其依赖。
+func (gm *GlobalManager) Register(name KeyName, initializer InitializerFunc, dependsOn ...KeyName) bool {
 	if initializer == nil {
 		return false
 	}
@@ -109,6 +131,7 @@ func (gm *GlobalManager) Register(name KeyName, initializer InitializerFunc) boo
 	newEntry := &entry{
 		initializer: initializer,
 		initialized: 0, // 显式初始化为未初始化状态
+		dependsOn:   normalizeDependencies(dependsOn),
 	}
 	newEntry.once.Store(&sync.Once{}) // 初始化原子指针
 	_, loaded := gm.container.LoadOrStore(name, newEntry)
@@ -125,7 +148,24 @@ func (gm *GlobalManager) Registers(initializers InitializerMap) {
 
 // Get 获取一个全局对象
 func (gm *GlobalManager) Get(name KeyName) (instance interface{}, err error) {
+	if gm.released.Load() {
+		err = fmt.Errorf("%w: get '%s'", ErrScopeReleased, name)
+		return
+	}
 	origin, ok := gm.container.Load(name)
+	if !ok {
+		// 子作用域：创建作用域对象或委托父管理器
+		materialized, parent, resolveErr := gm.resolveMissing(name)
+		switch {
+		case resolveErr != nil:
+			err = resolveErr
+			return
+		case parent != nil:
+			return parent.Get(name)
+		case materialized:
+			origin, ok = gm.container.Load(name)
+		}
+	}
 	if !ok {
 		err = fmt.Errorf("entry '%s' not found for loading", name)
 		return
@@ -159,6 +199,11 @@ func (gm *GlobalManager) Get(name KeyName) (instance interface{}, err error) {
 		entity.mu.Unlock()
 	}
 
+	// 先初始化声明的依赖，依赖失败时不消耗本对象的初始化机会
+	if err = gm.initDependencies(name, entity); err != nil {
+		return
+	}
+
 	// 仅初始化一次
 	entity.once.Load().Do(func() {
 		defer func() {
@@ -180,7 +225,8 @@ func (gm *GlobalManager) Get(name KeyName) (instance interface{}, err error) {
 			atomic.StoreInt32(&entity.initialized, -1)
 			return
 		}
-		entity.instance.Store(&storedValue{value: instance})
+		entity.instance.Store(newStoredValue(instance))
+		entity.initSeq.Store(gm.initSeq.Add(1))
 		entity.initErr.Store(nil)
 		// 初始化成功，设置初始化状态为1
 		atomic.StoreInt32(&entity.initialized, 1)
@@ -201,8 +247,29 @@ func (gm *GlobalManager) Range(f func(key, value interface{}) bool) {
 	gm.container.Range(f)
 }
 
+// SetObserver 设置健康检查与重建的观察者，nil 清除；子作用域未设置时沿用祖先的观察者
+func (gm *GlobalManager) SetObserver(observer Observer) {
+	if observer == nil {
+		gm.observer.Store(nil)
+		return
+	}
+	gm.observer.Store(&observer)
+}
+
+func (gm *GlobalManager) observerOf() Observer {
+	for m := gm; m != nil; m = m.parent {
+		if o := m.observer.Load(); o != nil {
+			return *o
+		}
+	}
+	return nil
+}
+
 // CheckHealth 检查全局对象是否健康
-func (gm *GlobalManager) CheckHealth(name KeyName) (bool, error) {
+func (gm *GlobalManager) CheckHealth(name KeyName) (healthy bool, err error) {
+	if observer := gm.observerOf(); observer != nil {
+		defer func() { observer.HealthChecked(name, healthy, err) }()
+	}
 	origin, ok := gm.container.Load(name)
 	if !ok {
 		return true, fmt.Errorf("global instance '%s' not found in GlobalManager with CheckHealth method", name)
@@ -225,7 +292,14 @@ func (gm *GlobalManager) CheckHealth(name KeyName) (bool, error) {
 }
 
 // Rebuild 重建全局对象
-func (gm *GlobalManager) Rebuild(name KeyName) error {
+//
+// 新实例替换后，与新实例不同的旧实例被退役：不再接受新的 Borrow，待已借出的引用全部归还后，
+// 若实现 Closable 则异步关闭；关闭错误由 CloseAll 汇总返回。仅通过 Get 取得的引用不参与借用计数。
+// 替换成功后按依赖图级联重建已初始化的依赖方（重新执行其初始化器），级联失败的聚合错误一并返回。
+func (gm *GlobalManager) Rebuild(name KeyName) (err error) {
+	if observer := gm.observerOf(); observer != nil {
+		defer func() { observer.Rebuilt(name, err) }()
+	}
 	origin, ok := gm.container.Load(name)
 	if !ok {
 		return fmt.Errorf("global key '%s' not found in GlobalManager with rebuild method", name)
@@ -240,8 +314,9 @@ func (gm *GlobalManager) Rebuild(name KeyName) error {
 	defer entity.endMaintenance()
 
 	var currentInstance interface{}
-	if stored := entity.instance.Load(); stored != nil {
-		currentInstance = stored.value
+	current := entity.instance.Load()
+	if current != nil {
+		currentInstance = current.value
 	}
 	if currentInstance == nil {
 		return fmt.Errorf("global object '%s' not initialized with rebuild method", name)
@@ -254,7 +329,14 @@ func (gm *GlobalManager) Rebuild(name KeyName) error {
 		if newInstance == nil {
 			return fmt.Errorf("failed to rebuild global object '%s': rebuilder returned nil instance", name)
 		}
-		entity.instance.Store(&storedValue{value: newInstance})
+		entity.instance.Store(newStoredValue(newInstance))
+		if sameInstance(currentInstance, newInstance) {
+			return nil
+		}
+		gm.retire(name, current) // 旧实例在借用方全部归还后关闭
+		if err := gm.rebuildDependents(name, currentInstance); err != nil {
+			return fmt.Errorf("global object '%s' rebuilt, but dependent cascade failed: %w", name, err)
+		}
 		return nil
 	}
 
@@ -302,16 +384,21 @@ func (gm *GlobalManager) Release(name KeyName) error {
 }
 
 // ReleaseAll 释放所有已注册对象的资源，仅仅释放，支持get时重建对象
+//
+// 按依赖图释放，依赖方先于其依赖。
 func (gm *GlobalManager) ReleaseAll(conform ...bool) {
 	if len(conform) > 0 && conform[0] {
+		var names []KeyName
 		gm.container.Range(func(key, value interface{}) bool {
-			name := key.(string)
+			names = append(names, key.(string))
+			return true
+		})
+		for _, name := range gm.releaseOrder(names) {
 			err := gm.Release(name)
 			if err != nil {
 				fmt.Printf("failed to release global object '%s': %v.\n", name, err)
 			}
-			return true
-		})
+		}
 	}
 }
 
@@ -337,3 +424,13 @@ func (gm *GlobalManager) IsRegistered(name KeyName) bool {
 	_, ok := gm.container.Load(name)
 	return ok
 }
+
+// IsInitialized 检查 key 对应的对象是否已初始化
+func (gm *GlobalManager) IsInitialized(name KeyName) bool {
+	origin, ok := gm.container.Load(name)
+	if !ok {
+		return false
+	}
+	entity, ok := origin.(*entry)
+	return ok && entity.instance.Load() != nil
+}
@@ -940,8 +940,14 @@ func TestMaintenance_SameEntryReentryReturnsBusy(t *testing.T) {
 			t.Fatalf("outer Rebuild error = %v", err)
 		}
 		assertLifecycleBusy(t, nestedErr)
-		if got := closeCalls.Load(); got != 0 {
-			t.Fatalf("Close callbacks = %d, want 0", got)
+		// 嵌套 Release 被拒绝，旧实例只由 Rebuild 的退役流程关闭一次
+		deadline := time.Now().Add(lifecycleTestTimeout)
+		for closeCalls.Load() == 0 && time.Now().Before(deadline) {
+			runtime.Gosched()
+		}
+		time.Sleep(10 * time.Millisecond)
+		if got := closeCalls.Load(); got != 1 {
+			t.Fatalf("Close callbacks = %d, want 1 (retirement only)", got)
 		}
 	})
 
@@ -376,3 +376,73 @@ outer:
 	}
 	return -1
 }
+
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (o *recordingObserver) HealthChecked(name KeyName, healthy bool, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, fmt.Sprintf("health:%s:%t:%t", name, healthy, err != nil))
+}
+
+func (o *recordingObserver) Rebuilt(name KeyName, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, fmt.Sprintf("rebuild:%s:%t", name, err != nil))
+}
+
+func TestObserver_RecordsHealthChecksAndRebuilds(t *testing.T) {
+	m := newMgr()
+	observer := &recordingObserver{}
+	m.SetObserver(observer)
+	m.Register("obs_svc", func() (interface{}, error) {
+		return &rebuildableResource{version: 1, path: "config/resource.yml"}, nil
+	})
+	if _, err := m.Get("obs_svc"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	_, _ = m.CheckHealth("obs_svc")
+	_, _ = m.CheckHealth("obs_missing")
+	_ = m.Rebuild("obs_svc")
+	_ = m.Rebuild("obs_missing")
+
+	// 子作用域沿用父管理器的观察者
+	_, _ = m.NewScope().CheckHealth("obs_scope_missing")
+
+	want := []string{
+		"health:obs_svc:true:false",
+		"health:obs_missing:true:true",
+		"rebuild:obs_svc:false",
+		"rebuild:obs_missing:true",
+		"health:obs_scope_missing:true:true",
+	}
+	if !reflect.DeepEqual(observer.events, want) {
+		t.Fatalf("observer events = %v, want %v", observer.events, want)
+	}
+
+	m.SetObserver(nil)
+	_, _ = m.CheckHealth("obs_svc")
+	if len(observer.events) != len(want) {
+		t.Fatalf("observer events after clear = %d, want %d", len(observer.events), len(want))
+	}
+}
+
+func TestObservers_NotifiesEachInOrderAndSkipsNil(t *testing.T) {
+	first, second := &recordingObserver{}, &recordingObserver{}
+	m := newMgr()
+	m.SetObserver(Observers(first, nil, second))
+
+	_, _ = m.CheckHealth("obs_multi_missing")
+	_ = m.Rebuild("obs_multi_missing")
+
+	want := []string{"health:obs_multi_missing:true:true", "rebuild:obs_multi_missing:true"}
+	for i, o := range []*recordingObserver{first, second} {
+		if !reflect.DeepEqual(o.events, want) {
+			t.Fatalf("observer %d events = %v, want %v", i, o.events, want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package globalmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrScopeReleased 作用域已释放，不再解析任何对象
+var ErrScopeReleased = errors.New("global manager scope released")
+
+// DefaultScopeReleaseTimeout 作用域释放时单个对象的默认关闭超时（含等待借用方归还）
+const DefaultScopeReleaseTimeout = 5 * time.Second
+
+// ScopedInitializerFunc 作用域对象初始化器，scope 为创建该实例的作用域，可从中解析其他对象
+type ScopedInitializerFunc func(scope *GlobalManager) (interface{}, error)
+
+type scopedTemplate struct {
+	initializer ScopedInitializerFunc
+	dependsOn   []KeyName
+}
+
+// NewScope 创建子作用域管理器
+//
+// 子作用域持有自己的条目与延迟创建的实例：本地未注册的 key 先按祖先通过 RegisterScoped 注册的作用域初始化器
+// 在本作用域内创建实例，否则委托父管理器解析。Rebuild、Release、CloseAll 等维护操作只作用于本地条目。
+// 子作用域使用结束后必须调用 ReleaseScope 关闭本地实例。
+func (gm *GlobalManager) NewScope() *GlobalManager {
+	return &GlobalManager{
+		container: sync.Map{},
+		parent:    gm,
+	}
+}
+
+// Parent 返回父管理器，根管理器返回 nil
+func (gm *GlobalManager) Parent() *GlobalManager {
+	return gm.parent
+}
+
+// RegisterScoped 注册作用域对象初始化器：本管理器不创建该对象，每个后代作用域首次解析时各自创建一个实例，
+// 并在该作用域释放时关闭。dependsOn 声明其依赖，可以是祖先中的对象或其他作用域对象。
+func (gm *GlobalManager) RegisterScoped(name KeyName, initializer ScopedInitializerFunc, dependsOn ...KeyName) bool {
+	if initializer == nil {
+		return false
+	}
+	_, loaded := gm.scoped.LoadOrStore(name, &scopedTemplate{
+		initializer: initializer,
+		dependsOn:   normalizeDependencies(dependsOn),
+	})
+	return !loaded
+}
+
+// ReleaseScope 释放子作用域：按依赖图逆序关闭本地实例并清空条目，之后解析任何 key 均返回 ErrScopeReleased
+//
+// 重复调用返回 nil；对根管理器调用返回错误。单个对象的关闭超时为 DefaultScopeReleaseTimeout，可通过 SetCloseTimeout 按条目覆盖。
+func (gm *GlobalManager) ReleaseScope(ctx context.Context) error {
+	if gm.parent == nil {
+		return errors.New("ReleaseScope called on a root global manager")
+	}
+	if !gm.released.CompareAndSwap(false, true) {
+		return nil
+	}
+	err := gm.CloseAll(ctx, CloseOptions{Timeout: DefaultScopeReleaseTimeout})
+	gm.ClearAll(true)
+	return err
+}
+
+// materializeScoped 按祖先注册的作用域初始化器在本作用域登记条目，未找到初始化器时返回 false
+func (gm *GlobalManager) materializeScoped(name KeyName) bool {
+	for ancestor := gm.parent; ancestor != nil; ancestor = ancestor.parent {
+		origin, ok := ancestor.scoped.Load(name)
+		if !ok {
+			continue
+		}
+		template := origin.(*scopedTemplate)
+		gm.Register(name, func() (interface{}, error) {
+			return template.initializer(gm)
+		}, template.dependsOn...)
+		return true
+	}
+	return false
+}
+
+// resolvable 本地未注册的 key 能否由祖先或作用域初始化器解析
+func (gm *GlobalManager) resolvable(name KeyName) bool {
+	for ancestor := gm.parent; ancestor != nil; ancestor = ancestor.parent {
+		if _, ok := ancestor.scoped.Load(name); ok {
+			return true
+		}
+		if ancestor.IsRegistered(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveMissing 解析本地未注册的 key：作用域对象在本地登记后返回 true，由父管理器解析时返回 parent
+func (gm *GlobalManager) resolveMissing(name KeyName) (materialized bool, parent *GlobalManager, err error) {
+	if gm.materializeScoped(name) {
+		return true, nil, nil
+	}
+	if gm.parent != nil {
+		return false, gm.parent, nil
+	}
+	if _, ok := gm.scoped.Load(name); ok {
+		return false, nil, fmt.Errorf("global object '%s' is scoped, resolve it from a scope created by NewScope", name)
+	}
+	return false, nil, nil
+}
+
+// LazyScope 延迟创建的子作用域，首次调用 Scope 时才创建，适合按请求或按任务挂载
+type LazyScope struct {
+	parent   *GlobalManager
+	mu       sync.Mutex
+	scope    *GlobalManager
+	released bool
+}
+
+// NewLazyScope 创建以 parent 为父管理器的延迟作用域
+func NewLazyScope(parent *GlobalManager) *LazyScope {
+	return &LazyScope{parent: parent}
+}
+
+// Scope 返回作用域管理器，首次调用时创建；Release 之后返回的作用域已释放
+func (l *LazyScope) Scope() *GlobalManager {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.scope == nil {
+		l.scope = l.parent.NewScope()
+		if l.released {
+			l.scope.released.Store(true)
+		}
+	}
+	return l.scope
+}
+
+// Release 释放已创建的作用域，未创建时直接返回
+func (l *LazyScope) Release(ctx context.Context) error {
+	l.mu.Lock()
+	l.released = true
+	scope := l.scope
+	l.mu.Unlock()
+	if scope == nil {
+		return nil
+	}
+	return scope.ReleaseScope(ctx)
+}
+
+type scopeContextKey struct{}
+
+// ScopeLocalsKey 请求作用域在 Web 框架请求上下文（Fiber Locals、Gin/Hertz Keys）中的存储键
+const ScopeLocalsKey = "__fiberhouse_request_scope"
+
+// ContextWithScope 将延迟作用域挂载到 context.Context
+func ContextWithScope(ctx context.Context, scope *LazyScope) context.Context {
+	return context.WithValue(ctx, scopeContextKey{}, scope)
+}
+
+// ScopeFromContext 从 context.Context 获取作用域管理器，未挂载时返回 nil
+func ScopeFromContext(ctx context.Context) *GlobalManager {
+	if ctx == nil {
+		return nil
+	}
+	if scope, ok := ctx.Value(scopeContextKey{}).(*LazyScope); ok && scope != nil {
+		return scope.Scope()
+	}
+	return nil
+}
+
+// ScopeFromValue 从请求上下文存储的值（ScopeLocalsKey 对应的值）获取作用域管理器，类型不符时返回 nil
+func ScopeFromValue(value interface{}) *GlobalManager {
+	if scope, ok := value.(*LazyScope); ok && scope != nil {
+		return scope.Scope()
+	}
+	return nil
+}
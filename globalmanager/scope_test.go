@@ -0,0 +1,164 @@
+package globalmanager
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestScope_ResolvesFromParentOnMiss(t *testing.T) {
+	root := NewGlobalManager()
+	root.Register("db", func() (interface{}, error) { return "root-db", nil })
+	scope := root.NewScope()
+	scope.Register("db", func() (interface{}, error) { return "tenant-db", nil })
+	scope.Register("config", func() (interface{}, error) { return "tenant-config", nil })
+
+	if value, err := scope.Get("db"); err != nil || value != "tenant-db" {
+		t.Fatalf("scope.Get(db) = (%v, %v), want local tenant-db", value, err)
+	}
+	request := scope.NewScope()
+	if value, err := request.Get("config"); err != nil || value != "tenant-config" {
+		t.Fatalf("request.Get(config) = (%v, %v), want inherited tenant-config", value, err)
+	}
+	if value, err := root.Get("db"); err != nil || value != "root-db" {
+		t.Fatalf("root.Get(db) = (%v, %v), want root-db", value, err)
+	}
+	if request.IsRegistered("config") {
+		t.Fatal("parent resolution registered entry locally")
+	}
+	if _, err := request.Get("missing"); err == nil || !strings.Contains(err.Error(), "'missing' not found") {
+		t.Fatalf("request.Get(missing) error = %v", err)
+	}
+	if request.Parent() != scope || root.Parent() != nil {
+		t.Fatal("Parent() mismatch")
+	}
+}
+
+func TestScope_ScopedInitializerCreatesInstancePerScope(t *testing.T) {
+	root := NewGlobalManager()
+	recorder := &closeRecorder{}
+	root.Register("db", func() (interface{}, error) { return newRecordingClosable("db", recorder), nil })
+	var created atomic.Int32
+	root.RegisterScoped("uow", func(scope *GlobalManager) (interface{}, error) {
+		db, err := scope.Get("db")
+		if err != nil {
+			return nil, err
+		}
+		created.Add(1)
+		return newRecordingClosable("uow@"+db.(*recordingClosable).name, recorder), nil
+	}, "db")
+	root.RegisterScoped("audit", func(scope *GlobalManager) (interface{}, error) {
+		return newRecordingClosable("audit", recorder), nil
+	}, "uow")
+
+	if _, err := root.Get("uow"); err == nil || !strings.Contains(err.Error(), "is scoped") {
+		t.Fatalf("root.Get(uow) error = %v, want scoped error", err)
+	}
+
+	first, second := root.NewScope(), root.NewScope()
+	a, err := first.Get("audit")
+	if err != nil {
+		t.Fatalf("first.Get(audit) error = %v", err)
+	}
+	uow1, _ := first.Get("uow")
+	uow1Again, _ := first.Get("uow")
+	uow2, _ := second.Get("uow")
+	if uow1 != uow1Again || uow1 == uow2 || created.Load() != 2 {
+		t.Fatalf("scoped instances: same scope equal=%v, cross scope equal=%v, created=%d", uow1 == uow1Again, uow1 == uow2, created.Load())
+	}
+	if err := first.Validate(); err != nil {
+		t.Fatalf("first.Validate() error = %v", err)
+	}
+
+	if err := first.ReleaseScope(context.Background()); err != nil {
+		t.Fatalf("ReleaseScope() error = %v", err)
+	}
+	// 作用域内依赖逆序关闭，父管理器的对象不受影响
+	if got, want := strings.Join(recorder.list(), ","), "audit,uow@db"; got != want {
+		t.Fatalf("close order = %s, want %s", got, want)
+	}
+	lifecycleAwait(t, a.(*recordingClosable).closed, "audit close")
+	if _, err := first.Get("uow"); !errors.Is(err, ErrScopeReleased) {
+		t.Fatalf("Get after ReleaseScope error = %v, want ErrScopeReleased", err)
+	}
+	if _, _, err := first.Borrow("db"); !errors.Is(err, ErrScopeReleased) {
+		t.Fatalf("Borrow after ReleaseScope error = %v, want ErrScopeReleased", err)
+	}
+	if err := first.ReleaseScope(context.Background()); err != nil {
+		t.Fatalf("second ReleaseScope() error = %v", err)
+	}
+	if value, err := second.Get("uow"); err != nil || value != uow2 {
+		t.Fatalf("sibling scope after release = (%v, %v)", value, err)
+	}
+	if err := root.ReleaseScope(context.Background()); err == nil {
+		t.Fatal("root.ReleaseScope() error = nil")
+	}
+}
+
+func TestScope_BorrowFromParentAndScopedEntries(t *testing.T) {
+	root := NewGlobalManager()
+	recorder := &closeRecorder{}
+	root.Register("db", func() (interface{}, error) { return newRecordingClosable("db", recorder), nil })
+	root.RegisterScoped("uow", func(*GlobalManager) (interface{}, error) {
+		return newRecordingClosable("uow", recorder), nil
+	})
+	scope := root.NewScope()
+
+	db, releaseDB, err := scope.Borrow("db")
+	if err != nil || db.(*recordingClosable).name != "db" {
+		t.Fatalf("Borrow(db) = (%v, %v)", db, err)
+	}
+	releaseDB()
+	uow, releaseUOW, err := scope.Borrow("uow")
+	if err != nil {
+		t.Fatalf("Borrow(uow) error = %v", err)
+	}
+
+	result := make(chan error, 1)
+	go func() { result <- scope.ReleaseScope(context.Background()) }()
+	releaseUOW()
+	if err := lifecycleReceive(t, result, "ReleaseScope"); err != nil {
+		t.Fatalf("ReleaseScope() error = %v", err)
+	}
+	lifecycleAwait(t, uow.(*recordingClosable).closed, "uow close")
+	if got := recorder.list(); len(got) != 1 || got[0] != "uow" {
+		t.Fatalf("closed = %v, want only scoped uow", got)
+	}
+}
+
+func TestLazyScope_CreatesOnFirstUseAndReleases(t *testing.T) {
+	root := NewGlobalManager()
+	recorder := &closeRecorder{}
+	root.RegisterScoped("uow", func(*GlobalManager) (interface{}, error) {
+		return newRecordingClosable("uow", recorder), nil
+	})
+
+	unused := NewLazyScope(root)
+	if err := unused.Release(context.Background()); err != nil {
+		t.Fatalf("unused Release() error = %v", err)
+	}
+	if _, err := unused.Scope().Get("uow"); !errors.Is(err, ErrScopeReleased) {
+		t.Fatalf("Scope() after Release error = %v, want ErrScopeReleased", err)
+	}
+
+	lazy := NewLazyScope(root)
+	ctx := ContextWithScope(context.Background(), lazy)
+	scope := ScopeFromContext(ctx)
+	if scope == nil || scope != ScopeFromValue(lazy) || scope.Parent() != root {
+		t.Fatal("ScopeFromContext did not return the lazily created scope")
+	}
+	if _, err := scope.Get("uow"); err != nil {
+		t.Fatal(err)
+	}
+	if err := lazy.Release(context.Background()); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if got := recorder.list(); len(got) != 1 || got[0] != "uow" {
+		t.Fatalf("closed = %v, want [uow]", got)
+	}
+	if ScopeFromContext(context.Background()) != nil || ScopeFromValue("other") != nil {
+		t.Fatal("scope lookup without a mounted scope returned non-nil")
+	}
+}
@@ -12,6 +12,9 @@ type InitializerFunc func() (interface{}, error)
 
 type InitializerMap map[string]InitializerFunc
 
+// DependencyMap 全局对象依赖声明: key -> 依赖的 key 列表
+type DependencyMap map[KeyName][]KeyName
+
 type RegisterKeyFunc func(...string) string
 
 type RegisterKeySlice []func(...string) string
@@ -0,0 +1,31 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/component/health"
+)
+
+// healthEndpoints 读取 application.health 配置，启用时把超时与非关键全局对象应用到 health.Default()，返回探针路径配置及是否启用
+func healthEndpoints(ctx IApplicationContext) (conf health.Config, enabled bool) {
+	cfg := ctx.GetConfig()
+	conf, err := appconfig.Bind[health.Config](cfg, health.ConfPath)
+	if err != nil {
+		ctx.GetLogger().ErrorWith(cfg.LogOriginFrame()).Err(err).Msg("bind health config failed")
+		return conf, false
+	}
+	if !conf.Enable {
+		return conf, false
+	}
+	registry := health.Default()
+	registry.SetTimeout(conf.Timeout)
+	for _, name := range conf.Optional {
+		registry.SetGlobalCriticality(name, health.NonCritical)
+	}
+	return conf, true
+}
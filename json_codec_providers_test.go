@@ -115,3 +115,35 @@ func TestSonicJCodecGinProvider_RepeatedInitializeReturnsSameCodec(t *testing.T)
 	require.NotNil(t, second, "repeated Initialize() after StateLoaded must not return nil")
 	assert.Same(t, sentinel, second, "repeated Initialize() must return the previously cached instance")
 }
+
+func TestJsonJCodecHertzProvider_RepeatedInitializeReturnsSameCodec(t *testing.T) {
+	p := NewJsonJCodecHertzProvider()
+	ctx := newJSONCodecProviderTestContext(t)
+
+	first, err := p.Initialize(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, first, "first Initialize() must return a non-nil codec")
+
+	second, err := p.Initialize(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, second, "repeated Initialize() after StateLoaded must not return nil")
+	assert.Same(t, first, second, "repeated Initialize() must return the same cached codec instance")
+}
+
+// TestSonicJCodecHertzProvider_RepeatedInitializeReturnsSameCodec
+//
+// 采用方案与原因同 TestSonicJCodecFiberProvider_RepeatedInitializeReturnsSameCodec：
+// 缓存字段类型为 JsonWrapper，直接写入 StdJSON sentinel 并置为 StateLoaded。
+func TestSonicJCodecHertzProvider_RepeatedInitializeReturnsSameCodec(t *testing.T) {
+	p := NewSonicJCodecHertzProvider()
+	ctx := newJSONCodecProviderTestContext(t)
+
+	sentinel := jsoncodec.StdJsonDefault()
+	p.jcodec = sentinel
+	p.SetStatus(StateLoaded)
+
+	second, err := p.Initialize(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, second, "repeated Initialize() after StateLoaded must not return nil")
+	assert.Same(t, sentinel, second, "repeated Initialize() must return the previously cached instance")
+}
@@ -0,0 +1,34 @@
+package fiberhouse
+
+import (
+	"github.com/lamxy/fiberhouse/component/codec/json"
+	"github.com/lamxy/fiberhouse/constant"
+)
+
+type JsonJCodecHertzProvider struct {
+	IProvider
+	jcodec *jsoncodec.StdJSON
+}
+
+// NewJsonJCodecHertzProvider 创建一个新的 JSON 编解码提供者
+func NewJsonJCodecHertzProvider() *JsonJCodecHertzProvider {
+	return &JsonJCodecHertzProvider{
+		IProvider: NewProvider().
+			SetName("JsonJCodecHertzProvider").
+			SetVersion(constant.TrafficCodecWithStd).
+			SetTarget(constant.CoreTypeWithHertz).
+			SetType(ProviderTypeDefault().GroupTrafficCodecChoose),
+	}
+}
+
+// Initialize 重载初始化 JSON 编解码提供者
+func (j *JsonJCodecHertzProvider) Initialize(ctx IContext, fn ...ProviderInitFunc) (any, error) {
+	if j.Status() == StateLoaded {
+		return j.jcodec, nil
+	}
+	// 实现 JSON 编解码器的注册逻辑
+	jcodec := jsoncodec.StdJsonDefault()
+
+	j.jcodec = jcodec
+	return j.jcodec, nil
+}
@@ -0,0 +1,33 @@
+package fiberhouse
+
+import "github.com/lamxy/fiberhouse/constant"
+
+type SonicJCodecHertzProvider struct {
+	IProvider
+	jcodec JsonWrapper
+}
+
+// NewSonicJCodecHertzProvider 创建一个新的 JSON 编解码提供者
+func NewSonicJCodecHertzProvider() *SonicJCodecHertzProvider {
+	return &SonicJCodecHertzProvider{
+		IProvider: NewProvider().
+			SetName("SonicJCodecHertzProvider").
+			SetVersion(constant.TrafficCodecWithSonic).
+			SetTarget(constant.CoreTypeWithHertz).
+			SetType(ProviderTypeDefault().GroupTrafficCodecChoose),
+	}
+}
+
+// Initialize 重载初始化 JSON 编解码提供者
+func (j *SonicJCodecHertzProvider) Initialize(ctx IContext, fn ...ProviderInitFunc) (any, error) {
+	if j.Status() == StateLoaded {
+		return j.jcodec, nil
+	}
+	// 实现 JSON 编解码器的注册逻辑
+	jcodec, err := GetInstance[JsonWrapper](ctx.GetStarter().GetApplication().GetDefaultTrafficCodecKey())
+	if err != nil {
+		return nil, err
+	}
+	j.jcodec = jcodec
+	return j.jcodec, nil
+}
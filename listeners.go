@@ -0,0 +1,266 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/lamxy/fiberhouse/appconfig"
+)
+
+// ListenersConfPath 附加监听器配置路径，其下每个 key 为一个监听器名称
+const ListenersConfPath = "application.listeners"
+
+// ListenerConfig 附加监听器配置，对应配置路径 application.listeners.<name>
+type ListenerConfig struct {
+	Name    string    `koanf:"-"`                                                         // 监听器名称，取自配置 key
+	Network string    `koanf:"network" default:"tcp" validate:"oneof=tcp tcp4 tcp6 unix"` // 网络类型
+	Address string    `koanf:"address" validate:"required"`                               // 监听地址，unix 时为套接字文件路径
+	TLS     TLSConfig `koanf:"tls"`                                                       // 启用时以 HTTPS 提供服务，证书热加载与 mTLS 同主监听器
+	Pprof   bool      `koanf:"pprof"`                                                     // 是否在该监听器上挂载 /debug/pprof 路由
+}
+
+// Scheme 返回监听器的协议名
+func (c ListenerConfig) Scheme() string {
+	if c.TLS.Enable {
+		return "https"
+	}
+	return "http"
+}
+
+// ListenerStarter 支持附加监听器的核心启动器
+//
+// 每个附加监听器拥有独立的引擎实例（Fiber 为 *fiber.App，Gin 为 *gin.Engine）与中间件链，
+// 应用在注册路由时按名称取得引擎并挂载只对该监听器开放的路由。
+type ListenerStarter interface {
+	// GetListenerApp 返回指定名称监听器的引擎实例，未配置时返回 nil
+	GetListenerApp(name string) interface{}
+	// ListenerNames 返回已配置的附加监听器名称，已排序
+	ListenerNames() []string
+}
+
+// loadListenerConfigs 按名称顺序绑定 application.listeners 下的全部监听器配置
+func loadListenerConfigs(cfg appconfig.IAppConfig) ([]ListenerConfig, error) {
+	ko, err := appconfig.GetCoreWithConfig[*koanf.Koanf](cfg)
+	if err != nil {
+		return nil, err
+	}
+	names := ko.MapKeys(ListenersConfPath)
+	sort.Strings(names)
+	confs := make([]ListenerConfig, 0, len(names))
+	var errs []error
+	for _, name := range names {
+		conf, err := appconfig.Bind[ListenerConfig](cfg, ListenersConfPath+"."+name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		conf.Name = name
+		confs = append(confs, conf)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return confs, nil
+}
+
+// listenersConfigured 配置中是否声明了附加监听器
+func listenersConfigured(cfg appconfig.IAppConfig) bool {
+	ko, err := appconfig.GetCoreWithConfig[*koanf.Koanf](cfg)
+	return err == nil && len(ko.MapKeys(ListenersConfPath)) > 0
+}
+
+// listen 按配置绑定监听器；unix 套接字绑定前移除遗留的套接字文件，tlsConfig 非 nil 时返回 TLS 监听器
+func (c ListenerConfig) listen(tlsConfig *tls.Config) (net.Listener, error) {
+	if c.Network == "unix" {
+		if info, err := os.Stat(c.Address); err == nil && info.Mode()&os.ModeSocket != 0 {
+			_ = os.Remove(c.Address)
+		}
+	}
+	ln, err := net.Listen(c.Network, c.Address)
+	if err != nil {
+		return nil, fmt.Errorf("listener %s: %w", c.Name, err)
+	}
+	if tlsConfig != nil {
+		ln = tls.NewListener(ln, tlsConfig)
+	}
+	return ln, nil
+}
+
+// listenerServer 附加监听器：配置、独立的引擎实例及其服务与关闭函数
+type listenerServer struct {
+	conf     ListenerConfig
+	app      interface{}
+	serve    func(net.Listener) error
+	shutdown func(context.Context) error
+	ln       net.Listener
+	tls      *serverTLS
+	// nextProtos TLS ALPN 协议列表，引擎支持 HTTP/2 时包含 h2；为空时只协商 HTTP/1.1
+	nextProtos []string
+}
+
+// listenerGroup 核心启动器的附加监听器集合，零值可用
+type listenerGroup struct {
+	servers   []*listenerServer
+	err       error // 配置或路由挂载错误，由 AppCoreRun 返回
+	wg        sync.WaitGroup
+	mu        sync.Mutex // 保护 ln、stopped 与 serveErrs，Shutdown 可能与 AppCoreRun 并发
+	stopped   bool
+	serveErrs []error
+}
+
+// fail 记录附加监听器错误，AppCoreRun 绑定前返回
+func (g *listenerGroup) fail(err error) {
+	g.err = errors.Join(g.err, err)
+}
+
+// app 返回指定名称监听器的引擎实例
+func (g *listenerGroup) app(name string) (interface{}, bool) {
+	for _, s := range g.servers {
+		if s.conf.Name == name {
+			return s.app, true
+		}
+	}
+	return nil, false
+}
+
+func (g *listenerGroup) names() []string {
+	names := make([]string, 0, len(g.servers))
+	for _, s := range g.servers {
+		names = append(names, s.conf.Name)
+	}
+	return names
+}
+
+// bind 绑定全部附加监听器，任一失败时关闭已绑定的监听器并返回错误；已进入关闭链时不再绑定
+func (g *listenerGroup) bind(appCtx IApplicationContext) error {
+	if g.err != nil {
+		return g.err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stopped {
+		return nil
+	}
+	for _, s := range g.servers {
+		var tlsConfig *tls.Config
+		if s.conf.TLS.Enable {
+			st, err := newServerTLS(appCtx, s.conf.TLS)
+			if err != nil {
+				g.closeLocked()
+				return fmt.Errorf("listener %s: %w", s.conf.Name, err)
+			}
+			s.tls = st
+			tlsConfig = st.Config()
+			tlsConfig.NextProtos = s.nextProtos
+		}
+		ln, err := s.conf.listen(tlsConfig)
+		if err != nil {
+			g.closeLocked()
+			return err
+		}
+		s.ln = ln
+	}
+	return nil
+}
+
+// close 关闭已绑定但尚未提供服务的附加监听器，主监听器绑定失败时调用
+func (g *listenerGroup) close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.closeLocked()
+}
+
+func (g *listenerGroup) closeLocked() {
+	for _, s := range g.servers {
+		if s.ln != nil {
+			_ = s.ln.Close()
+			s.ln = nil
+		}
+		s.tls.close()
+	}
+}
+
+// serve 在后台为已绑定的附加监听器提供服务，非正常关闭的服务错误记录日志并由 wait 返回
+func (g *listenerGroup) serve(appCtx IApplicationContext) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, s := range g.servers {
+		if s.ln == nil {
+			continue
+		}
+		appCtx.GetLogger().InfoWith(appCtx.GetConfig().LogOriginFrame()).
+			Str("listener", s.conf.Name).
+			Str("network", s.conf.Network).
+			Msg(s.conf.Scheme() + "://" + s.ln.Addr().String())
+		g.wg.Add(1)
+		go func(s *listenerServer, ln net.Listener) {
+			defer g.wg.Done()
+			if err := s.serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) && !errors.Is(err, net.ErrClosed) {
+				appCtx.GetLogger().ErrorWith(appCtx.GetConfig().LogOriginFrame()).
+					Err(err).
+					Str("listener", s.conf.Name).
+					Msg("listener serve failed")
+				g.mu.Lock()
+				g.serveErrs = append(g.serveErrs, fmt.Errorf("listener %s: %w", s.conf.Name, err))
+				g.mu.Unlock()
+			}
+		}(s, s.ln)
+	}
+}
+
+// abort 主监听器异常退出时立即关闭附加监听器，不等待在途请求
+func (g *listenerGroup) abort() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = g.stop(ctx)
+}
+
+// wait 等待全部附加监听器的服务返回，返回非正常关闭的服务错误
+func (g *listenerGroup) wait() error {
+	g.wg.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.serveErrs...)
+}
+
+// stop 在 ctx 截止前并发关闭全部已绑定的附加监听器，等待其在途请求结束
+func (g *listenerGroup) stop(ctx context.Context) error {
+	g.mu.Lock()
+	g.stopped = true
+	var bound []*listenerServer
+	for _, s := range g.servers {
+		if s.ln != nil {
+			bound = append(bound, s)
+		}
+	}
+	g.mu.Unlock()
+
+	errs := make([]error, len(bound))
+	var wg sync.WaitGroup
+	for i, s := range bound {
+		wg.Add(1)
+		go func(i int, s *listenerServer) {
+			defer wg.Done()
+			defer s.tls.close()
+			if err := s.shutdown(ctx); err != nil {
+				errs[i] = fmt.Errorf("listener %s: %w", s.conf.Name, err)
+			}
+		}(i, s)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
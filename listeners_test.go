@@ -0,0 +1,298 @@
+package fiberhouse
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lamxy/fiberhouse/component/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadListenerConfigs_BindsNamedEntriesInOrder(t *testing.T) {
+	ctx := newTask4InternalAppContext(t, map[string]interface{}{
+		"application.listeners.metrics.address":      "127.0.0.1:9100",
+		"application.listeners.admin.network":        "unix",
+		"application.listeners.admin.address":        "/run/app/admin.sock",
+		"application.listeners.admin.pprof":          true,
+		"application.listeners.admin.tls.enable":     true,
+		"application.listeners.admin.tls.certFile":   "server.crt",
+		"application.listeners.admin.tls.keyFile":    "server.key",
+		"application.listeners.metrics.tls.certFile": "",
+	})
+	confs, err := loadListenerConfigs(ctx.GetConfig())
+	require.NoError(t, err)
+	require.Len(t, confs, 2)
+
+	assert.Equal(t, "admin", confs[0].Name)
+	assert.Equal(t, "unix", confs[0].Network)
+	assert.True(t, confs[0].Pprof)
+	assert.Equal(t, "https", confs[0].Scheme())
+	assert.Equal(t, ListenerConfig{
+		Name:    "metrics",
+		Network: "tcp",
+		Address: "127.0.0.1:9100",
+		TLS:     TLSConfig{MinVersion: "1.2", Watch: true},
+	}, confs[1])
+	assert.Equal(t, "http", confs[1].Scheme())
+
+	none, err := loadListenerConfigs(newTask4InternalAppContext(t, nil).GetConfig())
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestLoadListenerConfigs_RejectsInvalidEntries(t *testing.T) {
+	ctx := newTask4InternalAppContext(t, map[string]interface{}{
+		"application.listeners.admin.network":    "udp",
+		"application.listeners.admin.address":    ":9000",
+		"application.listeners.internal.network": "unix",
+		"application.listeners.public.address":   ":8443",
+		"application.listeners.public.tls.cert":  "server.crt",
+	})
+	_, err := loadListenerConfigs(ctx.GetConfig())
+	require.Error(t, err)
+	for _, key := range []string{
+		"application.listeners.admin.network",
+		"application.listeners.internal.address",
+		"application.listeners.public.tls.cert: unknown key",
+	} {
+		assert.Contains(t, err.Error(), key)
+	}
+}
+
+// listenerTestClient 返回通过 unix 套接字或 TCP 发起请求的客户端
+func listenerTestClient(network, address string) *http.Client {
+	return &http.Client{
+		Timeout: 3 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, address)
+			},
+			DisableKeepAlives: true,
+		},
+	}
+}
+
+// listenerRecoverManager 直接返回指定核心的恢复中间件提供者
+type listenerRecoverManager struct {
+	IProviderManager
+	recovery IRecover
+}
+
+func (m *listenerRecoverManager) LoadProvider(...ProviderLoadFunc) (any, error) {
+	return m.recovery, nil
+}
+
+func listenerTestStatus(t *testing.T, client *http.Client, path string) int {
+	t.Helper()
+	resp, err := client.Get("http://listener" + path)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestCoreRun_ServesAdditionalListenersWithOwnRoutes(t *testing.T) {
+	preserveTask4GinMode(t)
+	registry := health.Default()
+	registry.SetGlobalManager(nil)
+	t.Cleanup(func() { registry.SetReady(true) })
+
+	for _, testCase := range []struct {
+		name  string
+		setup func(t *testing.T, ctx IApplicationContext) (CoreStarter, *listenerGroup)
+	}{
+		{
+			name: "fiber",
+			setup: func(t *testing.T, ctx IApplicationContext) (CoreStarter, *listenerGroup) {
+				NewErrorHandlerOnce(ctx).SetRecoverManager(&listenerRecoverManager{recovery: NewFiberRecovery(ctx)})
+				core := NewCoreWithFiber(ctx).(*CoreWithFiber)
+				core.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+				core.RegisterAppMiddleware(&task4Frame{})
+				noContent := func(c *fiber.Ctx) error { return c.SendStatus(http.StatusNoContent) }
+				core.coreApp.Get("/public", noContent)
+				core.GetListenerApp("admin").(*fiber.App).Get("/admin-only", noContent)
+				core.GetListenerApp("internal").(*fiber.App).Get("/internal", noContent)
+				// 测试上下文没有应用注册器，未匹配的路由不经过框架错误处理器
+				for _, app := range []interface{}{core.coreApp, core.GetListenerApp("admin"), core.GetListenerApp("internal")} {
+					app.(*fiber.App).Use(func(c *fiber.Ctx) error { return c.SendStatus(http.StatusNotFound) })
+				}
+				return core, &core.listeners
+			},
+		},
+		{
+			name: "gin",
+			setup: func(t *testing.T, ctx IApplicationContext) (CoreStarter, *listenerGroup) {
+				NewErrorHandlerOnce(ctx).SetRecoverManager(&listenerRecoverManager{recovery: NewGinRecovery(ctx)})
+				core := NewCoreWithGin(ctx).(*CoreWithGin)
+				core.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+				cleanupTask4GinCore(t, core)
+				core.RegisterAppMiddleware(&task4Frame{})
+				noContent := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+				core.coreApp.GET("/public", noContent)
+				core.GetListenerApp("admin").(*gin.Engine).GET("/admin-only", noContent)
+				core.GetListenerApp("internal").(*gin.Engine).GET("/internal", noContent)
+				return core, &core.listeners
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			isolateTask4ErrorHandlerSingleton(t)
+			// 上一个子测试的 Shutdown 已把进程级注册表置为未就绪
+			registry.SetReady(true)
+			socket := filepath.Join(t.TempDir(), "internal.sock")
+			ctx := newTask4InternalAppContext(t, map[string]interface{}{
+				"application.server.host":                     "127.0.0.1",
+				"application.server.port":                     "0",
+				"application.plugins.engine.servers.gin.host": "127.0.0.1",
+				"application.plugins.engine.servers.gin.port": "0",
+				"application.listeners.admin.address":         "127.0.0.1:0",
+				"application.listeners.admin.pprof":           true,
+				"application.listeners.internal.network":      "unix",
+				"application.listeners.internal.address":      socket,
+				"application.health.enable":                   true,
+				"application.health.listener":                 "admin",
+				"application.metrics.enable":                  true,
+				"application.metrics.listener":                "admin",
+			})
+			core, listeners := testCase.setup(t, ctx)
+			ctx.RegisterStarterApp(&WebApplication{FrameStarter: &FrameApplication{Ctx: ctx}, CoreStarter: core})
+			assert.Equal(t, []string{"admin", "internal"}, core.(ListenerStarter).ListenerNames())
+			assert.Nil(t, core.(ListenerStarter).GetListenerApp("missing"))
+
+			probe := &task14RunAfterProbe{
+				task4LifecycleManager: task4LifecycleManager{
+					typ:      ProviderTypeDefault().GroupProviderAutoRun,
+					location: ProviderLocationDefault().LocationServerRunAfter,
+				},
+				infos: make(chan *ServerInfo, 1),
+			}
+			runErr := make(chan error, 1)
+			go func() { runErr <- core.AppCoreRun(probe) }()
+			var info *ServerInfo
+			select {
+			case info = <-probe.infos:
+			case err := <-runErr:
+				t.Fatalf("AppCoreRun returned before serving: %v", err)
+			case <-time.After(3 * time.Second):
+				t.Fatal("main listener was not bound")
+			}
+			// 附加监听器先于主监听器绑定，ServerRunAfter 触发时其地址已确定
+			adminAddr := listeners.servers[0].ln.Addr().String()
+
+			main := listenerTestClient("tcp", info.Addr.String())
+			admin := listenerTestClient("tcp", adminAddr)
+			internal := listenerTestClient("unix", socket)
+			require.Eventually(t, registry.Ready, time.Second, 10*time.Millisecond)
+
+			assert.Equal(t, http.StatusNoContent, listenerTestStatus(t, main, "/public"))
+			assert.Equal(t, http.StatusNotFound, listenerTestStatus(t, main, "/admin-only"))
+			assert.Equal(t, http.StatusNotFound, listenerTestStatus(t, main, health.DefaultHealthPath))
+			assert.Equal(t, http.StatusNotFound, listenerTestStatus(t, main, "/metrics"))
+
+			assert.Equal(t, http.StatusNoContent, listenerTestStatus(t, admin, "/admin-only"))
+			assert.Equal(t, http.StatusNotFound, listenerTestStatus(t, admin, "/public"))
+			assert.Equal(t, http.StatusOK, listenerTestStatus(t, admin, health.DefaultReadyPath))
+			assert.Equal(t, http.StatusOK, listenerTestStatus(t, admin, "/metrics"))
+			assert.Equal(t, http.StatusOK, listenerTestStatus(t, admin, "/debug/pprof/"))
+
+			assert.Equal(t, http.StatusNoContent, listenerTestStatus(t, internal, "/internal"))
+			assert.Equal(t, http.StatusNotFound, listenerTestStatus(t, internal, "/debug/pprof/"))
+
+			require.NoError(t, core.Shutdown())
+			select {
+			case err := <-runErr:
+				assert.NoError(t, err)
+			case <-time.After(3 * time.Second):
+				t.Fatal("AppCoreRun did not return after Shutdown")
+			}
+			for _, addr := range []string{info.Addr.String(), adminAddr} {
+				_, err := net.DialTimeout("tcp", addr, time.Second)
+				assert.Error(t, err, "listener %s closed", addr)
+			}
+			_, err := os.Stat(socket)
+			assert.True(t, os.IsNotExist(err), "unix socket file removed on shutdown")
+		})
+	}
+}
+
+func TestCoreRun_ListenerErrorsFailBeforeServingMain(t *testing.T) {
+	isolateTask4ErrorHandlerSingleton(t)
+	preserveTask4GinMode(t)
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer occupied.Close()
+
+	for _, testCase := range []struct {
+		name   string
+		values map[string]interface{}
+		errMsg string
+	}{
+		{
+			name: "bind failure",
+			values: map[string]interface{}{
+				"application.listeners.a-first.address": "127.0.0.1:0",
+				"application.listeners.b-busy.address":  occupied.Addr().String(),
+			},
+			errMsg: "listener b-busy",
+		},
+		{
+			name: "unknown endpoint listener",
+			values: map[string]interface{}{
+				"application.health.enable":   true,
+				"application.health.listener": "ops",
+			},
+			errMsg: "listener ops is not configured in application.listeners",
+		},
+	} {
+		for _, core := range []string{"fiber", "gin"} {
+			t.Run(testCase.name+"/"+core, func(t *testing.T) {
+				values := map[string]interface{}{
+					"application.server.host":                     "127.0.0.1",
+					"application.server.port":                     "0",
+					"application.plugins.engine.servers.gin.host": "127.0.0.1",
+					"application.plugins.engine.servers.gin.port": "0",
+				}
+				for k, v := range testCase.values {
+					values[k] = v
+				}
+				ctx := newTask4InternalAppContext(t, values)
+				var starter CoreStarter
+				var listeners *listenerGroup
+				if core == "fiber" {
+					c := NewCoreWithFiber(ctx).(*CoreWithFiber)
+					c.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+					c.registerHealth()
+					starter, listeners = c, &c.listeners
+				} else {
+					c := NewCoreWithGin(ctx).(*CoreWithGin)
+					c.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+					cleanupTask4GinCore(t, c)
+					c.registerHealth()
+					starter, listeners = c, &c.listeners
+				}
+				probe := &task14RunAfterProbe{
+					task4LifecycleManager: task4LifecycleManager{
+						typ:      ProviderTypeDefault().GroupProviderAutoRun,
+						location: ProviderLocationDefault().LocationServerRunAfter,
+					},
+					infos: make(chan *ServerInfo, 1),
+				}
+
+				err := starter.AppCoreRun(probe)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.errMsg)
+				assert.Empty(t, probe.infos, "main listener is not served")
+				for _, s := range listeners.servers {
+					assert.Nil(t, s.ln, "listeners bound before the failure are closed")
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/component/metrics"
+)
+
+// metricsEndpoint 读取 application.metrics 配置，返回指标端点路径、挂载的附加监听器名称（空为主监听器）及是否启用
+func metricsEndpoint(cfg appconfig.IAppConfig) (path, listener string, enabled bool) {
+	if !cfg.Bool("application.metrics.enable") {
+		return "", "", false
+	}
+	return cfg.String("application.metrics.path", metrics.DefaultPath), cfg.String("application.metrics.listener"), true
+}
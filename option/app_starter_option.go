@@ -8,6 +8,7 @@ package option
 
 import (
 	"fmt"
+	"github.com/cloudwego/hertz/pkg/common/config"
 	"github.com/gofiber/fiber/v2"
 	"github.com/lamxy/fiberhouse"
 )
@@ -53,3 +54,12 @@ func WithCoreCfg(cfg *fiber.Config) fiberhouse.CoreStarterOption {
 		}
 	}
 }
+
+// WithHertzOptions 返回Hertz核心服务端选项注入选项函数，提供后将替代按全局配置组装的服务端选项
+func WithHertzOptions(opts ...config.Option) fiberhouse.CoreStarterOption {
+	return func(s fiberhouse.CoreStarter) {
+		if core, ok := s.(*fiberhouse.CoreWithHertz); ok {
+			core.Options = append(core.Options, opts...)
+		}
+	}
+}
@@ -4,17 +4,38 @@
 // Author: lamxy <pytho5170@hotmail.com>
 // GitHub: https://github.com/lamxy
 
+// Package plugins 提供插件生命周期注册表：发现已注册的插件，按声明的依赖排序，
+// 在全局对象保活注册之后启动，并在服务关闭时逆序停止。
 package plugins
 
 import (
+	"errors"
+
 	"github.com/lamxy/fiberhouse"
 )
 
-// TODO
-
+// Plugin 插件接口，插件本身是一个提供者，通过 Start/Stop/Restart 管理其运行期生命周期
 type Plugin interface {
 	fiberhouse.IProvider
+	// Start 启动插件，在服务运行前由注册表按依赖顺序调用
 	Start() error
+	// Stop 停止插件，在服务关闭时由注册表按启动顺序的逆序调用
 	Stop() error
+	// Restart 重启插件
 	Restart() error
 }
+
+// Dependent 可选接口，插件实现该接口以声明其依赖的其他插件名称（即依赖插件的 Name()）
+// 注册表保证被依赖的插件先于依赖方启动、后于依赖方停止
+type Dependent interface {
+	DependsOn() []string
+}
+
+// 定义插件注册表错误类型
+var (
+	ErrPluginAlreadyRegistered = errors.New("plugin already registered")
+	ErrPluginNotFound          = errors.New("plugin not found")
+	ErrPluginDependencyMissing = errors.New("plugin dependency missing")
+	ErrPluginDependencyCycle   = errors.New("plugin dependency cycle")
+	ErrPluginDependencyStopped = errors.New("plugin dependency not running")
+)
@@ -1,3 +1,175 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
 package plugins
 
-// TODO
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/lamxy/fiberhouse"
+)
+
+var (
+	pluginTypeInstance     fiberhouse.IProviderType
+	pluginStopTypeInstance fiberhouse.IProviderType
+	pluginTypeOnce         sync.Once
+)
+
+// ProviderTypePlugin 插件提供者类型，插件须设置该类型以便 RunServer 将其分发给插件启动管理器
+func ProviderTypePlugin() fiberhouse.IProviderType {
+	initPluginTypes()
+	return pluginTypeInstance
+}
+
+// ProviderTypePluginStop 插件停止管理器类型，不承载提供者，仅用于区分插件启动管理器
+func ProviderTypePluginStop() fiberhouse.IProviderType {
+	initPluginTypes()
+	return pluginStopTypeInstance
+}
+
+func initPluginTypes() {
+	pluginTypeOnce.Do(func() {
+		pluginTypeInstance = fiberhouse.ProviderTypeGen().MustCustom("PluginRegisterType")
+		pluginStopTypeInstance = fiberhouse.ProviderTypeGen().MustCustom("PluginStopType")
+	})
+}
+
+// PluginStartPManager 插件启动管理器
+// 绑定到服务运行前执行位置点（位于全局对象保活注册之后），将已分发的插件注册到注册表并按依赖顺序启动
+type PluginStartPManager struct {
+	fiberhouse.IProviderManager
+	registry *Registry
+}
+
+// NewPluginStartPManager 创建插件启动管理器，registry 为空时使用默认注册表
+func NewPluginStartPManager(ctx fiberhouse.IApplicationContext, registry ...*Registry) *PluginStartPManager {
+	son := &PluginStartPManager{
+		IProviderManager: fiberhouse.NewProviderManager(ctx).
+			SetName("PluginStartPManager").
+			SetType(ProviderTypePlugin()),
+		registry: resolveRegistry(registry...),
+	}
+	son.MountToParent(son).SetOrBindToLocation(fiberhouse.ProviderLocationDefault().LocationServerRunBefore, true)
+	return son
+}
+
+// MountToParent 挂载子实例到父属性
+func (m *PluginStartPManager) MountToParent(son ...fiberhouse.IProviderManager) fiberhouse.IProviderManager {
+	if len(son) > 0 {
+		m.IProviderManager.MountToParent(son[0])
+		return m
+	}
+	m.IProviderManager.MountToParent(m)
+	return m
+}
+
+// Registry 返回管理器使用的插件注册表
+func (m *PluginStartPManager) Registry() *Registry {
+	return m.registry
+}
+
+// LoadProvider 注册并启动插件
+// 提供者按名称排序后注册，未实现 Plugin 接口的提供者返回错误；单个插件启动失败不影响其他无依赖关系的插件
+func (m *PluginStartPManager) LoadProvider(loadFunc ...fiberhouse.ProviderLoadFunc) (any, error) {
+	m.Check()
+	providers := m.List()
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].Name() < providers[j].Name()
+	})
+
+	var errs []error
+	for _, provider := range providers {
+		p, ok := provider.(Plugin)
+		if !ok {
+			errs = append(errs, fmt.Errorf("provider '%s' does not implement plugins.Plugin", provider.Name()))
+			continue
+		}
+		if _, exists := m.registry.Get(p.Name()); exists {
+			continue // 已注册（如重复加载），由注册表跳过已运行的插件
+		}
+		if err := m.registry.Register(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := m.registry.StartAll(); err != nil {
+		errs = append(errs, err)
+	}
+
+	err := errors.Join(errs...)
+	logResult(m.GetContext(), m.registry, "plugins start", err)
+	return m.registry, err
+}
+
+// PluginStopPManager 插件停止管理器
+// 绑定到服务关闭前执行位置点，在核心应用关闭和全局对象清理之前按启动顺序的逆序停止插件
+type PluginStopPManager struct {
+	fiberhouse.IProviderManager
+	registry *Registry
+}
+
+// NewPluginStopPManager 创建插件停止管理器，registry 为空时使用默认注册表
+func NewPluginStopPManager(ctx fiberhouse.IApplicationContext, registry ...*Registry) *PluginStopPManager {
+	son := &PluginStopPManager{
+		IProviderManager: fiberhouse.NewProviderManager(ctx).
+			SetName("PluginStopPManager").
+			SetType(ProviderTypePluginStop()),
+		registry: resolveRegistry(registry...),
+	}
+	son.MountToParent(son).SetOrBindToLocation(fiberhouse.ProviderLocationDefault().LocationServerShutdownBefore, true)
+	return son
+}
+
+// MountToParent 挂载子实例到父属性
+func (m *PluginStopPManager) MountToParent(son ...fiberhouse.IProviderManager) fiberhouse.IProviderManager {
+	if len(son) > 0 {
+		m.IProviderManager.MountToParent(son[0])
+		return m
+	}
+	m.IProviderManager.MountToParent(m)
+	return m
+}
+
+// Registry 返回管理器使用的插件注册表
+func (m *PluginStopPManager) Registry() *Registry {
+	return m.registry
+}
+
+// LoadProvider 停止全部运行中的插件
+func (m *PluginStopPManager) LoadProvider(loadFunc ...fiberhouse.ProviderLoadFunc) (any, error) {
+	m.Check()
+	err := m.registry.StopAll()
+	logResult(m.GetContext(), m.registry, "plugins stop", err)
+	return m.registry, err
+}
+
+func resolveRegistry(registry ...*Registry) *Registry {
+	if len(registry) > 0 && registry[0] != nil {
+		return registry[0]
+	}
+	return DefaultRegistry()
+}
+
+// logResult 记录插件生命周期操作结果及各插件状态
+func logResult(ctx fiberhouse.IContext, registry *Registry, action string, err error) {
+	appCtx, ok := ctx.(fiberhouse.IApplicationContext)
+	if !ok || appCtx == nil || appCtx.GetLogger() == nil {
+		return
+	}
+	statuses := make(map[string]string)
+	for name, s := range registry.Statuses() {
+		statuses[name] = s.Name()
+	}
+	if err != nil {
+		appCtx.GetLogger().ErrorWith(appCtx.GetConfig().LogOriginFrame()).Err(err).
+			Interface("plugins", statuses).Msg(action + " failed")
+		return
+	}
+	appCtx.GetLogger().InfoWith(appCtx.GetConfig().LogOriginFrame()).
+		Interface("plugins", statuses).Msg(action + " completed")
+}
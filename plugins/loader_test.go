@@ -0,0 +1,68 @@
+package plugins
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lamxy/fiberhouse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginManagers_BindToRunBeforeAndShutdownBefore(t *testing.T) {
+	registry := NewRegistry()
+	start := NewPluginStartPManager(nil, registry)
+	stop := NewPluginStopPManager(nil, registry)
+
+	assert.Same(t, registry, start.Registry())
+	assert.Same(t, registry, stop.Registry())
+	assert.Equal(t, fiberhouse.ProviderLocationDefault().LocationServerRunBefore.GetLocationID(), start.Location().GetLocationID())
+	assert.Equal(t, fiberhouse.ProviderLocationDefault().LocationServerShutdownBefore.GetLocationID(), stop.Location().GetLocationID())
+	assert.Contains(t, fiberhouse.ProviderLocationDefault().LocationServerRunBefore.GetManagers(), fiberhouse.IProviderManager(start))
+	assert.Contains(t, fiberhouse.ProviderLocationDefault().LocationServerShutdownBefore.GetManagers(), fiberhouse.IProviderManager(stop))
+	assert.NotEqual(t, start.Type().GetTypeID(), stop.Type().GetTypeID())
+	assert.False(t, start.IsUnique())
+	assert.Same(t, DefaultRegistry(), NewPluginStartPManager(nil).Registry())
+}
+
+func TestPluginManagers_LoadProviderStartsAndStopsPlugins(t *testing.T) {
+	registry := NewRegistry()
+	rec := &lifecycleRecorder{}
+	start := NewPluginStartPManager(nil, registry)
+	stop := NewPluginStopPManager(nil, registry)
+	require.NoError(t, start.Register(newTestPlugin("api", rec, "db")))
+	require.NoError(t, start.Register(newTestPlugin("db", rec)))
+
+	instance, err := start.LoadProvider()
+	require.NoError(t, err)
+	assert.Same(t, registry, instance)
+
+	// 重复加载不会重复注册或启动
+	_, err = start.LoadProvider()
+	require.NoError(t, err)
+
+	_, err = stop.LoadProvider()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"start:db", "start:api", "stop:api", "stop:db"}, rec.list())
+	status, _ := registry.Status("api")
+	assert.Equal(t, fiberhouse.StateStopped, status)
+}
+
+func TestPluginStartPManager_LoadProviderReportsNonPluginAndStartErrors(t *testing.T) {
+	registry := NewRegistry()
+	rec := &lifecycleRecorder{}
+	start := NewPluginStartPManager(nil, registry)
+	broken := newTestPlugin("broken", rec)
+	broken.startErr = errors.New("boom")
+	require.NoError(t, start.Register(broken))
+	require.NoError(t, start.Register(fiberhouse.NewProvider().SetName("plain").SetType(ProviderTypePlugin())))
+
+	_, err := start.LoadProvider()
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, broken.startErr)
+	assert.Contains(t, err.Error(), "provider 'plain' does not implement plugins.Plugin")
+	status, _ := registry.Status("broken")
+	assert.Equal(t, fiberhouse.StateFailed, status)
+}
@@ -1,3 +1,277 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
 package plugins
 
-// TODO
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lamxy/fiberhouse"
+)
+
+// Registry 插件生命周期注册表
+//
+// 插件状态复用提供者状态 fiberhouse.State：
+// 注册后为 pending，启动成功为 running，停止后为 stopped，启动/停止/重启失败为 failed，
+// 因依赖启动失败而未启动的插件为 skipped。启动后的状态变化同时经 SetLifecycleStatus 写入插件自身，插件的 Status() 与注册表一致。
+//
+// 生命周期操作（StartAll/StopAll/Restart）相互串行；状态查询可在插件的 Start/Stop 内并发调用。
+type Registry struct {
+	lifeMu  sync.Mutex // 串行化生命周期操作
+	mu      sync.RWMutex
+	plugins map[string]Plugin
+	order   []string // 注册顺序，依赖无约束时保持该顺序
+	states  map[string]fiberhouse.State
+	started []string // 实际启动成功的顺序，停止时逆序
+}
+
+var (
+	defaultRegistryInstance *Registry
+	defaultRegistryOnce     sync.Once
+)
+
+// NewRegistry 创建插件注册表
+func NewRegistry() *Registry {
+	return &Registry{
+		plugins: make(map[string]Plugin),
+		states:  make(map[string]fiberhouse.State),
+	}
+}
+
+// DefaultRegistry 获取默认插件注册表（单例）
+func DefaultRegistry() *Registry {
+	defaultRegistryOnce.Do(func() {
+		defaultRegistryInstance = NewRegistry()
+	})
+	return defaultRegistryInstance
+}
+
+// Register 注册插件，同名插件仅允许注册一次
+func (r *Registry) Register(p Plugin) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := p.Name()
+	if _, exists := r.plugins[name]; exists {
+		return fmt.Errorf("plugin '%s': %w", name, ErrPluginAlreadyRegistered)
+	}
+	r.plugins[name] = p
+	r.order = append(r.order, name)
+	r.states[name] = fiberhouse.StatePending
+	return nil
+}
+
+// Get 根据名称获取插件
+func (r *Registry) Get(name string) (Plugin, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.plugins[name]
+	return p, ok
+}
+
+// List 按注册顺序列出插件
+func (r *Registry) List() []Plugin {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]Plugin, 0, len(r.order))
+	for _, name := range r.order {
+		result = append(result, r.plugins[name])
+	}
+	return result
+}
+
+// Status 获取插件状态，插件未注册时返回 false
+func (r *Registry) Status(name string) (fiberhouse.State, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.states[name]
+	return s, ok
+}
+
+// Statuses 以插件名称为键返回全部插件状态的副本
+func (r *Registry) Statuses() map[string]fiberhouse.State {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]fiberhouse.State, len(r.states))
+	for name, s := range r.states {
+		result[name] = s
+	}
+	return result
+}
+
+// Resolve 按依赖关系返回插件的启动顺序
+// 依赖缺失或存在循环依赖时返回错误；无依赖约束的插件保持注册顺序
+func (r *Registry) Resolve() ([]Plugin, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	marks := make(map[string]int, len(r.order))
+	sorted := make([]Plugin, 0, len(r.order))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch marks[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("plugin '%s' (%v): %w", name, append(path, name), ErrPluginDependencyCycle)
+		}
+		marks[name] = visiting
+		for _, dep := range dependenciesOf(r.plugins[name]) {
+			if _, ok := r.plugins[dep]; !ok {
+				return fmt.Errorf("plugin '%s' depends on '%s': %w", name, dep, ErrPluginDependencyMissing)
+			}
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		marks[name] = visited
+		sorted = append(sorted, r.plugins[name])
+		return nil
+	}
+
+	for _, name := range r.order {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// StartAll 按依赖顺序启动全部未运行的插件
+// 某个插件启动失败时，标记为 failed，其直接或间接依赖方标记为 skipped 且不启动，其余插件继续启动；
+// 返回全部启动失败的聚合错误
+func (r *Registry) StartAll() error {
+	r.lifeMu.Lock()
+	defer r.lifeMu.Unlock()
+
+	ordered, err := r.Resolve()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, p := range ordered {
+		name := p.Name()
+		if s, _ := r.Status(name); s == fiberhouse.StateRunning {
+			continue
+		}
+		if dep, ok := r.firstNotRunningDependency(p); ok {
+			r.setState(name, fiberhouse.StateSkipped)
+			errs = append(errs, fmt.Errorf("plugin '%s' skipped, dependency '%s': %w", name, dep, ErrPluginDependencyStopped))
+			continue
+		}
+		if err := p.Start(); err != nil {
+			r.setState(name, fiberhouse.StateFailed)
+			errs = append(errs, fmt.Errorf("plugin '%s' start failed: %w", name, err))
+			continue
+		}
+		r.markStarted(name)
+	}
+	return errors.Join(errs...)
+}
+
+// StopAll 按启动顺序的逆序停止全部运行中的插件，返回全部停止失败的聚合错误
+func (r *Registry) StopAll() error {
+	r.lifeMu.Lock()
+	defer r.lifeMu.Unlock()
+
+	r.mu.RLock()
+	started := make([]string, len(r.started))
+	copy(started, r.started)
+	r.mu.RUnlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		name := started[i]
+		if s, _ := r.Status(name); s != fiberhouse.StateRunning {
+			continue
+		}
+		p, _ := r.Get(name)
+		if err := p.Stop(); err != nil {
+			r.setState(name, fiberhouse.StateFailed)
+			errs = append(errs, fmt.Errorf("plugin '%s' stop failed: %w", name, err))
+			continue
+		}
+		r.setState(name, fiberhouse.StateStopped)
+	}
+
+	r.mu.Lock()
+	r.started = r.started[:0]
+	r.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// Restart 重启指定插件，要求其依赖的插件均处于运行状态
+func (r *Registry) Restart(name string) error {
+	r.lifeMu.Lock()
+	defer r.lifeMu.Unlock()
+
+	p, ok := r.Get(name)
+	if !ok {
+		return fmt.Errorf("plugin '%s': %w", name, ErrPluginNotFound)
+	}
+	if dep, ok := r.firstNotRunningDependency(p); ok {
+		return fmt.Errorf("plugin '%s' restart refused, dependency '%s': %w", name, dep, ErrPluginDependencyStopped)
+	}
+	if err := p.Restart(); err != nil {
+		r.setState(name, fiberhouse.StateFailed)
+		return fmt.Errorf("plugin '%s' restart failed: %w", name, err)
+	}
+	r.markStarted(name)
+	return nil
+}
+
+// firstNotRunningDependency 返回第一个未处于运行状态的依赖插件名称
+func (r *Registry) firstNotRunningDependency(p Plugin) (string, bool) {
+	for _, dep := range dependenciesOf(p) {
+		if s, ok := r.Status(dep); !ok || s != fiberhouse.StateRunning {
+			return dep, true
+		}
+	}
+	return "", false
+}
+
+// markStarted 标记插件为运行状态并记录启动顺序（重启的插件保留原有位置，保证逆序停止时先停依赖方）
+func (r *Registry) markStarted(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.states[name] = fiberhouse.StateRunning
+	r.plugins[name].SetLifecycleStatus(fiberhouse.StateRunning)
+	for _, n := range r.started {
+		if n == name {
+			return
+		}
+	}
+	r.started = append(r.started, name)
+}
+
+// setState 更新注册表与插件自身的状态
+func (r *Registry) setState(name string, s fiberhouse.State) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[name] = s
+	r.plugins[name].SetLifecycleStatus(s)
+}
+
+// dependenciesOf 获取插件声明的依赖
+func dependenciesOf(p Plugin) []string {
+	if d, ok := p.(Dependent); ok {
+		return d.DependsOn()
+	}
+	return nil
+}
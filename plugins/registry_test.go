@@ -0,0 +1,227 @@
+package plugins
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/lamxy/fiberhouse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lifecycleRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *lifecycleRecorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *lifecycleRecorder) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+type testPlugin struct {
+	fiberhouse.IProvider
+	deps       []string
+	recorder   *lifecycleRecorder
+	startErr   error
+	stopErr    error
+	restartErr error
+}
+
+func newTestPlugin(name string, recorder *lifecycleRecorder, deps ...string) *testPlugin {
+	p := &testPlugin{
+		IProvider: fiberhouse.NewProvider().SetName(name).SetType(ProviderTypePlugin()),
+		deps:      deps,
+		recorder:  recorder,
+	}
+	p.MountToParent(p)
+	return p
+}
+
+func (p *testPlugin) DependsOn() []string { return p.deps }
+
+func (p *testPlugin) Start() error {
+	p.recorder.add("start:" + p.Name())
+	return p.startErr
+}
+
+func (p *testPlugin) Stop() error {
+	p.recorder.add("stop:" + p.Name())
+	return p.stopErr
+}
+
+func (p *testPlugin) Restart() error {
+	p.recorder.add("restart:" + p.Name())
+	return p.restartErr
+}
+
+func names(ps []Plugin) []string {
+	result := make([]string, 0, len(ps))
+	for _, p := range ps {
+		result = append(result, p.Name())
+	}
+	return result
+}
+
+func TestRegistry_RegisterRejectsDuplicateName(t *testing.T) {
+	r := NewRegistry()
+	rec := &lifecycleRecorder{}
+
+	require.NoError(t, r.Register(newTestPlugin("cache", rec)))
+	err := r.Register(newTestPlugin("cache", rec))
+
+	assert.ErrorIs(t, err, ErrPluginAlreadyRegistered)
+	assert.Len(t, r.List(), 1)
+	status, ok := r.Status("cache")
+	assert.True(t, ok)
+	assert.Equal(t, fiberhouse.StatePending, status)
+}
+
+func TestRegistry_ResolveOrdersByDependenciesAndKeepsRegistrationOrder(t *testing.T) {
+	r := NewRegistry()
+	rec := &lifecycleRecorder{}
+	require.NoError(t, r.Register(newTestPlugin("api", rec, "cache", "db")))
+	require.NoError(t, r.Register(newTestPlugin("metrics", rec)))
+	require.NoError(t, r.Register(newTestPlugin("cache", rec, "db")))
+	require.NoError(t, r.Register(newTestPlugin("db", rec)))
+
+	ordered, err := r.Resolve()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db", "cache", "api", "metrics"}, names(ordered))
+}
+
+func TestRegistry_ResolveReportsMissingDependencyAndCycle(t *testing.T) {
+	rec := &lifecycleRecorder{}
+
+	missing := NewRegistry()
+	require.NoError(t, missing.Register(newTestPlugin("api", rec, "db")))
+	_, err := missing.Resolve()
+	assert.ErrorIs(t, err, ErrPluginDependencyMissing)
+
+	cycle := NewRegistry()
+	require.NoError(t, cycle.Register(newTestPlugin("a", rec, "b")))
+	require.NoError(t, cycle.Register(newTestPlugin("b", rec, "a")))
+	_, err = cycle.Resolve()
+	assert.ErrorIs(t, err, ErrPluginDependencyCycle)
+	assert.ErrorIs(t, cycle.StartAll(), ErrPluginDependencyCycle)
+	assert.Empty(t, rec.list())
+}
+
+func TestRegistry_StartAllAndStopAllFollowDependencyOrder(t *testing.T) {
+	r := NewRegistry()
+	rec := &lifecycleRecorder{}
+	db := newTestPlugin("db", rec)
+	require.NoError(t, r.Register(newTestPlugin("api", rec, "cache")))
+	require.NoError(t, r.Register(newTestPlugin("cache", rec, "db")))
+	require.NoError(t, r.Register(db))
+
+	require.NoError(t, r.StartAll())
+	assert.Equal(t, map[string]fiberhouse.State{
+		"api":   fiberhouse.StateRunning,
+		"cache": fiberhouse.StateRunning,
+		"db":    fiberhouse.StateRunning,
+	}, r.Statuses())
+	assert.Equal(t, fiberhouse.StateRunning, db.Status(), "plugin's own status follows the registry")
+
+	require.NoError(t, r.StartAll()) // 已运行的插件不会重复启动
+	require.NoError(t, r.StopAll())
+
+	assert.Equal(t, []string{
+		"start:db", "start:cache", "start:api",
+		"stop:api", "stop:cache", "stop:db",
+	}, rec.list())
+	status, _ := r.Status("db")
+	assert.Equal(t, fiberhouse.StateStopped, status)
+	assert.Equal(t, fiberhouse.StateStopped, db.Status())
+}
+
+func TestRegistry_StartFailureSkipsDependentsAndContinues(t *testing.T) {
+	r := NewRegistry()
+	rec := &lifecycleRecorder{}
+	db := newTestPlugin("db", rec)
+	db.startErr = errors.New("dial failed")
+	require.NoError(t, r.Register(db))
+	require.NoError(t, r.Register(newTestPlugin("cache", rec, "db")))
+	require.NoError(t, r.Register(newTestPlugin("api", rec, "cache")))
+	require.NoError(t, r.Register(newTestPlugin("metrics", rec)))
+
+	err := r.StartAll()
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, db.startErr)
+	assert.ErrorIs(t, err, ErrPluginDependencyStopped)
+	assert.Equal(t, map[string]fiberhouse.State{
+		"db":      fiberhouse.StateFailed,
+		"cache":   fiberhouse.StateSkipped,
+		"api":     fiberhouse.StateSkipped,
+		"metrics": fiberhouse.StateRunning,
+	}, r.Statuses())
+	assert.Equal(t, fiberhouse.StateFailed, db.Status())
+
+	require.NoError(t, r.StopAll())
+	assert.Equal(t, []string{"start:db", "start:metrics", "stop:metrics"}, rec.list())
+}
+
+func TestRegistry_StopAllContinuesAfterStopFailure(t *testing.T) {
+	r := NewRegistry()
+	rec := &lifecycleRecorder{}
+	db := newTestPlugin("db", rec)
+	cache := newTestPlugin("cache", rec, "db")
+	cache.stopErr = errors.New("flush failed")
+	require.NoError(t, r.Register(db))
+	require.NoError(t, r.Register(cache))
+	require.NoError(t, r.StartAll())
+
+	err := r.StopAll()
+
+	assert.ErrorIs(t, err, cache.stopErr)
+	assert.Equal(t, []string{"start:db", "start:cache", "stop:cache", "stop:db"}, rec.list())
+	cacheStatus, _ := r.Status("cache")
+	dbStatus, _ := r.Status("db")
+	assert.Equal(t, fiberhouse.StateFailed, cacheStatus)
+	assert.Equal(t, fiberhouse.StateStopped, dbStatus)
+}
+
+func TestRegistry_RestartRequiresRunningDependencies(t *testing.T) {
+	r := NewRegistry()
+	rec := &lifecycleRecorder{}
+	require.NoError(t, r.Register(newTestPlugin("db", rec)))
+	require.NoError(t, r.Register(newTestPlugin("cache", rec, "db")))
+
+	assert.ErrorIs(t, r.Restart("unknown"), ErrPluginNotFound)
+	assert.ErrorIs(t, r.Restart("cache"), ErrPluginDependencyStopped)
+
+	require.NoError(t, r.StartAll())
+	require.NoError(t, r.Restart("db"))
+	require.NoError(t, r.StopAll())
+
+	// 重启的插件保留原有启动位置，依赖方仍先于其停止
+	assert.Equal(t, []string{
+		"start:db", "start:cache", "restart:db",
+		"stop:cache", "stop:db",
+	}, rec.list())
+}
+
+func TestRegistry_RestartFailureMarksFailed(t *testing.T) {
+	r := NewRegistry()
+	rec := &lifecycleRecorder{}
+	db := newTestPlugin("db", rec)
+	db.restartErr = errors.New("reconnect failed")
+	require.NoError(t, r.Register(db))
+	require.NoError(t, r.StartAll())
+
+	err := r.Restart("db")
+
+	assert.ErrorIs(t, err, db.restartErr)
+	status, _ := r.Status("db")
+	assert.Equal(t, fiberhouse.StateFailed, status)
+}
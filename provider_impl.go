@@ -18,6 +18,8 @@ const (
 	StateLoaded
 	StateSkipped
 	StateFailed
+	StateRunning // 运行中，用于插件等具有运行期生命周期的提供者
+	StateStopped // 已停止，用于插件等具有运行期生命周期的提供者
 )
 
 // Id 状态Id
@@ -36,6 +38,10 @@ func (s State) Name() string {
 		return "skipped"
 	case StateFailed:
 		return "failed"
+	case StateRunning:
+		return "running"
+	case StateStopped:
+		return "stopped"
 	default:
 		return "unknown"
 	}
@@ -122,7 +128,7 @@ func (p *Provider) Initialize(ctx IContext, initFunc ...ProviderInitFunc) (any,
 
 // ReturnAndSetSuccessInitialize 设置并返回成功的初始化结果
 func (p *Provider) ReturnAndSetSuccessInitialize(initInstance any, initErr error) (any, error) {
-	p.setLifecycleStatus(StateLoaded)
+	p.SetLifecycleStatus(StateLoaded)
 	p.initInstance = initInstance
 	p.initErr = initErr
 	return p.initInstance, p.initErr
@@ -130,7 +136,7 @@ func (p *Provider) ReturnAndSetSuccessInitialize(initInstance any, initErr error
 
 // ReturnAndSetFailInitialize 设置并返回失败的初始化结果
 func (p *Provider) ReturnAndSetFailInitialize(initInstance any, initErr error) (any, error) {
-	p.setLifecycleStatus(StateFailed)
+	p.SetLifecycleStatus(StateFailed)
 	p.initInstance = initInstance
 	p.initErr = initErr
 	return p.initInstance, p.initErr
@@ -197,8 +203,10 @@ func (p *Provider) SetStatus(status State) IProvider {
 	return p
 }
 
-func (p *Provider) setLifecycleStatus(status State) {
+// SetLifecycleStatus 设置提供者生命周期状态，不受 SetStatus 仅设置一次的限制，供初始化流程与插件注册表等生命周期管理者更新状态
+func (p *Provider) SetLifecycleStatus(status State) IProvider {
 	p.status = status
+	return p
 }
 
 // Type 返回提供者类型
@@ -88,6 +88,8 @@ func TestState_ValueContract(t *testing.T) {
 		{state: StateLoaded, id: 1, name: "loaded"},
 		{state: StateSkipped, id: 2, name: "skipped"},
 		{state: StateFailed, id: 3, name: "failed"},
+		{state: StateRunning, id: 4, name: "running"},
+		{state: StateStopped, id: 5, name: "stopped"},
 	}
 
 	for _, tt := range tests {
@@ -133,7 +135,7 @@ func TestProvider_LifecycleStatusBypassesPublicSetterOnce(t *testing.T) {
 	p := NewProvider()
 
 	assert.Same(t, p, p.SetStatus(StateSkipped))
-	p.setLifecycleStatus(StateFailed)
+	p.SetLifecycleStatus(StateFailed)
 	p.SetStatus(StateLoaded)
 
 	assert.Equal(t, StateFailed, p.Status())
@@ -36,6 +36,8 @@ type IProvider interface {
 	SetTarget(string) IProvider
 	// SetStatus 设置提供者状态
 	SetStatus(State) IProvider
+	// SetLifecycleStatus 设置提供者生命周期状态，可多次设置，用于插件 running/stopped 等运行期状态变化
+	SetLifecycleStatus(State) IProvider
 	// SetType 设置提供者类型，仅允许设置一次
 	SetType(IProviderType) IProvider
 	// Check 检查提供者是否设置类型值
@@ -136,9 +136,9 @@ type DefaultPLocation struct {
 	LocationModuleSwaggerInit      IProviderLocation // 注册Swagger初始化位点（如需要）
 	LocationServerRunBefore        IProviderLocation // 服务运行前位点
 	LocationServerRun              IProviderLocation // 服务运行位点
-	LocationServerRunAfter         IProviderLocation // 服务运行后位点（框架默认实现未使用该位点）
+	LocationServerRunAfter         IProviderLocation // 服务运行后位点，Fiber/Gin 监听绑定后注入 *ServerInfo 执行
 	LocationServerShutdownBefore   IProviderLocation // 服务关闭前位点
-	LocationServerShutdown         IProviderLocation // 服务关闭位点（框架默认实现未使用该位点）
+	LocationServerShutdown         IProviderLocation // 服务关闭位点，GroupExtendReplace 管理器可替代默认关闭逻辑
 	LocationServerShutdownAfter    IProviderLocation // 服务关闭后位点
 	LocationResponseInfoInit       IProviderLocation // 响应信息初始化位点
 }
@@ -172,9 +172,9 @@ func ProviderLocationDefault() *DefaultPLocation {
 			LocationModuleSwaggerInit:      registry.MustDefault("ModuleSwaggerInit"),      // 注册Swagger初始化位点
 			LocationServerRunBefore:        registry.MustDefault("ServerRunBefore"),        // 服务运行前位点
 			LocationServerRun:              registry.MustDefault("ServerRun"),              // 服务运行位点
-			LocationServerRunAfter:         registry.MustDefault("ServerRunAfter"),         // 服务运行后位点（框架默认实现未使用该位点）
+			LocationServerRunAfter:         registry.MustDefault("ServerRunAfter"),         // 服务运行后位点
 			LocationServerShutdownBefore:   registry.MustDefault("ServerShutdownBefore"),   // 服务关闭前位点
-			LocationServerShutdown:         registry.MustDefault("ServerShutdown"),         // 服务关闭位点（框架默认实现未使用该位点）
+			LocationServerShutdown:         registry.MustDefault("ServerShutdown"),         // 服务关闭位点
 			LocationServerShutdownAfter:    registry.MustDefault("ServerShutdownAfter"),    // 服务关闭后位点
 			LocationResponseInfoInit:       registry.MustDefault("ResponseInfoInit"),       // 响应信息初始化位点
 		}
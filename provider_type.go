@@ -77,6 +77,7 @@ type DefaultPType struct {
 	GroupRecoverMiddlewareChoose    IProviderType // 恢复中间件选择组，该类型提供者中仅选择一个进行恢复中间件处理（根据核心类型选择）
 	GroupResponseInfoChoose         IProviderType // 响应信息选择组，该类型提供者中仅选择一个进行响应信息处理（根据name存储的http内容类型来选择）
 	GroupExtendReplace              IProviderType // 扩展替代组，该类型提供者仅用于在特定执行位置点扩展和替代其他的实现逻辑
+	GroupConfigSourceType           IProviderType // 配置来源类型组，该类型提供者都在引导配置位点按优先级加载为配置来源
 }
 
 var (
@@ -104,6 +105,7 @@ func ProviderTypeDefault() *DefaultPType {
 			GroupRecoverMiddlewareChoose:    registry.MustDefault("RecoverMiddlewareChoose"),
 			GroupResponseInfoChoose:         registry.MustDefault("ResponseInfoChoose"),
 			GroupExtendReplace:              registry.MustDefault("ExtendReplace"),
+			GroupConfigSourceType:           registry.MustDefault("ConfigSourceType"),
 		}
 	})
 	return providerTypeInstance
@@ -22,6 +22,7 @@ RecoverConfig 结构体包含以下配置选项：
   - Stdout: 标准输出开关
   - JsonCodec: JSON 编码函数
   - DebugMode: 调试模式开关
+  - DebugModeFunc: 动态调试模式开关，支持配置热更新
 
 # 使用示例--伪代码
 
@@ -75,6 +76,19 @@ type RecoverConfig struct {
 
 	// DebugMode 调试模式：true 将详细错误信息响应给客户端，否则仅记入日志
 	DebugMode bool
+
+	// DebugModeFunc 动态调试模式，非 nil 时每次恢复 panic 时调用并优先于 DebugMode，用于响应配置热更新
+	//
+	// 可选。 默认: nil
+	DebugModeFunc func() bool
+}
+
+// debugMode 返回当前调试模式
+func (c RecoverConfig) debugMode() bool {
+	if c.DebugModeFunc != nil {
+		return c.DebugModeFunc()
+	}
+	return c.DebugMode
 }
 
 // ConfigDefault 默认配置
@@ -1,9 +1,11 @@
 package fiberhouse
 
 import (
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http/httptest"
 	"sync"
 	"sync/atomic"
@@ -15,6 +17,7 @@ import (
 	"github.com/lamxy/fiberhouse/appconfig"
 	"github.com/lamxy/fiberhouse/bootstrap"
 	jsoncodec "github.com/lamxy/fiberhouse/component/codec/json"
+	"github.com/lamxy/fiberhouse/globalmanager"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,6 +39,11 @@ type task5Application struct {
 func (a *task5Application) GetFastTrafficCodecKey() string { return task5CodecKey }
 
 func newTask5AppContext(t *testing.T, debugMode, binarySupport bool) IApplicationContext {
+	t.Helper()
+	return newTask5AppContextWithLogger(t, debugMode, binarySupport, zerolog.Nop())
+}
+
+func newTask5AppContextWithLogger(t *testing.T, debugMode, binarySupport bool, logger zerolog.Logger) IApplicationContext {
 	t.Helper()
 	cfg := appconfig.NewAppConfig().LoadDefault(map[string]interface{}{
 		"application.recover.debugMode":        debugMode,
@@ -45,7 +53,6 @@ func newTask5AppContext(t *testing.T, debugMode, binarySupport bool) IApplicatio
 		"application.recover.debugFlagValue":   "enabled",
 		"application.trace.requestID":          "X-Trace-ID",
 	}).Initialize()
-	logger := zerolog.Nop()
 	ctx := NewAppContext(cfg, bootstrap.NewLoggerWrap(&logger))
 	ctx.RegisterBootConfig(&BootConfig{EnableBinaryProtocolSupport: binarySupport})
 	ctx.RegisterStarterApp(&task5Starter{application: &task5Application{}})
@@ -67,6 +74,9 @@ func installTask5ResponseManager(t *testing.T, ctx IApplicationContext) *RespInf
 	base.MountToParent(manager)
 	require.NoError(t, manager.Register(NewRespInfoProtobufProvider()))
 	require.NoError(t, manager.Register(NewRespInfoMsgpackProvider()))
+	require.NoError(t, manager.Register(NewRespInfoCBORProvider()))
+	require.NoError(t, manager.Register(NewRespInfoXMLProvider()))
+	require.NoError(t, manager.Register(NewRespInfoYAMLProvider()))
 
 	respInfoPManagerInstance = manager
 	respInfoPManagerOnce = sync.Once{}
@@ -102,6 +112,14 @@ func TestRecoverConfig_DefaultOverrideAndConcurrentIsolation(t *testing.T) {
 	injected := configDefault(RecoverConfig{EnableStackTrace: true})
 	assert.NotNil(t, injected.StackTraceHandler)
 
+	// 动态调试模式优先于静态 DebugMode，每次调用重新读取
+	dynamic := false
+	reloadable := configDefault(RecoverConfig{DebugMode: true, DebugModeFunc: func() bool { return dynamic }})
+	assert.False(t, reloadable.debugMode())
+	dynamic = true
+	assert.True(t, reloadable.debugMode())
+	assert.True(t, custom.debugMode())
+
 	const workers = 64
 	start := make(chan struct{})
 	results := make(chan error, workers)
@@ -230,13 +248,17 @@ func TestRecoverHelpers_RequestMetadataSanitizationAndWrongContext(t *testing.T)
 	assert.Equal(t, fiber.StatusNoContent, ginRecorder.Code)
 }
 
-type task5WrongCoreContext struct{}
+type task5WrongCoreContext struct {
+	adaptorctx.ICoreContext
+}
 
-func (*task5WrongCoreContext) GetCtx() interface{}         { return struct{}{} }
-func (*task5WrongCoreContext) GetHeader(string) string     { return "" }
-func (*task5WrongCoreContext) SetHeader(string, string)    {}
-func (*task5WrongCoreContext) JSON(int, interface{}) error { return nil }
-func (*task5WrongCoreContext) Send(int, []byte) error      { return nil }
+func (*task5WrongCoreContext) GetCtx() interface{}                  { return struct{}{} }
+func (*task5WrongCoreContext) Scope() *globalmanager.GlobalManager  { return nil }
+func (*task5WrongCoreContext) ClientCertificate() *x509.Certificate { return nil }
+func (*task5WrongCoreContext) GetHeader(string) string              { return "" }
+func (*task5WrongCoreContext) SetHeader(string, string)             {}
+func (*task5WrongCoreContext) JSON(int, interface{}) error          { return nil }
+func (*task5WrongCoreContext) Send(int, []byte) error               { return nil }
 
 func TestRecoverHelpers_HeaderMaskingRules(t *testing.T) {
 	assert.Equal(t, "", maskValue(""))
@@ -256,3 +278,29 @@ func TestRecoverHelpers_HeaderMaskingRules(t *testing.T) {
 	assert.Equal(t, []string{"plain"}, masked["X-Visible"])
 	assert.Equal(t, []string{"session-value"}, original["Cookie"])
 }
+
+func TestRecoverHelpers_ResolvedConfigSecretsAreRedacted(t *testing.T) {
+	t.Setenv("FH_RECOVER_TEST_SECRET", "recover-secret-1")
+	resolved, err := appconfig.ResolveSecret("${env:FH_RECOVER_TEST_SECRET}")
+	require.NoError(t, err)
+	require.Equal(t, "recover-secret-1", resolved)
+
+	masked := sanitizeHeaders(map[string][]string{"X-Visible": {"echo recover-secret-1"}})
+	assert.Equal(t, []string{"echo ******"}, masked["X-Visible"])
+	assert.Equal(t, "dial redis: auth ****** failed", sanitizeSecrets("dial redis: auth recover-secret-1 failed"))
+	assert.Equal(t, `{"dsn":"******"}`, string(sanitizeSecretsJSON([]byte(`{"dsn":"recover-secret-1"}`))))
+
+	// 调试模式下 panic 错误信息会回显到响应，其中的密钥值同样脱敏
+	ctx := newTask5AppContext(t, true, false)
+	installTask5ResponseManager(t, ctx)
+	app := fiber.New()
+	app.Use(NewFiberRecovery(ctx).RecoverPanic(RecoverConfig{DebugMode: true, JsonCodec: json.Marshal}).(fiber.Handler))
+	app.Get("/panic", func(*fiber.Ctx) error { panic(errors.New("connect with recover-secret-1 failed")) })
+	response, err := app.Test(httptest.NewRequest("GET", "/panic", nil))
+	require.NoError(t, err)
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "connect with ****** failed")
+	assert.NotContains(t, string(body), "recover-secret-1")
+}
@@ -14,12 +14,15 @@ import (
 
 	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
 	"github.com/lamxy/fiberhouse/bootstrap"
+	"github.com/lamxy/fiberhouse/component/i18n"
 	"github.com/lamxy/fiberhouse/component/jsonconvert"
 	frameUtils "github.com/lamxy/fiberhouse/utils"
 
 	"github.com/lamxy/fiberhouse/exception"
+	"github.com/lamxy/fiberhouse/response"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
 )
 
 var (
@@ -200,21 +203,22 @@ func (r *ErrorHandler) DefaultStackTraceHandler(ctx adaptorctx.ICoreContext, e i
 
 	switch err := e.(type) {
 	case *exception.ValidateException:
+		logExceptionOrigin(logEvent, err.Key, err.Cause)
 		dw := jsonconvert.NewDataWrap(err.Data)
 
 		if debugMode || enablePrintStack || (enableDebugFlag && debugFlagFromHeader == debugFlagValue) {
 			// 输出堆栈信息
-			msg := ErrorStack()
+			msg := sanitizeSecrets(ErrorStack())
 
 			if dw.CanJSONSerializable() {
 				data, errJson := dw.GetJson(jsonEnCoder)
 				if errJson != nil {
-					logEvent.Int("Code", err.Code).Str("Msg", err.Msg).Str("Data", "").Str("DataWrap-GetJson-error", errJson.Error()).Str("PrintStack", "true")
+					logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).Str("Data", "").Str("DataWrap-GetJson-error", errJson.Error()).Str("PrintStack", "true")
 				} else {
-					logEvent.Int("Code", err.Code).Str("Msg", err.Msg).RawJSON("Data", data).Str("PrintStack", "true")
+					logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).RawJSON("Data", sanitizeSecretsJSON(data)).Str("PrintStack", "true")
 				}
 			} else {
-				logEvent.Int("Code", err.Code).Str("Msg", err.Msg).Str("Data", dw.GetString()).Str("PrintStack", "true")
+				logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).Str("Data", sanitizeSecrets(dw.GetString())).Str("PrintStack", "true")
 			}
 
 			if len(reqParamsJson) > 0 {
@@ -239,31 +243,32 @@ func (r *ErrorHandler) DefaultStackTraceHandler(ctx adaptorctx.ICoreContext, e i
 			if dw.CanJSONSerializable() {
 				data, errJson := dw.GetJson(jsonEnCoder)
 				if errJson != nil {
-					logEvent.Int("Code", err.Code).Str("Msg", err.Msg).Str("Data", "").Str("DataWrap-GetJson-error", errJson.Error()).Msg(err.Error())
+					logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).Str("Data", "").Str("DataWrap-GetJson-error", errJson.Error()).Msg(sanitizeSecrets(err.Error()))
 				} else {
-					logEvent.Int("Code", err.Code).Str("Msg", err.Msg).RawJSON("Data", data).Msg(err.Error())
+					logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).RawJSON("Data", sanitizeSecretsJSON(data)).Msg(sanitizeSecrets(err.Error()))
 				}
 			} else {
-				logEvent.Int("Code", err.Code).Str("Msg", err.Msg).Str("Data", dw.GetString()).Msg(err.Error())
+				logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).Str("Data", sanitizeSecrets(dw.GetString())).Msg(sanitizeSecrets(err.Error()))
 			}
 		}
 		dw.Release()
 	case *exception.Exception:
+		logExceptionOrigin(logEvent, err.Key, err.Cause)
 		dw := jsonconvert.NewDataWrap(err.Data)
 
 		if debugMode || enablePrintStack || (enableDebugFlag && debugFlagFromHeader == debugFlagValue) {
 			// 输出堆栈信息
-			msg := ErrorStack()
+			msg := sanitizeSecrets(ErrorStack())
 
 			if dw.CanJSONSerializable() {
 				data, errJson := dw.GetJson(jsonEnCoder)
 				if errJson != nil {
-					logEvent.Int("Code", err.Code).Str("Msg", err.Msg).Str("Data", "").Str("DataWrap-GetJson-error", errJson.Error()).Str("PrintStack", "true")
+					logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).Str("Data", "").Str("DataWrap-GetJson-error", errJson.Error()).Str("PrintStack", "true")
 				} else {
-					logEvent.Int("Code", err.Code).Str("Msg", err.Msg).RawJSON("Data", data).Str("PrintStack", "true")
+					logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).RawJSON("Data", sanitizeSecretsJSON(data)).Str("PrintStack", "true")
 				}
 			} else {
-				logEvent.Int("Code", err.Code).Str("Msg", err.Msg).Str("Data", dw.GetString()).Str("PrintStack", "true")
+				logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).Str("Data", sanitizeSecrets(dw.GetString())).Str("PrintStack", "true")
 			}
 
 			if len(reqParamsJson) > 0 {
@@ -287,12 +292,12 @@ func (r *ErrorHandler) DefaultStackTraceHandler(ctx adaptorctx.ICoreContext, e i
 			if dw.CanJSONSerializable() {
 				data, errJson := dw.GetJson(jsonEnCoder)
 				if errJson != nil {
-					logEvent.Int("Code", err.Code).Str("Msg", err.Msg).Str("Data", "").Str("DataWrap-GetJson-error", errJson.Error()).Msg(err.Error())
+					logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).Str("Data", "").Str("DataWrap-GetJson-error", errJson.Error()).Msg(sanitizeSecrets(err.Error()))
 				} else {
-					logEvent.Int("Code", err.Code).Str("Msg", err.Msg).RawJSON("Data", data).Msg(err.Error())
+					logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).RawJSON("Data", sanitizeSecretsJSON(data)).Msg(sanitizeSecrets(err.Error()))
 				}
 			} else {
-				logEvent.Int("Code", err.Code).Str("Msg", err.Msg).Str("Data", dw.GetString()).Msg(err.Error())
+				logEvent.Int("Code", err.Code).Str("Msg", sanitizeSecrets(err.Msg)).Str("Data", sanitizeSecrets(dw.GetString())).Msg(sanitizeSecrets(err.Error()))
 			}
 		}
 		dw.Release()
@@ -313,9 +318,9 @@ func (r *ErrorHandler) DefaultStackTraceHandler(ctx adaptorctx.ICoreContext, e i
 			fiberErr.Code = code
 		}
 		if debugMode || enablePrintStack || (enableDebugFlag && debugFlagFromHeader == debugFlagValue) { // 输出堆栈信息
-			msg := ErrorStack()
+			msg := sanitizeSecrets(ErrorStack())
 
-			logEvent.Int("Code", fiberErr.Code).Str("Msg", fiberErr.Error()).Str("PrintStack", "true")
+			logEvent.Int("Code", fiberErr.Code).Str("Msg", sanitizeSecrets(fiberErr.Error())).Str("PrintStack", "true")
 
 			if len(reqParamsJson) > 0 {
 				logEvent.RawJSON("reqParams", reqParamsJson)
@@ -335,13 +340,25 @@ func (r *ErrorHandler) DefaultStackTraceHandler(ctx adaptorctx.ICoreContext, e i
 			}
 			logEvent.Msg(msg)
 		} else {
-			logEvent.Int("Code", fiberErr.Code).Msg(fiberErr.Error())
+			logEvent.Int("Code", fiberErr.Code).Msg(sanitizeSecrets(fiberErr.Error()))
 		}
 	case error:
+		// 包装在错误链中的异常（含 Gin 的 *gin.Error）补充记录异常字段，消息保留完整错误链
+		var (
+			wrappedVe *exception.ValidateException
+			wrappedEe *exception.Exception
+		)
+		if errors.As(err, &wrappedVe) {
+			logExceptionOrigin(logEvent, wrappedVe.Key, wrappedVe.Cause)
+			logEvent.Int("Code", wrappedVe.Code)
+		} else if errors.As(err, &wrappedEe) {
+			logExceptionOrigin(logEvent, wrappedEe.Key, wrappedEe.Cause)
+			logEvent.Int("Code", wrappedEe.Code)
+		}
 		if debugMode || enablePrintStack || (enableDebugFlag && debugFlagFromHeader == debugFlagValue) { // 输出堆栈信息
-			msg := ErrorStack()
+			msg := sanitizeSecrets(ErrorStack())
 
-			logEvent.Str("Msg", err.Error()).Str("PrintStack", "true")
+			logEvent.Str("Msg", sanitizeSecrets(err.Error())).Str("PrintStack", "true")
 
 			if len(reqParamsJson) > 0 {
 				logEvent.RawJSON("reqParams", reqParamsJson)
@@ -361,44 +378,121 @@ func (r *ErrorHandler) DefaultStackTraceHandler(ctx adaptorctx.ICoreContext, e i
 			}
 			logEvent.Msg(msg)
 		} else {
-			logEvent.Msg(err.Error())
+			logEvent.Msg(sanitizeSecrets(err.Error()))
 		}
 	}
 }
 
+// logExceptionOrigin 记录异常键与包装的原始错误链
+func logExceptionOrigin(logEvent *zerolog.Event, key string, cause error) {
+	if key != "" {
+		logEvent.Str("Key", key)
+	}
+	if cause != nil {
+		logEvent.Str("Cause", sanitizeSecrets(cause.Error()))
+	}
+}
+
 // ErrorHandler 处理错误并返回对应的HTTP响应
+//
+// 错误先归类为 ErrorDetail，再由本次请求的错误响应信封（见 UseErrorEnvelope 与 BootConfig.ErrorEnvelope）写入响应。
 func (r *ErrorHandler) ErrorHandler(ctx adaptorctx.ICoreContext, err error) error {
 	// 记录日志 & 堆栈
 	r.DefaultStackTraceHandler(ctx, err)
+	detail, release := r.errorDetail(ctx, err)
+	sendErr := errorEnvelopeOf(ctx, r.AppCtx).SendError(ctx, detail)
+	if release != nil {
+		release.Release()
+	}
+	return sendErr
+}
+
+// errorDetail 把错误归类为 ErrorDetail，并返回发送后需放回对象池的异常对象；异常消息按请求语言本地化
+func (r *ErrorHandler) errorDetail(ctx adaptorctx.ICoreContext, err error) (*ErrorDetail, response.IResponse) {
 	if code, message, ok := fiberHTTPError(err); ok {
-		return Response().Reset(code, message, nil).SendWithCtx(ctx, code)
+		return &ErrorDetail{Status: code, Code: code, Msg: message, Err: err}, nil
 	}
 
+	debugMode := r.GetContext().GetConfig().GetRecover().DebugMode
 	// ValidateException
-	var (
-		debugMode = r.GetContext().GetConfig().GetRecover().DebugMode
-		eve       = ValidateException()
-	)
-	okVe := errors.As(err, &eve)
-	if okVe {
+	var eve *exception.ValidateException
+	if errors.As(err, &eve) {
 		// 验证器错误，响应完整错误信息到客户端
-		//return eve.RespData().JsonWithCtx(ctx, http.StatusBadRequest)
-		return Response().From(eve, true).SendWithCtx(ctx, http.StatusBadRequest)
+		return validateErrorDetail(ctx, r.AppCtx, eve, err), eve
 	}
 	// Exception
-	var ee = Exception()
-	okEe := errors.As(err, &ee)
-	if okEe {
-		if debugMode {
-			return Response().From(ee, true).SendWithCtx(ctx, http.StatusBadRequest)
-		}
-		return Response().From(ee.RespData(nil), true).SendWithCtx(ctx, http.StatusBadRequest)
+	var ee *exception.Exception
+	if errors.As(err, &ee) {
+		return exceptionErrorDetail(ctx, r.AppCtx, ee, debugMode, err), ee
+	}
+	// 直接返回的异常键按注册的定义响应
+	var key exception.Key
+	if errors.As(err, &key) {
+		ee = exception.Err(string(key))
+		return exceptionErrorDetail(ctx, r.AppCtx, ee, debugMode, err), ee
 	}
 	// default
+	ue := exception.GetUnknownError()
+	detail := &ErrorDetail{
+		Status: statusOr(ue.Status, http.StatusInternalServerError),
+		Code:   ue.Code,
+		Msg:    localizedMsg(ctx, r.AppCtx, ue),
+		Data:   ue.Data,
+		Type:   ue.Type,
+		Err:    err,
+	}
 	if debugMode {
-		return Response().From(exception.GetUnknownError().RespData(err.Error()), true).SendWithCtx(ctx, http.StatusInternalServerError)
+		detail.Data = sanitizeSecrets(err.Error())
+	}
+	return detail, ue
+}
+
+// validateErrorDetail 验证异常默认以 400 响应完整数据
+func validateErrorDetail(c adaptorctx.ICoreContext, appCtx IApplicationContext, e *exception.ValidateException, err error) *ErrorDetail {
+	return &ErrorDetail{
+		Status:     statusOr(e.Status, http.StatusBadRequest),
+		Code:       e.Code,
+		Msg:        localizedMsg(c, appCtx, (*exception.Exception)(e)),
+		Data:       e.Data,
+		Type:       e.Type,
+		Validation: true,
+		Err:        err,
+	}
+}
+
+// exceptionErrorDetail 业务异常默认以 400 响应，非调试模式清空数据
+func exceptionErrorDetail(c adaptorctx.ICoreContext, appCtx IApplicationContext, e *exception.Exception, debugMode bool, err error) *ErrorDetail {
+	detail := &ErrorDetail{
+		Status: statusOr(e.Status, http.StatusBadRequest),
+		Code:   e.Code,
+		Msg:    localizedMsg(c, appCtx, e),
+		Type:   e.Type,
+		Err:    err,
+	}
+	if debugMode {
+		detail.Data = e.Data
+	}
+	return detail
+}
+
+// localizedMsg 返回异常在本次请求语言下的消息并插值 Args
+//
+// 异常有可用的本地化消息（定义中的 Messages 或 i18n.Default() 目录）时，按应用上下文的 i18n.Resolver 在这些语言中协商请求语言，
+// 并追加 Vary: Accept-Language。
+func localizedMsg(c adaptorctx.ICoreContext, appCtx IApplicationContext, e *exception.Exception) string {
+	langs := e.Langs()
+	if len(langs) == 0 {
+		return e.Localize("")
+	}
+	if appCtx == nil {
+		appCtx = applicationContext
+	}
+	resolver := i18n.NewResolverWith(i18n.Config{})
+	if appCtx != nil && appCtx.GetLangResolver() != nil {
+		resolver = appCtx.GetLangResolver()
 	}
-	return Response().From(exception.GetUnknownError(), true).SendWithCtx(ctx, http.StatusInternalServerError)
+	c.Vary("Accept-Language")
+	return e.Localize(resolver.Resolve(c, langs))
 }
 
 func fiberHTTPError(err error) (int, string, bool) {
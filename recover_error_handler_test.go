@@ -1,11 +1,14 @@
 package fiberhouse
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -14,6 +17,7 @@ import (
 	adaptorerrorhandler "github.com/lamxy/fiberhouse/adaptor/errorhandler"
 	"github.com/lamxy/fiberhouse/constant"
 	"github.com/lamxy/fiberhouse/exception"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
@@ -312,3 +316,96 @@ func TestErrorHandler_DefaultStackTraceHandler_NilProvider_DoesNotPanic(t *testi
 }
 
 var _ adaptorctx.ICoreContext = (*task5WrongCoreContext)(nil)
+
+func TestErrorHandler_ReturnedExceptionsMapStatusWithoutPanic(t *testing.T) {
+	for _, core := range []string{"fiber", "gin"} {
+		t.Run(core, func(t *testing.T) {
+			var logs bytes.Buffer
+			ctx := newTask5AppContextWithLogger(t, false, false, zerolog.New(&logs))
+			installTask5ResponseManager(t, ctx)
+			installEnvelopeTestExceptions(t, ctx)
+			handler := newTask5ErrorHandler(ctx, NewFiberRecovery(ctx))
+			var recovered atomic.Int32
+			cfg := RecoverConfig{
+				AppCtx:            ctx,
+				EnableStackTrace:  true,
+				StackTraceHandler: func(adaptorctx.ICoreContext, interface{}) { recovered.Add(1) },
+			}
+
+			routes := map[string]HandlerFunc{
+				"/returned": func(adaptorctx.ICoreContext) error {
+					return exception.Err("OrderNotFound", "order 7")
+				},
+				"/wrapped": func(adaptorctx.ICoreContext) error {
+					return fmt.Errorf("load order: %w", exception.Wrap(io.ErrUnexpectedEOF, "InputParamError").WithStatus(http.StatusConflict))
+				},
+				"/key": func(adaptorctx.ICoreContext) error {
+					return fmt.Errorf("lookup: %w", exception.Key("OrderNotFound"))
+				},
+				"/unregistered": func(adaptorctx.ICoreContext) error {
+					return exception.Err("Missing")
+				},
+			}
+			var serve func(path string) (int, map[string]interface{})
+			switch core {
+			case "fiber":
+				app := fiber.New(fiber.Config{ErrorHandler: adaptorerrorhandler.FiberErrorHandler(handler.ErrorHandler)})
+				app.Use(NewFiberRecovery(ctx).RecoverPanic(cfg).(fiber.Handler))
+				r, err := NewRouter(app)
+				require.NoError(t, err)
+				for path, h := range routes {
+					r.Get(path, h)
+				}
+				serve = func(path string) (int, map[string]interface{}) {
+					resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+					require.NoError(t, err)
+					defer resp.Body.Close()
+					var envelope map[string]interface{}
+					require.NoError(t, json.NewDecoder(resp.Body).Decode(&envelope))
+					return resp.StatusCode, envelope
+				}
+			case "gin":
+				preserveTask4GinMode(t)
+				gin.SetMode(gin.TestMode)
+				engine := gin.New()
+				engine.Use(gin.HandlerFunc(NewGinRecovery(ctx).RecoverPanic(cfg).(func(*gin.Context))))
+				engine.Use(adaptorerrorhandler.GinErrorHandler(handler.ErrorHandler))
+				r, err := NewRouter(engine)
+				require.NoError(t, err)
+				for path, h := range routes {
+					r.Get(path, h)
+				}
+				serve = func(path string) (int, map[string]interface{}) {
+					recorder := httptest.NewRecorder()
+					engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+					var envelope map[string]interface{}
+					require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &envelope))
+					return recorder.Code, envelope
+				}
+			}
+
+			status, envelope := serve("/returned")
+			assert.Equal(t, http.StatusNotFound, status)
+			assert.EqualValues(t, 4404, envelope["code"])
+			assert.Nil(t, envelope["data"], "production mode hides exception data")
+
+			status, envelope = serve("/wrapped")
+			assert.Equal(t, http.StatusConflict, status)
+			assert.EqualValues(t, 4000, envelope["code"])
+			assert.Equal(t, "invalid input", envelope["msg"], "the cause is not exposed to clients")
+
+			status, envelope = serve("/key")
+			assert.Equal(t, http.StatusNotFound, status)
+			assert.EqualValues(t, 4404, envelope["code"])
+
+			status, envelope = serve("/unregistered")
+			assert.Equal(t, http.StatusInternalServerError, status)
+			assert.EqualValues(t, constant.UnknownErrCode, envelope["code"])
+
+			assert.Zero(t, recovered.Load(), "returned exceptions never reach panic recovery")
+			assert.Contains(t, logs.String(), `"Key":"InputParamError"`)
+			assert.Contains(t, logs.String(), `"Cause":"unexpected EOF"`)
+			assert.Contains(t, logs.String(), `key \"Missing\" is not registered`)
+		})
+	}
+}
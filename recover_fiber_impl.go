@@ -11,7 +11,9 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/lamxy/fiberhouse/appconfig"
 	"github.com/lamxy/fiberhouse/bootstrap"
+	frameUtils "github.com/lamxy/fiberhouse/utils"
 )
 
 // FiberRecovery Fiber 框架的请求数据实现
@@ -160,12 +162,30 @@ func sanitizeHeaders(headers map[string][]string) map[string][]string {
 			}
 			sanitized[key] = maskedValues
 		} else {
-			sanitized[key] = values
+			redacted := make([]string, len(values))
+			for i, v := range values {
+				redacted[i] = sanitizeSecrets(v)
+			}
+			sanitized[key] = redacted
 		}
 	}
 	return sanitized
 }
 
+// sanitizeSecrets 把已解析的配置密钥值（${env:...}、${file:...}、enc:）替换为掩码，防止密钥出现在 panic 日志与调试响应中
+func sanitizeSecrets(s string) string {
+	return appconfig.RedactSecrets(s)
+}
+
+// sanitizeSecretsJSON 同 sanitizeSecrets，用于已编码的 JSON
+func sanitizeSecretsJSON(data []byte) []byte {
+	redacted := appconfig.RedactSecrets(frameUtils.UnsafeString(data))
+	if redacted == frameUtils.UnsafeString(data) {
+		return data
+	}
+	return []byte(redacted)
+}
+
 func isSensitiveHeader(key string) bool {
 	return key == "authorization" ||
 		key == "cookie" ||
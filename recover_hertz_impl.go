@@ -0,0 +1,140 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	ctxpkg "context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/lamxy/fiberhouse/bootstrap"
+)
+
+// HertzRecovery Hertz 框架的请求数据实现
+type HertzRecovery struct {
+	AppCtx IApplicationContext
+}
+
+// NewHertzRecovery 创建 Hertz 恢复实例
+func NewHertzRecovery(ctx IApplicationContext) *HertzRecovery {
+	return &HertzRecovery{
+		AppCtx: ctx,
+	}
+}
+
+func (h *HertzRecovery) GetParamsJson(ctx adaptorctx.ICoreContext, log bootstrap.LoggerWrapper, jsonEncoder func(interface{}) ([]byte, error), traceId string) []byte {
+	c, ok := ctx.GetCtx().(*app.RequestContext)
+	if !ok {
+		return nil
+	}
+	params := make(map[string]string, len(c.Params))
+	for _, p := range c.Params {
+		params[p.Key] = p.Value
+	}
+	j, err := jsonEncoder(params)
+	if err != nil {
+		log.Warn(h.AppCtx.GetConfig().LogOriginRecover()).Str("traceId", traceId).Str("reqParamsErr", err.Error()).Msg("getParamsJson error")
+		return nil
+	}
+	return j
+}
+
+func (h *HertzRecovery) GetQueriesJson(ctx adaptorctx.ICoreContext, log bootstrap.LoggerWrapper, jsonEncoder func(interface{}) ([]byte, error), traceId string) []byte {
+	c, ok := ctx.GetCtx().(*app.RequestContext)
+	if !ok {
+		return nil
+	}
+	queries := make(map[string][]string)
+	c.QueryArgs().VisitAll(func(key, value []byte) {
+		k := string(key)
+		queries[k] = append(queries[k], string(value))
+	})
+	j, err := jsonEncoder(queries)
+	if err != nil {
+		log.Warn(h.AppCtx.GetConfig().LogOriginRecover()).Str("traceId", traceId).Str("reqQueriesErr", err.Error()).Msg("getQueriesJson error")
+		return nil
+	}
+	return j
+}
+
+func (h *HertzRecovery) GetHeadersJson(ctx adaptorctx.ICoreContext, log bootstrap.LoggerWrapper, jsonEncoder func(interface{}) ([]byte, error), traceId string) []byte {
+	c, ok := ctx.GetCtx().(*app.RequestContext)
+	if !ok {
+		return nil
+	}
+	headers := make(map[string][]string)
+	c.Request.Header.VisitAll(func(key, value []byte) {
+		k := string(key)
+		headers[k] = append(headers[k], string(value))
+	})
+	sanitizedHeaders := sanitizeHeaders(headers)
+	j, err := jsonEncoder(sanitizedHeaders)
+	if err != nil {
+		log.Warn(h.AppCtx.GetConfig().LogOriginRecover()).Str("traceId", traceId).Str("reqHeadersErr", err.Error()).Msg("getHeadersJson error")
+		return nil
+	}
+	return j
+}
+
+func (h *HertzRecovery) GetHeader(ctx adaptorctx.ICoreContext, key string) string {
+	c, ok := ctx.GetCtx().(*app.RequestContext)
+	if !ok {
+		return ""
+	}
+	return string(c.GetHeader(key))
+}
+
+func (h *HertzRecovery) RecoverPanic(config ...RecoverConfig) any {
+	// 与 Fiber/Gin 一致，由恢复中间件管理器依据核心类型选择本提供者，
+	//框架以 MustRecoverMiddleware[app.HandlerFunc] 取出中间件，故返回 app.HandlerFunc 类型
+
+	// Set default config
+	cfg := configDefault(config...)
+
+	// Return new handler
+	return app.HandlerFunc(func(c ctxpkg.Context, reqCtx *app.RequestContext) {
+		pCtx := adaptorctx.WithHertzContext(reqCtx)
+		completed := false
+		func() {
+			defer recoverPanicInternal(pCtx, cfg)
+			// Don't execute middleware if Cfg Next returns true
+			if cfg.Next != nil && cfg.Next(pCtx) {
+				reqCtx.Next(c)
+				completed = true
+				return
+			}
+
+			reqCtx.Next(c)
+			completed = true
+		}()
+		if completed {
+			releaseCoreContext(pCtx)
+		}
+	})
+}
+
+func (h *HertzRecovery) TraceID(ctx adaptorctx.ICoreContext, flag ...string) string {
+	// 原生上下文
+	var (
+		c  *app.RequestContext
+		ok bool
+	)
+	if c, ok = ctx.GetCtx().(*app.RequestContext); !ok {
+		panic("ContextProvider is not *app.RequestContext")
+	}
+	// 请求requestId
+	rID := requestID
+	if len(flag) > 0 {
+		rID = flag[0]
+	}
+	if v, exists := c.Get(rID); exists {
+		if traceId, isStr := v.(string); isStr {
+			return traceId // 请求类错误，从上下文键值获取请求ID
+		}
+	}
+	return "" // 非请求接口出现的错误，请求ID空值
+}
@@ -1,6 +1,7 @@
 package fiberhouse
 
 import (
+	ctxpkg "context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -9,6 +10,10 @@ import (
 	"sync/atomic"
 	"testing"
 
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/protocol"
 	"github.com/gin-gonic/gin"
 	"github.com/gofiber/fiber/v2"
 	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
@@ -132,10 +137,48 @@ func task5RunGinContract(t *testing.T, testCase task5RecoverCase, cfg RecoverCon
 	return recorder, middlewareContext
 }
 
+func task5RunHertzContract(t *testing.T, testCase task5RecoverCase, cfg RecoverConfig, handler *ErrorHandler, downstream *atomic.Int32) (*protocol.Response, adaptorctx.ICoreContext) {
+	t.Helper()
+	var middlewareContext adaptorctx.ICoreContext
+	cfg.Next = func(ctx adaptorctx.ICoreContext) bool {
+		middlewareContext = ctx
+		return testCase.kind == "next"
+	}
+	h := server.New()
+	h.Use(NewHertzRecovery(handler.AppCtx).RecoverPanic(cfg).(app.HandlerFunc))
+	h.Use(adaptorerrorhandler.HertzErrorHandler(handler.ErrorHandler))
+	h.GET("/contract", func(_ ctxpkg.Context, c *app.RequestContext) {
+		downstream.Add(1)
+		switch testCase.kind {
+		case "panic-error":
+			panic(errors.New("panic detail"))
+		case "panic-exception":
+			panic(exception.New(4101, "business rule", "business data"))
+		case "panic-validation":
+			panic(exception.NewVE(4201, "invalid input", "field data"))
+		case "panic-runtime":
+			var value *int
+			_ = *value
+		case "panic-string":
+			panic("string detail")
+		case "http-404":
+			_ = c.Error(fiber.NewError(http.StatusNotFound, "route missing"))
+		case "http-405":
+			_ = c.Error(fiber.NewError(http.StatusMethodNotAllowed, "method rejected"))
+		case "returned-error":
+			_ = c.Error(errors.New("returned detail"))
+		case "next":
+			c.Status(http.StatusNoContent)
+		}
+	})
+	recorder := ut.PerformRequest(h.Engine, http.MethodGet, "/contract", nil)
+	return recorder.Result(), middlewareContext
+}
+
 func TestRecoverHTTPContract_FiberAndGin(t *testing.T) {
 	for _, testCase := range task5RecoverCases() {
 		t.Run(testCase.name, func(t *testing.T) {
-			for _, core := range []string{"fiber", "gin"} {
+			for _, core := range []string{"fiber", "gin", "hertz"} {
 				t.Run(core, func(t *testing.T) {
 					ctx := newTask5AppContext(t, testCase.debugMode, false)
 					installTask5ResponseManager(t, ctx)
@@ -167,6 +210,10 @@ func TestRecoverHTTPContract_FiberAndGin(t *testing.T) {
 						handler := newTask5ErrorHandler(ctx, NewGinRecovery(ctx))
 						recorder, captured := task5RunGinContract(t, testCase, cfg, handler, &downstream)
 						status, contentType, body, middlewareContext = recorder.Code, recorder.Header().Get("Content-Type"), recorder.Body.Bytes(), captured
+					case "hertz":
+						handler := newTask5ErrorHandler(ctx, NewHertzRecovery(ctx))
+						response, captured := task5RunHertzContract(t, testCase, cfg, handler, &downstream)
+						status, contentType, body, middlewareContext = response.StatusCode(), string(response.Header.ContentType()), response.Body(), captured
 					}
 
 					assert.Equal(t, testCase.wantStatus, status)
@@ -18,61 +18,52 @@ func recoverPanicInternal(pCtx adaptorctx.ICoreContext, cfg RecoverConfig) {
 		if cfg.EnableStackTrace {
 			cfg.StackTraceHandler(pCtx, r)
 		}
-		debugMode := cfg.DebugMode
+		debugMode := cfg.debugMode()
+		envelope := errorEnvelopeOf(pCtx, cfg.AppCtx)
 		switch re := r.(type) {
 		case *exception.ValidateException:
-			_ = Response().From(re.RespData(), true).SendWithCtx(pCtx, http.StatusBadRequest)
+			_ = envelope.SendError(pCtx, validateErrorDetail(pCtx, cfg.AppCtx, re, re))
+			re.Release()
 			return
 		case *exception.Exception:
-			if debugMode {
-				//_ = re.RespData().JsonWithCtx(pCtx, http.StatusBadRequest)
-				_ = Response().From(re.RespData(), true).SendWithCtx(pCtx, http.StatusBadRequest)
-				return
-			}
-			//_ = re.RespData(nil).JsonWithCtx(pCtx, http.StatusBadRequest)
-			_ = Response().From(re.RespData(nil), true).SendWithCtx(pCtx, http.StatusBadRequest)
+			_ = envelope.SendError(pCtx, exceptionErrorDetail(pCtx, cfg.AppCtx, re, debugMode, re))
+			re.Release()
 			return
 		case runtime.Error:
+			detail := &ErrorDetail{Status: http.StatusInternalServerError, Code: constant.UnknownErrCode, Err: re}
 			if debugMode {
-				// panic(re)
-				_ = Response().From(exception.New(constant.UnknownErrCode, "RuntimeError", re.Error()), true).SendWithCtx(pCtx, http.StatusInternalServerError)
-				return
-			}
-			var msg string
-			if strings.Contains(re.Error(), "invalid memory") || strings.Contains(re.Error(), "nil pointer") {
-				msg = "NullPointerException"
+				detail.Msg, detail.Data = "RuntimeError", sanitizeSecrets(re.Error())
+			} else if strings.Contains(re.Error(), "invalid memory") || strings.Contains(re.Error(), "nil pointer") {
+				detail.Msg = "NullPointerException"
 			} else {
-				msg = "UnknownRTException"
+				detail.Msg = "UnknownRTException"
 			}
-			_ = Response().From(exception.New(constant.UnknownErrCode, msg), true).SendWithCtx(pCtx, http.StatusInternalServerError)
+			_ = envelope.SendError(pCtx, detail)
 			return
 		case error:
+			detail := &ErrorDetail{Status: http.StatusInternalServerError, Code: constant.UnknownErrCode, Msg: constant.UnknownErrMsg, Err: re}
 			if debugMode {
-				_ = Response().From(exception.New(constant.UnknownErrCode, re.Error()), true).SendWithCtx(pCtx, http.StatusInternalServerError)
-				return
+				detail.Msg = sanitizeSecrets(re.Error())
 			}
-			_ = Response().From(exception.New(constant.UnknownErrCode, constant.UnknownErrMsg), true).SendWithCtx(pCtx, http.StatusInternalServerError)
+			_ = envelope.SendError(pCtx, detail)
 			return
 		default:
+			detail := &ErrorDetail{Status: http.StatusInternalServerError, Code: constant.UnknownErrCode, Msg: constant.UnknownErrMsg}
 			if debugMode {
 				dw := jsonconvert.NewDataWrap(re)
 				defer dw.Release()
 				if dw.CanJSONSerializable() {
-					var out interface{}
 					jsonRet, _ := dw.GetJson(cfg.JsonCodec) // ignore error
 					if jsonRet == nil {
-						out = ""
+						detail.Data = ""
 					} else {
-						out = frameUtils.UnsafeString(jsonRet)
+						detail.Data = sanitizeSecrets(frameUtils.UnsafeString(jsonRet))
 					}
-					_ = Response().From(exception.New(constant.UnknownErrCode, constant.UnknownErrMsg, out), true).SendWithCtx(pCtx, http.StatusInternalServerError)
-					return
 				} else {
-					_ = Response().From(exception.New(constant.UnknownErrCode, constant.UnknownErrMsg, dw.GetString()), true).SendWithCtx(pCtx, http.StatusInternalServerError)
-					return
+					detail.Data = sanitizeSecrets(dw.GetString())
 				}
 			}
-			_ = Response().From(exception.New(constant.UnknownErrCode, constant.UnknownErrMsg), true).SendWithCtx(pCtx, http.StatusInternalServerError)
+			_ = envelope.SendError(pCtx, detail)
 			return
 		}
 	}
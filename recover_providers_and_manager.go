@@ -61,6 +61,28 @@ func (p *GinRecoveryProvider) Initialize(ctx IContext, initFunc ...ProviderInitF
 	return NewGinRecovery(ctx.(IApplicationContext)), nil
 }
 
+// HertzRecoveryProvider Hertz 恢复提供者
+type HertzRecoveryProvider struct {
+	IProvider
+}
+
+// NewHertzRecoveryProvider 创建 Hertz 恢复提供者
+func NewHertzRecoveryProvider() *HertzRecoveryProvider {
+	p := &HertzRecoveryProvider{
+		IProvider: NewProvider().
+			SetName("HertzRecoveryProvider").
+			SetTarget(constant.CoreTypeWithHertz).
+			SetType(ProviderTypeDefault().GroupRecoverMiddlewareChoose),
+	}
+	p.MountToParent(p)
+	return p
+}
+
+// Initialize 重载hertz提供者初始化方法
+func (p *HertzRecoveryProvider) Initialize(ctx IContext, initFunc ...ProviderInitFunc) (any, error) {
+	return NewHertzRecovery(ctx.(IApplicationContext)), nil
+}
+
 // PManager-----------------------------------------------------------------------------------
 
 // RecoveryPManager 恢复惊慌管理器
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package response
+
+import (
+	"strconv"
+	"strings"
+)
+
+// 响应媒体类型
+const (
+	MIMEApplicationJSON = "application/json"
+	MIMEApplicationCBOR = "application/cbor"
+	MIMEApplicationXML  = "application/xml"
+	MIMEApplicationYAML = "application/yaml"
+
+	MIMEApplicationProblemJSON = "application/problem+json"
+)
+
+// mediaRange Accept 中的一项媒体范围及其权重
+type mediaRange struct {
+	typ     string
+	subtype string
+	q       float64
+}
+
+// specificity 返回 mediaRange 与 offer 的匹配精确度：2 为完全匹配，1 为 type/*，0 为 */*，-1 为不匹配
+func (r mediaRange) specificity(offerType, offerSubtype string) int {
+	switch {
+	case r.typ == "*" && r.subtype == "*":
+		return 0
+	case r.typ != offerType:
+		return -1
+	case r.subtype == "*":
+		return 1
+	case r.subtype == offerSubtype:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// NegotiateMediaType 按 RFC 9110 的 Accept 语义从 offers 中选出响应媒体类型
+//
+// 每个 offer 的权重取与之匹配的最精确媒体范围的 q 值（type/subtype 优先于 type/*，再优先于 */*），
+// 选出权重最高且大于 0 的 offer，权重相同时按 offers 的顺序。媒体范围中 q 以外的参数不参与匹配。
+// accept 为空时返回 offers[0]；没有可接受的 offer 时返回空字符串。
+func NegotiateMediaType(accept string, offers []string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		offerType, offerSubtype, ok := strings.Cut(strings.ToLower(offer), "/")
+		if !ok {
+			continue
+		}
+		q, matched := 0.0, -1
+		for _, r := range ranges {
+			if s := r.specificity(offerType, offerSubtype); s > matched {
+				matched, q = s, r.q
+			}
+		}
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+// NegotiateRequestMediaType 与 NegotiateMediaType 相同，但 accept 为空时沿用请求 contentType 的媒体类型：
+// 该类型属于 offers 时以其响应，否则返回 offers[0]。contentType 的参数不参与匹配。
+func NegotiateRequestMediaType(accept, contentType string, offers []string) string {
+	if strings.TrimSpace(accept) == "" {
+		mediaType, _, _ := strings.Cut(contentType, ";")
+		mediaType = strings.TrimSpace(mediaType)
+		for _, offer := range offers {
+			if strings.EqualFold(offer, mediaType) {
+				return offer
+			}
+		}
+	}
+	return NegotiateMediaType(accept, offers)
+}
+
+// parseAccept 解析 Accept 头，跳过格式错误的项；单独的 * 视为 */*
+func parseAccept(accept string) []mediaRange {
+	ranges := make([]mediaRange, 0, strings.Count(accept, ",")+1)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		if mediaType == "*" {
+			mediaType = "*/*"
+		}
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok || typ == "" || subtype == "" || (typ == "*" && subtype != "*") {
+			continue
+		}
+		r := mediaRange{typ: typ, subtype: subtype, q: 1}
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "q") {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && parsed >= 0 && parsed <= 1 {
+				r.q = parsed
+			}
+			break
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
@@ -0,0 +1,54 @@
+package response
+
+import "testing"
+
+func TestNegotiateMediaType_WeightsSpecificityAndWildcards(t *testing.T) {
+	offers := []string{MIMEApplicationJSON, "application/msgpack", MIMEApplicationXML}
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", MIMEApplicationJSON},
+		{"*/*", MIMEApplicationJSON},
+		{"*", MIMEApplicationJSON},
+		{"application/xml", MIMEApplicationXML},
+		{"APPLICATION/XML", MIMEApplicationXML},
+		{"application/json;q=0.2, application/msgpack;q=0.9", "application/msgpack"},
+		{"text/html, application/*;q=0.5", MIMEApplicationJSON},
+		{"application/*;q=0.5, application/json;q=0", "application/msgpack"},
+		{"*/*;q=0.1, application/xml;q=0.3", MIMEApplicationXML},
+		{"application/msgpack;charset=x;q=0.4, application/xml;q=0.4", "application/msgpack"},
+		{"text/html, image/png", ""},
+		{"application/json;q=0", ""},
+		{"invalid, */json, application/xml;q=abc", MIMEApplicationXML},
+	}
+	for _, test := range tests {
+		if got := NegotiateMediaType(test.accept, offers); got != test.want {
+			t.Errorf("NegotiateMediaType(%q) = %q, want %q", test.accept, got, test.want)
+		}
+	}
+	if got := NegotiateMediaType("*/*", nil); got != "" {
+		t.Errorf("NegotiateMediaType without offers = %q, want empty", got)
+	}
+}
+
+func TestNegotiateRequestMediaType_FallsBackToContentType(t *testing.T) {
+	offers := []string{MIMEApplicationJSON, "application/msgpack", MIMEApplicationXML}
+	tests := []struct {
+		accept      string
+		contentType string
+		want        string
+	}{
+		{"", "application/msgpack", "application/msgpack"},
+		{"", "Application/XML; charset=utf-8", MIMEApplicationXML},
+		{"", "application/x-www-form-urlencoded", MIMEApplicationJSON},
+		{"", "", MIMEApplicationJSON},
+		{"application/xml", "application/msgpack", MIMEApplicationXML},
+		{"text/html", "application/msgpack", ""},
+	}
+	for _, test := range tests {
+		if got := NegotiateRequestMediaType(test.accept, test.contentType, offers); got != test.want {
+			t.Errorf("NegotiateRequestMediaType(%q, %q) = %q, want %q", test.accept, test.contentType, got, test.want)
+		}
+	}
+}
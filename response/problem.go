@@ -0,0 +1,60 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package response
+
+import "encoding/json"
+
+// ProblemTypeBlank 未声明问题类型时使用的 type 成员
+const ProblemTypeBlank = "about:blank"
+
+// ProblemDetails RFC 7807（RFC 9457）问题详情，以 application/problem+json 响应
+type ProblemDetails struct {
+	// Type 问题类型 URI，为空时编码为 about:blank
+	Type string
+	// Title 问题类型的简短描述
+	Title string
+	// Status HTTP 状态码，为 0 时省略
+	Status int
+	// Detail 本次问题的说明，为空时省略
+	Detail string
+	// Instance 标识本次问题的 URI 引用，为空时省略
+	Instance string
+	// Extensions 扩展成员，与标准成员同名的键被忽略
+	Extensions map[string]interface{}
+}
+
+// ProblemFieldError problem+json 中 errors 扩展成员的单个字段错误
+type ProblemFieldError struct {
+	Field  string `json:"field"`
+	Detail string `json:"detail"`
+}
+
+// MarshalJSON 把标准成员与扩展成员编码为同一层级的 JSON 对象
+func (p *ProblemDetails) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+	members["type"] = p.Type
+	if p.Type == "" {
+		members["type"] = ProblemTypeBlank
+	}
+	members["title"] = p.Title
+	delete(members, "status")
+	if p.Status != 0 {
+		members["status"] = p.Status
+	}
+	delete(members, "detail")
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	delete(members, "instance")
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+	return json.Marshal(members)
+}
@@ -0,0 +1,378 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package response
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	hertzjson "github.com/cloudwego/hertz/pkg/common/json"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/gin-gonic/gin"
+	ginJson "github.com/gin-gonic/gin/codec/json"
+	"github.com/gofiber/fiber/v2"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"go.yaml.in/yaml/v3"
+)
+
+// jsonEncodeFunc JSON 编码函数，签名同 json.Marshal
+type jsonEncodeFunc func(v interface{}) ([]byte, error)
+
+// encodedFormat 通用信封响应的编码格式，每种格式使用独立的对象池，池中实例的格式固定不变
+//
+// marshal 的 encodeJSON 为请求所在核心的 JSON 编码器，XML、YAML 先用它把 data 转为通用值，见 genericData
+type encodedFormat struct {
+	contentType string
+	marshal     func(ri *RespInfo, encodeJSON jsonEncodeFunc) ([]byte, error)
+	pool        sync.Pool
+}
+
+var (
+	// cborFormat CBOR 编码，信封为 code、msg、data 三个键的 map，data 为 nil 时省略
+	cborFormat = newEncodedFormat(MIMEApplicationCBOR, func(ri *RespInfo, _ jsonEncodeFunc) ([]byte, error) {
+		return cbor.Marshal(envelopeMap(ri))
+	})
+	// yamlFormat YAML 编码，信封同 cborFormat，data 的字段名与 JSON 响应一致
+	yamlFormat = newEncodedFormat(MIMEApplicationYAML+"; charset=utf-8", func(ri *RespInfo, encodeJSON jsonEncodeFunc) ([]byte, error) {
+		envelope := envelopeMap(ri)
+		if ri.Data != nil {
+			data, err := genericData(ri.Data, encodeJSON)
+			if err != nil {
+				return nil, err
+			}
+			envelope["data"] = data
+		}
+		return yaml.Marshal(envelope)
+	})
+	// xmlFormat XML 编码，根元素为 response
+	xmlFormat = newEncodedFormat(MIMEApplicationXML+"; charset=utf-8", marshalXMLEnvelope)
+)
+
+func newEncodedFormat(contentType string, marshal func(ri *RespInfo, encodeJSON jsonEncodeFunc) ([]byte, error)) *encodedFormat {
+	format := &encodedFormat{contentType: contentType, marshal: marshal}
+	format.pool.New = func() interface{} {
+		return &RespInfoEncoded{
+			ri:     &RespInfo{},
+			format: format,
+		}
+	}
+	return format
+}
+
+// RespInfoEncoded 以 CBOR、XML 或 YAML 编码信封的响应实现
+type RespInfoEncoded struct {
+	ri     *RespInfo
+	format *encodedFormat
+}
+
+// GetRespInfoCBOR 从对象池创建 CBOR 响应实例
+func GetRespInfoCBOR() IResponse {
+	return cborFormat.pool.Get().(*RespInfoEncoded)
+}
+
+// GetRespInfoXML 从对象池创建 XML 响应实例
+func GetRespInfoXML() IResponse {
+	return xmlFormat.pool.Get().(*RespInfoEncoded)
+}
+
+// GetRespInfoYAML 从对象池创建 YAML 响应实例
+func GetRespInfoYAML() IResponse {
+	return yamlFormat.pool.Get().(*RespInfoEncoded)
+}
+
+// GetCode 获取响应码
+func (r *RespInfoEncoded) GetCode() int {
+	return r.ri.Code
+}
+
+// GetMsg 获取响应消息
+func (r *RespInfoEncoded) GetMsg() string {
+	return r.ri.Msg
+}
+
+// GetData 获取响应数据
+func (r *RespInfoEncoded) GetData() interface{} {
+	return r.ri.Data
+}
+
+// SendWithCtx 以实例的编码格式发送响应，并设置对应的 Content-Type
+func (r *RespInfoEncoded) SendWithCtx(c adaptorctx.ICoreContext, status ...int) error {
+	defer r.Release()
+	statusCode := http.StatusOK
+	if len(status) > 0 {
+		statusCode = status[0]
+	}
+
+	data, err := r.format.marshal(r.ri, trafficJSONEncoder(c))
+	if err != nil {
+		return err
+	}
+
+	c.SetHeader("Content-Type", r.format.contentType)
+	return c.Send(statusCode, data)
+}
+
+// JsonWithCtx 使用JSON格式发送响应
+func (r *RespInfoEncoded) JsonWithCtx(c adaptorctx.ICoreContext, status ...int) error {
+	defer r.Release()
+	statusCode := http.StatusOK
+	if len(status) > 0 {
+		statusCode = status[0]
+	}
+	return c.JSON(statusCode, envelopeMap(r.ri))
+}
+
+// Reset 重置响应内容
+func (r *RespInfoEncoded) Reset(code int, msg string, data interface{}) IResponse {
+	r.ri.Code = code
+	r.ri.Msg = msg
+	r.ri.Data = data
+	return r
+}
+
+// Release 释放资源并放回对象池
+func (r *RespInfoEncoded) Release() {
+	r.ri.Code = 0
+	r.ri.Msg = ""
+	r.ri.Data = nil
+	r.format.pool.Put(r)
+}
+
+// From 从另一个IResponse复制数据
+func (r *RespInfoEncoded) From(resp IResponse, needToRelease bool) IResponse {
+	r.Reset(resp.GetCode(), resp.GetMsg(), resp.GetData())
+
+	if needToRelease {
+		resp.Release()
+	}
+	return r
+}
+
+// SuccessWithData 成功时的响应，重置data字段
+// 无参调用时同样清空 Data，避免链式复用同一对象时残留上一次的旧数据
+func (r *RespInfoEncoded) SuccessWithData(data ...interface{}) IResponse {
+	if len(data) > 0 {
+		r.ri.Data = data[0]
+	} else {
+		r.ri.Data = nil
+	}
+	return r
+}
+
+// ErrorCustom 错误时的响应，重置code、msg和data字段
+func (r *RespInfoEncoded) ErrorCustom(code int, msg string) IResponse {
+	r.ri.Code = code
+	r.ri.Msg = msg
+	r.ri.Data = nil
+	return r
+}
+
+// envelopeMap 返回 code、msg、data 信封，data 为 nil 时省略
+func envelopeMap(ri *RespInfo) map[string]interface{} {
+	envelope := map[string]interface{}{
+		"code": ri.Code,
+		"msg":  ri.Msg,
+	}
+	if ri.Data != nil {
+		envelope["data"] = ri.Data
+	}
+	return envelope
+}
+
+// trafficJSONEncoder 返回请求所在核心的 JSON 编码函数：Fiber 读取 fiber.Config.JSONEncoder，
+// Gin 使用 gin codec json.API，Hertz 使用 hertz common/json，其他核心回退到 encoding/json
+func trafficJSONEncoder(c adaptorctx.ICoreContext) jsonEncodeFunc {
+	switch native := c.GetCtx().(type) {
+	case *fiber.Ctx:
+		if encoder := native.App().Config().JSONEncoder; encoder != nil {
+			return jsonEncodeFunc(encoder)
+		}
+	case *gin.Context:
+		return ginJson.API.Marshal
+	case *app.RequestContext:
+		return hertzjson.Marshal
+	}
+	return json.Marshal
+}
+
+// genericData 以 encodeJSON 编码 data 后解码为 map、切片与标量组成的通用值
+//
+// 结构体因此按 json 标签命名字段、遵循 omitempty 与 json.Marshaler，XML、YAML 信封的键与 JSON 响应一致；
+// 整数解码为 int64（超出时为 uint64），不经 float64 损失精度。
+func genericData(data interface{}, encodeJSON jsonEncodeFunc) (interface{}, error) {
+	raw, err := encodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return convertJSONNumbers(value), nil
+}
+
+// convertJSONNumbers 把通用值中的 json.Number 转为 int64、uint64 或 float64
+func convertJSONNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = convertJSONNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertJSONNumbers(item)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if n, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	}
+	return value
+}
+
+// marshalXMLEnvelope 编码 <response><code/><msg/><data/></response>
+//
+// data 实现 xml.Marshaler 时按其自身规则编码，否则先经 genericData 转为通用值：
+// map 按键排序编码为子元素，键不是合法 XML 名称时编码为 <entry key="...">；切片与数组的元素编码为 <item>。
+func marshalXMLEnvelope(ri *RespInfo, encodeJSON jsonEncodeFunc) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	root := xml.StartElement{Name: xml.Name{Local: "response"}}
+	if err := enc.EncodeToken(root); err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeElement(ri.Code, xml.StartElement{Name: xml.Name{Local: "code"}}); err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeElement(ri.Msg, xml.StartElement{Name: xml.Name{Local: "msg"}}); err != nil {
+		return nil, err
+	}
+	if ri.Data != nil {
+		data := ri.Data
+		if _, ok := data.(xml.Marshaler); !ok {
+			var err error
+			if data, err = genericData(data, encodeJSON); err != nil {
+				return nil, err
+			}
+		}
+		if err := encodeXMLValue(enc, xml.StartElement{Name: xml.Name{Local: "data"}}, reflect.ValueOf(data)); err != nil {
+			return nil, err
+		}
+	}
+	if err := enc.EncodeToken(root.End()); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var (
+	xmlMarshalerType  = reflect.TypeOf((*xml.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// encodeXMLValue 以 start 为元素编码任意值，nil 编码为空元素
+func encodeXMLValue(enc *xml.Encoder, start xml.StartElement, v reflect.Value) error {
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr) {
+		if v.IsNil() {
+			return enc.EncodeElement("", start)
+		}
+		if v.Type().Implements(xmlMarshalerType) || v.Type().Implements(textMarshalerType) {
+			return enc.EncodeElement(v.Interface(), start)
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return enc.EncodeElement("", start)
+	}
+	if v.Type().Implements(xmlMarshalerType) || v.Type().Implements(textMarshalerType) {
+		return enc.EncodeElement(v.Interface(), start)
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		keys := v.MapKeys()
+		names := make([]string, len(keys))
+		for i, key := range keys {
+			names[i] = fmt.Sprint(key.Interface())
+		}
+		order := make([]int, len(keys))
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool { return names[order[i]] < names[order[j]] })
+
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, i := range order {
+			child := xml.StartElement{Name: xml.Name{Local: names[i]}}
+			if !isXMLName(names[i]) {
+				child = xml.StartElement{
+					Name: xml.Name{Local: "entry"},
+					Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: names[i]}},
+				}
+			}
+			if err := encodeXMLValue(enc, child, v.MapIndex(keys[i])); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return enc.EncodeElement(v.Interface(), start)
+		}
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := encodeXMLValue(enc, xml.StartElement{Name: xml.Name{Local: "item"}}, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	default:
+		return enc.EncodeElement(v.Interface(), start)
+	}
+}
+
+// isXMLName 判断 name 能否作为 XML 元素名：字母或下划线开头，其余为字母、数字、'-'、'_' 或 '.'，且不以 xml 开头
+func isXMLName(name string) bool {
+	if name == "" || len(name) >= 3 && (name[0]|0x20) == 'x' && (name[1]|0x20) == 'm' && (name[2]|0x20) == 'l' {
+		return false
+	}
+	for i, ch := range name {
+		switch {
+		case ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z':
+		case i > 0 && (ch == '-' || ch == '.' || ch >= '0' && ch <= '9'):
+		default:
+			return false
+		}
+	}
+	return true
+}
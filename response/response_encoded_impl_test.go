@@ -0,0 +1,240 @@
+package response
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+type encodedPayload struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+// encodedVO 只声明 json 标签、含 map 字段的普通响应结构体
+type encodedVO struct {
+	UserName string                 `json:"user_name"`
+	ID       uint64                 `json:"id"`
+	Labels   map[string]string      `json:"labels"`
+	Extra    map[string]interface{} `json:"extra,omitempty"`
+	Items    []encodedPayload       `json:"items"`
+	internal string
+}
+
+func TestRespInfoEncoded_RoundTripsEachFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		get         func() IResponse
+		contentType string
+		decode      func(t *testing.T, body []byte) (int, string, string)
+	}{
+		{
+			name:        "cbor",
+			get:         GetRespInfoCBOR,
+			contentType: MIMEApplicationCBOR,
+			decode: func(t *testing.T, body []byte) (int, string, string) {
+				var envelope struct {
+					Code int    `cbor:"code"`
+					Msg  string `cbor:"msg"`
+					Data struct {
+						Name string `cbor:"name"`
+					} `cbor:"data"`
+				}
+				if err := cbor.Unmarshal(body, &envelope); err != nil {
+					t.Fatalf("cbor.Unmarshal() error = %v", err)
+				}
+				return envelope.Code, envelope.Msg, envelope.Data.Name
+			},
+		},
+		{
+			name:        "yaml",
+			get:         GetRespInfoYAML,
+			contentType: MIMEApplicationYAML,
+			decode: func(t *testing.T, body []byte) (int, string, string) {
+				var envelope struct {
+					Code int    `yaml:"code"`
+					Msg  string `yaml:"msg"`
+					Data struct {
+						Name string `yaml:"name"`
+					} `yaml:"data"`
+				}
+				if err := yaml.Unmarshal(body, &envelope); err != nil {
+					t.Fatalf("yaml.Unmarshal() error = %v", err)
+				}
+				return envelope.Code, envelope.Msg, envelope.Data.Name
+			},
+		},
+		{
+			name:        "xml",
+			get:         GetRespInfoXML,
+			contentType: MIMEApplicationXML,
+			decode: func(t *testing.T, body []byte) (int, string, string) {
+				var envelope struct {
+					Code int    `xml:"code"`
+					Msg  string `xml:"msg"`
+					Data struct {
+						Name string `xml:"name"`
+					} `xml:"data"`
+				}
+				if err := xml.Unmarshal(body, &envelope); err != nil {
+					t.Fatalf("xml.Unmarshal() error = %v", err)
+				}
+				return envelope.Code, envelope.Msg, envelope.Data.Name
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := &responseContextRecorder{}
+			response := test.get().(*RespInfoEncoded)
+			response.Reset(9, "encoded", encodedPayload{Name: "Ada", Age: 36})
+			if err := response.SendWithCtx(recorder, http.StatusCreated); err != nil {
+				t.Fatalf("SendWithCtx() error = %v", err)
+			}
+			if recorder.status != http.StatusCreated {
+				t.Fatalf("SendWithCtx() status = %d", recorder.status)
+			}
+			if !strings.HasPrefix(recorder.headers["Content-Type"], test.contentType) {
+				t.Fatalf("Content-Type = %q, want %q", recorder.headers["Content-Type"], test.contentType)
+			}
+			code, msg, name := test.decode(t, recorder.body)
+			if code != 9 || msg != "encoded" || name != "Ada" {
+				t.Fatalf("decoded envelope = %d %q %q", code, msg, name)
+			}
+			if response.GetCode() != 0 || response.GetMsg() != "" || response.GetData() != nil {
+				t.Fatal("SendWithCtx() did not release response")
+			}
+			if response.format == nil || !strings.HasPrefix(response.format.contentType, test.contentType) {
+				t.Fatal("released response must keep its format for pool reuse")
+			}
+		})
+	}
+}
+
+func TestRespInfoEncoded_XMLEncodesMapsSlicesAndInvalidNames(t *testing.T) {
+	stamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	body, err := marshalXMLEnvelope(&RespInfo{Code: 1, Msg: "x<y", Data: map[string]interface{}{
+		"list":   []interface{}{1, "two", nil},
+		"1bad":   "entry",
+		"nested": map[int]bool{2: true},
+		"when":   stamp,
+		"ptr":    &encodedPayload{Name: "p"},
+	}}, json.Marshal)
+	if err != nil {
+		t.Fatalf("marshalXMLEnvelope() error = %v", err)
+	}
+	want := `<response><code>1</code><msg>x&lt;y</msg><data>` +
+		`<entry key="1bad">entry</entry>` +
+		`<list><item>1</item><item>two</item><item></item></list>` +
+		`<nested><entry key="2">true</entry></nested>` +
+		`<ptr><age>0</age><name>p</name></ptr>` +
+		`<when>2025-01-02T03:04:05Z</when>` +
+		`</data></response>`
+	if got := strings.TrimPrefix(string(body), xml.Header); got != want {
+		t.Fatalf("XML =\n%s\nwant\n%s", got, want)
+	}
+
+	withoutData, err := marshalXMLEnvelope(&RespInfo{Code: 2, Msg: "none"}, json.Marshal)
+	if err != nil || strings.Contains(string(withoutData), "<data>") {
+		t.Fatalf("nil data must be omitted: %s, %v", withoutData, err)
+	}
+}
+
+// dataKeyPaths 返回通用值中全部 map 键的路径，切片元素记为 []
+func dataKeyPaths(prefix string, value interface{}, out map[string]bool) map[string]bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			out[prefix+"/"+key] = true
+			dataKeyPaths(prefix+"/"+key, item, out)
+		}
+	case []interface{}:
+		for _, item := range v {
+			dataKeyPaths(prefix+"/[]", item, out)
+		}
+	}
+	return out
+}
+
+func TestRespInfoEncoded_StructKeysMatchJSONAcrossFormats(t *testing.T) {
+	vo := encodedVO{
+		UserName: "ada",
+		ID:       1<<63 + 1,
+		Items:    []encodedPayload{{Name: "book", Age: 2}},
+		internal: "hidden",
+	}
+	jsonBody, err := json.Marshal(vo)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var jsonData interface{}
+	if err := json.Unmarshal(jsonBody, &jsonData); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	want := dataKeyPaths("", jsonData, map[string]bool{})
+
+	send := func(get func() IResponse) []byte {
+		recorder := &responseContextRecorder{}
+		if err := get().Reset(0, "ok", vo).SendWithCtx(recorder); err != nil {
+			t.Fatalf("SendWithCtx() error = %v", err)
+		}
+		return recorder.body
+	}
+
+	var yamlEnvelope struct {
+		Data map[string]interface{} `yaml:"data"`
+	}
+	yamlBody := send(GetRespInfoYAML)
+	if err := yaml.Unmarshal(yamlBody, &yamlEnvelope); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	if got := dataKeyPaths("", yamlEnvelope.Data, map[string]bool{}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("YAML keys = %v, want JSON keys %v", got, want)
+	}
+	if !strings.Contains(string(yamlBody), "id: 9223372036854775809") {
+		t.Fatalf("YAML lost integer precision:\n%s", yamlBody)
+	}
+
+	xmlBody := send(GetRespInfoXML)
+	wantXML := `<response><code>0</code><msg>ok</msg><data>` +
+		`<id>9223372036854775809</id>` +
+		`<items><item><age>2</age><name>book</name></item></items>` +
+		`<labels></labels>` +
+		`<user_name>ada</user_name>` +
+		`</data></response>`
+	if got := strings.TrimPrefix(string(xmlBody), xml.Header); got != wantXML {
+		t.Fatalf("XML =\n%s\nwant\n%s", got, wantXML)
+	}
+}
+
+func TestRespInfoEncoded_JsonAndLifecycle(t *testing.T) {
+	recorder := &responseContextRecorder{}
+	response := GetRespInfoYAML()
+	response.SuccessWithData("first")
+	response.From(NewRespInfoWithoutPool(3, "copied", "second"), false)
+	if response.GetCode() != 3 || response.GetMsg() != "copied" || response.GetData() != "second" {
+		t.Fatalf("From() = %d %q %v", response.GetCode(), response.GetMsg(), response.GetData())
+	}
+	response.ErrorCustom(4, "failed")
+	if response.GetData() != nil {
+		t.Fatal("ErrorCustom() must clear data")
+	}
+	if err := response.JsonWithCtx(recorder); err != nil {
+		t.Fatalf("JsonWithCtx() error = %v", err)
+	}
+	envelope, ok := recorder.jsonValue.(map[string]interface{})
+	if !ok || envelope["code"] != 4 || envelope["msg"] != "failed" {
+		t.Fatalf("JsonWithCtx() value = %#v", recorder.jsonValue)
+	}
+	if _, hasData := envelope["data"]; hasData {
+		t.Fatal("nil data must be omitted from the JSON envelope")
+	}
+}
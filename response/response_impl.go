@@ -32,6 +32,18 @@ type RespInfo struct {
 	Code int         `json:"code"`
 	Msg  string      `json:"msg"`
 	Data interface{} `json:"data"`
+	// Status 作为异常定义时的 HTTP 状态码，0 表示由错误处理器决定；不参与响应信封的编码
+	Status int `json:"-"`
+	// Type 作为异常定义时的 RFC 7807 问题类型 URI；不参与响应信封的编码
+	Type string `json:"-"`
+	// Key 作为异常时在 ExceptionMap 中的键，用于 errors.Is 匹配；不参与响应信封的编码
+	Key string `json:"-"`
+	// Cause 作为异常时包装的原始错误；不参与响应信封的编码
+	Cause error `json:"-"`
+	// Messages 作为异常定义时按语言标签声明的本地化消息模板，未匹配请求语言时使用 Msg；不参与响应信封的编码
+	Messages map[string]string `json:"-"`
+	// Args 作为异常时插值消息模板 {name} 占位符的参数；不参与响应信封的编码
+	Args map[string]interface{} `json:"-"`
 }
 
 // NewRespInfo 创建新的 RespInfo 实例（使用对象池）
@@ -82,6 +94,12 @@ func (r *RespInfo) Release() {
 	r.Code = 0
 	r.Msg = ""
 	r.Data = nil
+	r.Status = 0
+	r.Type = ""
+	r.Key = ""
+	r.Cause = nil
+	r.Messages = nil
+	r.Args = nil
 
 	respPool.Put(r)
 }
@@ -1,23 +1,35 @@
 package response
 
 import (
+	"crypto/x509"
 	"errors"
 	"net/http"
 	"testing"
 
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/lamxy/fiberhouse/globalmanager"
 	"github.com/vmihailenco/msgpack/v5"
 )
 
 type responseContextRecorder struct {
+	adaptorctx.ICoreContext
 	status    int
 	body      []byte
 	jsonValue interface{}
+	headers   map[string]string
 	err       error
 }
 
-func (c *responseContextRecorder) GetCtx() interface{}      { return nil }
-func (c *responseContextRecorder) GetHeader(string) string  { return "" }
-func (c *responseContextRecorder) SetHeader(string, string) {}
+func (c *responseContextRecorder) GetCtx() interface{}                  { return nil }
+func (c *responseContextRecorder) Scope() *globalmanager.GlobalManager  { return nil }
+func (c *responseContextRecorder) ClientCertificate() *x509.Certificate { return nil }
+func (c *responseContextRecorder) GetHeader(string) string              { return "" }
+func (c *responseContextRecorder) SetHeader(key, value string) {
+	if c.headers == nil {
+		c.headers = map[string]string{}
+	}
+	c.headers[key] = value
+}
 func (c *responseContextRecorder) Send(status int, body []byte) error {
 	c.status = status
 	c.body = append([]byte(nil), body...)
@@ -7,6 +7,7 @@
 package fiberhouse
 
 import (
+	"net/http"
 	"strings"
 	"sync"
 
@@ -66,36 +67,51 @@ func Response() *ResponseWrap {
 	return r
 }
 
-// SendWithCtx 使用核心上下文接口响应 JSON 数据
+// SendWithCtx 按请求 Accept 协商响应格式后发送
+//
+// 候选格式为 JSON 与（开启二进制协议支持时）RespInfoPManager 中注册的各媒体类型，JSON 始终优先；
+// 协商规则见 response.NegotiateMediaType。未携带 Accept 时沿用请求 Content-Type 属于候选的格式，见 response.NegotiateRequestMediaType。
+// 存在多个候选或开启严格协商时设置 Vary: Accept，未携带 Accept 时再追加 Content-Type。
+// 没有可接受的格式时，严格协商返回 406 Not Acceptable，否则回退 JSON；所选格式的提供者加载失败时同样回退 JSON。
 func (r *ResponseWrap) SendWithCtx(c adaptorctx.ICoreContext, status ...int) error {
 	defer r.Release()
 
 	// 获取响应信息提供者管理器单例
 	m := NewRespInfoPManagerOnce()
-	// 开启了二进制协议支持,则尝试使用对应的协议进行响应
-	support := m.GetContext().(IApplicationContext).GetBootConfig().EnableBinaryProtocolSupport
-	if support {
-		// 获取Content-Type头部
-		ct := c.GetHeader("Content-Type")
-		if ct == "" {
-			ct = c.GetHeader("Accept")
+	bootCfg := m.GetContext().(IApplicationContext).GetBootConfig()
+	offers := []string{response.MIMEApplicationJSON}
+	if bootCfg.EnableBinaryProtocolSupport {
+		offers = append(offers, m.MediaTypes()...)
+	}
+	if len(offers) == 1 && !bootCfg.StrictContentNegotiation {
+		return r.IResponse.JsonWithCtx(c, status...)
+	}
+
+	accept := c.GetHeader("Accept")
+	if strings.TrimSpace(accept) == "" {
+		c.Vary("Accept", "Content-Type")
+	} else {
+		c.Vary("Accept")
+	}
+	ct := response.NegotiateRequestMediaType(accept, c.GetHeader("Content-Type"), offers)
+	if ct == "" {
+		if bootCfg.StrictContentNegotiation {
+			r.IResponse.Release()
+			c.SetHeader("Content-Type", "text/plain; charset=utf-8")
+			return c.Send(http.StatusNotAcceptable, []byte("Not Acceptable; available: "+strings.Join(offers, ", ")))
 		}
-		if ct != "" {
-			// 处理多个 Accept 值和权重,提取主要的 MIME 类型
-			ct = extractPrimaryMimeType(ct)
-			if ct == "application/json" || ct == "application/*" || ct == "*/*" {
-				return r.IResponse.JsonWithCtx(c, status...)
-			}
-			// 获取指定协议的响应信息提供者
-			p, err := m.GetProvider(ct)
-			if err == nil && p != nil {
-				rpb, err := m.InitializeProvider(p)
-				if err == nil && rpb != nil {
-					if resp, ok := rpb.(response.IResponse); ok {
-						// 设置响应内容类型
-						c.SetHeader("Content-Type", ct)
-						return resp.From(r.IResponse, true).SendWithCtx(c, status...)
-					}
+		ct = response.MIMEApplicationJSON
+	}
+	if ct != response.MIMEApplicationJSON {
+		// 获取指定协议的响应信息提供者
+		p, err := m.GetProvider(ct)
+		if err == nil && p != nil {
+			rpb, err := m.InitializeProvider(p)
+			if err == nil && rpb != nil {
+				if resp, ok := rpb.(response.IResponse); ok {
+					// 设置响应内容类型
+					c.SetHeader("Content-Type", ct)
+					return resp.From(r.IResponse, true).SendWithCtx(c, status...)
 				}
 			}
 		}
@@ -148,20 +164,6 @@ func (r *ResponseWrap) From(resp response.IResponse, needToRelease bool) respons
 	return r
 }
 
-// extractPrimaryMimeType 从 Accept 头部提取主要的 MIME 类型
-// 例如: "application/json;q=0.9, text/html" -> "application/json"
-func extractPrimaryMimeType(accept string) string {
-	// 取第一个值(通常是优先级最高的)
-	if idx := strings.IndexByte(accept, ','); idx > 0 {
-		accept = accept[:idx]
-	}
-	// 移除参数(如 charset, q值)
-	if idx := strings.IndexByte(accept, ';'); idx > 0 {
-		accept = accept[:idx]
-	}
-	return strings.TrimSpace(accept)
-}
-
 // -----------------------------------------------------------------------------------------------------------------
 
 // Exception 获取异常响应对象
@@ -188,3 +190,18 @@ func RespProto() response.IResponse {
 func RespMsgpack() response.IResponse {
 	return response.GetRespInfoMsgPack()
 }
+
+// RespCBOR 获取 CBOR 响应对象
+func RespCBOR() response.IResponse {
+	return response.GetRespInfoCBOR()
+}
+
+// RespXML 获取 XML 响应对象
+func RespXML() response.IResponse {
+	return response.GetRespInfoXML()
+}
+
+// RespYAML 获取 YAML 响应对象
+func RespYAML() response.IResponse {
+	return response.GetRespInfoYAML()
+}
@@ -2,11 +2,13 @@ package fiberhouse
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/fxamacker/cbor/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/gofiber/fiber/v2"
 	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
@@ -14,12 +16,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vmihailenco/msgpack/v5"
+	"go.yaml.in/yaml/v3"
 	"google.golang.org/protobuf/proto"
 )
 
 type task5HTTPResponse struct {
 	status      int
 	contentType string
+	vary        string
 	body        []byte
 }
 
@@ -44,7 +48,7 @@ func runTask5ResponseRequest(t *testing.T, core, requestContentType, accept stri
 		defer response.Body.Close()
 		body, err := io.ReadAll(response.Body)
 		require.NoError(t, err)
-		return task5HTTPResponse{status: response.StatusCode, contentType: response.Header.Get("Content-Type"), body: body}
+		return task5HTTPResponse{status: response.StatusCode, contentType: response.Header.Get("Content-Type"), vary: response.Header.Get("Vary"), body: body}
 	case "gin":
 		preserveTask4GinMode(t)
 		gin.SetMode(gin.TestMode)
@@ -54,28 +58,13 @@ func runTask5ResponseRequest(t *testing.T, core, requestContentType, accept stri
 		})
 		recorder := httptest.NewRecorder()
 		engine.ServeHTTP(recorder, request)
-		return task5HTTPResponse{status: recorder.Code, contentType: recorder.Header().Get("Content-Type"), body: recorder.Body.Bytes()}
+		return task5HTTPResponse{status: recorder.Code, contentType: recorder.Header().Get("Content-Type"), vary: recorder.Header().Get("Vary"), body: recorder.Body.Bytes()}
 	default:
 		t.Fatalf("unknown core %q", core)
 		return task5HTTPResponse{}
 	}
 }
 
-func TestExtractPrimaryMimeType_FirstValueContract(t *testing.T) {
-	for _, testCase := range []struct {
-		accept string
-		want   string
-	}{
-		{"", ""},
-		{"application/json", "application/json"},
-		{" application/msgpack; q=0.4, application/json; q=1.0", "application/msgpack"},
-		{"*/*;q=0.8", "*/*"},
-		{",application/json", ",application/json"},
-	} {
-		assert.Equal(t, testCase.want, extractPrimaryMimeType(testCase.accept))
-	}
-}
-
 func TestResponseFacade_JSONFallbackBinaryDisabledAndCustomStatus(t *testing.T) {
 	ctx := newTask5AppContext(t, false, false)
 	installTask5ResponseManager(t, ctx)
@@ -93,6 +82,7 @@ func TestResponseFacade_JSONFallbackBinaryDisabledAndCustomStatus(t *testing.T)
 			assert.Equal(t, http.StatusCreated, result.status)
 			assert.Contains(t, result.contentType, "application/json")
 			assert.JSONEq(t, `{"code":701,"msg":"json fallback","data":{"core":"`+core+`"}}`, string(result.body))
+			assert.Empty(t, result.vary, "a single JSON offer does not vary by Accept")
 			assert.Nil(t, wrapper.IResponse, "wrapper must release its request-scoped response exactly once")
 			assert.Zero(t, inner.GetCode(), "inner response must be reset when ownership is released")
 		})
@@ -109,12 +99,13 @@ func TestResponseFacade_BinaryNegotiationAndUnknownMimeFallback(t *testing.T) {
 		contentType        string
 		accept             string
 		wantResponseType   string
+		wantVary           string
 		assertBodyContract func(*testing.T, []byte)
 	}{
 		{
-			name:             "fiber msgpack from first Accept value",
+			name:             "fiber msgpack by q-value",
 			core:             "fiber",
-			accept:           "application/msgpack;q=0.1, application/json;q=1.0",
+			accept:           "application/json;q=0.2, application/msgpack;q=0.9",
 			wantResponseType: "application/msgpack",
 			assertBodyContract: func(t *testing.T, body []byte) {
 				var envelope map[string]interface{}
@@ -124,10 +115,10 @@ func TestResponseFacade_BinaryNegotiationAndUnknownMimeFallback(t *testing.T) {
 			},
 		},
 		{
-			name:             "gin protobuf selected by Content-Type before Accept",
+			name:             "gin protobuf selected by Accept regardless of Content-Type",
 			core:             "gin",
-			contentType:      "application/x-protobuf",
-			accept:           "application/msgpack",
+			contentType:      "application/msgpack",
+			accept:           "text/html, application/x-protobuf;q=0.8",
 			wantResponseType: "application/x-protobuf",
 			assertBodyContract: func(t *testing.T, body []byte) {
 				envelope := &responsepb.RespInfoProto{}
@@ -136,6 +127,30 @@ func TestResponseFacade_BinaryNegotiationAndUnknownMimeFallback(t *testing.T) {
 				assert.Equal(t, "binary", envelope.Msg)
 			},
 		},
+		{
+			name:             "fiber msgpack by Content-Type without Accept",
+			core:             "fiber",
+			contentType:      "application/msgpack",
+			wantResponseType: "application/msgpack",
+			wantVary:         "Accept, Content-Type",
+			assertBodyContract: func(t *testing.T, body []byte) {
+				var envelope map[string]interface{}
+				require.NoError(t, msgpack.Unmarshal(body, &envelope))
+				assert.EqualValues(t, 702, envelope["code"])
+			},
+		},
+		{
+			name:             "gin unsupported Content-Type without Accept remains JSON",
+			core:             "gin",
+			contentType:      "application/x-www-form-urlencoded",
+			wantResponseType: "application/json",
+			wantVary:         "Accept, Content-Type",
+			assertBodyContract: func(t *testing.T, body []byte) {
+				var envelope map[string]interface{}
+				require.NoError(t, json.Unmarshal(body, &envelope))
+				assert.EqualValues(t, 702, envelope["code"])
+			},
+		},
 		{
 			name:             "unknown mime falls back to JSON",
 			core:             "fiber",
@@ -167,7 +182,114 @@ func TestResponseFacade_BinaryNegotiationAndUnknownMimeFallback(t *testing.T) {
 			})
 			assert.Equal(t, http.StatusAccepted, result.status)
 			assert.Contains(t, result.contentType, testCase.wantResponseType)
+			wantVary := testCase.wantVary
+			if wantVary == "" {
+				wantVary = "Accept"
+			}
+			assert.Equal(t, wantVary, result.vary)
 			testCase.assertBodyContract(t, result.body)
 		})
 	}
 }
+
+func TestResponseFacade_CBORXMLYAMLEncodings(t *testing.T) {
+	ctx := newTask5AppContext(t, false, true)
+	installTask5ResponseManager(t, ctx)
+
+	tests := []struct {
+		accept             string
+		wantResponseType   string
+		assertBodyContract func(*testing.T, []byte)
+	}{
+		{
+			accept:           "application/cbor",
+			wantResponseType: "application/cbor",
+			assertBodyContract: func(t *testing.T, body []byte) {
+				var envelope map[string]interface{}
+				require.NoError(t, cbor.Unmarshal(body, &envelope))
+				assert.EqualValues(t, 703, envelope["code"])
+				assert.Equal(t, map[interface{}]interface{}{"name": "Ada", "tags": []interface{}{"a", "b"}}, envelope["data"])
+			},
+		},
+		{
+			accept:           "text/*;q=0.1, application/xml",
+			wantResponseType: "application/xml",
+			assertBodyContract: func(t *testing.T, body []byte) {
+				var envelope struct {
+					XMLName xml.Name `xml:"response"`
+					Code    int      `xml:"code"`
+					Msg     string   `xml:"msg"`
+					Name    string   `xml:"data>name"`
+					Tags    []string `xml:"data>tags>item"`
+				}
+				require.NoError(t, xml.Unmarshal(body, &envelope))
+				assert.Equal(t, 703, envelope.Code)
+				assert.Equal(t, "encoded", envelope.Msg)
+				assert.Equal(t, "Ada", envelope.Name)
+				assert.Equal(t, []string{"a", "b"}, envelope.Tags)
+			},
+		},
+		{
+			accept:           "application/yaml",
+			wantResponseType: "application/yaml",
+			assertBodyContract: func(t *testing.T, body []byte) {
+				var envelope map[string]interface{}
+				require.NoError(t, yaml.Unmarshal(body, &envelope))
+				assert.Equal(t, 703, envelope["code"])
+				assert.Equal(t, map[string]interface{}{"name": "Ada", "tags": []interface{}{"a", "b"}}, envelope["data"])
+			},
+		},
+	}
+
+	for _, core := range []string{"fiber", "gin"} {
+		for _, testCase := range tests {
+			t.Run(core+" "+testCase.wantResponseType, func(t *testing.T) {
+				result := runTask5ResponseRequest(t, core, "", testCase.accept, func(c adaptorctx.ICoreContext) error {
+					data := map[string]interface{}{"name": "Ada", "tags": []string{"a", "b"}}
+					return Response().Reset(703, "encoded", data).SendWithCtx(c, http.StatusOK)
+				})
+				assert.Equal(t, http.StatusOK, result.status)
+				assert.Contains(t, result.contentType, testCase.wantResponseType)
+				assert.Equal(t, "Accept", result.vary)
+				testCase.assertBodyContract(t, result.body)
+			})
+		}
+	}
+}
+
+func TestResponseFacade_StrictNegotiationReturnsNotAcceptable(t *testing.T) {
+	for _, binarySupport := range []bool{true, false} {
+		ctx := newTask5AppContext(t, false, binarySupport)
+		ctx.GetBootConfig().StrictContentNegotiation = true
+		installTask5ResponseManager(t, ctx)
+
+		send := func(c adaptorctx.ICoreContext) error {
+			return Response().Reset(704, "strict", nil).SendWithCtx(c, http.StatusOK)
+		}
+
+		result := runTask5ResponseRequest(t, "gin", "", "text/html, application/json;q=0", send)
+		assert.Equal(t, http.StatusNotAcceptable, result.status)
+		assert.Equal(t, "Accept", result.vary)
+		assert.Contains(t, string(result.body), "application/json")
+
+		result = runTask5ResponseRequest(t, "fiber", "", "application/msgpack", send)
+		if binarySupport {
+			assert.Equal(t, http.StatusOK, result.status)
+			assert.Contains(t, result.contentType, "application/msgpack")
+		} else {
+			assert.Equal(t, http.StatusNotAcceptable, result.status, "binary formats are not offered while binary support is off")
+		}
+
+		result = runTask5ResponseRequest(t, "fiber", "", "", send)
+		assert.Equal(t, http.StatusOK, result.status)
+		assert.Contains(t, result.contentType, "application/json", "a missing Accept accepts JSON")
+	}
+}
+
+func TestRespInfoPManager_MediaTypesIncludeDefaultEncodings(t *testing.T) {
+	ctx := newTask5AppContext(t, false, true)
+	manager := installTask5ResponseManager(t, ctx)
+	assert.Equal(t, []string{
+		"application/cbor", "application/msgpack", "application/x-protobuf", "application/xml", "application/yaml",
+	}, manager.MediaTypes())
+}
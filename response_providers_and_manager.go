@@ -9,6 +9,7 @@ package fiberhouse
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/lamxy/fiberhouse/response"
 )
@@ -51,6 +52,60 @@ func (p *RespInfoMsgpackProvider) Initialize(ctx IContext, initFunc ...ProviderI
 	return response.GetRespInfoMsgPack(), nil
 }
 
+// RespInfoCBORProvider 响应信息 CBOR 提供者
+type RespInfoCBORProvider struct {
+	IProvider
+}
+
+func NewRespInfoCBORProvider() *RespInfoCBORProvider {
+	son := &RespInfoCBORProvider{
+		IProvider: NewProvider().SetName(response.MIMEApplicationCBOR).SetType(ProviderTypeDefault().GroupResponseInfoChoose),
+	}
+	son.MountToParent(son)
+	return son
+}
+
+// Initialize 初始化
+func (p *RespInfoCBORProvider) Initialize(ctx IContext, initFunc ...ProviderInitFunc) (any, error) {
+	return response.GetRespInfoCBOR(), nil
+}
+
+// RespInfoXMLProvider 响应信息 XML 提供者
+type RespInfoXMLProvider struct {
+	IProvider
+}
+
+func NewRespInfoXMLProvider() *RespInfoXMLProvider {
+	son := &RespInfoXMLProvider{
+		IProvider: NewProvider().SetName(response.MIMEApplicationXML).SetType(ProviderTypeDefault().GroupResponseInfoChoose),
+	}
+	son.MountToParent(son)
+	return son
+}
+
+// Initialize 初始化
+func (p *RespInfoXMLProvider) Initialize(ctx IContext, initFunc ...ProviderInitFunc) (any, error) {
+	return response.GetRespInfoXML(), nil
+}
+
+// RespInfoYAMLProvider 响应信息 YAML 提供者
+type RespInfoYAMLProvider struct {
+	IProvider
+}
+
+func NewRespInfoYAMLProvider() *RespInfoYAMLProvider {
+	son := &RespInfoYAMLProvider{
+		IProvider: NewProvider().SetName(response.MIMEApplicationYAML).SetType(ProviderTypeDefault().GroupResponseInfoChoose),
+	}
+	son.MountToParent(son)
+	return son
+}
+
+// Initialize 初始化
+func (p *RespInfoYAMLProvider) Initialize(ctx IContext, initFunc ...ProviderInitFunc) (any, error) {
+	return response.GetRespInfoYAML(), nil
+}
+
 // PManager------------------------------------------------------------------------------------------------------------
 
 // RespInfoPManager 响应信息提供者管理器
@@ -86,3 +141,14 @@ func (m *RespInfoPManager) LoadProvider(loadFunc ...ProviderLoadFunc) (any, erro
 	}
 	return m.GetProvider(contentType)
 }
+
+// MediaTypes 返回已注册提供者的媒体类型（提供者名称），按名称排序
+func (m *RespInfoPManager) MediaTypes() []string {
+	providers := m.List()
+	mediaTypes := make([]string, 0, len(providers))
+	for _, p := range providers {
+		mediaTypes = append(mediaTypes, p.Name())
+	}
+	sort.Strings(mediaTypes)
+	return mediaTypes
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	ctxpkg "context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+)
+
+// HandlerFunc 跨核心的请求处理器与中间件
+//
+// 返回的错误交给所选核心的统一错误处理（Fiber 的 ErrorHandler，Gin/Hertz 的错误处理中间件）。
+// 中间件须调用 c.Next() 才会继续执行后续处理器，未调用时处理器链在此结束（三种核心一致）。
+type HandlerFunc func(c adaptorctx.ICoreContext) error
+
+// Router 跨核心路由注册器，把路由、分组与中间件翻译到所选核心的原生路由上
+//
+// 路径参数统一使用 :name 语法；通配符、可选参数等引擎特有语法不做转换。
+// Use 与 Group 的中间件只作用于其后注册的路由。
+type Router interface {
+	// Use 为当前路由器追加中间件
+	Use(middleware ...HandlerFunc) Router
+	// Group 以 prefix 创建子路由器，middleware 作用于该分组下的全部路由
+	Group(prefix string, middleware ...HandlerFunc) Router
+	// Handle 以指定方法注册路由，最后一个处理器之前的处理器为路由级中间件
+	Handle(method, path string, handlers ...HandlerFunc) Router
+	// Get 注册 GET 路由
+	Get(path string, handlers ...HandlerFunc) Router
+	// Post 注册 POST 路由
+	Post(path string, handlers ...HandlerFunc) Router
+	// Put 注册 PUT 路由
+	Put(path string, handlers ...HandlerFunc) Router
+	// Patch 注册 PATCH 路由
+	Patch(path string, handlers ...HandlerFunc) Router
+	// Delete 注册 DELETE 路由
+	Delete(path string, handlers ...HandlerFunc) Router
+	// Head 注册 HEAD 路由
+	Head(path string, handlers ...HandlerFunc) Router
+	// Options 注册 OPTIONS 路由
+	Options(path string, handlers ...HandlerFunc) Router
+	// Any 为全部常用方法注册路由
+	Any(path string, handlers ...HandlerFunc) Router
+	// Native 返回底层原生路由器（fiber.Router、gin.IRouter 或 route.IRouter），用于注册引擎特有的路由
+	Native() interface{}
+}
+
+// NewRouter 按原生路由器的类型创建跨核心路由器
+//
+// 支持 *fiber.App 及其分组（fiber.Router）、*gin.Engine 及其分组（gin.IRouter）、
+// *server.Hertz 及其分组（route.IRouter）；附加监听器的引擎经 ListenerStarter.GetListenerApp 取得后同样适用。
+// 自定义核心的 GetCoreApp 返回值自身实现 Router 时原样返回。
+func NewRouter(native interface{}) (Router, error) {
+	switch r := native.(type) {
+	case Router:
+		return r, nil
+	case fiber.Router:
+		return &fiberRouter{native: r}, nil
+	case gin.IRouter:
+		return &ginRouter{native: r}, nil
+	case route.IRouter:
+		return &hertzRouter{native: r}, nil
+	default:
+		return nil, fmt.Errorf("router: unsupported core app type %T", native)
+	}
+}
+
+// NewCoreRouter 为核心启动器的主引擎创建跨核心路由器，供模块在 RegisterModuleRouteHandlers 中注册路由
+func NewCoreRouter(cs CoreStarter) (Router, error) {
+	return NewRouter(cs.GetCoreApp())
+}
+
+// routeMethods Any 注册的请求方法
+var routeMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodHead, http.MethodOptions,
+}
+
+// fiberRouter Fiber 路由适配，处理器错误直接返回给 Fiber 的 ErrorHandler
+type fiberRouter struct {
+	native fiber.Router
+}
+
+func (r *fiberRouter) handlers(handlers []HandlerFunc) []fiber.Handler {
+	out := make([]fiber.Handler, len(handlers))
+	for i, h := range handlers {
+		out[i] = func(c *fiber.Ctx) error {
+			return h(adaptorctx.WithFiberContext(c))
+		}
+	}
+	return out
+}
+
+func (r *fiberRouter) Use(middleware ...HandlerFunc) Router {
+	for _, h := range r.handlers(middleware) {
+		r.native.Use(h)
+	}
+	return r
+}
+
+func (r *fiberRouter) Group(prefix string, middleware ...HandlerFunc) Router {
+	return &fiberRouter{native: r.native.Group(prefix, r.handlers(middleware)...)}
+}
+
+func (r *fiberRouter) Handle(method, path string, handlers ...HandlerFunc) Router {
+	r.native.Add(method, path, r.handlers(handlers)...)
+	return r
+}
+
+func (r *fiberRouter) Get(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodGet, path, handlers...)
+}
+
+func (r *fiberRouter) Post(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodPost, path, handlers...)
+}
+
+func (r *fiberRouter) Put(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodPut, path, handlers...)
+}
+
+func (r *fiberRouter) Patch(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodPatch, path, handlers...)
+}
+
+func (r *fiberRouter) Delete(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodDelete, path, handlers...)
+}
+
+func (r *fiberRouter) Head(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodHead, path, handlers...)
+}
+
+func (r *fiberRouter) Options(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodOptions, path, handlers...)
+}
+
+func (r *fiberRouter) Any(path string, handlers ...HandlerFunc) Router {
+	for _, method := range routeMethods {
+		r.Handle(method, path, handlers...)
+	}
+	return r
+}
+
+func (r *fiberRouter) Native() interface{} {
+	return r.native
+}
+
+// ginRouter Gin 路由适配，处理器错误经 Context.Error 交给 GinErrorHandler
+//
+// 每个处理器返回后调用 Abort：已调用 Next 时后续处理器均已执行，Abort 无副作用；
+// 未调用 Next 时以此结束处理器链，与 Fiber 的中间件语义一致。
+type ginRouter struct {
+	native gin.IRouter
+}
+
+func (r *ginRouter) handlers(handlers []HandlerFunc) []gin.HandlerFunc {
+	out := make([]gin.HandlerFunc, len(handlers))
+	for i, h := range handlers {
+		out[i] = func(c *gin.Context) {
+			if err := h(adaptorctx.WithGinContext(c)); err != nil {
+				// Next 返回的下游错误已记录，避免重复
+				if last := c.Errors.Last(); last == nil || !errors.Is(last.Err, err) {
+					_ = c.Error(err)
+				}
+			}
+			c.Abort()
+		}
+	}
+	return out
+}
+
+func (r *ginRouter) Use(middleware ...HandlerFunc) Router {
+	r.native.Use(r.handlers(middleware)...)
+	return r
+}
+
+func (r *ginRouter) Group(prefix string, middleware ...HandlerFunc) Router {
+	return &ginRouter{native: r.native.Group(prefix, r.handlers(middleware)...)}
+}
+
+func (r *ginRouter) Handle(method, path string, handlers ...HandlerFunc) Router {
+	r.native.Handle(method, path, r.handlers(handlers)...)
+	return r
+}
+
+func (r *ginRouter) Get(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodGet, path, handlers...)
+}
+
+func (r *ginRouter) Post(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodPost, path, handlers...)
+}
+
+func (r *ginRouter) Put(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodPut, path, handlers...)
+}
+
+func (r *ginRouter) Patch(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodPatch, path, handlers...)
+}
+
+func (r *ginRouter) Delete(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodDelete, path, handlers...)
+}
+
+func (r *ginRouter) Head(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodHead, path, handlers...)
+}
+
+func (r *ginRouter) Options(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodOptions, path, handlers...)
+}
+
+func (r *ginRouter) Any(path string, handlers ...HandlerFunc) Router {
+	for _, method := range routeMethods {
+		r.Handle(method, path, handlers...)
+	}
+	return r
+}
+
+func (r *ginRouter) Native() interface{} {
+	return r.native
+}
+
+// hertzRouter Hertz 路由适配，处理器错误经 RequestContext.Error 交给 HertzErrorHandler，Abort 语义同 ginRouter
+type hertzRouter struct {
+	native route.IRouter
+}
+
+func (r *hertzRouter) handlers(handlers []HandlerFunc) []app.HandlerFunc {
+	out := make([]app.HandlerFunc, len(handlers))
+	for i, h := range handlers {
+		out[i] = func(c ctxpkg.Context, reqCtx *app.RequestContext) {
+			if err := h(adaptorctx.WithHertzHandlerContext(c, reqCtx)); err != nil {
+				if last := reqCtx.Errors.Last(); last == nil || !errors.Is(last.Err, err) {
+					_ = reqCtx.Error(err)
+				}
+			}
+			reqCtx.Abort()
+		}
+	}
+	return out
+}
+
+func (r *hertzRouter) Use(middleware ...HandlerFunc) Router {
+	r.native.Use(r.handlers(middleware)...)
+	return r
+}
+
+func (r *hertzRouter) Group(prefix string, middleware ...HandlerFunc) Router {
+	return &hertzRouter{native: r.native.Group(prefix, r.handlers(middleware)...)}
+}
+
+func (r *hertzRouter) Handle(method, path string, handlers ...HandlerFunc) Router {
+	r.native.Handle(method, path, r.handlers(handlers)...)
+	return r
+}
+
+func (r *hertzRouter) Get(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodGet, path, handlers...)
+}
+
+func (r *hertzRouter) Post(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodPost, path, handlers...)
+}
+
+func (r *hertzRouter) Put(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodPut, path, handlers...)
+}
+
+func (r *hertzRouter) Patch(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodPatch, path, handlers...)
+}
+
+func (r *hertzRouter) Delete(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodDelete, path, handlers...)
+}
+
+func (r *hertzRouter) Head(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodHead, path, handlers...)
+}
+
+func (r *hertzRouter) Options(path string, handlers ...HandlerFunc) Router {
+	return r.Handle(http.MethodOptions, path, handlers...)
+}
+
+func (r *hertzRouter) Any(path string, handlers ...HandlerFunc) Router {
+	for _, method := range routeMethods {
+		r.Handle(method, path, handlers...)
+	}
+	return r
+}
+
+func (r *hertzRouter) Native() interface{} {
+	return r.native
+}
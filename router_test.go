@@ -0,0 +1,193 @@
+package fiberhouse
+
+import (
+	ctxpkg "context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	adaptorctx "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errRouterTest = errors.New("router handler failed")
+
+// routerTestResponse 跨核心比较的响应摘要
+type routerTestResponse struct {
+	status  int
+	body    string
+	trace   string
+	wrapped string
+}
+
+// routerTestServe 以指定方法与请求头请求 target
+type routerTestServe func(t *testing.T, method, target string, header map[string]string) routerTestResponse
+
+// registerRouterTestRoutes 只依赖 Router 的模块路由，三种核心共用同一份注册代码
+func registerRouterTestRoutes(r Router, protectedCalls *atomic.Int32) {
+	r.Use(func(c adaptorctx.ICoreContext) error {
+		c.SetHeader("X-Trace", "on")
+		return c.Next()
+	})
+
+	api := r.Group("/api", func(c adaptorctx.ICoreContext) error {
+		if c.GetHeader("X-Token") != "secret" {
+			return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		}
+		return c.Next()
+	})
+	api.Get("/items/:id", func(c adaptorctx.ICoreContext) error {
+		protectedCalls.Add(1)
+		return c.JSON(http.StatusOK, map[string]string{"id": c.Param("id"), "q": c.Query("q")})
+	})
+	api.Post("/items", func(c adaptorctx.ICoreContext) error {
+		c.Locals("stage", "route-middleware")
+		return c.Next()
+	}, func(c adaptorctx.ICoreContext) error {
+		return c.Send(http.StatusCreated, []byte(c.Locals("stage").(string)))
+	})
+	api.Get("/fail", func(c adaptorctx.ICoreContext) error {
+		return errRouterTest
+	})
+
+	r.Group("/wrap", func(c adaptorctx.ICoreContext) error {
+		err := c.Next()
+		if err != nil {
+			c.SetHeader("X-Wrapped", err.Error())
+		}
+		return err
+	}).Get("/fail", func(c adaptorctx.ICoreContext) error {
+		return errRouterTest
+	})
+
+	r.Any("/any", func(c adaptorctx.ICoreContext) error {
+		return c.Send(http.StatusOK, []byte(c.Method()))
+	})
+}
+
+func TestNewRouter_RejectsUnsupportedAppAndPassesThroughRouter(t *testing.T) {
+	_, err := NewRouter(http.NewServeMux())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "*http.ServeMux")
+
+	custom := &fiberRouter{native: fiber.New()}
+	r, err := NewRouter(custom)
+	require.NoError(t, err)
+	assert.Same(t, custom, r)
+}
+
+func TestRouter_SameModuleServesEveryCore(t *testing.T) {
+	preserveTask4GinMode(t)
+	gin.SetMode(gin.TestMode)
+
+	cores := map[string]func(t *testing.T, calls *atomic.Int32) routerTestServe{
+		"fiber": func(t *testing.T, calls *atomic.Int32) routerTestServe {
+			fiberApp := fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+				return c.Status(http.StatusInternalServerError).SendString("handled 1: " + err.Error())
+			}})
+			r, err := NewRouter(fiberApp)
+			require.NoError(t, err)
+			assert.Same(t, fiberApp, r.Native())
+			registerRouterTestRoutes(r, calls)
+			return func(t *testing.T, method, target string, header map[string]string) routerTestResponse {
+				req := httptest.NewRequest(method, target, nil)
+				for k, v := range header {
+					req.Header.Set(k, v)
+				}
+				resp, err := fiberApp.Test(req)
+				require.NoError(t, err)
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				return routerTestResponse{resp.StatusCode, string(body), resp.Header.Get("X-Trace"), resp.Header.Get("X-Wrapped")}
+			}
+		},
+		"gin": func(t *testing.T, calls *atomic.Int32) routerTestServe {
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Next()
+				if last := c.Errors.Last(); last != nil {
+					c.String(http.StatusInternalServerError, fmt.Sprintf("handled %d: %s", len(c.Errors), last.Err))
+				}
+			})
+			r, err := NewRouter(engine)
+			require.NoError(t, err)
+			registerRouterTestRoutes(r, calls)
+			return func(t *testing.T, method, target string, header map[string]string) routerTestResponse {
+				req := httptest.NewRequest(method, target, nil)
+				for k, v := range header {
+					req.Header.Set(k, v)
+				}
+				recorder := httptest.NewRecorder()
+				engine.ServeHTTP(recorder, req)
+				return routerTestResponse{recorder.Code, recorder.Body.String(), recorder.Header().Get("X-Trace"), recorder.Header().Get("X-Wrapped")}
+			}
+		},
+		"hertz": func(t *testing.T, calls *atomic.Int32) routerTestServe {
+			h := server.New()
+			h.Use(func(c ctxpkg.Context, reqCtx *app.RequestContext) {
+				reqCtx.Next(c)
+				if last := reqCtx.Errors.Last(); last != nil {
+					reqCtx.String(http.StatusInternalServerError, fmt.Sprintf("handled %d: %s", len(reqCtx.Errors), last.Err))
+				}
+			})
+			r, err := NewRouter(h)
+			require.NoError(t, err)
+			registerRouterTestRoutes(r, calls)
+			return func(t *testing.T, method, target string, header map[string]string) routerTestResponse {
+				headers := make([]ut.Header, 0, len(header))
+				for k, v := range header {
+					headers = append(headers, ut.Header{Key: k, Value: v})
+				}
+				resp := ut.PerformRequest(h.Engine, method, target, nil, headers...).Result()
+				return routerTestResponse{resp.StatusCode(), string(resp.Body()), resp.Header.Get("X-Trace"), resp.Header.Get("X-Wrapped")}
+			}
+		},
+	}
+
+	for name, build := range cores {
+		t.Run(name, func(t *testing.T) {
+			calls := &atomic.Int32{}
+			serve := build(t, calls)
+			token := map[string]string{"X-Token": "secret"}
+
+			resp := serve(t, http.MethodGet, "/api/items/42?q=go", token)
+			assert.Equal(t, http.StatusOK, resp.status)
+			assert.JSONEq(t, `{"id":"42","q":"go"}`, resp.body)
+			assert.Equal(t, "on", resp.trace, "router-level middleware runs for every route")
+
+			resp = serve(t, http.MethodGet, "/api/items/42", nil)
+			assert.Equal(t, http.StatusUnauthorized, resp.status)
+			assert.JSONEq(t, `{"error":"unauthorized"}`, resp.body)
+			assert.Equal(t, int32(1), calls.Load(), "middleware that does not call Next ends the chain")
+
+			resp = serve(t, http.MethodPost, "/api/items", token)
+			assert.Equal(t, http.StatusCreated, resp.status)
+			assert.Equal(t, "route-middleware", resp.body)
+
+			resp = serve(t, http.MethodGet, "/api/fail", token)
+			assert.Equal(t, http.StatusInternalServerError, resp.status)
+			assert.Equal(t, "handled 1: "+errRouterTest.Error(), resp.body)
+
+			resp = serve(t, http.MethodGet, "/wrap/fail", nil)
+			assert.Equal(t, http.StatusInternalServerError, resp.status)
+			assert.Equal(t, "handled 1: "+errRouterTest.Error(), resp.body, "error returned through Next is handled once")
+			assert.Equal(t, errRouterTest.Error(), resp.wrapped)
+
+			for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+				resp = serve(t, method, "/any", nil)
+				assert.Equal(t, http.StatusOK, resp.status)
+				assert.Equal(t, method, resp.body)
+			}
+		})
+	}
+}
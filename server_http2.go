@@ -0,0 +1,60 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	"net/http"
+
+	"github.com/lamxy/fiberhouse/appconfig"
+)
+
+// GinHTTP2ConfPath Gin core 的 HTTP/2 配置路径
+const GinHTTP2ConfPath = "application.plugins.engine.servers.gin.http2"
+
+func init() {
+	appconfig.RegisterSchema(GinHTTP2ConfPath, HTTP2Config{})
+}
+
+// HTTP2Config 基于 net/http 的核心的 HTTP/2 配置段结构
+type HTTP2Config struct {
+	Enable               bool `koanf:"enable" default:"true"`                                        // TLS 连接经 ALPN 协商 HTTP/2
+	H2C                  bool `koanf:"h2c"`                                                          // 明文连接接受 HTTP/2（prior knowledge，不支持 Upgrade: h2c）
+	MaxConcurrentStreams int  `koanf:"maxConcurrentStreams" validate:"gte=0"`                        // 每个连接的最大并发流，0 为 net/http 缺省 250
+	MaxReadFrameSize     int  `koanf:"maxReadFrameSize" validate:"omitempty,min=16384,max=16777215"` // 接收帧的最大字节数，0 为 net/http 缺省 1MB
+}
+
+// bindHTTP2Config 绑定 path 下的 HTTP/2 配置
+func bindHTTP2Config(cfg appconfig.IAppConfig, path string) (HTTP2Config, error) {
+	return appconfig.Bind[HTTP2Config](cfg, path)
+}
+
+// ConfigureHTTP2 按配置设置 server 支持的协议与 HTTP/2 参数，供 Gin 及其他基于 net/http 的核心复用
+//
+// server.TLSConfig 已设置时同步其 ALPN 协议列表，使经 GetConfigForClient 提供的握手配置（mTLS）与
+// 直接 Serve 自建 TLS 监听器的场景同样能协商 HTTP/2；须在开始服务前调用。
+func ConfigureHTTP2(server *http.Server, conf HTTP2Config) {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(conf.Enable)
+	protocols.SetUnencryptedHTTP2(conf.H2C)
+	server.Protocols = protocols
+	server.HTTP2 = &http.HTTP2Config{
+		MaxConcurrentStreams: conf.MaxConcurrentStreams,
+		MaxReadFrameSize:     conf.MaxReadFrameSize,
+	}
+	if server.TLSConfig != nil {
+		server.TLSConfig.NextProtos = serverNextProtos(server)
+	}
+}
+
+// serverNextProtos 按 server 支持的协议返回 TLS ALPN 协议列表，未设置 Protocols 时与 net/http 缺省一致
+func serverNextProtos(server *http.Server) []string {
+	if server.Protocols == nil || server.Protocols.HTTP2() {
+		return []string{"h2", "http/1.1"}
+	}
+	return []string{"http/1.1"}
+}
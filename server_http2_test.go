@@ -0,0 +1,190 @@
+package fiberhouse
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lamxy/fiberhouse/component/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindHTTP2Config_DefaultsAndRejectsInvalidEntries(t *testing.T) {
+	conf, err := bindHTTP2Config(newTask4InternalAppContext(t, nil).GetConfig(), GinHTTP2ConfPath)
+	require.NoError(t, err)
+	assert.Equal(t, HTTP2Config{Enable: true}, conf)
+
+	ctx := newTask4InternalAppContext(t, map[string]interface{}{
+		GinHTTP2ConfPath + ".maxConcurrentStreams": -1,
+		GinHTTP2ConfPath + ".maxReadFrameSize":     1024,
+	})
+	_, err = bindHTTP2Config(ctx.GetConfig(), GinHTTP2ConfPath)
+	require.Error(t, err)
+	for _, key := range []string{"maxConcurrentStreams", "maxReadFrameSize"} {
+		assert.Contains(t, err.Error(), GinHTTP2ConfPath+"."+key)
+	}
+}
+
+func TestConfigureHTTP2_SetsProtocolsAndALPN(t *testing.T) {
+	server := &http.Server{TLSConfig: &tls.Config{}}
+	ConfigureHTTP2(server, HTTP2Config{Enable: true, H2C: true, MaxConcurrentStreams: 16, MaxReadFrameSize: 1 << 16})
+	assert.True(t, server.Protocols.HTTP1())
+	assert.True(t, server.Protocols.HTTP2())
+	assert.True(t, server.Protocols.UnencryptedHTTP2())
+	assert.Equal(t, 16, server.HTTP2.MaxConcurrentStreams)
+	assert.Equal(t, 1<<16, server.HTTP2.MaxReadFrameSize)
+	assert.Equal(t, []string{"h2", "http/1.1"}, server.TLSConfig.NextProtos)
+
+	ConfigureHTTP2(server, HTTP2Config{})
+	assert.False(t, server.Protocols.HTTP2())
+	assert.False(t, server.Protocols.UnencryptedHTTP2())
+	assert.Equal(t, []string{"http/1.1"}, server.TLSConfig.NextProtos)
+}
+
+// runGinHTTP2TestCore 在 loopback 上启动 Gin core，/proto 返回请求协议；测试结束时关闭
+func runGinHTTP2TestCore(t *testing.T, values map[string]interface{}) (*CoreWithGin, *ServerInfo) {
+	t.Helper()
+	isolateTask4ErrorHandlerSingleton(t)
+	health.Default().SetReady(true)
+	t.Cleanup(func() { health.Default().SetReady(true) })
+	base := map[string]interface{}{
+		"application.plugins.engine.servers.gin.host": "127.0.0.1",
+		"application.plugins.engine.servers.gin.port": "0",
+	}
+	for k, v := range values {
+		base[k] = v
+	}
+	ctx := newTask4InternalAppContext(t, base)
+	core := NewCoreWithGin(ctx).(*CoreWithGin)
+	core.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+	cleanupTask4GinCore(t, core)
+	proto := func(c *gin.Context) { c.String(http.StatusOK, c.Request.Proto) }
+	core.coreApp.GET("/proto", proto)
+	for _, name := range core.ListenerNames() {
+		core.GetListenerApp(name).(*gin.Engine).GET("/proto", proto)
+	}
+	ctx.RegisterStarterApp(&WebApplication{FrameStarter: &FrameApplication{Ctx: ctx}, CoreStarter: core})
+
+	probe := &task14RunAfterProbe{
+		task4LifecycleManager: task4LifecycleManager{
+			typ:      ProviderTypeDefault().GroupProviderAutoRun,
+			location: ProviderLocationDefault().LocationServerRunAfter,
+		},
+		infos: make(chan *ServerInfo, 1),
+	}
+	runErr := make(chan error, 1)
+	go func() { runErr <- core.AppCoreRun(probe) }()
+	var info *ServerInfo
+	select {
+	case info = <-probe.infos:
+	case err := <-runErr:
+		t.Fatalf("AppCoreRun returned before serving: %v", err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("main listener was not bound")
+	}
+	t.Cleanup(func() {
+		require.NoError(t, core.Shutdown())
+		select {
+		case err := <-runErr:
+			assert.NoError(t, err)
+		case <-time.After(3 * time.Second):
+			t.Fatal("AppCoreRun did not return after Shutdown")
+		}
+	})
+	return core, info
+}
+
+// http2TestProto 以 client 请求 url 并返回服务端看到的协议
+func http2TestProto(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, string(body), resp.Proto, "client and server agree on the protocol")
+	return string(body)
+}
+
+// h2cTestClient 以 prior knowledge 发起明文 HTTP/2 请求的客户端
+func h2cTestClient() *http.Client {
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	return &http.Client{Timeout: 3 * time.Second, Transport: &http.Transport{Protocols: protocols}}
+}
+
+func TestCoreRun_GinServesHTTP2OverTLS(t *testing.T) {
+	preserveTask4GinMode(t)
+	ca := newTLSTestCA(t)
+	dir := t.TempDir()
+	serverCert, serverKey := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, "gateway", x509.ExtKeyUsageClientAuth)
+	writeTLSTestFiles(t, dir, map[string][]byte{"cert.pem": serverCert, "key.pem": serverKey, "ca.pem": ca.pem()})
+	clientPair, err := tls.X509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+	tlsValues := func(path string) map[string]interface{} {
+		return map[string]interface{}{
+			path + ".enable":       true,
+			path + ".certFile":     filepath.Join(dir, "cert.pem"),
+			path + ".keyFile":      filepath.Join(dir, "key.pem"),
+			path + ".clientCAFile": filepath.Join(dir, "ca.pem"),
+		}
+	}
+	client := &http.Client{Timeout: 3 * time.Second, Transport: &http.Transport{
+		ForceAttemptHTTP2: true,
+		TLSClientConfig:   &tls.Config{RootCAs: ca.pool(), ServerName: "localhost", Certificates: []tls.Certificate{clientPair}},
+	}}
+
+	t.Run("negotiates h2 with mTLS on main and additional listeners", func(t *testing.T) {
+		values := tlsValues(GinTLSConfPath)
+		for k, v := range tlsValues("application.listeners.admin.tls") {
+			values[k] = v
+		}
+		values["application.listeners.admin.address"] = "127.0.0.1:0"
+		core, info := runGinHTTP2TestCore(t, values)
+		assert.Equal(t, "HTTP/2.0", http2TestProto(t, client, "https://"+info.Addr.String()+"/proto"))
+		adminAddr := core.listeners.servers[0].ln.Addr().String()
+		assert.Equal(t, "HTTP/2.0", http2TestProto(t, client, "https://"+adminAddr+"/proto"))
+	})
+
+	t.Run("disabled falls back to HTTP/1.1", func(t *testing.T) {
+		values := tlsValues(GinTLSConfPath)
+		values[GinHTTP2ConfPath+".enable"] = false
+		_, info := runGinHTTP2TestCore(t, values)
+		assert.Equal(t, "HTTP/1.1", http2TestProto(t, client, "https://"+info.Addr.String()+"/proto"))
+	})
+}
+
+func TestCoreRun_GinServesH2C(t *testing.T) {
+	preserveTask4GinMode(t)
+	http1 := &http.Client{Timeout: 3 * time.Second}
+
+	t.Run("accepts prior knowledge h2c alongside HTTP/1.1", func(t *testing.T) {
+		core, info := runGinHTTP2TestCore(t, map[string]interface{}{
+			GinHTTP2ConfPath + ".h2c":                  true,
+			GinHTTP2ConfPath + ".maxConcurrentStreams": 32,
+			GinHTTP2ConfPath + ".maxReadFrameSize":     1 << 20,
+			"application.listeners.admin.address":      "127.0.0.1:0",
+		})
+		assert.Equal(t, 32, core.httpServer.HTTP2.MaxConcurrentStreams)
+		assert.Equal(t, 1<<20, core.httpServer.HTTP2.MaxReadFrameSize)
+		assert.Equal(t, "HTTP/2.0", http2TestProto(t, h2cTestClient(), "http://"+info.Addr.String()+"/proto"))
+		assert.Equal(t, "HTTP/1.1", http2TestProto(t, http1, "http://"+info.Addr.String()+"/proto"))
+		adminAddr := core.listeners.servers[0].ln.Addr().String()
+		assert.Equal(t, "HTTP/2.0", http2TestProto(t, h2cTestClient(), "http://"+adminAddr+"/proto"))
+	})
+
+	t.Run("rejects h2c when disabled", func(t *testing.T) {
+		_, info := runGinHTTP2TestCore(t, nil)
+		_, err := h2cTestClient().Get("http://" + info.Addr.String() + "/proto")
+		assert.Error(t, err)
+		assert.Equal(t, "HTTP/1.1", http2TestProto(t, http1, "http://"+info.Addr.String()+"/proto"))
+	})
+}
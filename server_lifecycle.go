@@ -1,9 +1,18 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
 package fiberhouse
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"runtime/debug"
+
+	"github.com/lamxy/fiberhouse/component/health"
 )
 
 func coordinateServerRun(
@@ -32,3 +41,50 @@ func coordinateServerRun(
 		return nil, shutdownErr, true
 	}
 }
+
+// ServerInfo 监听已绑定的服务器信息，注入给 LocationServerRunAfter 管理器的加载函数
+type ServerInfo struct {
+	Starter CoreStarter // 当前核心启动器
+	Addr    net.Addr    // 实际绑定的地址，配置端口为 0 时含系统分配的端口
+	Scheme  string      // http 或 https
+}
+
+// URL 返回服务器根地址，如 http://127.0.0.1:8080
+func (si *ServerInfo) URL() string {
+	return si.Scheme + "://" + si.Addr.String()
+}
+
+// startServerRunAfter 监听绑定后在后台执行 LocationServerRunAfter 管理器，不阻塞请求服务
+//
+// 管理器执行期间经 health.Default().Hold() 保持未就绪，全部返回后释放；加载错误只记录日志。
+func startServerRunAfter(ctx IApplicationContext, info *ServerInfo, managers []IProviderManager) {
+	location := ProviderLocationDefault().LocationServerRunAfter
+	matched := false
+	for _, manager := range managers {
+		if manager != nil && manager.Location() != nil && manager.Location().GetLocationID() == location.GetLocationID() {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return
+	}
+
+	release := health.Default().Hold()
+	go func() {
+		defer release()
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				ctx.GetLogger().ErrorWith(ctx.GetConfig().LogOriginFrame()).
+					Str("addr", info.Addr.String()).
+					Msgf("ServerRunAfter managers panic: %v\n%s", recovered, debug.Stack())
+			}
+		}()
+		if _, _, err := LoadProviderManagersAtLocation(managers, location, info); err != nil {
+			ctx.GetLogger().ErrorWith(ctx.GetConfig().LogOriginFrame()).
+				Err(err).
+				Str("addr", info.Addr.String()).
+				Msg("ServerRunAfter managers load failed")
+		}
+	}()
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lamxy/fiberhouse/appconfig"
+)
+
+const (
+	// FiberTLSConfPath Fiber core 主监听器的 TLS 配置路径
+	FiberTLSConfPath = "application.server.tls"
+	// GinTLSConfPath Gin core 主监听器的 TLS 配置路径
+	GinTLSConfPath = "application.plugins.engine.servers.gin.tls"
+)
+
+func init() {
+	appconfig.RegisterSchema(FiberTLSConfPath, TLSConfig{})
+	appconfig.RegisterSchema(GinTLSConfPath, TLSConfig{})
+}
+
+// TLSConfig 服务端 TLS 配置段结构，主监听器与附加监听器共用
+type TLSConfig struct {
+	Enable       bool     `koanf:"enable"`                                                                                    // 是否以 HTTPS 提供服务
+	CertFile     string   `koanf:"certFile" validate:"required_if=Enable true"`                                               // 证书文件路径
+	KeyFile      string   `koanf:"keyFile" validate:"required_if=Enable true"`                                                // 私钥文件路径
+	ClientCAFile string   `koanf:"clientCAFile"`                                                                              // 校验客户端证书的 CA bundle，配置后启用 mTLS
+	ClientAuth   string   `koanf:"clientAuth" validate:"omitempty,oneof=none request require verifyIfGiven requireAndVerify"` // 客户端证书策略，缺省时配置了 clientCAFile 为 requireAndVerify，否则为 none
+	MinVersion   string   `koanf:"minVersion" default:"1.2" validate:"oneof=1.0 1.1 1.2 1.3"`                                 // 最低 TLS 版本
+	CipherSuites []string `koanf:"cipherSuites"`                                                                              // TLS 1.2 及以下的密码套件名称，空为 Go 缺省；TLS 1.3 套件不可配置
+	Watch        bool     `koanf:"watch" default:"true"`                                                                      // 证书、私钥与 CA 文件变更时自动重新加载
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	"none":             tls.NoClientCert,
+	"request":          tls.RequestClientCert,
+	"require":          tls.RequireAnyClientCert,
+	"verifyIfGiven":    tls.VerifyClientCertIfGiven,
+	"requireAndVerify": tls.RequireAndVerifyClientCert,
+}
+
+// bindTLSConfig 绑定 path 下的 TLS 配置
+func bindTLSConfig(cfg appconfig.IAppConfig, path string) (TLSConfig, error) {
+	return appconfig.Bind[TLSConfig](cfg, path)
+}
+
+// serverTLS 服务端 TLS 状态：证书经 GetCertificate 提供，客户端 CA 经 GetConfigForClient 提供，文件变更时原子替换
+//
+// 重新加载失败时保留上一份证书并记录错误日志，不中断服务。
+type serverTLS struct {
+	appCtx    IApplicationContext
+	conf      TLSConfig
+	base      *tls.Config
+	cert      atomic.Pointer[tls.Certificate]
+	clientCAs atomic.Pointer[x509.CertPool]
+	raw       []byte // 上次加载的文件内容，内容未变时跳过替换
+
+	stopOnce sync.Once
+	stopFn   func()
+}
+
+// newServerTLS 按配置加载证书并构造 tls.Config；conf.Watch 为 true 时监听文件所在目录
+func newServerTLS(appCtx IApplicationContext, conf TLSConfig) (*serverTLS, error) {
+	clientAuth := tls.NoClientCert
+	if conf.ClientCAFile != "" {
+		clientAuth = tls.RequireAndVerifyClientCert
+	}
+	if conf.ClientAuth != "" {
+		clientAuth = clientAuthTypes[conf.ClientAuth]
+	}
+	if (clientAuth == tls.VerifyClientCertIfGiven || clientAuth == tls.RequireAndVerifyClientCert) && conf.ClientCAFile == "" {
+		return nil, fmt.Errorf("tls clientAuth %s requires clientCAFile", conf.ClientAuth)
+	}
+	cipherSuites, err := parseCipherSuites(conf.CipherSuites)
+	if err != nil {
+		return nil, err
+	}
+	minVersion, ok := tlsVersions[conf.MinVersion]
+	if !ok {
+		minVersion = tls.VersionTLS12
+	}
+
+	s := &serverTLS{appCtx: appCtx, conf: conf}
+	if err = s.load(); err != nil {
+		return nil, err
+	}
+	s.base = &tls.Config{
+		MinVersion:     minVersion,
+		CipherSuites:   cipherSuites,
+		ClientAuth:     clientAuth,
+		GetCertificate: s.getCertificate,
+	}
+	if conf.ClientCAFile != "" {
+		s.base.GetConfigForClient = s.getConfigForClient
+	}
+	if conf.Watch {
+		if err = s.watch(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// parseCipherSuites 按名称解析密码套件，只接受 tls.CipherSuites 中的安全套件
+func parseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("tls cipher suite %s is unknown or insecure", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Config 返回服务端 tls.Config，证书与客户端 CA 在握手时读取最新值
+func (s *serverTLS) Config() *tls.Config {
+	return s.base
+}
+
+func (s *serverTLS) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return s.cert.Load(), nil
+}
+
+func (s *serverTLS) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	conf := s.base.Clone()
+	conf.GetConfigForClient = nil
+	conf.ClientCAs = s.clientCAs.Load()
+	return conf, nil
+}
+
+// load 读取证书、私钥与 CA 文件，内容变化时替换；返回是否发生替换之外的错误
+func (s *serverTLS) load() error {
+	certPEM, err := os.ReadFile(s.conf.CertFile)
+	if err != nil {
+		return fmt.Errorf("read tls certFile: %w", err)
+	}
+	keyPEM, err := os.ReadFile(s.conf.KeyFile)
+	if err != nil {
+		return fmt.Errorf("read tls keyFile: %w", err)
+	}
+	var caPEM []byte
+	if s.conf.ClientCAFile != "" {
+		if caPEM, err = os.ReadFile(s.conf.ClientCAFile); err != nil {
+			return fmt.Errorf("read tls clientCAFile: %w", err)
+		}
+	}
+	raw := bytes.Join([][]byte{certPEM, keyPEM, caPEM}, []byte{0})
+	if s.raw != nil && bytes.Equal(raw, s.raw) {
+		return nil
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("load tls key pair: %w", err)
+	}
+	var pool *x509.CertPool
+	if caPEM != nil {
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return errors.New("load tls clientCAFile: no PEM certificates found")
+		}
+	}
+	s.cert.Store(&cert)
+	s.clientCAs.Store(pool)
+	s.raw = raw
+	return nil
+}
+
+// reload 文件变更后重新加载，失败时保留当前证书
+func (s *serverTLS) reload() {
+	previous := s.raw
+	if err := s.load(); err != nil {
+		s.appCtx.GetLogger().ErrorWith(s.appCtx.GetConfig().LogOriginFrame()).
+			Err(err).
+			Str("certFile", s.conf.CertFile).
+			Msg("TLS certificate reload failed, keeping previous certificate")
+		return
+	}
+	if !bytes.Equal(previous, s.raw) {
+		s.appCtx.GetLogger().InfoWith(s.appCtx.GetConfig().LogOriginFrame()).
+			Str("certFile", s.conf.CertFile).
+			Msg("TLS certificate reloaded")
+	}
+}
+
+// watch 监听证书、私钥与 CA 文件所在目录，合并 appconfig.DefaultWatchDebounce 窗口内的事件后重新加载
+//
+// 监听目录而非文件，以便覆盖重命名替换与 Kubernetes Secret 挂载的符号链接切换。
+func (s *serverTLS) watch() error {
+	seen := make(map[string]struct{})
+	var dirs []string
+	for _, file := range []string{s.conf.CertFile, s.conf.KeyFile, s.conf.ClientCAFile} {
+		if file == "" {
+			continue
+		}
+		dir := filepath.Dir(absPath(file))
+		if _, ok := seen[dir]; ok {
+			continue
+		}
+		seen[dir] = struct{}{}
+		dirs = append(dirs, dir)
+	}
+	stop, err := appconfig.WatchDirs(dirs, appconfig.DefaultWatchDebounce, nil, s.reload)
+	if err != nil {
+		return fmt.Errorf("tls: %w", err)
+	}
+	s.stopFn = func() { _ = stop() }
+	return nil
+}
+
+// close 停止文件监听，可重复调用
+func (s *serverTLS) close() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		if s.stopFn != nil {
+			s.stopFn()
+		}
+	})
+}
+
+func absPath(name string) string {
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
+	}
+	return filepath.Clean(name)
+}
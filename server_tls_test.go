@@ -0,0 +1,296 @@
+package fiberhouse
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	adaptorcontext "github.com/lamxy/fiberhouse/adaptor/context"
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/component/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tlsTestCA 测试用 CA，签发服务端与客户端证书
+type tlsTestCA struct {
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	serial int64
+}
+
+func newTLSTestCA(t *testing.T) *tlsTestCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fiberhouse test CA"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &tlsTestCA{cert: cert, key: key, serial: 1}
+}
+
+// issue 签发 CommonName 为 cn 的证书，返回证书与私钥 PEM
+func (ca *tlsTestCA) issue(t *testing.T, cn string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ca.serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(ca.serial),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+func (ca *tlsTestCA) pem() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+func (ca *tlsTestCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+func writeTLSTestFiles(t *testing.T, dir string, files map[string][]byte) {
+	t.Helper()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0o600))
+	}
+}
+
+func TestNewServerTLS_AppliesAndValidatesOptions(t *testing.T) {
+	ctx := newTask4InternalAppContext(t, nil)
+	ca := newTLSTestCA(t)
+	dir := t.TempDir()
+	certPEM, keyPEM := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	writeTLSTestFiles(t, dir, map[string][]byte{"cert.pem": certPEM, "key.pem": keyPEM, "ca.pem": ca.pem()})
+	base := TLSConfig{
+		Enable:     true,
+		CertFile:   filepath.Join(dir, "cert.pem"),
+		KeyFile:    filepath.Join(dir, "key.pem"),
+		MinVersion: "1.2",
+	}
+
+	conf := base
+	conf.MinVersion = "1.3"
+	conf.ClientCAFile = filepath.Join(dir, "ca.pem")
+	conf.CipherSuites = []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}
+	st, err := newServerTLS(ctx, conf)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), st.Config().MinVersion)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}, st.Config().CipherSuites)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, st.Config().ClientAuth, "clientCAFile defaults to requireAndVerify")
+	clientConf, err := st.Config().GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.NotNil(t, clientConf.ClientCAs)
+
+	conf = base
+	conf.CipherSuites = []string{"TLS_RSA_WITH_RC4_128_SHA"}
+	_, err = newServerTLS(ctx, conf)
+	assert.ErrorContains(t, err, "TLS_RSA_WITH_RC4_128_SHA is unknown or insecure")
+
+	conf = base
+	conf.ClientAuth = "verifyIfGiven"
+	_, err = newServerTLS(ctx, conf)
+	assert.ErrorContains(t, err, "requires clientCAFile")
+
+	conf = base
+	conf.KeyFile = filepath.Join(dir, "missing.pem")
+	_, err = newServerTLS(ctx, conf)
+	assert.ErrorContains(t, err, "read tls keyFile")
+}
+
+func TestBindTLSConfig_RejectsInvalidEntries(t *testing.T) {
+	ctx := newTask4InternalAppContext(t, map[string]interface{}{
+		FiberTLSConfPath + ".enable":     true,
+		FiberTLSConfPath + ".minVersion": "1.4",
+		FiberTLSConfPath + ".clientAuth": "always",
+	})
+	_, err := bindTLSConfig(ctx.GetConfig(), FiberTLSConfPath)
+	require.Error(t, err)
+	for _, key := range []string{"certFile", "keyFile", "minVersion", "clientAuth"} {
+		assert.Contains(t, err.Error(), FiberTLSConfPath+"."+key)
+	}
+}
+
+func TestServerTLS_ReloadsCertificateOnChange(t *testing.T) {
+	ctx := newTask4InternalAppContext(t, nil)
+	ca := newTLSTestCA(t)
+	dir := t.TempDir()
+	certPEM, keyPEM := ca.issue(t, "first", x509.ExtKeyUsageServerAuth)
+	writeTLSTestFiles(t, dir, map[string][]byte{"cert.pem": certPEM, "key.pem": keyPEM})
+
+	st, err := newServerTLS(ctx, TLSConfig{
+		Enable:     true,
+		CertFile:   filepath.Join(dir, "cert.pem"),
+		KeyFile:    filepath.Join(dir, "key.pem"),
+		MinVersion: "1.2",
+		Watch:      true,
+	})
+	require.NoError(t, err)
+	defer st.close()
+	servedCN := func() string {
+		cert, err := st.Config().GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+		return leaf.Subject.CommonName
+	}
+	require.Equal(t, "first", servedCN())
+
+	certPEM, keyPEM = ca.issue(t, "second", x509.ExtKeyUsageServerAuth)
+	writeTLSTestFiles(t, dir, map[string][]byte{"cert.pem": certPEM, "key.pem": keyPEM})
+	require.Eventually(t, func() bool { return servedCN() == "second" }, 3*time.Second, 20*time.Millisecond)
+
+	// 写入半份文件时重新加载失败，继续使用上一份证书
+	writeTLSTestFiles(t, dir, map[string][]byte{"cert.pem": []byte("truncated")})
+	time.Sleep(3 * appconfig.DefaultWatchDebounce)
+	assert.Equal(t, "second", servedCN())
+
+	st.close()
+	st.close()
+}
+
+func TestCoreRun_TLSMutualAuthExposesClientCertificate(t *testing.T) {
+	preserveTask4GinMode(t)
+	registry := health.Default()
+	t.Cleanup(func() { registry.SetReady(true) })
+
+	ca := newTLSTestCA(t)
+	dir := t.TempDir()
+	serverCert, serverKey := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, "billing-service", x509.ExtKeyUsageClientAuth)
+	writeTLSTestFiles(t, dir, map[string][]byte{"cert.pem": serverCert, "key.pem": serverKey, "ca.pem": ca.pem()})
+	clientPair, err := tls.X509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+
+	for _, testCase := range []struct {
+		name    string
+		tlsPath string
+		setup   func(t *testing.T, ctx IApplicationContext) CoreStarter
+	}{
+		{
+			name:    "fiber",
+			tlsPath: FiberTLSConfPath,
+			setup: func(t *testing.T, ctx IApplicationContext) CoreStarter {
+				core := NewCoreWithFiber(ctx).(*CoreWithFiber)
+				core.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+				core.coreApp.Get("/whoami", func(c *fiber.Ctx) error {
+					cert := adaptorcontext.WithFiberContext(c).ClientCertificate()
+					return c.SendString(cert.Subject.CommonName)
+				})
+				return core
+			},
+		},
+		{
+			name:    "gin",
+			tlsPath: GinTLSConfPath,
+			setup: func(t *testing.T, ctx IApplicationContext) CoreStarter {
+				core := NewCoreWithGin(ctx).(*CoreWithGin)
+				core.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+				cleanupTask4GinCore(t, core)
+				core.coreApp.GET("/whoami", func(c *gin.Context) {
+					cert := adaptorcontext.WithGinContext(c).ClientCertificate()
+					c.String(http.StatusOK, cert.Subject.CommonName)
+				})
+				return core
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			isolateTask4ErrorHandlerSingleton(t)
+			registry.SetReady(true)
+			ctx := newTask4InternalAppContext(t, map[string]interface{}{
+				"application.server.host":                     "127.0.0.1",
+				"application.server.port":                     "0",
+				"application.plugins.engine.servers.gin.host": "127.0.0.1",
+				"application.plugins.engine.servers.gin.port": "0",
+				testCase.tlsPath + ".enable":                  true,
+				testCase.tlsPath + ".certFile":                filepath.Join(dir, "cert.pem"),
+				testCase.tlsPath + ".keyFile":                 filepath.Join(dir, "key.pem"),
+				testCase.tlsPath + ".clientCAFile":            filepath.Join(dir, "ca.pem"),
+			})
+			core := testCase.setup(t, ctx)
+			ctx.RegisterStarterApp(&WebApplication{FrameStarter: &FrameApplication{Ctx: ctx}, CoreStarter: core})
+
+			probe := &task14RunAfterProbe{
+				task4LifecycleManager: task4LifecycleManager{
+					typ:      ProviderTypeDefault().GroupProviderAutoRun,
+					location: ProviderLocationDefault().LocationServerRunAfter,
+				},
+				infos: make(chan *ServerInfo, 1),
+			}
+			runErr := make(chan error, 1)
+			go func() { runErr <- core.AppCoreRun(probe) }()
+			var info *ServerInfo
+			select {
+			case info = <-probe.infos:
+			case err := <-runErr:
+				t.Fatalf("AppCoreRun returned before serving: %v", err)
+			case <-time.After(3 * time.Second):
+				t.Fatal("main listener was not bound")
+			}
+			assert.Equal(t, "https", info.Scheme)
+			url := "https://" + info.Addr.String() + "/whoami"
+
+			anonymous := &http.Client{Timeout: 3 * time.Second, Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: ca.pool(), ServerName: "localhost"},
+			}}
+			_, err := anonymous.Get(url)
+			assert.Error(t, err, "client without certificate is rejected")
+
+			authenticated := &http.Client{Timeout: 3 * time.Second, Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: ca.pool(), ServerName: "localhost", Certificates: []tls.Certificate{clientPair}},
+			}}
+			resp, err := authenticated.Get(url)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "billing-service", string(body))
+
+			require.NoError(t, core.Shutdown())
+			select {
+			case err := <-runErr:
+				assert.NoError(t, err)
+			case <-time.After(3 * time.Second):
+				t.Fatal("AppCoreRun did not return after Shutdown")
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2025 lamxy and Contributors
+// SPDX-License-Identifier: MIT
+//
+// Author: lamxy <pytho5170@hotmail.com>
+// GitHub: https://github.com/lamxy
+
+package fiberhouse
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lamxy/fiberhouse/appconfig"
+)
+
+// ShutdownConfPath 优雅关闭配置路径
+const ShutdownConfPath = "application.shutdown"
+
+// defaultShutdownTimeout 关闭预算缺省值，配置绑定失败时使用
+const defaultShutdownTimeout = 30 * time.Second
+
+func init() {
+	appconfig.RegisterSchema(ShutdownConfPath, ShutdownConfig{})
+}
+
+// ShutdownConfig 优雅关闭配置段结构，对应配置路径 application.shutdown
+type ShutdownConfig struct {
+	Timeout    time.Duration `koanf:"timeout" default:"30s" validate:"gt=0"` // 关闭链总预算，覆盖 ServerShutdownBefore、排空与 ServerShutdownAfter
+	DrainDelay time.Duration `koanf:"drainDelay" validate:"gte=0"`           // 标记未就绪后、停止接收连接前的等待，供负载均衡器摘除实例
+}
+
+// inflightCounter 在途计数器，零值可用
+type inflightCounter struct {
+	mu   sync.Mutex
+	n    int64
+	idle chan struct{} // 计数归零时关闭
+}
+
+func (c *inflightCounter) add() {
+	c.mu.Lock()
+	if c.n == 0 {
+		c.idle = make(chan struct{})
+	}
+	c.n++
+	c.mu.Unlock()
+}
+
+func (c *inflightCounter) done() {
+	c.mu.Lock()
+	c.n--
+	if c.n == 0 {
+		close(c.idle)
+	}
+	c.mu.Unlock()
+}
+
+func (c *inflightCounter) count() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+// wait 等待计数归零，ctx 结束时返回仍在途的数量
+func (c *inflightCounter) wait(ctx context.Context) int64 {
+	c.mu.Lock()
+	if c.n == 0 {
+		c.mu.Unlock()
+		return 0
+	}
+	idle := c.idle
+	c.mu.Unlock()
+
+	select {
+	case <-idle:
+		return 0
+	case <-ctx.Done():
+		return c.count()
+	}
+}
+
+// shutdownCoordinator 在 application.shutdown.timeout 预算内协调一次关闭链
+//
+// 顺序：ServerShutdownBefore 提供者 → 等待 drainDelay → 停止接收连接并等待在途请求，同时停止拉取任务并等待运行中的任务处理器
+// → 关闭全局对象 → 以剩余预算执行 ServerShutdownAfter 提供者。超出预算被截断的请求、任务与提供者记录 Warn 日志。
+type shutdownCoordinator struct {
+	appCtx     IApplicationContext
+	ctx        context.Context
+	cancel     context.CancelFunc
+	drainDelay time.Duration
+	tasksDone  chan struct{}
+	endOnce    sync.Once
+}
+
+// newShutdownCoordinator 绑定 application.shutdown 配置并开始计时，绑定失败时记录错误并使用缺省预算
+func newShutdownCoordinator(appCtx IApplicationContext) *shutdownCoordinator {
+	conf := loadShutdownConfig(appCtx)
+	ctx, cancel := context.WithTimeout(context.Background(), conf.Timeout)
+	return &shutdownCoordinator{
+		appCtx:     appCtx,
+		ctx:        ctx,
+		cancel:     cancel,
+		drainDelay: conf.DrainDelay,
+	}
+}
+
+// loadShutdownConfig 绑定 application.shutdown 配置段，绑定失败时记录日志并使用缺省预算
+func loadShutdownConfig(appCtx IApplicationContext) ShutdownConfig {
+	cfg := appCtx.GetConfig()
+	conf, err := appconfig.Bind[ShutdownConfig](cfg, ShutdownConfPath)
+	if err != nil {
+		appCtx.GetLogger().ErrorWith(cfg.LogOriginFrame()).Err(err).Msg("bind shutdown config failed, using default budget")
+		conf = ShutdownConfig{Timeout: defaultShutdownTimeout}
+	}
+	return conf
+}
+
+// Context 返回携带关闭截止时间的 context，用于停止 HTTP 服务器
+func (sc *shutdownCoordinator) Context() context.Context {
+	return sc.ctx
+}
+
+// close 释放预算计时器
+func (sc *shutdownCoordinator) close() {
+	sc.cancel()
+}
+
+// beginDrain 等待 drainDelay 后开始排空任务 worker，调用方随后停止 HTTP 服务器并调用 endDrain
+func (sc *shutdownCoordinator) beginDrain() {
+	if sc.drainDelay > 0 {
+		timer := time.NewTimer(sc.drainDelay)
+		select {
+		case <-timer.C:
+		case <-sc.ctx.Done():
+			timer.Stop()
+		}
+	}
+	sc.tasksDone = make(chan struct{})
+	go func() {
+		defer close(sc.tasksDone)
+		drainFrameTasks(sc.appCtx, sc.ctx)
+	}()
+}
+
+// endDrain HTTP 服务器停止后调用，记录被截断的在途请求并等待任务排空结束；多次调用只生效一次
+//
+// Fiber 的 OnShutdown 钩子在关闭全局对象前调用，保证任务处理器返回后才关闭其依赖的连接池。
+func (sc *shutdownCoordinator) endDrain(inflight *inflightCounter) {
+	sc.endOnce.Do(func() {
+		if n := inflight.count(); n > 0 {
+			sc.appCtx.GetLogger().WarnWith(sc.appCtx.GetConfig().LogOriginFrame()).
+				Int64("inflightRequests", n).
+				Msg("HTTP requests cut off by shutdown budget")
+		}
+		if sc.tasksDone != nil {
+			<-sc.tasksDone
+		}
+	})
+}
+
+// runAfter 以剩余预算执行 fn；预算耗尽时记录日志并返回 context 错误，fn 继续在后台运行至返回
+func (sc *shutdownCoordinator) runAfter(stage string, fn func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-sc.ctx.Done():
+		sc.appCtx.GetLogger().WarnWith(sc.appCtx.GetConfig().LogOriginFrame()).
+			Str("stage", stage).
+			Msg("shutdown stage cut off by shutdown budget")
+		return fmt.Errorf("%s: %w", stage, sc.ctx.Err())
+	}
+}
@@ -0,0 +1,322 @@
+package fiberhouse
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofiber/fiber/v2"
+	"github.com/hibiken/asynq"
+	"github.com/lamxy/fiberhouse/appconfig"
+	"github.com/lamxy/fiberhouse/bootstrap"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockedLogBuffer 并发安全的日志缓冲，关闭链中请求、任务与提供者的截断日志来自不同 goroutine
+type lockedLogBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedLogBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedLogBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func newShutdownTestContext(t *testing.T, timeout string) (IApplicationContext, *lockedLogBuffer) {
+	t.Helper()
+	cfg := appconfig.NewAppConfig()
+	cfg.LoadDefault(map[string]interface{}{"application.shutdown.timeout": timeout})
+	cfg.Initialize()
+	logs := &lockedLogBuffer{}
+	logger := zerolog.New(logs)
+	return NewAppContext(cfg, bootstrap.NewLoggerWrap(&logger)), logs
+}
+
+type blockingLifecycleManager struct {
+	task4LifecycleManager
+	loaded  chan struct{}
+	release chan struct{}
+}
+
+func (m *blockingLifecycleManager) LoadProvider(...ProviderLoadFunc) (any, error) {
+	close(m.loaded)
+	<-m.release
+	return nil, nil
+}
+
+type drainOrderGlobal struct {
+	taskDone *atomic.Bool
+	closed   chan bool
+}
+
+func (g *drainOrderGlobal) Close() error {
+	g.closed <- g.taskDone.Load()
+	return nil
+}
+
+// afterOrderGlobal 关闭时记录 ServerShutdownAfter 提供者是否已开始执行
+type afterOrderGlobal struct {
+	afterStarted <-chan struct{}
+	closed       chan bool
+}
+
+func (g *afterOrderGlobal) Close() error {
+	select {
+	case <-g.afterStarted:
+		g.closed <- true
+	default:
+		g.closed <- false
+	}
+	return nil
+}
+
+// startBlockingTask 在 worker 中运行一个阻塞到 release 关闭的任务处理器，返回处理器返回后关闭的通道
+func startBlockingTask(t *testing.T, worker *TaskWorker, release <-chan struct{}, finished *atomic.Bool) <-chan struct{} {
+	t.Helper()
+	started, done := make(chan struct{}), make(chan struct{})
+	worker.HandleFunc("drain:block", func(context.Context, *asynq.Task) error {
+		close(started)
+		<-release
+		finished.Store(true)
+		return nil
+	})
+	go func() {
+		defer close(done)
+		_ = worker.GetMux().ProcessTask(context.Background(), NewTask("drain:block", nil))
+	}()
+	select {
+	case <-started:
+	case <-time.After(3 * time.Second):
+		t.Fatal("task handler did not start")
+	}
+	return done
+}
+
+func TestInflightCounter_WaitReturnsRemainingAtDeadline(t *testing.T) {
+	var c inflightCounter
+	assert.Zero(t, c.wait(context.Background()), "zero value is idle")
+
+	c.add()
+	c.add()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, int64(2), c.wait(ctx))
+
+	c.done()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		c.done()
+	}()
+	assert.Zero(t, c.wait(context.Background()))
+	assert.Zero(t, c.count())
+
+	c.add()
+	c.done()
+	assert.Zero(t, c.wait(context.Background()), "counter can be reused after idle")
+}
+
+func TestTaskWorker_DrainWaitsForRunningHandlers(t *testing.T) {
+	worker := NewTaskWorker(newTask6Context(), newTask6RedisClient(t), asynq.Config{Concurrency: 1})
+	release := make(chan struct{})
+	var finished atomic.Bool
+	done := startBlockingTask(t, worker, release, &finished)
+	assert.Equal(t, int64(1), worker.InFlight())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, int64(1), worker.Drain(ctx), "handler still running at deadline")
+
+	close(release)
+	<-done
+	assert.True(t, finished.Load())
+	assert.Zero(t, worker.InFlight())
+	assert.Zero(t, worker.Drain(context.Background()))
+}
+
+func TestCoreShutdown_FiberWaitsForRequestsAndTasksBeforeClosingGlobals(t *testing.T) {
+	ctx, logs := newShutdownTestContext(t, "5s")
+	manager := isolateFrameHealthManager(t, ctx)
+	worker := NewTaskWorker(ctx, newTask6RedisClient(t), asynq.Config{Concurrency: 1})
+	frame := &FrameApplication{Ctx: ctx, taskWorker: worker}
+	core := &CoreWithFiber{ctx: ctx, coreApp: fiber.New(fiber.Config{DisableStartupMessage: true})}
+	ctx.RegisterStarterApp(&WebApplication{FrameStarter: frame, CoreStarter: core})
+
+	var taskDone atomic.Bool
+	global := &drainOrderGlobal{taskDone: &taskDone, closed: make(chan bool, 1)}
+	require.True(t, manager.Register("drain-global", func() (interface{}, error) { return global, nil }))
+	_, err := manager.Get("drain-global")
+	require.NoError(t, err)
+
+	core.RegisterAppHooks(&task4Frame{})
+	core.coreApp.Use(core.inflightMiddleware())
+	requestStarted, releaseRequest := make(chan struct{}), make(chan struct{})
+	core.coreApp.Get("/slow", func(c *fiber.Ctx) error {
+		close(requestStarted)
+		<-releaseRequest
+		return c.SendStatus(http.StatusNoContent)
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = core.coreApp.Listener(listener) }()
+
+	releaseTask := make(chan struct{})
+	startBlockingTask(t, worker, releaseTask, &taskDone)
+
+	status := make(chan int, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/slow")
+		if err != nil {
+			status <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+	<-requestStarted
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(releaseRequest)
+		time.Sleep(50 * time.Millisecond)
+		close(releaseTask)
+	}()
+	require.NoError(t, core.Shutdown())
+	assert.Equal(t, http.StatusNoContent, <-status)
+	select {
+	case taskFinishedFirst := <-global.closed:
+		assert.True(t, taskFinishedFirst, "globals closed only after the task handler returned")
+	default:
+		t.Fatal("application globals were not closed")
+	}
+	assert.NotContains(t, logs.String(), "cut off")
+}
+
+type closeRecorder struct{ closed atomic.Bool }
+
+func (w *closeRecorder) Write(p []byte) (int, error) { return len(p), nil }
+func (w *closeRecorder) Close() error                { w.closed.Store(true); return nil }
+
+func TestCoreShutdown_FiberBudgetCutOffAfterProvidersStillClosesLogger(t *testing.T) {
+	cfg := appconfig.NewAppConfig()
+	cfg.LoadDefault(map[string]interface{}{"application.shutdown.timeout": "200ms"})
+	cfg.Initialize()
+	logs, writer := &lockedLogBuffer{}, &closeRecorder{}
+	logger := zerolog.New(logs)
+	ctx := NewAppContext(cfg, bootstrap.NewLoggerWrap(&logger, writer))
+	isolateFrameHealthManager(t, ctx)
+	core := &CoreWithFiber{ctx: ctx, coreApp: fiber.New(fiber.Config{DisableStartupMessage: true})}
+	ctx.RegisterStarterApp(&WebApplication{FrameStarter: &FrameApplication{Ctx: ctx}, CoreStarter: core})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = core.coreApp.Listener(listener) }()
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	after := &blockingLifecycleManager{
+		task4LifecycleManager: task4LifecycleManager{
+			typ:      ProviderTypeDefault().GroupProviderAutoRun,
+			location: ProviderLocationDefault().LocationServerShutdownAfter,
+		},
+		loaded:  make(chan struct{}),
+		release: release,
+	}
+	err = core.Shutdown(after)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotContains(t, err.Error(), "failed to load post-shutdown providers")
+	assert.True(t, writer.closed.Load(), "logger closed although the after stage was cut off")
+	assert.Contains(t, logs.String(), `"stage":"ServerShutdownAfter","message":"shutdown stage cut off by shutdown budget"`)
+}
+
+func TestCoreShutdown_GinBudgetCutsOffRequestsTasksAndAfterProviders(t *testing.T) {
+	preserveTask4GinMode(t)
+	ctx, logs := newShutdownTestContext(t, "200ms")
+	manager := isolateFrameHealthManager(t, ctx)
+	worker := NewTaskWorker(ctx, newTask6RedisClient(t), asynq.Config{Concurrency: 1})
+	frame := &FrameApplication{Ctx: ctx, taskWorker: worker}
+	core := NewCoreWithGin(ctx).(*CoreWithGin)
+	core.InitCoreApp(&task4Frame{}, task4GoodCodecManager())
+	t.Cleanup(core.releaseGinLogger)
+	ctx.RegisterStarterApp(&WebApplication{FrameStarter: frame, CoreStarter: core})
+
+	release := make(chan struct{})
+	var releaseOnce sync.Once
+	t.Cleanup(func() { releaseOnce.Do(func() { close(release) }) })
+
+	core.coreApp.Use(core.inflightMiddleware())
+	requestStarted := make(chan struct{})
+	core.coreApp.GET("/stuck", func(c *gin.Context) {
+		close(requestStarted)
+		<-release
+		c.Status(http.StatusNoContent)
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = core.httpServer.Close() })
+	go func() { _ = core.httpServer.Serve(listener) }()
+
+	var taskDone atomic.Bool
+	startBlockingTask(t, worker, release, &taskDone)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/stuck")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-requestStarted
+
+	after := &blockingLifecycleManager{
+		task4LifecycleManager: task4LifecycleManager{
+			typ:      ProviderTypeDefault().GroupProviderAutoRun,
+			location: ProviderLocationDefault().LocationServerShutdownAfter,
+		},
+		loaded:  make(chan struct{}),
+		release: release,
+	}
+	// 全局对象与 Fiber 一致地先于 ServerShutdownAfter 提供者关闭，不与被截断的提供者交错
+	global := &afterOrderGlobal{afterStarted: after.loaded, closed: make(chan bool, 1)}
+	require.True(t, manager.Register("after-order-global", func() (interface{}, error) { return global, nil }))
+	_, err = manager.Get("after-order-global")
+	require.NoError(t, err)
+	start := time.Now()
+	err = core.Shutdown(after)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	select {
+	case <-after.loaded:
+	case <-time.After(time.Second):
+		t.Fatal("ServerShutdownAfter provider was not started")
+	}
+	assert.False(t, taskDone.Load())
+	select {
+	case afterStartedFirst := <-global.closed:
+		assert.False(t, afterStartedFirst, "globals closed before the ServerShutdownAfter providers started")
+	default:
+		t.Fatal("application globals were not closed")
+	}
+
+	out := logs.String()
+	for _, msg := range []string{
+		`"inflightRequests":1,"message":"HTTP requests cut off by shutdown budget"`,
+		`"runningTasks":1,"message":"task handlers cut off by shutdown budget"`,
+		`"stage":"ServerShutdownAfter","message":"shutdown stage cut off by shutdown budget"`,
+	} {
+		assert.True(t, strings.Contains(out, msg), "missing log %s in %s", msg, out)
+	}
+}
@@ -6,7 +6,7 @@ import "errors"
 //
 // 该函数是框架对外导出的扩展点，供自定义核心启动器（CoreStarter 的第三方实现）
 // 复用框架统一的「位点 provider 分派与替代」语义，无需自行复制一份等价逻辑。
-// 内置的 Fiber/Gin 核心与 example_application 下的 Hertz 核心均基于本函数实现。
+// 内置的 Fiber/Gin/Hertz 核心均基于本函数实现。
 //
 // 参数：
 //   - managers   当前启动阶段传入的提供者管理器列表
@@ -122,6 +122,7 @@ func TestTask7ProviderGlobalFixtures_RestoreLocationsAndSingletons(t *testing.T)
 		_ = NewJsonCodecPManager(ctx)
 		_ = NewRespInfoPManager(ctx)
 		_ = NewRecoveryPManagerOnce(ctx)
+		_ = NewConfigSourcePManager(ctx)
 		_ = DefaultProviders()
 		_ = DefaultPManagers(ctx)
 	})
@@ -11,7 +11,12 @@ import (
 	"fmt"
 	"github.com/hibiken/asynq"
 	"github.com/lamxy/fiberhouse/component/codec/json"
+	"github.com/lamxy/fiberhouse/component/metrics"
+	"github.com/lamxy/fiberhouse/component/tracing"
+	"github.com/lamxy/fiberhouse/globalmanager"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TaskHandlerMap 定义任务处理函数映射，键为任务类型，值为对应的处理函数
@@ -22,9 +27,10 @@ type ContextKey string
 
 // TaskWorker 是一个异步任务处理器，使用asynq库来处理任务队列
 type TaskWorker struct {
-	Ctx    IContext
-	server *asynq.Server
-	mux    *asynq.ServeMux
+	Ctx      IContext
+	server   *asynq.Server
+	mux      *asynq.ServeMux
+	inflight *inflightCounter
 }
 
 const (
@@ -34,12 +40,27 @@ const (
 
 func NewTaskWorker(appCtx IContext, redisClient *redis.Client, cfg asynq.Config) *TaskWorker {
 	sm := asynq.NewServeMux()
-	// 注册自定义中间件，注入项目应用上下文对象到context.Context上下文
+	inflight := &inflightCounter{}
+	// 注册自定义中间件，注入项目应用上下文对象与任务作用域到context.Context上下文
 	sm.Use(func(h asynq.Handler) asynq.Handler {
 		return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+			// 计入运行中的任务处理器，Drain 据此等待
+			inflight.add()
+			defer inflight.done()
 			// 注入应用上下文
 			ctxWithAppCtx := context.WithValue(ctx, ContextKeyAppCtx, appCtx)
+			// 注入任务作用域，任务处理返回后释放，见 globalmanager.ScopeFromContext
+			scope := globalmanager.NewLazyScope(appCtx.GetContainer())
+			defer releaseTaskScope(appCtx, t, scope)
+			ctxWithAppCtx = globalmanager.ContextWithScope(ctxWithAppCtx, scope)
+			// 从任务头部恢复入队方的链路上下文，handler 在 consumer span 下执行
+			ctxWithAppCtx, span := tracing.Tracer().Start(tracing.ExtractMap(ctxWithAppCtx, t.Headers()), "asynq.process "+t.Type(),
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(attribute.String("messaging.system", "asynq"), attribute.String("asynq.task.type", t.Type())))
+			defer span.End()
 			err := h.ProcessTask(ctxWithAppCtx, t)
+			metrics.ObserveTaskProcessed(t.Type(), err)
+			tracing.RecordError(span, err)
 			if err != nil {
 				return err
 			}
@@ -47,9 +68,17 @@ func NewTaskWorker(appCtx IContext, redisClient *redis.Client, cfg asynq.Config)
 		})
 	})
 	return &TaskWorker{
-		Ctx:    appCtx,
-		server: asynq.NewServerFromRedisClient(redisClient, cfg),
-		mux:    sm,
+		Ctx:      appCtx,
+		server:   asynq.NewServerFromRedisClient(redisClient, cfg),
+		mux:      sm,
+		inflight: inflight,
+	}
+}
+
+// releaseTaskScope 释放任务作用域，失败时记录错误日志
+func releaseTaskScope(appCtx IContext, t *asynq.Task, scope *globalmanager.LazyScope) {
+	if err := scope.Release(context.Background()); err != nil {
+		appCtx.GetLogger().Error(appCtx.GetConfig().LogOriginTask()).Err(err).Str("taskType", t.Type()).Msg("[Asynq] release task scope failed")
 	}
 }
 
@@ -105,7 +134,7 @@ func (tk *TaskWorker) runSimple() error {
 	return nil
 }
 
-// RunServer 本身支持信号监听，同时阻塞在等待信号上，因此非独立执行worker服务的条件下应置于异步go routine中启动
+// RunServer sync 为 true 时同步运行并监听系统信号，否则后台运行，由 Drain 停止
 func (tk *TaskWorker) RunServer(sync ...bool) {
 	if len(sync) > 0 && sync[0] {
 		_ = tk.RunSync()
@@ -115,18 +144,36 @@ func (tk *TaskWorker) RunServer(sync ...bool) {
 }
 
 // RunAsync 启动任务处理器，并在后台运行
+//
+// 后台运行不安装 asynq 的信号监听，关闭由宿主应用调用 Drain 完成，使任务排空与 HTTP 排空共享同一关闭预算。
 func (tk *TaskWorker) RunAsync() (err error) {
-	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				tk.GetContext().GetLogger().Error(tk.GetContext().GetConfig().LogOriginTask()).Msgf("[Asynq] Worker panic: %v", r)
-			}
-		}()
-		_ = tk.runSimple()
-	}()
+	tk.GetContext().GetLogger().Info(tk.GetContext().GetConfig().LogOriginTask()).Msg("[Asynq] Staring server...")
+	if err = tk.server.Start(tk.mux); err != nil {
+		tk.GetContext().GetLogger().Error(tk.GetContext().GetConfig().LogOriginTask()).Err(err).Msg("[Asynq] Staring server failed")
+	}
 	return
 }
 
+// InFlight 返回正在运行的任务处理器数量
+func (tk *TaskWorker) InFlight() int64 {
+	return tk.inflight.count()
+}
+
+// Drain 停止拉取新任务并等待运行中的任务处理器返回，ctx 结束时返回仍在运行的数量
+//
+// 全部返回后同步关闭服务器；被截断时在后台关闭，asynq 在 Config.ShutdownTimeout 后把未完成的任务放回队列，
+// 进程先于此退出时由 asynq 在任务租约过期后恢复。
+func (tk *TaskWorker) Drain(ctx context.Context) int64 {
+	tk.server.Stop()
+	remaining := tk.inflight.wait(ctx)
+	if remaining == 0 {
+		tk.server.Shutdown()
+	} else {
+		go tk.server.Shutdown()
+	}
+	return remaining
+}
+
 // TaskDispatcher 封装 asynq.Client，简化任务发送到 asynq 服务器的流程，支持异步和同步任务调度。
 type TaskDispatcher struct {
 	Client *asynq.Client
@@ -140,12 +187,48 @@ func NewTaskDispatcher(redisClient *redis.Client) *TaskDispatcher {
 
 // Enqueue 将任务添加到asynq队列中
 func (td *TaskDispatcher) Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
-	return td.Client.Enqueue(task, opts...)
+	return td.EnqueueContext(context.Background(), task, opts...)
 }
 
 // EnqueueContext 将任务添加到asynq队列中，支持上下文
+//
+// ctx 中的链路上下文写入任务头部，由 TaskWorker 中间件恢复。头部为 nil 的任务（asynq.NewTask 创建）以相同类型与负载
+// 经 asynq.NewTaskWithHeaders 重建后入队；asynq 不开放读取任务级选项，重建任务只保留 opts，任务级选项请改用 NewTask 创建。
 func (td *TaskDispatcher) EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
-	return td.Client.EnqueueContext(ctx, task, opts...)
+	taskType := taskTypeOf(task)
+	ctx, span := tracing.Tracer().Start(ctx, "asynq.enqueue "+taskType,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("messaging.system", "asynq"), attribute.String("asynq.task.type", taskType)))
+	defer span.End()
+	info, err := td.Client.EnqueueContext(ctx, injectTaskTrace(ctx, task), opts...)
+	metrics.ObserveTaskEnqueued(taskType, err)
+	tracing.RecordError(span, err)
+	return info, err
+}
+
+// NewTask 创建带空头部的 asynq 任务，经 TaskDispatcher 入队时可携带链路上下文
+func NewTask(typename string, payload []byte, opts ...asynq.Option) *asynq.Task {
+	return asynq.NewTaskWithHeaders(typename, payload, map[string]string{}, opts...)
+}
+
+// injectTaskTrace 将链路上下文写入任务头部，头部为 nil 的任务重建为带头部的任务，nil 任务原样返回
+func injectTaskTrace(ctx context.Context, task *asynq.Task) *asynq.Task {
+	if task == nil {
+		return nil
+	}
+	if task.Headers() == nil {
+		task = asynq.NewTaskWithHeaders(task.Type(), task.Payload(), map[string]string{})
+	}
+	tracing.InjectMap(ctx, task.Headers())
+	return task
+}
+
+// taskTypeOf 任务类型名，nil 任务（由 asynq 返回错误）返回空串
+func taskTypeOf(task *asynq.Task) string {
+	if task == nil {
+		return ""
+	}
+	return task.Type()
 }
 
 // IPayload 定义了获取JSON编解码器的方法接口，适用于需要处理JSON数据的场景。
@@ -53,9 +53,9 @@ func TestLive_TaskWorkerDispatcher_EnqueueConsumeShutdown(t *testing.T) {
 		return nil
 	})
 
-	// RunAsync 是非阻塞的：内部用 goroutine 执行 server.Run，错误只在内部被
-	// recover/记录日志，函数本身永远返回 nil。因此这里不依赖其返回值判断
-	// worker 是否成功启动，而是用下方"10 秒内是否收到消费信号"作为证据。
+	// RunAsync 是非阻塞的：内部调用 server.Start，只返回启动期错误（如服务器
+	// 已关闭）；Redis 连接错误不在此暴露。因此这里以下方"10 秒内是否收到
+	// 消费信号"作为 worker 成功启动的证据。
 	require.NoError(t, worker.RunAsync())
 	t.Cleanup(func() {
 		shutdownDone := make(chan struct{})
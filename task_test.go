@@ -14,6 +14,12 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type task6Application struct{ fastKey globalmanager.KeyName }
@@ -77,6 +83,31 @@ func TestTaskWorker_MuxInjectsAppContextAndPropagatesHandlerError(t *testing.T)
 	assert.ErrorIs(t, err, sentinel)
 }
 
+func TestTaskWorker_MuxInjectsTaskScopeReleasedAfterHandler(t *testing.T) {
+	appCtx := newTask6Context()
+	manager := isolateFrameHealthManager(t, appCtx.IApplicationContext)
+	probe := &requestScopeProbe{}
+	next := 0
+	require.True(t, manager.RegisterScoped("uow", func(*globalmanager.GlobalManager) (interface{}, error) {
+		next++
+		return &requestScopedUnit{id: next, probe: probe}, nil
+	}))
+	worker := NewTaskWorker(appCtx, newTask6RedisClient(t), asynq.Config{Concurrency: 1})
+	worker.HandleFunc("task6:scope", func(ctx context.Context, task *asynq.Task) error {
+		assert.Same(t, appCtx, ctx.Value(ContextKeyAppCtx))
+		scope := globalmanager.ScopeFromContext(ctx)
+		require.NotNil(t, scope)
+		assert.Same(t, manager, scope.Parent())
+		_, err := scope.Get("uow")
+		return err
+	})
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, worker.GetMux().ProcessTask(context.Background(), asynq.NewTask("task6:scope", nil)))
+	}
+	assert.Equal(t, []string{"uow-1", "uow-2"}, probe.list())
+}
+
 func TestTaskWorker_HandleAndRegisterHandlersProcessWithoutRedis(t *testing.T) {
 	appCtx := newTask6Context()
 	worker := NewTaskWorker(appCtx, newTask6RedisClient(t), asynq.Config{Concurrency: 1})
@@ -103,6 +134,69 @@ func TestTaskDispatcher_ConstructsClientWithoutNetworkOperation(t *testing.T) {
 	assert.NotNil(t, dispatcher.Client)
 }
 
+func TestTaskDispatcher_PropagatesTraceContextToWorkerHandler(t *testing.T) {
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	dispatcher := NewTaskDispatcher(newTask6RedisClient(t))
+	task := NewTask("task6:trace", nil)
+	// 无可用 Redis，入队失败，但链路上下文已在发送前写入任务头部
+	_, err := dispatcher.EnqueueContext(ctx, task)
+	require.Error(t, err)
+	require.Contains(t, task.Headers(), "traceparent")
+	parent.End()
+
+	worker := NewTaskWorker(newTask6Context(), newTask6RedisClient(t), asynq.Config{Concurrency: 1})
+	var handlerTraceID trace.TraceID
+	worker.HandleFunc("task6:trace", func(ctx context.Context, task *asynq.Task) error {
+		handlerTraceID = trace.SpanContextFromContext(ctx).TraceID()
+		return nil
+	})
+	require.NoError(t, worker.GetMux().ProcessTask(context.Background(), asynq.NewTaskWithHeaders("task6:trace", nil, task.Headers())))
+	assert.Equal(t, parent.SpanContext().TraceID(), handlerTraceID)
+
+	names := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		names[span.Name] = span
+	}
+	require.Contains(t, names, "asynq.enqueue task6:trace")
+	require.Contains(t, names, "asynq.process task6:trace")
+	assert.Equal(t, codes.Error, names["asynq.enqueue task6:trace"].Status.Code)
+	assert.Equal(t, trace.SpanKindConsumer, names["asynq.process task6:trace"].SpanKind)
+	assert.Equal(t, names["asynq.enqueue task6:trace"].SpanContext.SpanID(), names["asynq.process task6:trace"].Parent.SpanID())
+}
+
+func TestInjectTaskTrace_RebuildsTasksWithoutHeaders(t *testing.T) {
+	prevProp := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(prevProp) })
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+
+	plain := asynq.NewTask("task6:plain", []byte("payload"))
+	require.Nil(t, plain.Headers())
+	rebuilt := injectTaskTrace(ctx, plain)
+	require.NotSame(t, plain, rebuilt)
+	assert.Equal(t, "task6:plain", rebuilt.Type())
+	assert.Equal(t, []byte("payload"), rebuilt.Payload())
+	assert.Contains(t, rebuilt.Headers(), "traceparent")
+
+	// 已有头部的任务原地写入
+	withHeaders := NewTask("task6:headers", nil)
+	assert.Same(t, withHeaders, injectTaskTrace(ctx, withHeaders))
+	assert.Contains(t, withHeaders.Headers(), "traceparent")
+
+	assert.Nil(t, injectTaskTrace(ctx, nil))
+}
+
 func TestPayload_NilFallbackContainerHitMissingAndWrongType(t *testing.T) {
 	payload := NewPayloadBase()
 	assert.IsType(t, &jsoncodec.SonicJSON{}, payload.GetDefault(nil))